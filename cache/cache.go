@@ -2,8 +2,11 @@ package cache
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"io"
 	"net/http"
+	"time"
 )
 
 type Cache interface {
@@ -15,6 +18,86 @@ type Cache interface {
 	Close()
 }
 
+// EntryOptions control how long an entry is kept and which tags it carries.
+type EntryOptions struct {
+	// TTL overrides the cache's default time to live when positive.
+	TTL time.Duration
+	// Tags group entries for InvalidateTags, e.g. the resource type of the
+	// endpoint the response came from.
+	Tags []string
+}
+
+// Stats are the cumulative counters of a TaggedCache.
+type Stats struct {
+	Hits   int64
+	Misses int64
+	Sets   int64
+	// Expirations counts entries dropped because their TTL elapsed.
+	Expirations int64
+	// Evictions counts entries dropped to make room for new ones.
+	Evictions int64
+	// Invalidations counts entries removed by Delete, prefix or tag
+	// invalidation.
+	Invalidations int64
+	// Revalidations counts expired entries kept after revalidation found
+	// them unchanged.
+	Revalidations int64
+	// Entries is the current number of entries, including expired entries
+	// retained for revalidation.
+	Entries int
+}
+
+// TaggedCache is a Cache with per-entry TTLs, tag based invalidation and
+// statistics. The caches returned by NewCache and NewNopCache implement it;
+// custom caches may, and the OneAPI client falls back to prefix invalidation
+// for those that do not.
+type TaggedCache interface {
+	Cache
+	SetWithOptions(key string, value *http.Response, opts EntryOptions)
+	// InvalidateTags removes every entry carrying one of tags and returns
+	// how many were removed.
+	InvalidateTags(tags ...string) int
+	Stats() Stats
+}
+
+// Entry is a cached response with its freshness and validators.
+type Entry struct {
+	Response *http.Response
+	Expires  time.Time
+	// ETag and LastModified are the validators sent with the response, empty
+	// when the API did not send them.
+	ETag         string
+	LastModified string
+	// Hash is the BodyHash of the response body, used to detect changes when
+	// there are no validators.
+	Hash string
+}
+
+// Fresh reports whether the entry has not expired.
+func (e *Entry) Fresh() bool {
+	return time.Now().Before(e.Expires)
+}
+
+// RevalidatingCache is a TaggedCache that keeps expired entries for a while
+// so they can be revalidated with a conditional request instead of fetched
+// again. The cache returned by NewCache with WithStaleRetention implements it.
+type RevalidatingCache interface {
+	TaggedCache
+	// Lookup returns the entry of key, fresh or expired, and whether there is
+	// one.
+	Lookup(key string) (*Entry, bool)
+	// Refresh makes the entry of key fresh for ttl more, or the default TTL
+	// if ttl is not positive, keeping its response and tags. It reports
+	// whether there was an entry.
+	Refresh(key string, ttl time.Duration) bool
+}
+
+// BodyHash returns the hex SHA-256 of a response body.
+func BodyHash(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
 func CreateCacheKey(req *http.Request) string {
 	s := req.URL.Scheme + "://" + req.URL.Host + req.URL.RequestURI()
 	return s
@@ -4,63 +4,282 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/binary"
+	"io"
 	"net/http"
 	"net/http/httputil"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/allegro/bigcache/v3"
 )
 
+// expiryLen is the size of the expiry time stored in front of each entry.
+const expiryLen = 8
+
 type cache struct {
 	bcache *bigcache.BigCache
+	ttl    time.Duration
+	// maxTTL is the longest TTL entries are set with, retention how long
+	// they are kept after expiring.
+	maxTTL, retention time.Duration
+
+	mu      sync.Mutex
+	tags    map[string]map[string]struct{} // tag -> keys
+	keyTags map[string][]string            // key -> tags
+	// tagGens counts the invalidations of each tag, so that an entry written
+	// while its tags were invalidated is detected and dropped.
+	tagGens map[string]uint64
+
+	hits, misses, sets, expirations, evictions, invalidations, revalidations atomic.Int64
 }
 
-func NewCache(ttl, cleanWindow time.Duration, maxCacheSizeMB int) (Cache, error) {
-	bCache, err := bigcache.New(context.Background(), bigcache.Config{
+// Option configures the cache returned by NewCache.
+type Option func(*cache)
+
+// WithMaxTTL raises the time entries are kept in memory to the longest TTL
+// passed to SetWithOptions, when it exceeds the default TTL.
+func WithMaxTTL(ttl time.Duration) Option {
+	return func(c *cache) {
+		if ttl > c.maxTTL {
+			c.maxTTL = ttl
+		}
+	}
+}
+
+// WithStaleRetention keeps entries for d after they expire, so that Lookup
+// can return them for revalidation. Get never returns expired entries.
+func WithStaleRetention(d time.Duration) Option {
+	return func(c *cache) {
+		c.retention = d
+	}
+}
+
+// NewCache returns an in-memory cache whose entries expire after ttl unless
+// stored with another TTL through SetWithOptions. The returned cache
+// implements RevalidatingCache.
+func NewCache(ttl, cleanWindow time.Duration, maxCacheSizeMB int, opts ...Option) (Cache, error) {
+	c := &cache{
+		ttl:     ttl,
+		maxTTL:  ttl,
+		tags:    make(map[string]map[string]struct{}),
+		keyTags: make(map[string][]string),
+		tagGens: make(map[string]uint64),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	config := bigcache.Config{
 		Shards:           16,
-		LifeWindow:       ttl,
+		LifeWindow:       c.maxTTL + c.retention,
 		CleanWindow:      cleanWindow,
 		HardMaxCacheSize: maxCacheSizeMB,
 		Verbose:          false,
 		StatsEnabled:     false,
-	})
+		OnRemoveWithReason: func(key string, _ []byte, reason bigcache.RemoveReason) {
+			switch reason {
+			case bigcache.Expired:
+				c.expirations.Add(1)
+			case bigcache.NoSpace:
+				c.evictions.Add(1)
+			}
+			c.untag(key)
+		},
+	}
+	bCache, err := bigcache.New(context.Background(), config)
 	if err != nil {
 		return nil, err
 	}
-	return &cache{bCache}, nil
+	c.bcache = bCache
+	return c, nil
+}
+
+func (c *cache) Get(key string) *http.Response {
+	item, err := c.bcache.Get(key)
+	if err != nil || len(item) < expiryLen {
+		c.misses.Add(1)
+		return nil
+	}
+	if time.Now().After(expiryOf(item)) {
+		c.misses.Add(1)
+		if c.retention <= 0 {
+			c.expirations.Add(1)
+			c.bcache.Delete(key)
+		}
+		return nil
+	}
+	resp, err := readResponse(item)
+	if err != nil {
+		c.misses.Add(1)
+		return nil
+	}
+	c.hits.Add(1)
+	return resp
 }
 
-func (c cache) Get(key string) *http.Response {
+func (c *cache) Lookup(key string) (*Entry, bool) {
 	item, err := c.bcache.Get(key)
-	if err == nil && item != nil {
-		r := bufio.NewReader(bytes.NewReader(item))
-		resp, _ := http.ReadResponse(r, nil)
-		return resp
+	if err != nil || len(item) < expiryLen {
+		return nil, false
+	}
+	resp, err := readResponse(item)
+	if err != nil {
+		return nil, false
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return &Entry{
+		Response:     resp,
+		Expires:      expiryOf(item),
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Hash:         BodyHash(body),
+	}, true
+}
+
+func (c *cache) Refresh(key string, ttl time.Duration) bool {
+	if ttl <= 0 {
+		ttl = c.ttl
+	}
+	item, err := c.bcache.Get(key)
+	if err != nil || len(item) < expiryLen {
+		return false
+	}
+	entry := append([]byte(nil), item...)
+	binary.BigEndian.PutUint64(entry, uint64(time.Now().Add(ttl).UnixNano()))
+	// Overwriting an entry keeps its tags: the removal callback only runs
+	// for deletions and evictions.
+	if c.bcache.Set(key, entry) != nil {
+		return false
+	}
+	c.revalidations.Add(1)
+	return true
+}
+
+func (c *cache) Set(key string, value *http.Response) {
+	c.SetWithOptions(key, value, EntryOptions{})
+}
+
+func (c *cache) SetWithOptions(key string, value *http.Response, opts EntryOptions) {
+	ttl := opts.TTL
+	if ttl <= 0 {
+		ttl = c.ttl
+	}
+	cacheableResponse, err := httputil.DumpResponse(value, true)
+	if err != nil {
+		return
 	}
+	entry := make([]byte, expiryLen, expiryLen+len(cacheableResponse))
+	binary.BigEndian.PutUint64(entry, uint64(time.Now().Add(ttl).UnixNano()))
+	entry = append(entry, cacheableResponse...)
+	gens := c.tagGenerations(opts.Tags)
+	if c.bcache.Set(key, entry) != nil {
+		return
+	}
+	c.sets.Add(1)
+	if !c.tag(key, opts.Tags, gens) {
+		// InvalidateTags ran between the write and the tagging and could not
+		// see the entry: drop it rather than serve it stale.
+		c.bcache.Delete(key)
+	}
+}
 
-	return nil
+// tagGenerations returns the invalidation counts of tags.
+func (c *cache) tagGenerations(tags []string) []uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	gens := make([]uint64, len(tags))
+	for i, tag := range tags {
+		gens[i] = c.tagGens[tag]
+	}
+	return gens
 }
 
-func (c cache) Set(key string, value *http.Response) {
-	cacheableResponse, _ := httputil.DumpResponse(value, true)
+// tag records key under tags, and reports false if one of them was
+// invalidated since gens were read.
+func (c *cache) tag(key string, tags []string, gens []uint64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.untagLocked(key)
+	if len(tags) == 0 {
+		return true
+	}
+	for i, tag := range tags {
+		if c.tagGens[tag] != gens[i] {
+			return false
+		}
+	}
+	c.keyTags[key] = append([]string(nil), tags...)
+	for _, tag := range tags {
+		keys := c.tags[tag]
+		if keys == nil {
+			keys = make(map[string]struct{})
+			c.tags[tag] = keys
+		}
+		keys[key] = struct{}{}
+	}
+	return true
+}
+
+func (c *cache) InvalidateTags(tags ...string) int {
+	c.mu.Lock()
+	var keys []string
+	for _, tag := range tags {
+		c.tagGens[tag]++
+		for key := range c.tags[tag] {
+			keys = append(keys, key)
+		}
+	}
+	c.mu.Unlock()
+
+	n := 0
+	for _, key := range keys {
+		// Deleting runs the removal callback, which updates the tag index.
+		if c.bcache.Delete(key) == nil {
+			n++
+		}
+	}
+	c.invalidations.Add(int64(n))
+	return n
+}
 
-	c.bcache.Set(key, cacheableResponse)
+func (c *cache) Stats() Stats {
+	return Stats{
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		Sets:          c.sets.Load(),
+		Expirations:   c.expirations.Load(),
+		Evictions:     c.evictions.Load(),
+		Invalidations: c.invalidations.Load(),
+		Revalidations: c.revalidations.Load(),
+		Entries:       c.bcache.Len(),
+	}
 }
 
-func (c cache) Delete(key string) {
-	c.bcache.Delete(key)
+func (c *cache) Delete(key string) {
+	if c.bcache.Delete(key) == nil {
+		c.invalidations.Add(1)
+	}
 }
 
-func (c cache) Clear() {
+func (c *cache) Clear() {
 	c.bcache.Reset()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tags = make(map[string]map[string]struct{})
+	c.keyTags = make(map[string][]string)
 }
 
-func (c cache) Close() {
+func (c *cache) Close() {
 	c.bcache.Close()
 }
 
-func (c cache) ClearAllKeysWithPrefix(prefix string) {
+func (c *cache) ClearAllKeysWithPrefix(prefix string) {
 	it := c.bcache.Iterator()
 	for it.SetNext() {
 		e, err := it.Value()
@@ -68,7 +287,33 @@ func (c cache) ClearAllKeysWithPrefix(prefix string) {
 			continue
 		}
 		if strings.HasPrefix(e.Key(), prefix) {
-			c.bcache.Delete(e.Key())
+			c.Delete(e.Key())
 		}
 	}
 }
+
+func (c *cache) untag(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.untagLocked(key)
+}
+
+func (c *cache) untagLocked(key string) {
+	for _, tag := range c.keyTags[key] {
+		if keys := c.tags[tag]; keys != nil {
+			delete(keys, key)
+			if len(keys) == 0 {
+				delete(c.tags, tag)
+			}
+		}
+	}
+	delete(c.keyTags, key)
+}
+
+func expiryOf(item []byte) time.Time {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(item[:expiryLen])))
+}
+
+func readResponse(item []byte) (*http.Response, error) {
+	return http.ReadResponse(bufio.NewReader(bytes.NewReader(item[expiryLen:])), nil)
+}
@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestCacheTTLAndTags(t *testing.T) {
+	c, err := NewCache(time.Hour, time.Minute, 8)
+	require.NoError(t, err)
+	defer c.Close()
+	tc := c.(TaggedCache)
+
+	tc.SetWithOptions("short", newResponse(`[]`), EntryOptions{TTL: 10 * time.Millisecond})
+	tc.SetWithOptions("rules", newResponse(`[1]`), EntryOptions{Tags: []string{"zia:urlFilteringRules"}})
+	tc.SetWithOptions("rule", newResponse(`{}`), EntryOptions{Tags: []string{"zia:urlFilteringRules", "custom"}})
+	c.Set("categories", newResponse(`[2]`))
+
+	resp := c.Get("rules")
+	require.NotNil(t, resp)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, `[1]`, string(body))
+
+	time.Sleep(20 * time.Millisecond)
+	assert.Nil(t, c.Get("short"), "expired before the default TTL")
+
+	assert.Equal(t, 2, tc.InvalidateTags("zia:urlFilteringRules", "custom", "unknown"))
+	assert.Nil(t, c.Get("rules"))
+	assert.Nil(t, c.Get("rule"))
+	assert.NotNil(t, c.Get("categories"))
+	assert.Zero(t, tc.InvalidateTags("zia:urlFilteringRules"), "the tag index is updated on removal")
+
+	assert.Equal(t, Stats{
+		Hits:          2,
+		Misses:        3,
+		Sets:          4,
+		Expirations:   1,
+		Invalidations: 2,
+		Entries:       1,
+	}, tc.Stats())
+
+	tc.SetWithOptions("rules", newResponse(`[1]`), EntryOptions{Tags: []string{"zia:urlFilteringRules"}})
+	c.Clear()
+	assert.Zero(t, tc.InvalidateTags("zia:urlFilteringRules"))
+	assert.Zero(t, tc.Stats().Entries)
+}
+
+func TestCacheInvalidationDuringSet(t *testing.T) {
+	tc, err := NewCache(time.Hour, time.Minute, 8)
+	require.NoError(t, err)
+	defer tc.Close()
+	c := tc.(*cache)
+	tags := []string{"zia:urlFilteringRules"}
+
+	// The steps of SetWithOptions, with InvalidateTags running between the
+	// write and the tagging.
+	gens := c.tagGenerations(tags)
+	require.NoError(t, c.bcache.Set("rules", []byte("entry")))
+	assert.Zero(t, c.InvalidateTags(tags...), "the entry is not tagged yet")
+	assert.False(t, c.tag("rules", tags, gens), "the invalidation is detected")
+
+	tc.(TaggedCache).SetWithOptions("rules", newResponse(`[1]`), EntryOptions{Tags: tags})
+	assert.NotNil(t, tc.Get("rules"), "later writes are kept")
+	assert.Equal(t, 1, c.InvalidateTags(tags...))
+}
+
+func TestNopCacheIsTagged(t *testing.T) {
+	tc, ok := NewNopCache().(TaggedCache)
+	require.True(t, ok)
+	tc.SetWithOptions("key", newResponse(`[]`), EntryOptions{Tags: []string{"a"}})
+	assert.Nil(t, tc.Get("key"))
+	assert.Zero(t, tc.InvalidateTags("a"))
+	assert.Equal(t, Stats{}, tc.Stats())
+}
+
+func TestCacheStaleRetention(t *testing.T) {
+	c, err := NewCache(time.Hour, time.Minute, 8, WithStaleRetention(time.Hour))
+	require.NoError(t, err)
+	defer c.Close()
+	rc := c.(RevalidatingCache)
+
+	resp := newResponse(`[1]`)
+	resp.Header.Set("ETag", `"v1"`)
+	rc.SetWithOptions("rules", resp, EntryOptions{TTL: 10 * time.Millisecond, Tags: []string{"zia:urlFilteringRules"}})
+	time.Sleep(20 * time.Millisecond)
+	assert.Nil(t, c.Get("rules"))
+
+	entry, ok := rc.Lookup("rules")
+	require.True(t, ok, "expired entries are retained")
+	assert.False(t, entry.Fresh())
+	assert.Equal(t, `"v1"`, entry.ETag)
+	assert.Equal(t, BodyHash([]byte(`[1]`)), entry.Hash)
+	body, _ := io.ReadAll(entry.Response.Body)
+	assert.Equal(t, `[1]`, string(body))
+
+	require.True(t, rc.Refresh("rules", 0))
+	assert.NotNil(t, c.Get("rules"))
+	assert.Equal(t, int64(1), rc.Stats().Revalidations)
+	assert.Equal(t, 1, rc.InvalidateTags("zia:urlFilteringRules"), "refreshing keeps the tags")
+	assert.False(t, rc.Refresh("rules", 0))
+	_, ok = rc.Lookup("rules")
+	assert.False(t, ok)
+}
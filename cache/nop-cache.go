@@ -2,6 +2,7 @@ package cache
 
 import (
 	"net/http"
+	"time"
 )
 
 type nopCache struct {
@@ -29,3 +30,22 @@ func (c nopCache) Close() {
 
 func (c nopCache) ClearAllKeysWithPrefix(prefix string) {
 }
+
+func (c nopCache) SetWithOptions(key string, value *http.Response, opts EntryOptions) {
+}
+
+func (c nopCache) InvalidateTags(tags ...string) int {
+	return 0
+}
+
+func (c nopCache) Stats() Stats {
+	return Stats{}
+}
+
+func (c nopCache) Lookup(key string) (*Entry, bool) {
+	return nil, false
+}
+
+func (c nopCache) Refresh(key string, ttl time.Duration) bool {
+	return false
+}
@@ -0,0 +1,193 @@
+package cassette
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newUpstream(t *testing.T, calls *int32) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(calls, 1)
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "JSESSIONID=secret-session")
+		switch r.URL.Path {
+		case "/oauth2/v1/token":
+			_, _ = io.WriteString(w, `{"access_token":"live-token","expires_in":3600}`)
+		default:
+			_, _ = io.WriteString(w, `{"path":"`+r.URL.Path+`","n":`+strconv.Itoa(int(atomic.LoadInt32(calls)))+`,"echo":`+string(orNull(body))+`}`)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func orNull(b []byte) []byte {
+	if len(b) == 0 {
+		return []byte("null")
+	}
+	return b
+}
+
+func do(t *testing.T, c *http.Client, method, url, contentType, body string) (*http.Response, string) {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Authorization", "Bearer live-token")
+	resp, err := c.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(data)
+}
+
+func TestRecordThenReplay(t *testing.T) {
+	for _, name := range []string{"fixture.yaml", "fixture.json"} {
+		t.Run(name, func(t *testing.T) {
+			var calls int32
+			srv := newUpstream(t, &calls)
+			path := filepath.Join(t.TempDir(), "nested", name)
+
+			rec, err := New(path, ModeRecord)
+			require.NoError(t, err)
+			c := rec.Client(nil)
+
+			_, tok := do(t, c, "POST", srv.URL+"/oauth2/v1/token", "application/x-www-form-urlencoded",
+				"grant_type=client_credentials&client_id=my-id&client_secret=topsecret")
+			assert.Contains(t, tok, "live-token", "the caller still sees the live response")
+			_, got := do(t, c, "POST", srv.URL+"/zia/api/v1/users?b=2&a=1", "application/json", `{"name":"u1","role":{"id":7}}`)
+			_, again := do(t, c, "GET", srv.URL+"/zia/api/v1/status", "", "")
+			require.Equal(t, int32(3), calls)
+
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			for _, secret := range []string{"topsecret", "my-id", "live-token", "secret-session"} {
+				assert.NotContains(t, string(data), secret)
+			}
+
+			replayed, err := New(path, ModeReplay)
+			require.NoError(t, err)
+			rc := replayed.Client(nil)
+
+			// Reordered form fields, JSON keys and query parameters still match.
+			_, tok2 := do(t, rc, "POST", "https://elsewhere.example/oauth2/v1/token", "application/x-www-form-urlencoded",
+				"client_secret=other&client_id=other-id&grant_type=client_credentials")
+			assert.Contains(t, tok2, `"expires_in":3600`)
+			resp, got2 := do(t, rc, "POST", "https://elsewhere.example/zia/api/v1/users?a=1&b=2", "application/json", `{"role":{"id":7}, "name":"u1"}`)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.JSONEq(t, got, got2)
+			_, again2 := do(t, rc, "GET", "https://elsewhere.example/zia/api/v1/status", "", "")
+			assert.JSONEq(t, again, again2)
+			assert.Empty(t, replayed.Unused())
+			assert.Equal(t, int32(3), calls, "replay must not reach the API")
+		})
+	}
+}
+
+func TestReplayUnmatched(t *testing.T) {
+	var calls int32
+	srv := newUpstream(t, &calls)
+	path := filepath.Join(t.TempDir(), "fixture.yaml")
+
+	rec, err := New(path, ModeRecord)
+	require.NoError(t, err)
+	do(t, rec.Client(nil), "POST", srv.URL+"/zpa/v1/items", "application/json", `{"name":"a"}`)
+
+	replayed, err := New(path, ModeReplay)
+	require.NoError(t, err)
+	rc := replayed.Client(nil)
+
+	for _, tc := range []struct{ method, url, body string }{
+		{"GET", "http://x/zpa/v1/items", ""},
+		{"POST", "http://x/zpa/v1/other", `{"name":"a"}`},
+		{"POST", "http://x/zpa/v1/items?page=2", `{"name":"a"}`},
+		{"POST", "http://x/zpa/v1/items", `{"name":"b"}`},
+	} {
+		req, _ := http.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+		_, err := rc.Do(req)
+		require.Error(t, err, "%s %s %s", tc.method, tc.url, tc.body)
+		assert.True(t, errors.Is(err, ErrUnmatchedRequest))
+		var unmatched *UnmatchedRequestError
+		require.True(t, errors.As(err, &unmatched))
+		assert.Equal(t, tc.method, unmatched.Method)
+	}
+}
+
+func TestReplayRepeatsLastMatch(t *testing.T) {
+	var calls int32
+	srv := newUpstream(t, &calls)
+	path := filepath.Join(t.TempDir(), "fixture.yaml")
+
+	rec, err := New(path, ModeRecord)
+	require.NoError(t, err)
+	_, first := do(t, rec.Client(nil), "GET", srv.URL+"/zia/api/v1/status", "", "")
+	_, second := do(t, rec.Client(nil), "GET", srv.URL+"/zia/api/v1/status", "", "")
+	require.NotEqual(t, first, second)
+
+	replayed, err := New(path, ModeReplay)
+	require.NoError(t, err)
+	rc := replayed.Client(nil)
+	_, r1 := do(t, rc, "GET", "http://x/zia/api/v1/status", "", "")
+	_, r2 := do(t, rc, "GET", "http://x/zia/api/v1/status", "", "")
+	_, r3 := do(t, rc, "GET", "http://x/zia/api/v1/status", "", "")
+	assert.JSONEq(t, first, r1, "interactions replay in recorded order")
+	assert.JSONEq(t, second, r2)
+	assert.JSONEq(t, second, r3, "the last match is reused once all are consumed")
+}
+
+func TestIgnoredFieldsAndHook(t *testing.T) {
+	var calls int32
+	srv := newUpstream(t, &calls)
+	path := filepath.Join(t.TempDir(), "fixture.yaml")
+
+	hook := WithHook(func(i *Interaction) {
+		i.Response.Body = strings.ReplaceAll(i.Response.Body, "zia", "product")
+	})
+	rec, err := New(path, ModeRecord, WithIgnoredFields("nonce"), hook)
+	require.NoError(t, err)
+	do(t, rec.Client(nil), "POST", srv.URL+"/zia/api/v1/authenticatedSession", "application/json",
+		`{"username":"admin@example.com","timestamp":"1700000000000","nonce":"abc","apiKey":"k"}`)
+
+	replayed, err := New(path, ModeReplay, WithIgnoredFields("nonce"))
+	require.NoError(t, err)
+	_, body := do(t, replayed.Client(nil), "POST", "http://x/zia/api/v1/authenticatedSession", "application/json",
+		`{"username":"ci@example.com","timestamp":"1800000000000","nonce":"xyz","apiKey":"other"}`)
+	assert.Contains(t, body, "/product/api/v1/authenticatedSession")
+}
+
+func TestNewErrors(t *testing.T) {
+	_, err := New(filepath.Join(t.TempDir(), "missing.yaml"), ModeReplay)
+	assert.ErrorIs(t, err, ErrCassetteNotFound)
+
+	_, err = New(filepath.Join(t.TempDir(), "x.yaml"), Mode(0))
+	assert.Error(t, err)
+
+	bad := filepath.Join(t.TempDir(), "bad.json")
+	require.NoError(t, os.WriteFile(bad, []byte("{"), 0o600))
+	_, err = New(bad, ModeReplay)
+	assert.Error(t, err)
+}
+
+func TestClientWrapsOnce(t *testing.T) {
+	rec, err := New(filepath.Join(t.TempDir(), "x.yaml"), ModeRecord)
+	require.NoError(t, err)
+	c := rec.Client(nil)
+	assert.Same(t, c, rec.Client(c))
+	assert.Equal(t, "record", rec.Mode().String())
+}
@@ -0,0 +1,405 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/zscaler/zscaler-sdk-go/v3/logger"
+)
+
+// Mode selects whether a Recorder captures or replays traffic.
+type Mode int
+
+const (
+	// ModeRecord sends requests to the real API and appends each exchange to
+	// the cassette, which is rewritten after every interaction.
+	ModeRecord Mode = iota + 1
+	// ModeReplay answers requests from the cassette and never contacts the API.
+	ModeReplay
+)
+
+func (m Mode) String() string {
+	switch m {
+	case ModeRecord:
+		return "record"
+	case ModeReplay:
+		return "replay"
+	default:
+		return fmt.Sprintf("Mode(%d)", int(m))
+	}
+}
+
+// ErrUnmatchedRequest is matched by the error a replaying Recorder returns for
+// a request that has no recorded interaction.
+var ErrUnmatchedRequest = errors.New("cassette: no recorded interaction matches request")
+
+// UnmatchedRequestError reports a replayed request missing from the cassette.
+type UnmatchedRequestError struct {
+	Method string
+	URL    string
+	Path   string
+}
+
+func (e *UnmatchedRequestError) Error() string {
+	return fmt.Sprintf("cassette: no interaction in %s matches %s %s", e.Path, e.Method, e.URL)
+}
+
+// Is reports whether target is ErrUnmatchedRequest.
+func (e *UnmatchedRequestError) Is(target error) bool {
+	return target == ErrUnmatchedRequest
+}
+
+// DefaultRedactionRules returns the rules used to scrub recorded traffic
+// unless WithRedactor is given: the logger defaults (credentials, tokens,
+// session cookies and other secrets) plus the client ID, so fixtures do not
+// identify the tenant they were recorded against.
+func DefaultRedactionRules() logger.RedactionRules {
+	rules := logger.DefaultRedactionRules()
+	rules.JSONFields = append(rules.JSONFields, "client_id", "clientId")
+	return rules
+}
+
+// defaultIgnoredFields vary between runs or users and are left out of request
+// matching: the legacy ZIA/ZTW login timestamp (and the API key obfuscated
+// with it) and the login user name.
+var defaultIgnoredFields = []string{"timestamp", "username"}
+
+// Option configures a Recorder.
+type Option func(*Recorder)
+
+// WithRedactor replaces the redactor applied to recorded headers, URLs and
+// bodies. The same redactor is applied to incoming requests before they are
+// matched during replay.
+func WithRedactor(r *logger.Redactor) Option {
+	return func(rec *Recorder) {
+		rec.redactor = r
+	}
+}
+
+// WithIgnoredFields adds request body fields that are masked in the cassette
+// and ignored when matching, e.g. nonces or client generated timestamps.
+func WithIgnoredFields(fields ...string) Option {
+	return func(rec *Recorder) {
+		rec.ignored = append(rec.ignored, fields...)
+	}
+}
+
+// WithHook registers fn to edit each interaction before it is saved, e.g. to
+// scrub tenant specific values the redactor does not know about.
+func WithHook(fn func(*Interaction)) Option {
+	return func(rec *Recorder) {
+		rec.hooks = append(rec.hooks, fn)
+	}
+}
+
+// Recorder is an http.RoundTripper middleware that records traffic to, or
+// replays it from, a cassette file. A Recorder is safe for concurrent use and
+// may be shared by several HTTP clients.
+type Recorder struct {
+	mu       sync.Mutex
+	path     string
+	mode     Mode
+	cassette *Cassette
+	keys     []matchKey
+	used     []bool
+	redactor *logger.Redactor
+	ignorer  *logger.Redactor
+	ignored  []string
+	hooks    []func(*Interaction)
+}
+
+// New returns a Recorder for the cassette at path. In ModeRecord any existing
+// cassette is replaced; in ModeReplay the cassette must exist.
+func New(path string, mode Mode, opts ...Option) (*Recorder, error) {
+	rec := &Recorder{
+		path:     path,
+		mode:     mode,
+		redactor: logger.MustNewRedactor(DefaultRedactionRules()),
+		ignored:  append([]string(nil), defaultIgnoredFields...),
+	}
+	for _, opt := range opts {
+		opt(rec)
+	}
+	ignorer, err := logger.NewRedactor(logger.RedactionRules{JSONFields: rec.ignored})
+	if err != nil {
+		return nil, err
+	}
+	rec.ignorer = ignorer
+
+	switch mode {
+	case ModeRecord:
+		rec.cassette = &Cassette{}
+		if err := rec.cassette.Save(path); err != nil {
+			return nil, fmt.Errorf("cassette: creating %s: %w", path, err)
+		}
+	case ModeReplay:
+		c, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		rec.cassette = c
+		for _, i := range c.Interactions {
+			rec.keys = append(rec.keys, rec.storedKey(i))
+		}
+		rec.used = make([]bool, len(c.Interactions))
+	default:
+		return nil, fmt.Errorf("cassette: invalid mode %v", mode)
+	}
+	return rec, nil
+}
+
+// Mode returns the mode the recorder was created with.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Path returns the cassette file path.
+func (r *Recorder) Path() string {
+	return r.path
+}
+
+// Unused returns the replayed interactions that no request has matched yet.
+// Tests can assert it is empty to check that every recorded call was made.
+func (r *Recorder) Unused() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var unused []*Interaction
+	for i, used := range r.used {
+		if !used {
+			unused = append(unused, r.cassette.Interactions[i])
+		}
+	}
+	return unused
+}
+
+// Transport wraps base, which is only used in ModeRecord. A nil base uses
+// http.DefaultTransport.
+func (r *Recorder) Transport(base http.RoundTripper) http.RoundTripper {
+	if t, ok := base.(*transport); ok && t.rec == r {
+		return t
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{rec: r, base: base}
+}
+
+// Client returns a copy of base whose transport goes through the recorder.
+// Wrapping the client's transport from the outside records only the final
+// response of retried requests and replays without rate limit waits. A nil
+// base uses a zero http.Client. Clients already wrapped by r are returned as is.
+func (r *Recorder) Client(base *http.Client) *http.Client {
+	if base == nil {
+		base = &http.Client{}
+	}
+	if t, ok := base.Transport.(*transport); ok && t.rec == r {
+		return base
+	}
+	c := *base
+	c.Transport = r.Transport(base.Transport)
+	return &c
+}
+
+type transport struct {
+	rec  *Recorder
+	base http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if t.rec.mode == ModeReplay {
+		return t.rec.replay(req, body)
+	}
+	return t.rec.record(req, body, t.base)
+}
+
+func (r *Recorder) record(req *http.Request, body []byte, base http.RoundTripper) (*http.Response, error) {
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	i := &Interaction{
+		Request: Request{
+			Method:  req.Method,
+			URL:     r.redactor.RedactString(req.URL.RequestURI()),
+			Headers: r.redactHeaders(req.Header),
+			Body:    r.ignorer.RedactBody([]byte(r.redactBody(body, req.Header.Get("Content-Type")))),
+		},
+		Response: Response{
+			Status:  resp.StatusCode,
+			Headers: r.redactHeaders(resp.Header),
+			Body:    r.redactBody(respBody, resp.Header.Get("Content-Type")),
+		},
+	}
+	for _, hook := range r.hooks {
+		hook(i)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, i)
+	if err := r.cassette.Save(r.path); err != nil {
+		return nil, fmt.Errorf("cassette: saving %s: %w", r.path, err)
+	}
+	return resp, nil
+}
+
+// replay answers req with the first unused matching interaction, or with the
+// last matching one once all have been used, so polling and token requests
+// may repeat.
+func (r *Recorder) replay(req *http.Request, body []byte) (*http.Response, error) {
+	key := r.requestKey(req, body)
+
+	r.mu.Lock()
+	match := -1
+	for i, k := range r.keys {
+		if !k.equal(key) {
+			continue
+		}
+		match = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if match >= 0 {
+		r.used[match] = true
+	}
+	r.mu.Unlock()
+
+	if match < 0 {
+		return nil, &UnmatchedRequestError{Method: req.Method, URL: req.URL.RequestURI(), Path: r.path}
+	}
+	recorded := r.cassette.Interactions[match].Response
+	header := recorded.Headers.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		StatusCode:    recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+// matchKey is the part of a request replay matches on: method, path, query
+// and normalised body, all after redaction.
+type matchKey struct {
+	method string
+	path   string
+	query  string
+	body   string
+}
+
+func (k matchKey) equal(o matchKey) bool {
+	return k.method == o.method && k.path == o.path && k.query == o.query && k.body == o.body
+}
+
+func (r *Recorder) requestKey(req *http.Request, body []byte) matchKey {
+	redacted := r.ignorer.RedactBody([]byte(r.redactBody(body, req.Header.Get("Content-Type"))))
+	return r.key(req.Method, r.redactor.RedactString(req.URL.RequestURI()), redacted, req.Header.Get("Content-Type"))
+}
+
+func (r *Recorder) storedKey(i *Interaction) matchKey {
+	return r.key(i.Request.Method, i.Request.URL, i.Request.Body, i.Request.Headers.Get("Content-Type"))
+}
+
+func (r *Recorder) key(method, rawURL, body, contentType string) matchKey {
+	path, query, _ := strings.Cut(rawURL, "?")
+	if values, err := url.ParseQuery(query); err == nil {
+		query = values.Encode()
+	}
+	return matchKey{
+		method: strings.ToUpper(method),
+		path:   path,
+		query:  query,
+		body:   normalizeBody(body, contentType),
+	}
+}
+
+// normalizeBody makes bodies that differ only in JSON key order, whitespace or
+// form field order compare equal.
+func normalizeBody(body, contentType string) string {
+	trimmed := strings.TrimSpace(body)
+	if trimmed == "" {
+		return ""
+	}
+	if trimmed[0] == '{' || trimmed[0] == '[' {
+		var v interface{}
+		dec := json.NewDecoder(strings.NewReader(trimmed))
+		dec.UseNumber()
+		if err := dec.Decode(&v); err == nil {
+			if out, err := json.Marshal(v); err == nil {
+				return string(out)
+			}
+		}
+	}
+	if isForm(contentType) {
+		if values, err := url.ParseQuery(trimmed); err == nil {
+			return values.Encode()
+		}
+	}
+	return trimmed
+}
+
+func (r *Recorder) redactBody(body []byte, contentType string) string {
+	if isForm(contentType) {
+		return r.redactor.RedactForm(body)
+	}
+	return r.redactor.RedactBody(body)
+}
+
+func (r *Recorder) redactHeaders(h http.Header) http.Header {
+	if len(h) == 0 {
+		return nil
+	}
+	out := make(http.Header, len(h))
+	for name, values := range h {
+		for _, v := range values {
+			out.Add(name, r.redactor.RedactHeader(name, v))
+		}
+	}
+	return out
+}
+
+func isForm(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return mediaType == "application/x-www-form-urlencoded"
+}
+
+// readBody reads and restores the request body.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"sort"
+	"strings"
+)
+
+//go:generate go run gen_catalog.go
+
+// products are the products zscalerctl manages: ZWA through its own client,
+// the others through the OneAPI client. The slice is sorted.
+var products = []string{"zcc", "zdx", "zia", "zid", "zpa", "ztw", "zwa"}
+
+// Resource is a collection endpoint of a service package, e.g. the
+// urlCategories of zscaler/zia/services/urlcategories. The catalog is
+// generated from the endpoint constants of the service packages and the
+// requests the packages send to them.
+type Resource struct {
+	Product string `json:"product"`
+	Name    string `json:"name"`
+	// Endpoint is the collection path. ZPA paths contain {customerId}.
+	Endpoint string `json:"endpoint"`
+	// Package is the service package, relative to zscaler/<product>/services.
+	Package string `json:"package"`
+	// Verbs are the zscalerctl verbs the service package uses the
+	// resource with, e.g. list and get for a read-only collection.
+	Verbs []string `json:"verbs"`
+}
+
+// supports reports whether verb is one of the verbs of r.
+func (r Resource) supports(verb string) bool {
+	for _, v := range r.Verbs {
+		if v == verb {
+			return true
+		}
+	}
+	return false
+}
+
+func isProduct(name string) bool {
+	i := sort.SearchStrings(products, name)
+	return i < len(products) && products[i] == name
+}
+
+// findResource looks a resource of product up by name, ignoring case.
+func findResource(product, name string) (Resource, bool) {
+	for _, r := range catalog {
+		if r.Product == product && strings.EqualFold(r.Name, name) {
+			return r, true
+		}
+	}
+	return Resource{}, false
+}
+
+// resourcesOf returns the resources of product, or all of them when product
+// is empty.
+func resourcesOf(product string) []Resource {
+	if product == "" {
+		return catalog
+	}
+	var resources []Resource
+	for _, r := range catalog {
+		if r.Product == product {
+			resources = append(resources, r)
+		}
+	}
+	return resources
+}
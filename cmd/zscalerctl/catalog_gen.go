@@ -0,0 +1,162 @@
+// Code generated by gen_catalog.go; DO NOT EDIT.
+
+package main
+
+var catalog = []Resource{
+	{Product: "zcc", Name: "application-profiles", Endpoint: "/zcc/papi/public/v1/application-profiles", Package: "application_profiles", Verbs: []string{"get"}},
+	{Product: "zcc", Name: "custom-ip-based-apps", Endpoint: "/zcc/papi/public/v1/custom-ip-based-apps", Package: "custom_ip_apps", Verbs: []string{"get"}},
+	{Product: "zcc", Name: "getAdminRoles", Endpoint: "/zcc/papi/public/v1/getAdminRoles", Package: "admin_roles", Verbs: []string{"list"}},
+	{Product: "zcc", Name: "getAdminUsers", Endpoint: "/zcc/papi/public/v1/getAdminUsers", Package: "admin_users", Verbs: []string{"list"}},
+	{Product: "zcc", Name: "getDeviceDetails", Endpoint: "/zcc/papi/public/v1/getDeviceDetails", Package: "devices", Verbs: []string{"list"}},
+	{Product: "zcc", Name: "getDevices", Endpoint: "/zcc/papi/public/v1/getDevices", Package: "devices", Verbs: []string{"list"}},
+	{Product: "zcc", Name: "getZdxGroupEntitlements", Endpoint: "/zcc/papi/public/v1/getZdxGroupEntitlements", Package: "entitlements", Verbs: []string{"list"}},
+	{Product: "zcc", Name: "getZpaGroupEntitlements", Endpoint: "/zcc/papi/public/v1/getZpaGroupEntitlements", Package: "entitlements", Verbs: []string{"list"}},
+	{Product: "zcc", Name: "predefined-ip-based-apps", Endpoint: "/zcc/papi/public/v1/predefined-ip-based-apps", Package: "predefined_ip_apps", Verbs: []string{"get"}},
+	{Product: "zcc", Name: "process-based-apps", Endpoint: "/zcc/papi/public/v1/process-based-apps", Package: "process_based_apps", Verbs: []string{"get"}},
+	{Product: "zdx", Name: "analysis", Endpoint: "/zdx/v1/analysis", Package: "troubleshooting/analysis", Verbs: []string{"get", "create", "delete"}},
+	{Product: "zdx", Name: "apps", Endpoint: "/zdx/v1/apps", Package: "reports/applications", Verbs: []string{"list", "get"}},
+	{Product: "zdx", Name: "devices", Endpoint: "/zdx/v1/devices", Package: "reports/devices", Verbs: []string{"get"}},
+	{Product: "zdx", Name: "users", Endpoint: "/zdx/v1/users", Package: "reports/users", Verbs: []string{"get"}},
+	{Product: "zia", Name: "adaptiveAccessProfiles", Endpoint: "/zia/api/v1/adaptiveAccessProfiles", Package: "adaptive_access", Verbs: []string{"list"}},
+	{Product: "zia", Name: "adminRoles", Endpoint: "/zia/api/v1/adminRoles", Package: "adminuserrolemgmt/roles", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "adminUsers", Endpoint: "/zia/api/v1/adminUsers", Package: "adminuserrolemgmt/admins", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "alertDefinitions", Endpoint: "/zia/api/v1/alertDefinitions", Package: "security_ueba_alerts/alert_definitions", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "alertSubscriptions", Endpoint: "/zia/api/v1/alertSubscriptions", Package: "alerts", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "bandwidthClasses", Endpoint: "/zia/api/v1/bandwidthClasses", Package: "bandwidth_control/bandwidth_classes", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "bandwidthControlRules", Endpoint: "/zia/api/v1/bandwidthControlRules", Package: "bandwidth_control/bandwidth_control_rules", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "casbDlpRules", Endpoint: "/zia/api/v1/casbDlpRules", Package: "saas_security_api/casb_dlp_rules", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "casbMalwareRules", Endpoint: "/zia/api/v1/casbMalwareRules", Package: "saas_security_api/casb_malware_rules", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "cloudApplicationInstances", Endpoint: "/zia/api/v1/cloudApplicationInstances", Package: "cloud_app_instances", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "cloudToCloudIR", Endpoint: "/zia/api/v1/cloudToCloudIR", Package: "c2c_incident_receiver", Verbs: []string{"list", "get"}},
+	{Product: "zia", Name: "customTags", Endpoint: "/zia/api/v1/customTags", Package: "shadowitreport", Verbs: []string{"list"}},
+	{Product: "zia", Name: "datacenters", Endpoint: "/zia/api/v1/datacenters", Package: "trafficforwarding/dc_exclusions", Verbs: []string{"list"}},
+	{Product: "zia", Name: "dcExclusions", Endpoint: "/zia/api/v1/dcExclusions", Package: "trafficforwarding/dc_exclusions", Verbs: []string{"list", "create", "delete"}},
+	{Product: "zia", Name: "departments", Endpoint: "/zia/api/v1/departments", Package: "usermanagement/departments", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "deviceGroups", Endpoint: "/zia/api/v1/deviceGroups", Package: "devicegroups", Verbs: []string{"list"}},
+	{Product: "zia", Name: "devices", Endpoint: "/zia/api/v1/devices", Package: "devices", Verbs: []string{"list", "get"}},
+	{Product: "zia", Name: "dlpDictionaries", Endpoint: "/zia/api/v1/dlpDictionaries", Package: "dlp/dlpdictionaries", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "dlpEngines", Endpoint: "/zia/api/v1/dlpEngines", Package: "dlp/dlp_engines", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "dlpExactDataMatchSchemas", Endpoint: "/zia/api/v1/dlpExactDataMatchSchemas", Package: "dlp/dlp_exact_data_match", Verbs: []string{"list", "get"}},
+	{Product: "zia", Name: "dlpNotificationTemplates", Endpoint: "/zia/api/v1/dlpNotificationTemplates", Package: "dlp/dlp_notification_templates", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "dnatRules", Endpoint: "/zia/api/v1/dnatRules", Package: "nat_control_policies", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "dnsGateways", Endpoint: "/zia/api/v1/dnsGateways", Package: "firewallpolicies/dns_gateways", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "domainProfiles", Endpoint: "/zia/api/v1/domainProfiles", Package: "saas_security_api", Verbs: []string{"list"}},
+	{Product: "zia", Name: "emailRecipientProfile", Endpoint: "/zia/api/v1/emailRecipientProfile", Package: "email_profiles", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "eventlogEntryReport", Endpoint: "/zia/api/v1/eventlogEntryReport", Package: "eventlogentryreport", Verbs: []string{"list", "create"}},
+	{Product: "zia", Name: "extranet", Endpoint: "/zia/api/v1/extranet", Package: "trafficforwarding/extranet", Verbs: []string{"list", "get", "create", "delete"}},
+	{Product: "zia", Name: "fileTypeRules", Endpoint: "/zia/api/v1/fileTypeRules", Package: "filetypecontrol", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "firewallDnsRules", Endpoint: "/zia/api/v1/firewallDnsRules", Package: "firewalldnscontrolpolicies", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "firewallFilteringRules", Endpoint: "/zia/api/v1/firewallFilteringRules", Package: "firewallpolicies/filteringrules", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "firewallIpsRules", Endpoint: "/zia/api/v1/firewallIpsRules", Package: "ips_control_policies/ips_policies", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "forwardingRules", Endpoint: "/zia/api/v1/forwardingRules", Package: "forwarding_control_policy/forwarding_rules", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "greTunnels", Endpoint: "/zia/api/v1/greTunnels", Package: "trafficforwarding/gretunnels", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "groups", Endpoint: "/zia/api/v1/groups", Package: "usermanagement/groups", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "httpHeaderActionProfile", Endpoint: "/zia/api/v1/httpHeaderActionProfile", Package: "http_header_control/http_header_action_profile", Verbs: []string{"list", "create", "update", "delete"}},
+	{Product: "zia", Name: "httpHeaderProfile", Endpoint: "/zia/api/v1/httpHeaderProfile", Package: "http_header_control/http_header_profile", Verbs: []string{"list", "create", "update", "delete"}},
+	{Product: "zia", Name: "icapServers", Endpoint: "/zia/api/v1/icapServers", Package: "dlp/dlp_icap_servers", Verbs: []string{"list", "get"}},
+	{Product: "zia", Name: "idmprofile", Endpoint: "/zia/api/v1/idmprofile", Package: "dlp/dlp_idm_profiles", Verbs: []string{"list", "get"}},
+	{Product: "zia", Name: "incidentReceiverServers", Endpoint: "/zia/api/v1/incidentReceiverServers", Package: "dlp/dlp_incident_receiver_servers", Verbs: []string{"list", "get"}},
+	{Product: "zia", Name: "intermediateCaCertificate", Endpoint: "/zia/api/v1/intermediateCaCertificate", Package: "intermediatecacertificates", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "ipDestinationGroups", Endpoint: "/zia/api/v1/ipDestinationGroups", Package: "firewallpolicies/ipdestinationgroups", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "ipSourceGroups", Endpoint: "/zia/api/v1/ipSourceGroups", Package: "firewallpolicies/ipsourcegroups", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "ipsSignatureRules", Endpoint: "/zia/api/v1/ipsSignatureRules", Package: "ips_control_policies/ips_signature_rules", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "isolationVotiroCdr", Endpoint: "/zia/api/v1/isolationVotiroCdr", Package: "votiro_cdr", Verbs: []string{"list"}},
+	{Product: "zia", Name: "locations", Endpoint: "/zia/api/v1/locations", Package: "location/locationmanagement", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "networkApplicationGroups", Endpoint: "/zia/api/v1/networkApplicationGroups", Package: "firewallpolicies/networkapplicationgroups", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "networkApplications", Endpoint: "/zia/api/v1/networkApplications", Package: "firewallpolicies/networkapplications", Verbs: []string{"list", "get"}},
+	{Product: "zia", Name: "networkServiceGroups", Endpoint: "/zia/api/v1/networkServiceGroups", Package: "firewallpolicies/networkservicegroups", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "networkServices", Endpoint: "/zia/api/v1/networkServices", Package: "firewallpolicies/networkservices", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "nssFeeds", Endpoint: "/zia/api/v1/nssFeeds", Package: "cloudnss/cloudnss", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "nssServers", Endpoint: "/zia/api/v1/nssServers", Package: "cloudnss/nss_servers", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "pacFiles", Endpoint: "/zia/api/v1/pacFiles", Package: "pacfiles", Verbs: []string{"list", "create", "delete"}},
+	{Product: "zia", Name: "proxies", Endpoint: "/zia/api/v1/proxies", Package: "forwarding_control_policy/proxies", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "proxyGateways", Endpoint: "/zia/api/v1/proxyGateways", Package: "forwarding_control_policy/proxy_gateways", Verbs: []string{"list"}},
+	{Product: "zia", Name: "riskProfiles", Endpoint: "/zia/api/v1/riskProfiles", Package: "cloudapplications/risk_profiles", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "ruleLabels", Endpoint: "/zia/api/v1/ruleLabels", Package: "rule_labels", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "sandboxRules", Endpoint: "/zia/api/v1/sandboxRules", Package: "sandbox/sandbox_rules", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "sslInspectionRules", Endpoint: "/zia/api/v1/sslInspectionRules", Package: "sslinspection", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "staticIP", Endpoint: "/zia/api/v1/staticIP", Package: "trafficforwarding/staticips", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "subclouds", Endpoint: "/zia/api/v1/subclouds", Package: "trafficforwarding/sub_clouds", Verbs: []string{"list", "update", "delete"}},
+	{Product: "zia", Name: "subscriptions", Endpoint: "/zia/api/v1/subscriptions", Package: "organization_details", Verbs: []string{"list"}},
+	{Product: "zia", Name: "tenancyRestrictionProfile", Endpoint: "/zia/api/v1/tenancyRestrictionProfile", Package: "tenancy_restriction", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "timeIntervals", Endpoint: "/zia/api/v1/timeIntervals", Package: "time_intervals", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "timeWindows", Endpoint: "/zia/api/v1/timeWindows", Package: "firewallpolicies/timewindow", Verbs: []string{"list"}},
+	{Product: "zia", Name: "trafficCaptureRules", Endpoint: "/zia/api/v1/trafficCaptureRules", Package: "traffic_capture", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "urlCategories", Endpoint: "/zia/api/v1/urlCategories", Package: "urlcategories", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "urlFilteringRules", Endpoint: "/zia/api/v1/urlFilteringRules", Package: "urlfilteringpolicies", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "users", Endpoint: "/zia/api/v1/users", Package: "usermanagement/users", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "vips", Endpoint: "/zia/api/v1/vips", Package: "trafficforwarding/virtualipaddress", Verbs: []string{"list"}},
+	{Product: "zia", Name: "virtualZenClusters", Endpoint: "/zia/api/v1/virtualZenClusters", Package: "vzen_clusters", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "virtualZenNodes", Endpoint: "/zia/api/v1/virtualZenNodes", Package: "vzen_nodes", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "vpnCredentials", Endpoint: "/zia/api/v1/vpnCredentials", Package: "trafficforwarding/vpncredentials", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "webApplicationRules", Endpoint: "/zia/api/v1/webApplicationRules", Package: "cloudappcontrol", Verbs: []string{"list"}},
+	{Product: "zia", Name: "webDlpRules", Endpoint: "/zia/api/v1/webDlpRules", Package: "dlp/dlp_web_rules", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "workloadGroups", Endpoint: "/zia/api/v1/workloadGroups", Package: "workloadgroups", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zia", Name: "zpaGateways", Endpoint: "/zia/api/v1/zpaGateways", Package: "forwarding_control_policy/zpa_gateways", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zid", Name: "groups", Endpoint: "/admin/api/v1/groups", Package: "groups", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zid", Name: "resource-servers", Endpoint: "/admin/api/v1/resource-servers", Package: "resource_servers", Verbs: []string{"list", "get"}},
+	{Product: "zid", Name: "users", Endpoint: "/admin/api/v1/users", Package: "user_entitlement", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zpa", Name: "administrators", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/administrators", Package: "administrator_controller", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zpa", Name: "apiKeys", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/apiKeys", Package: "api_keys", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zpa", Name: "appConnectorGroup", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/appConnectorGroup", Package: "appconnectorgroup", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zpa", Name: "application", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/application", Package: "applicationsegment", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zpa", Name: "approval", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/approval", Package: "privilegedremoteaccess/praapproval", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zpa", Name: "banners", Endpoint: "/zpa/cbiconfig/cbi/api/customers/{customerId}/banners", Package: "cloudbrowserisolation/cbibannercontroller", Verbs: []string{"list", "get", "update", "delete"}},
+	{Product: "zpa", Name: "branchConnector", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/branchConnector", Package: "branch_connector", Verbs: []string{"list"}},
+	{Product: "zpa", Name: "certificate", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/certificate", Package: "bacertificate", Verbs: []string{"list", "get", "create", "delete"}},
+	{Product: "zpa", Name: "certificates", Endpoint: "/zpa/cbiconfig/cbi/api/customers/{customerId}/certificates", Package: "cloudbrowserisolation/cbicertificatecontroller", Verbs: []string{"list", "get", "update", "delete"}},
+	{Product: "zpa", Name: "cloudConnector", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/cloudConnector", Package: "cloud_connector", Verbs: []string{"list"}},
+	{Product: "zpa", Name: "cloudConnectorGroup", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/cloudConnectorGroup", Package: "cloud_connector_group", Verbs: []string{"list", "get"}},
+	{Product: "zpa", Name: "configOverrides", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/configOverrides", Package: "config_override", Verbs: []string{"list", "get", "create", "update"}},
+	{Product: "zpa", Name: "connector", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/connector", Package: "appconnectorcontroller", Verbs: []string{"list", "get", "update", "delete"}},
+	{Product: "zpa", Name: "credential", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/credential", Package: "privilegedremoteaccess/pracredential", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zpa", Name: "customerDRToolVersion", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/customerDRToolVersion", Package: "customer_dr_tool", Verbs: []string{"list"}},
+	{Product: "zpa", Name: "enrollmentCert", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/enrollmentCert", Package: "enrollmentcert", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zpa", Name: "idp", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/idp", Package: "idpcontroller", Verbs: []string{"list", "get"}},
+	{Product: "zpa", Name: "inspectionProfile", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/inspectionProfile", Package: "inspectioncontrol/inspection_profile", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zpa", Name: "lssConfig", Endpoint: "/zpa/mgmtconfig/v2/admin/customers/{customerId}/lssConfig", Package: "lssconfigcontroller", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zpa", Name: "machineGroup", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/machineGroup", Package: "machinegroup", Verbs: []string{"list", "get"}},
+	{Product: "zpa", Name: "microtenants", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/microtenants", Package: "microtenants", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zpa", Name: "namespace", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/namespace", Package: "tag_controller/tag_namespace", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zpa", Name: "network", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/network", Package: "trustednetwork", Verbs: []string{"list", "get"}},
+	{Product: "zpa", Name: "permissionGroups", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/permissionGroups", Package: "role_controller", Verbs: []string{"list"}},
+	{Product: "zpa", Name: "posture", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/posture", Package: "postureprofile", Verbs: []string{"list", "get"}},
+	{Product: "zpa", Name: "praConsole", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/praConsole", Package: "privilegedremoteaccess/praconsole", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zpa", Name: "praPortal", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/praPortal", Package: "privilegedremoteaccess/praportal", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zpa", Name: "privateCloud", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/privateCloud", Package: "private_cloud", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zpa", Name: "privateCloudController", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/privateCloudController", Package: "private_cloud_controller", Verbs: []string{"list", "get", "update", "delete"}},
+	{Product: "zpa", Name: "privateCloudControllerGroup", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/privateCloudControllerGroup", Package: "private_cloud_group", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zpa", Name: "profiles", Endpoint: "/zpa/cbiconfig/cbi/api/customers/{customerId}/profiles", Package: "cloudbrowserisolation/cbiprofilecontroller", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zpa", Name: "regions", Endpoint: "/zpa/cbiconfig/cbi/api/customers/{customerId}/regions", Package: "cloudbrowserisolation/cbiregions", Verbs: []string{"list"}},
+	{Product: "zpa", Name: "roles", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/roles", Package: "role_controller", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zpa", Name: "scimgroup", Endpoint: "/zpa/userconfig/v1/customers/{customerId}/scimgroup", Package: "scimgroup", Verbs: []string{"get"}},
+	{Product: "zpa", Name: "segmentGroup", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/segmentGroup", Package: "segmentgroup", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zpa", Name: "server", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/server", Package: "appservercontroller", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zpa", Name: "serverGroup", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/serverGroup", Package: "servergroup", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zpa", Name: "serviceEdge", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/serviceEdge", Package: "serviceedgecontroller", Verbs: []string{"list", "get", "update", "delete"}},
+	{Product: "zpa", Name: "serviceEdgeGroup", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/serviceEdgeGroup", Package: "serviceedgegroup", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zpa", Name: "tagGroup", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/tagGroup", Package: "tag_controller/tag_group", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zpa", Name: "userPortal", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/userPortal", Package: "userportal/portal_controller", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zpa", Name: "userPortalLink", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/userPortalLink", Package: "userportal/portal_link", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "zpa", Name: "vpnConnectedUsers", Endpoint: "/zpa/mgmtconfig/v1/admin/customers/{customerId}/vpnConnectedUsers", Package: "np_client", Verbs: []string{"list"}},
+	{Product: "zpa", Name: "zpaprofiles", Endpoint: "/zpa/cbiconfig/cbi/api/customers/{customerId}/zpaprofiles", Package: "cloudbrowserisolation/cbizpaprofile", Verbs: []string{"list"}},
+	{Product: "ztw", Name: "accountGroups", Endpoint: "/ztw/api/v1/accountGroups", Package: "partner_integrations/account_groups", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "ztw", Name: "adminRoles", Endpoint: "/ztw/api/v1/adminRoles", Package: "adminuserrolemgmt/adminroles", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "ztw", Name: "adminUsers", Endpoint: "/ztw/api/v1/adminUsers", Package: "adminuserrolemgmt/adminusers", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "ztw", Name: "apiKeys", Endpoint: "/ztw/api/v1/apiKeys", Package: "provisioning/api_keys", Verbs: []string{"list", "get", "create"}},
+	{Product: "ztw", Name: "dnsGateways", Endpoint: "/ztw/api/v1/dnsGateways", Package: "dns_gateway", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "ztw", Name: "ecgroup", Endpoint: "/ztw/api/v1/ecgroup", Package: "ecgroup", Verbs: []string{"list", "get", "delete"}},
+	{Product: "ztw", Name: "gateways", Endpoint: "/ztw/api/v1/gateways", Package: "forwarding_gateways/zia_forwarding_gateway", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "ztw", Name: "ipDestinationGroups", Endpoint: "/ztw/api/v1/ipDestinationGroups", Package: "policyresources/ipdestinationgroups", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "ztw", Name: "ipGroups", Endpoint: "/ztw/api/v1/ipGroups", Package: "policyresources/ipgroups", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "ztw", Name: "ipSourceGroups", Endpoint: "/ztw/api/v1/ipSourceGroups", Package: "policyresources/ipsourcegroups", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "ztw", Name: "location", Endpoint: "/ztw/api/v1/location", Package: "locationmanagement/location", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "ztw", Name: "locationTemplate", Endpoint: "/ztw/api/v1/locationTemplate", Package: "locationmanagement/locationtemplate", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "ztw", Name: "networkServiceGroups", Endpoint: "/ztw/api/v1/networkServiceGroups", Package: "policyresources/networkservicegroups", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "ztw", Name: "networkServices", Endpoint: "/ztw/api/v1/networkServices", Package: "policyresources/networkservices", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "ztw", Name: "provUrl", Endpoint: "/ztw/api/v1/provUrl", Package: "provisioning/provisioning_url", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "ztw", Name: "publicCloudAccountDetails", Endpoint: "/ztw/api/v1/publicCloudAccountDetails", Package: "provisioning/public_cloud_account", Verbs: []string{"list", "get"}},
+	{Product: "ztw", Name: "publicCloudInfo", Endpoint: "/ztw/api/v1/publicCloudInfo", Package: "partner_integrations", Verbs: []string{"list", "get", "create", "update", "delete"}},
+	{Product: "ztw", Name: "workloadGroups", Endpoint: "/ztw/api/v1/workloadGroups", Package: "workload_groups", Verbs: []string{"list", "get"}},
+	{Product: "zwa", Name: "incidents", Endpoint: "/dlp/v1/incidents", Package: "dlp_incidents", Verbs: []string{"get", "delete"}},
+}
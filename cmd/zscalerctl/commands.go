@@ -0,0 +1,385 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	ziaactivation "github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/activation"
+	ziacommon "github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
+	zpacommon "github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
+	ztwactivation "github.com/zscaler/zscaler-sdk-go/v3/zscaler/ztw/services/activation"
+	ztwcommon "github.com/zscaler/zscaler-sdk-go/v3/zscaler/ztw/services/common"
+	zwaservices "github.com/zscaler/zscaler-sdk-go/v3/zscaler/zwa/services"
+)
+
+// zpaFilterParams are the -param keys passed to ZPA list requests.
+var zpaFilterParams = []string{"search", "sortBy", "sortOrder", "applicationType", "expandAll"}
+
+func (a *app) dispatch(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageErrorf("missing command")
+	}
+	if args[0] == "resources" {
+		return a.resources(args[1:])
+	}
+	product := strings.ToLower(args[0])
+	if !isProduct(product) {
+		return usageErrorf("unknown product or command %q", args[0])
+	}
+	if len(args) < 2 {
+		return usageErrorf("missing %s command", product)
+	}
+	verb, args := args[1], args[2:]
+
+	switch {
+	case product == "zia" && verb == "activate":
+		return a.withService(ctx, args, 0, a.ziaActivate)
+	case product == "zia" && verb == "activation-status":
+		return a.withService(ctx, args, 0, a.ziaActivationStatus)
+	case product == "ztw" && verb == "activate":
+		return a.withService(ctx, args, 0, a.ztwActivate)
+	case product == "ztw" && verb == "activation-status":
+		return a.withService(ctx, args, 0, a.ztwActivationStatus)
+	}
+
+	var method string
+	want := 2
+	switch verb {
+	case "list":
+		method, want = http.MethodGet, 1
+	case "get":
+		method = http.MethodGet
+	case "create":
+		method, want = http.MethodPost, 1
+	case "update":
+		method = http.MethodPut
+	case "delete":
+		method = http.MethodDelete
+	default:
+		return usageErrorf("unknown %s command %q", product, verb)
+	}
+	// list also takes a key for the collections that are listed per key,
+	// such as the web application rules of a rule type.
+	if verb == "list" && len(args) == 2 {
+		want = 2
+	}
+	if len(args) != want {
+		if verb == "list" {
+			return usageErrorf("%s list takes a resource and an optional key", product)
+		}
+		if want == 1 {
+			return usageErrorf("%s %s takes a resource", product, verb)
+		}
+		return usageErrorf("%s %s takes a resource and an ID", product, verb)
+	}
+	endpoint, err := endpointOf(product, verb, args[0])
+	if err != nil {
+		return err
+	}
+	if want == 2 {
+		endpoint += "/" + url.PathEscape(args[1])
+	}
+	var body io.Reader
+	if method == http.MethodPost || method == http.MethodPut {
+		if body, err = a.body(); err != nil {
+			return err
+		}
+	}
+
+	if product == "zwa" {
+		return a.withZwaService(func(service *zwaservices.Service) error {
+			return a.zwaCall(ctx, service, method, endpoint, body)
+		})
+	}
+	return a.withService(ctx, nil, 0, func(ctx context.Context, service *zscaler.Service) error {
+		endpoint := strings.ReplaceAll(endpoint, "{customerId}", service.Client.GetCustomerID())
+		if verb == "list" {
+			return a.list(ctx, service, product, endpoint)
+		}
+		return a.call(ctx, service, method, endpoint, body)
+	})
+}
+
+// endpointOf resolves a resource name, checking that the resource supports
+// verb, or takes a path as is.
+func endpointOf(product, verb, resource string) (string, error) {
+	if strings.HasPrefix(resource, "/") {
+		return strings.TrimSuffix(resource, "/"), nil
+	}
+	r, ok := findResource(product, resource)
+	if !ok {
+		return "", usageErrorf("unknown %s resource %q: run 'zscalerctl resources %s' for the list", product, resource, product)
+	}
+	if !r.supports(verb) {
+		return "", usageErrorf("%s %s does not support %s: use %s, or an API path", product, r.Name, verb, strings.Join(r.Verbs, ", "))
+	}
+	return r.Endpoint, nil
+}
+
+func (a *app) resources(args []string) error {
+	if len(args) > 1 {
+		return usageErrorf("resources takes at most a product")
+	}
+	product := ""
+	if len(args) == 1 {
+		product = strings.ToLower(args[0])
+		if !isProduct(product) {
+			return usageErrorf("unknown product %q", args[0])
+		}
+	}
+	v, err := toJSONValue(resourcesOf(product))
+	if err != nil {
+		return err
+	}
+	if len(a.columns) == 0 {
+		a.columns = []string{"product", "name", "verbs", "package", "endpoint"}
+	}
+	return a.print(v)
+}
+
+// withService checks that args holds n arguments, builds the service and
+// calls fn with it.
+func (a *app) withService(ctx context.Context, args []string, n int, fn func(ctx context.Context, service *zscaler.Service) error) error {
+	if len(args) != n {
+		return usageErrorf("unexpected arguments %q", args)
+	}
+	service, err := a.newService(a.setters()...)
+	if err != nil {
+		return err
+	}
+	defer service.Client.Close()
+	return fn(ctx, service)
+}
+
+// withZwaService builds the ZWA service and calls fn with it.
+func (a *app) withZwaService(fn func(service *zwaservices.Service) error) error {
+	if a.profile != "" {
+		return usageErrorf("zwa does not support -profile: set ZWA_API_KEY_ID, ZWA_API_SECRET and ZWA_CLOUD")
+	}
+	service, err := a.newZwaService(a.zwaSetters()...)
+	if err != nil {
+		return err
+	}
+	return fn(service)
+}
+
+// list reads every page of a collection. ZIA, ZTW and ZPA collections are
+// paginated; the collections of the other products are read in one request.
+func (a *app) list(ctx context.Context, service *zscaler.Service, product, endpoint string) error {
+	var items []json.RawMessage
+	var err error
+	switch product {
+	case "zia", "ztw":
+		paged := withQuery(endpoint, a.queryParams())
+		if product == "zia" {
+			err = ziacommon.ReadAllPages(ctx, service.Client, paged, &items)
+		} else {
+			err = ztwcommon.ReadAllPages(ctx, service.Client, paged, &items)
+		}
+		// Some list endpoints return an object rather than an array; show
+		// it as is.
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return a.call(ctx, service, http.MethodGet, endpoint, nil)
+		}
+	case "zpa":
+		filter, ferr := a.zpaFilter()
+		if ferr != nil {
+			return ferr
+		}
+		items, _, err = zpacommon.GetAllPagesGenericWithCustomFilters[json.RawMessage](ctx, service.Client, endpoint, filter)
+	default:
+		return a.call(ctx, service, http.MethodGet, endpoint, nil)
+	}
+	if err != nil {
+		return err
+	}
+	v, err := toJSONValue(items)
+	if err != nil {
+		return err
+	}
+	if v == nil {
+		v = []interface{}{}
+	}
+	return a.print(v)
+}
+
+// queryParams returns the -param values as query parameters.
+func (a *app) queryParams() url.Values {
+	values := url.Values{}
+	for _, p := range a.params {
+		values.Add(p.key, p.value)
+	}
+	return values
+}
+
+func (a *app) zpaFilter() (zpacommon.Filter, error) {
+	var filter zpacommon.Filter
+	if a.microtenant != "" {
+		filter.MicroTenantID = &a.microtenant
+	}
+	for _, p := range a.params {
+		switch p.key {
+		case "search":
+			filter.Search = p.value
+		case "sortBy":
+			filter.SortBy = p.value
+		case "sortOrder":
+			filter.SortOrder = p.value
+		case "applicationType":
+			filter.ApplicationType = p.value
+		case "expandAll":
+			filter.ExpandAll = p.value == "true"
+		default:
+			return filter, usageErrorf("zpa list does not support -param %s: use %s", p.key, strings.Join(zpaFilterParams, ", "))
+		}
+	}
+	return filter, nil
+}
+
+// call sends one request and prints the decoded response body, if any.
+func (a *app) call(ctx context.Context, service *zscaler.Service, method, endpoint string, body io.Reader) error {
+	params := a.queryParams()
+	if a.microtenant != "" && strings.HasPrefix(endpoint, "/zpa/") && params.Get("microtenantId") == "" {
+		params.Set("microtenantId", a.microtenant)
+	}
+	respBody, _, _, err := service.Client.ExecuteRequest(ctx, method, endpoint, body, params, "application/json")
+	if err != nil {
+		return err
+	}
+	return a.printBody(respBody)
+}
+
+// printBody prints a decoded response body, or the body as is when it is
+// not JSON.
+func (a *app) printBody(respBody []byte) error {
+	if len(bytes.TrimSpace(respBody)) == 0 {
+		return nil
+	}
+	v, err := decodeJSON(respBody)
+	if err != nil {
+		_, err = a.stdout.Write(respBody)
+		return err
+	}
+	return a.print(v)
+}
+
+// zwaCall sends one ZWA request and prints the decoded response body, if
+// any.
+func (a *app) zwaCall(ctx context.Context, service *zwaservices.Service, method, endpoint string, body io.Reader) error {
+	var payload interface{}
+	if body != nil {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return err
+		}
+		payload = json.RawMessage(data)
+	}
+	resp, err := service.Client.NewRequestDo(ctx, method, withQuery(endpoint, a.queryParams()), nil, payload, nil)
+	if err != nil {
+		return err
+	}
+	// The client buffers the body before returning, so it can be read
+	// after it was closed.
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	return a.printBody(respBody)
+}
+
+// body reads the -f request body and checks that it is JSON.
+func (a *app) body() (io.Reader, error) {
+	var data []byte
+	var err error
+	switch a.file {
+	case "":
+		return nil, usageErrorf("missing request body: use -f file.json or -f - for stdin")
+	case "-":
+		data, err = io.ReadAll(a.stdin)
+	default:
+		data, err = os.ReadFile(a.file)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !json.Valid(data) {
+		return nil, usageErrorf("request body %s is not valid JSON", a.fileName())
+	}
+	return bytes.NewReader(data), nil
+}
+
+func (a *app) fileName() string {
+	if a.file == "-" {
+		return "from stdin"
+	}
+	return a.file
+}
+
+func withQuery(endpoint string, values url.Values) string {
+	if len(values) == 0 {
+		return endpoint
+	}
+	sep := "?"
+	if strings.Contains(endpoint, "?") {
+		sep = "&"
+	}
+	return endpoint + sep + values.Encode()
+}
+
+// ziaActivate activates the pending ZIA changes and waits until the tenant
+// reports ACTIVE.
+func (a *app) ziaActivate(ctx context.Context, service *zscaler.Service) error {
+	session := ziaactivation.NewSession(service, ziaactivation.WithQuietPeriod(0))
+	session.Track()
+	if err := session.Commit(ctx); err != nil {
+		return err
+	}
+	return a.ziaActivationStatus(ctx, service)
+}
+
+func (a *app) ziaActivationStatus(ctx context.Context, service *zscaler.Service) error {
+	status, err := ziaactivation.GetActivationStatus(ctx, service)
+	if err != nil {
+		return err
+	}
+	return a.printValue(status)
+}
+
+// ztwActivate activates the pending ZTW changes, forcing the activation over
+// other admins' sessions with -force.
+func (a *app) ztwActivate(ctx context.Context, service *zscaler.Service) error {
+	activate := ztwactivation.UpdateActivationStatus
+	if a.force {
+		activate = ztwactivation.ForceActivationStatus
+	}
+	status, err := activate(ctx, service, ztwactivation.ECAdminActivation{})
+	if err != nil {
+		return err
+	}
+	return a.printValue(status)
+}
+
+func (a *app) ztwActivationStatus(ctx context.Context, service *zscaler.Service) error {
+	status, err := ztwactivation.GetActivationStatus(ctx, service)
+	if err != nil {
+		return err
+	}
+	return a.printValue(status)
+}
+
+func (a *app) printValue(v interface{}) error {
+	v, err := toJSONValue(v)
+	if err != nil {
+		return err
+	}
+	return a.print(v)
+}
@@ -0,0 +1,637 @@
+//go:build ignore
+
+// gen_catalog scans the endpoint constants of the service packages and
+// writes catalog_gen.go, the resources zscalerctl knows by name. Run it with
+// go generate from cmd/zscalerctl.
+//
+// A resource is listed with the verbs its package uses: list when the
+// package reads the collection into a slice or through a paging helper, or
+// exports GetAll or All for it, get,
+// update and delete when it sends GET, PUT and DELETE to <endpoint>/<id>,
+// and create when it POSTs to the collection. Endpoints that support neither
+// list nor get, such as the ZCC removeDevices action, are left out.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// collectionPath matches the endpoint of a collection: the product API prefix
+// followed by a single path segment, which is the resource name.
+var collectionPath = map[string]*regexp.Regexp{
+	"zia": regexp.MustCompile(`^/zia/api/v1/([A-Za-z][\w-]*)$`),
+	"ztw": regexp.MustCompile(`^/ztw/api/v1/([A-Za-z][\w-]*)$`),
+	"zpa": regexp.MustCompile(`^/zpa/[a-z]+/(?:v\d+/admin/customers|v\d+/customers|cbi/api/customers)/\{customerId\}/([A-Za-z][\w-]*)$`),
+	"zcc": regexp.MustCompile(`^/zcc/papi/public/v1/([A-Za-z][\w-]*)$`),
+	"zdx": regexp.MustCompile(`^/zdx/v1/([A-Za-z][\w-]*)$`),
+	"zid": regexp.MustCompile(`^/admin/api/v1/([A-Za-z][\w-]*)$`),
+	"zwa": regexp.MustCompile(`^/dlp/v1/([A-Za-z][\w-]*)$`),
+}
+
+// verbOrder is the order in which the verbs of a resource are listed.
+var verbOrder = []string{"list", "get", "create", "update", "delete"}
+
+type resource struct {
+	product, name, endpoint, pkg string
+	verbs                        map[string]bool
+}
+
+func main() {
+	root := filepath.Join("..", "..", "zscaler")
+	seen := map[string]resource{}
+	for product := range collectionPath {
+		dir := filepath.Join(root, product, "services")
+		pkgs := map[string][]*ast.File{}
+		fset := token.NewFileSet()
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+				return err
+			}
+			f, err := parser.ParseFile(fset, path, nil, 0)
+			if err != nil {
+				return err
+			}
+			pkgs[filepath.Dir(path)] = append(pkgs[filepath.Dir(path)], f)
+			return nil
+		})
+		if err != nil {
+			log.Fatal(err)
+		}
+		for dir, files := range pkgs {
+			pkg, _ := filepath.Rel(filepath.Join(root, product, "services"), dir)
+			consts := constants(files)
+			verbs := verbsOf(files, consts)
+			paths := endpoints(files, consts)
+			inferList(files, verbs, paths, collectionPath[product])
+			for name, paths := range paths {
+				for _, endpoint := range paths {
+					m := collectionPath[product].FindStringSubmatch(endpoint)
+					if m == nil {
+						continue
+					}
+					key := product + " " + strings.ToLower(m[1])
+					r, ok := seen[key]
+					if !ok || filepath.ToSlash(pkg) < r.pkg {
+						prev := r.verbs
+						r = resource{product: product, name: m[1], endpoint: endpoint, pkg: filepath.ToSlash(pkg), verbs: map[string]bool{}}
+						for v := range prev {
+							r.verbs[v] = true
+						}
+					}
+					for v := range verbs[name] {
+						r.verbs[v] = true
+					}
+					seen[key] = r
+				}
+			}
+		}
+	}
+
+	resources := make([]resource, 0, len(seen))
+	for _, r := range seen {
+		if r.verbs["list"] || r.verbs["get"] {
+			resources = append(resources, r)
+		}
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].product != resources[j].product {
+			return resources[i].product < resources[j].product
+		}
+		return resources[i].name < resources[j].name
+	})
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by gen_catalog.go; DO NOT EDIT.\n\npackage main\n\nvar catalog = []Resource{\n")
+	for _, r := range resources {
+		var verbs []string
+		for _, v := range verbOrder {
+			if r.verbs[v] {
+				verbs = append(verbs, strconv.Quote(v))
+			}
+		}
+		fmt.Fprintf(&b, "\t{Product: %q, Name: %q, Endpoint: %q, Package: %q, Verbs: []string{%s}},\n",
+			r.product, r.name, r.endpoint, r.pkg, strings.Join(verbs, ", "))
+	}
+	b.WriteString("}\n")
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("catalog_gen.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// constants returns the string constants of a package.
+func constants(files []*ast.File) map[string]string {
+	consts := map[string]string{}
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				for i, name := range vs.Names {
+					if i >= len(vs.Values) {
+						continue
+					}
+					if lit, ok := vs.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+						consts[name.Name], _ = strconv.Unquote(lit.Value)
+					}
+				}
+			}
+		}
+	}
+	return consts
+}
+
+// endpoints returns the paths of the *Endpoint constants of a package by
+// constant name. ZPA constants are relative to a customer prefix such as
+// mgmtConfigV1; they are resolved from the expressions that join them, e.g.
+// mgmtConfigV1 + service.Client.GetCustomerID() + segmentGroupEndpoint.
+func endpoints(files []*ast.File, consts map[string]string) map[string][]string {
+	paths := map[string][]string{}
+	for name, value := range consts {
+		if !isEndpoint(name) {
+			continue
+		}
+		if !strings.HasPrefix(value, "/") || strings.Count(value, "/") > 1 {
+			paths[name] = append(paths[name], value)
+			continue
+		}
+		for _, prefix := range prefixesOf(files, consts, name) {
+			paths[name] = append(paths[name], prefix+"{customerId}"+value)
+		}
+		paths[name] = append(paths[name], value)
+	}
+	return paths
+}
+
+func isEndpoint(name string) bool {
+	return strings.HasSuffix(name, "Endpoint")
+}
+
+// prefixesOf returns the values of the constants that name is appended to
+// after a GetCustomerID call.
+func prefixesOf(files []*ast.File, consts map[string]string, name string) []string {
+	var prefixes []string
+	for _, f := range files {
+		ast.Inspect(f, func(n ast.Node) bool {
+			expr, ok := n.(*ast.BinaryExpr)
+			if !ok || expr.Op != token.ADD {
+				return true
+			}
+			operands := flatten(expr)
+			for i := 2; i < len(operands); i++ {
+				last, ok := operands[i].(*ast.Ident)
+				if !ok || last.Name != name || !isCustomerIDCall(operands[i-1]) {
+					continue
+				}
+				if first, ok := operands[i-2].(*ast.Ident); ok {
+					if prefix, ok := consts[first.Name]; ok {
+						prefixes = append(prefixes, prefix)
+					}
+				}
+			}
+			return false
+		})
+	}
+	return prefixes
+}
+
+func flatten(e ast.Expr) []ast.Expr {
+	if b, ok := e.(*ast.BinaryExpr); ok && b.Op == token.ADD {
+		return append(flatten(b.X), flatten(b.Y)...)
+	}
+	return []ast.Expr{e}
+}
+
+func isCustomerIDCall(e ast.Expr) bool {
+	call, ok := e.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	return ok && sel.Sel.Name == "GetCustomerID"
+}
+
+// Markers used when rendering the path a request is sent to: an endpoint
+// constant is rendered as endpointMark + name + endpointMark, and any value
+// not known from the source, such as an ID, as unknownMark.
+const (
+	endpointMark = "\x00"
+	unknownMark  = "\x01"
+)
+
+// maxRenderings bounds the paths rendered for one expression, which grow
+// with the branches that assign its variables.
+const maxRenderings = 64
+
+// verbsOf returns the verbs each endpoint constant of a package is used with.
+// It looks at every call with an argument built from the constant: the
+// method is an argument such as http.MethodGet, or is implied by the name of
+// the called function, e.g. Create or UpdateWithPut.
+func verbsOf(files []*ast.File, consts map[string]string) map[string]map[string]bool {
+	verbs := map[string]map[string]bool{}
+	funcs := map[string]*ast.FuncDecl{}
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Body != nil {
+				funcs[fn.Name.Name] = fn
+			}
+		}
+	}
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil {
+				continue
+			}
+			r := &renderer{consts: consts, funcs: funcs, locals: locals(fn.Body)}
+			ast.Inspect(fn.Body, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				method := methodOf(call)
+				if method == "" {
+					return true
+				}
+				for _, arg := range call.Args {
+					for _, path := range r.render(arg, 0) {
+						for name, item := range addressed(path) {
+							verb := verbOf(method, item, r.many(call))
+							if verb == "" {
+								continue
+							}
+							if verbs[name] == nil {
+								verbs[name] = map[string]bool{}
+							}
+							verbs[name][verb] = true
+						}
+					}
+				}
+				return true
+			})
+		}
+	}
+	return verbs
+}
+
+// inferList adds list to the only readable collection of a package that
+// exports GetAll or All but lists it through another endpoint, such as a
+// POST to <endpoint>/search or a GET of <endpoint>/issued.
+func inferList(files []*ast.File, verbs map[string]map[string]bool, paths map[string][]string, collection *regexp.Regexp) {
+	if !exportsList(files) {
+		return
+	}
+	var readable []string
+	for name, ps := range paths {
+		for _, p := range ps {
+			if collection.MatchString(p) && verbs[name]["get"] {
+				readable = append(readable, name)
+				break
+			}
+		}
+	}
+	if len(readable) == 1 {
+		verbs[readable[0]]["list"] = true
+	}
+}
+
+// exportsList reports whether a package has a GetAll or All function.
+func exportsList(files []*ast.File) bool {
+	for _, f := range files {
+		for _, decl := range f.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && (fn.Name.Name == "GetAll" || fn.Name.Name == "All") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// verbOf maps a request to the zscalerctl verb that sends it, if any. A GET
+// of <endpoint>/<key> that reads a list, such as the web application rules
+// of a rule type, lists the collection scoped by that key.
+func verbOf(method string, item, many bool) string {
+	switch {
+	case method == http.MethodGet && many:
+		return "list"
+	case method == http.MethodGet && item:
+		return "get"
+	case method == http.MethodPost && !item:
+		return "create"
+	case method == http.MethodPut && item:
+		return "update"
+	case method == http.MethodDelete && item:
+		return "delete"
+	}
+	return ""
+}
+
+var methods = map[string]bool{
+	http.MethodGet:    true,
+	http.MethodPost:   true,
+	http.MethodPut:    true,
+	http.MethodPatch:  true,
+	http.MethodDelete: true,
+}
+
+// methodOf returns the HTTP method of a call, or "" if it is not a request.
+func methodOf(call *ast.CallExpr) string {
+	for _, arg := range call.Args {
+		switch arg := arg.(type) {
+		case *ast.BasicLit:
+			if s, err := strconv.Unquote(arg.Value); err == nil && methods[s] {
+				return s
+			}
+		case *ast.SelectorExpr:
+			if x, ok := arg.X.(*ast.Ident); ok && x.Name == "http" && strings.HasPrefix(arg.Sel.Name, "Method") {
+				return strings.ToUpper(strings.TrimPrefix(arg.Sel.Name, "Method"))
+			}
+		}
+	}
+	name := funcName(call)
+	switch {
+	case strings.HasPrefix(name, "Read"), strings.HasPrefix(name, "GetAll"), strings.Contains(name, "Page"):
+		return http.MethodGet
+	case strings.HasPrefix(name, "Create"), name == "BulkDelete", name == "BulkDeleteResource":
+		return http.MethodPost
+	case strings.HasPrefix(name, "UpdateWithPatch"):
+		return http.MethodPatch
+	case strings.HasPrefix(name, "Update"):
+		return http.MethodPut
+	case name == "Delete", name == "DeleteResource":
+		return http.MethodDelete
+	}
+	return ""
+}
+
+func funcName(call *ast.CallExpr) string {
+	fun := call.Fun
+	switch f := fun.(type) {
+	case *ast.IndexExpr:
+		fun = f.X
+	case *ast.IndexListExpr:
+		fun = f.X
+	}
+	switch f := fun.(type) {
+	case *ast.SelectorExpr:
+		return f.Sel.Name
+	case *ast.Ident:
+		return f.Name
+	}
+	return ""
+}
+
+// addressed returns the endpoint constants a rendered path is built from,
+// with whether it addresses an item (<endpoint>/<id>) rather than the
+// collection. Paths to sub-resources are ignored.
+func addressed(path string) map[string]bool {
+	found := map[string]bool{}
+	for {
+		i := strings.Index(path, endpointMark)
+		if i < 0 {
+			return found
+		}
+		j := strings.Index(path[i+1:], endpointMark)
+		if j < 0 {
+			return found
+		}
+		name, rest := path[i+1:i+1+j], path[i+2+j:]
+		if q := strings.IndexByte(rest, '?'); q >= 0 {
+			rest = rest[:q]
+		}
+		switch rest {
+		case "":
+			found[name] = false
+		case "/" + unknownMark:
+			found[name] = true
+		}
+		path = path[i+2+j:]
+	}
+}
+
+// renderer renders the paths an expression of a function may evaluate to.
+type renderer struct {
+	consts map[string]string
+	// funcs are the functions of the package, whose results are rendered
+	// where they are called, e.g. getAllEndpoint(opts).
+	funcs  map[string]*ast.FuncDecl
+	locals map[string]*local
+}
+
+// local holds the values assigned to a variable of a function.
+type local struct {
+	values []ast.Expr
+	// appended are the values added with +=.
+	appended []ast.Expr
+	// slice is set when the variable is declared as a slice.
+	slice bool
+}
+
+func locals(body *ast.BlockStmt) map[string]*local {
+	vars := map[string]*local{}
+	get := func(name string) *local {
+		if vars[name] == nil {
+			vars[name] = &local{}
+		}
+		return vars[name]
+	}
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			if len(n.Lhs) != len(n.Rhs) {
+				return true
+			}
+			for i, lhs := range n.Lhs {
+				id, ok := lhs.(*ast.Ident)
+				if !ok || id.Name == "_" {
+					continue
+				}
+				v := get(id.Name)
+				switch n.Tok {
+				case token.ADD_ASSIGN:
+					v.appended = append(v.appended, n.Rhs[i])
+				case token.DEFINE, token.ASSIGN:
+					v.values = append(v.values, n.Rhs[i])
+					v.slice = v.slice || isSliceExpr(n.Rhs[i])
+				}
+			}
+		case *ast.ValueSpec:
+			for i, id := range n.Names {
+				v := get(id.Name)
+				v.slice = v.slice || isSliceType(n.Type)
+				if i < len(n.Values) {
+					v.values = append(v.values, n.Values[i])
+					v.slice = v.slice || isSliceExpr(n.Values[i])
+				}
+			}
+		}
+		return true
+	})
+	return vars
+}
+
+func isSliceType(e ast.Expr) bool {
+	t, ok := e.(*ast.ArrayType)
+	return ok && t.Len == nil
+}
+
+func isSliceExpr(e ast.Expr) bool {
+	switch e := e.(type) {
+	case *ast.CompositeLit:
+		return isSliceType(e.Type)
+	case *ast.CallExpr:
+		if id, ok := e.Fun.(*ast.Ident); ok && (id.Name == "make" || id.Name == "new") && len(e.Args) > 0 {
+			return isSliceType(e.Args[0])
+		}
+	}
+	return false
+}
+
+// many reports whether a call reads a list: it is a paging helper, or its
+// result is decoded into a slice.
+func (r *renderer) many(call *ast.CallExpr) bool {
+	name := funcName(call)
+	if strings.Contains(name, "Page") || strings.HasPrefix(name, "GetAll") {
+		return true
+	}
+	if len(call.Args) == 0 {
+		return false
+	}
+	last := call.Args[len(call.Args)-1]
+	if u, ok := last.(*ast.UnaryExpr); ok && u.Op == token.AND {
+		last = u.X
+	}
+	if id, ok := last.(*ast.Ident); ok && r.locals[id.Name] != nil {
+		return r.locals[id.Name].slice
+	}
+	return false
+}
+
+var formatVerb = regexp.MustCompile(`%[-+# 0]*\d*(?:\.\d+)?[a-zA-Z%]`)
+
+func (r *renderer) render(e ast.Expr, depth int) []string {
+	if depth > 8 {
+		return []string{unknownMark}
+	}
+	switch e := e.(type) {
+	case *ast.BasicLit:
+		if s, err := strconv.Unquote(e.Value); err == nil && e.Kind == token.STRING {
+			return []string{s}
+		}
+	case *ast.ParenExpr:
+		return r.render(e.X, depth+1)
+	case *ast.Ident:
+		if value, ok := r.consts[e.Name]; ok {
+			if isEndpoint(e.Name) {
+				return []string{endpointMark + e.Name + endpointMark}
+			}
+			return []string{value}
+		}
+		v, ok := r.locals[e.Name]
+		if !ok || len(v.values) == 0 {
+			break
+		}
+		var out []string
+		for _, value := range v.values {
+			out = append(out, r.render(value, depth+1)...)
+		}
+		for _, suffix := range v.appended {
+			out = append(out, join(out, r.render(suffix, depth+1))...)
+		}
+		return limit(out)
+	case *ast.BinaryExpr:
+		if e.Op == token.ADD {
+			return join(r.render(e.X, depth+1), r.render(e.Y, depth+1))
+		}
+	case *ast.CallExpr:
+		if sel, ok := e.Fun.(*ast.SelectorExpr); ok && sel.Sel.Name == "Sprintf" && len(e.Args) > 0 {
+			return r.sprintf(e.Args[0], e.Args[1:], depth)
+		}
+		if id, ok := e.Fun.(*ast.Ident); ok && r.funcs[id.Name] != nil {
+			return r.results(r.funcs[id.Name], depth)
+		}
+	}
+	return []string{unknownMark}
+}
+
+// results renders the first result of the return statements of fn.
+func (r *renderer) results(fn *ast.FuncDecl, depth int) []string {
+	inner := &renderer{consts: r.consts, funcs: r.funcs, locals: locals(fn.Body)}
+	var out []string
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if len(n.Results) > 0 {
+				out = append(out, inner.render(n.Results[0], depth+1)...)
+			}
+		}
+		return true
+	})
+	if len(out) == 0 {
+		return []string{unknownMark}
+	}
+	return limit(out)
+}
+
+// sprintf renders a fmt.Sprintf call, replacing each verb with its argument.
+func (r *renderer) sprintf(format ast.Expr, args []ast.Expr, depth int) []string {
+	var out []string
+	for _, f := range r.render(format, depth+1) {
+		parts := []string{""}
+		i, n := 0, 0
+		for _, loc := range formatVerb.FindAllStringIndex(f, -1) {
+			parts = join(parts, []string{f[i:loc[0]]})
+			switch {
+			case f[loc[1]-1] == '%':
+				parts = join(parts, []string{"%"})
+			case n < len(args):
+				parts = join(parts, r.render(args[n], depth+1))
+				n++
+			default:
+				parts = join(parts, []string{unknownMark})
+			}
+			i = loc[1]
+		}
+		out = append(out, join(parts, []string{f[i:]})...)
+	}
+	return limit(out)
+}
+
+func join(xs, ys []string) []string {
+	var out []string
+	for _, x := range xs {
+		for _, y := range ys {
+			out = append(out, x+y)
+		}
+	}
+	return limit(out)
+}
+
+func limit(paths []string) []string {
+	if len(paths) > maxRenderings {
+		return paths[:maxRenderings]
+	}
+	return paths
+}
@@ -0,0 +1,261 @@
+// Command zscalerctl manages Zscaler tenants from the command line through the
+// OneAPI client of the SDK, and ZWA through its own client.
+//
+// Usage:
+//
+//	zscalerctl [flags] resources [product]
+//	zscalerctl [flags] <product> list <resource> [key]
+//	zscalerctl [flags] <product> get <resource> <id>
+//	zscalerctl [flags] <product> create <resource> -f body.json
+//	zscalerctl [flags] <product> update <resource> <id> -f body.json
+//	zscalerctl [flags] <product> delete <resource> <id>
+//	zscalerctl [flags] zia activate|activation-status
+//	zscalerctl [flags] ztw activate [-force]|activation-status
+//
+// Products are zcc, zdx, zia, zid, zpa, ztw and zwa. A resource is a name
+// listed by "zscalerctl resources", such as urlCategories or segmentGroup,
+// or an API path starting with "/". A named resource only takes the verbs
+// its service package uses, shown by "zscalerctl resources". The key of
+// list scopes collections that are listed per key, such as the ZIA
+// webApplicationRules of a rule type. Credentials
+// and the cloud are read from the environment or, with -profile, from the
+// profiles file, like the SDK does; ZWA reads ZWA_API_KEY_ID,
+// ZWA_API_SECRET and ZWA_CLOUD:
+//
+//	zscalerctl -profile prod -o json -query "[?name=='web'].id" zpa list segmentGroup
+//	zscalerctl zia create ruleLabels -f label.json && zscalerctl zia activate
+//
+// Flags may appear anywhere on the command line.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zwa"
+	zwaservices "github.com/zscaler/zscaler-sdk-go/v3/zscaler/zwa/services"
+)
+
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+// errUsage marks errors caused by the command line rather than the API.
+var errUsage = errors.New("usage")
+
+func usageErrorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: "+format, append([]interface{}{errUsage}, args...)...)
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	code := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// app holds the parsed command line of one zscalerctl run.
+type app struct {
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+
+	// newService builds the OneAPI service; tests replace it with a fake
+	// tenant.
+	newService func(setters ...zscaler.ConfigSetter) (*zscaler.Service, error)
+	// newZwaService builds the ZWA service, which is not part of OneAPI.
+	newZwaService func(setters ...zwa.ConfigSetter) (*zwaservices.Service, error)
+
+	profile     string
+	output      string
+	query       string
+	file        string
+	microtenant string
+	columns     []string
+	params      []param
+	force       bool
+	debug       bool
+}
+
+type param struct {
+	key, value string
+}
+
+func newApp(stdin io.Reader, stdout, stderr io.Writer) *app {
+	return &app{
+		stdin:         stdin,
+		stdout:        stdout,
+		stderr:        stderr,
+		newService:    newService,
+		newZwaService: newZwaService,
+	}
+}
+
+func newService(setters ...zscaler.ConfigSetter) (*zscaler.Service, error) {
+	cfg, err := zscaler.NewConfiguration(setters...)
+	if err != nil {
+		return nil, err
+	}
+	return zscaler.NewOneAPIClient(cfg)
+}
+
+func newZwaService(setters ...zwa.ConfigSetter) (*zwaservices.Service, error) {
+	cfg, err := zwa.NewConfiguration(setters...)
+	if err != nil {
+		return nil, err
+	}
+	client, err := zwa.NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return zwaservices.New(client), nil
+}
+
+// run executes zscalerctl with args and returns the exit code.
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	return newApp(stdin, stdout, stderr).run(ctx, args)
+}
+
+func (a *app) run(ctx context.Context, args []string) int {
+	positional, err := a.parse(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+	if err == nil {
+		if !a.debug {
+			log.SetOutput(io.Discard)
+		}
+		err = a.dispatch(ctx, positional)
+	}
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(a.stderr, "zscalerctl: %v\n", strings.TrimPrefix(err.Error(), errUsage.Error()+": "))
+		fmt.Fprintln(a.stderr, "Run 'zscalerctl -h' for usage.")
+		return exitUsage
+	default:
+		fmt.Fprintf(a.stderr, "zscalerctl: %v\n", err)
+		return exitError
+	}
+}
+
+// parse parses the flags, which may be interleaved with the positional
+// arguments, and returns the positional arguments.
+func (a *app) parse(args []string) ([]string, error) {
+	fs := flag.NewFlagSet("zscalerctl", flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	fs.StringVar(&a.profile, "profile", "", "profile from the profiles file (default $ZSCALER_PROFILE)")
+	fs.StringVar(&a.output, "o", "table", "output format: table, json or yaml")
+	fs.StringVar(&a.output, "output", "table", "output format: table, json or yaml")
+	fs.StringVar(&a.query, "query", "", "JMESPath expression applied to the result")
+	fs.StringVar(&a.file, "f", "", "JSON request body for create and update, - for stdin")
+	fs.StringVar(&a.microtenant, "microtenant", "", "ZPA microtenant ID")
+	fs.BoolVar(&a.force, "force", false, "force the ZTW activation")
+	fs.BoolVar(&a.debug, "debug", false, "log SDK requests to stderr")
+	fs.Func("columns", "comma-separated fields shown by the table output", func(s string) error {
+		a.columns = nil
+		for _, c := range strings.Split(s, ",") {
+			if c = strings.TrimSpace(c); c != "" {
+				a.columns = append(a.columns, c)
+			}
+		}
+		return nil
+	})
+	fs.Func("param", "query parameter key=value, repeatable", func(s string) error {
+		key, value, ok := strings.Cut(s, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("want key=value, got %q", s)
+		}
+		a.params = append(a.params, param{key, value})
+		return nil
+	})
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), usage)
+		fs.PrintDefaults()
+	}
+
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, usageErrorf("%v", err)
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+	switch a.output {
+	case "table", "json", "yaml":
+	default:
+		return nil, usageErrorf("unknown output format %q: use table, json or yaml", a.output)
+	}
+	return positional, nil
+}
+
+const usage = `Usage:
+  zscalerctl [flags] resources [product]
+  zscalerctl [flags] <product> list <resource> [key]
+  zscalerctl [flags] <product> get <resource> <id>
+  zscalerctl [flags] <product> create <resource> -f body.json
+  zscalerctl [flags] <product> update <resource> <id> -f body.json
+  zscalerctl [flags] <product> delete <resource> <id>
+  zscalerctl [flags] zia activate|activation-status
+  zscalerctl [flags] ztw activate [-force]|activation-status
+
+Products: zcc, zdx, zia, zid, zpa, ztw, zwa. A resource is a name listed by
+"zscalerctl resources", which also shows the verbs it takes, or an API path
+starting with "/".
+
+Flags:
+`
+
+// setters returns the configuration selected by the flags.
+func (a *app) setters() []zscaler.ConfigSetter {
+	setters := []zscaler.ConfigSetter{zscaler.WithUserAgentExtra("zscalerctl")}
+	if a.profile != "" {
+		setters = append(setters, zscaler.WithProfile(a.profile))
+	}
+	if a.microtenant != "" {
+		setters = append(setters, zscaler.WithZPAMicrotenantID(a.microtenant))
+	}
+	if a.debug {
+		setters = append(setters, zscaler.WithDebug(true))
+	}
+	return setters
+}
+
+// zwaSetters returns the ZWA configuration selected by the flags. ZWA is
+// not part of OneAPI, so it has no profiles.
+func (a *app) zwaSetters() []zwa.ConfigSetter {
+	setters := []zwa.ConfigSetter{zwa.WithUserAgentExtra("zscalerctl")}
+	if a.debug {
+		setters = append(setters, zwa.WithDebug(true))
+	}
+	return setters
+}
+
+// print applies -query to v and renders it in the -o format.
+func (a *app) print(v interface{}) error {
+	v, err := zscaler.SearchJMESPath(v, a.query)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+	return render(a.stdout, a.output, a.columns, v)
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zscaler/zscaler-sdk-go/v3/logger"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zwa"
+	zwaservices "github.com/zscaler/zscaler-sdk-go/v3/zscaler/zwa/services"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscalertest"
+)
+
+// runFake runs zscalerctl against fake and returns the exit code, stdout and
+// stderr.
+func runFake(t *testing.T, fake *zscalertest.Server, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	a := newApp(strings.NewReader(stdin), &stdout, &stderr)
+	a.newService = func(setters ...zscaler.ConfigSetter) (*zscaler.Service, error) {
+		return fake.NewService(setters...)
+	}
+	code := a.run(context.Background(), args)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestCatalog(t *testing.T) {
+	for product, name := range map[string]string{
+		"zia": "urlCategories",
+		"zpa": "segmentGroup",
+		"ztw": "ecgroup",
+	} {
+		r, ok := findResource(product, strings.ToUpper(name))
+		require.True(t, ok, "%s %s", product, name)
+		assert.True(t, strings.HasSuffix(r.Endpoint, "/"+name), r.Endpoint)
+	}
+	for _, r := range catalog {
+		assert.True(t, isProduct(r.Product), r.Product)
+		assert.True(t, r.supports("list") || r.supports("get"), "%s %s", r.Product, r.Name)
+	}
+	for _, name := range []string{"removeDevices", "forceRemoveDevices", "getOtp", "setCompanyInfo", "syncZpaAdminUsers"} {
+		_, ok := findResource("zcc", name)
+		assert.False(t, ok, "zcc %s is an action, not a collection", name)
+	}
+	r, ok := findResource("ztw", "ecgroup")
+	require.True(t, ok)
+	assert.Equal(t, []string{"list", "get", "delete"}, r.Verbs)
+	r, ok = findResource("zwa", "incidents")
+	require.True(t, ok)
+	assert.Equal(t, []string{"get", "delete"}, r.Verbs)
+}
+
+func TestZIACrudAndActivate(t *testing.T) {
+	fake := zscalertest.NewServer()
+
+	code, out, errOut := runFake(t, fake, `{"name":"prod","description":"production"}`, "zia", "create", "ruleLabels", "-f", "-", "-o", "json")
+	require.Equal(t, exitOK, code, errOut)
+	var created map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(out), &created))
+	assert.Equal(t, "prod", created["name"])
+	id := jsonID(created["id"])
+
+	code, out, errOut = runFake(t, fake, "", "zia", "list", "ruleLabels")
+	require.Equal(t, exitOK, code, errOut)
+	assert.Contains(t, out, "id")
+	assert.Contains(t, out, "prod")
+
+	code, out, errOut = runFake(t, fake, "", "-o", "yaml", "zia", "get", "ruleLabels", id)
+	require.Equal(t, exitOK, code, errOut)
+	assert.Contains(t, out, "name: prod")
+	assert.Contains(t, out, "id: "+id)
+
+	assert.Equal(t, zscalertest.StatusPending, fake.ActivationStatus())
+	code, out, errOut = runFake(t, fake, "", "zia", "activate", "-query", "status", "-o", "json")
+	require.Equal(t, exitOK, code, errOut)
+	assert.JSONEq(t, `"ACTIVE"`, out)
+	assert.Equal(t, zscalertest.StatusActive, fake.ActivationStatus())
+
+	code, _, errOut = runFake(t, fake, "", "zia", "delete", "ruleLabels", id)
+	require.Equal(t, exitOK, code, errOut)
+	assert.Empty(t, fake.Items(zscalertest.ZIARuleLabels))
+}
+
+func TestZPAListQuery(t *testing.T) {
+	fake := zscalertest.NewServer()
+	_, err := fake.Seed(zscalertest.ZPASegmentGroups,
+		map[string]interface{}{"name": "web", "enabled": true},
+		map[string]interface{}{"name": "db", "enabled": false},
+	)
+	require.NoError(t, err)
+
+	code, out, errOut := runFake(t, fake, "", "zpa", "list", "segmentGroup", "-o", "json", "-query", "[?enabled].name")
+	require.Equal(t, exitOK, code, errOut)
+	assert.JSONEq(t, `["web"]`, out)
+
+	code, out, errOut = runFake(t, fake, "", "zpa", "list", "segmentGroup", "-columns", "name")
+	require.Equal(t, exitOK, code, errOut)
+	assert.Contains(t, out, "web")
+	assert.NotContains(t, out, "enabled")
+}
+
+func TestListCoreCollections(t *testing.T) {
+	for _, name := range []string{"firewallFilteringRules", "users", "groups", "departments", "trafficCaptureRules", "webApplicationRules"} {
+		r, ok := findResource("zia", name)
+		require.True(t, ok, name)
+		assert.True(t, r.supports("list"), "zia %s", name)
+	}
+	for _, name := range []string{"namespace", "tagGroup", "certificate"} {
+		r, ok := findResource("zpa", name)
+		require.True(t, ok, name)
+		assert.True(t, r.supports("list"), "zpa %s", name)
+	}
+
+	fake := zscalertest.NewServer()
+	_, err := fake.Seed(zscalertest.ZIAFirewallRules, map[string]interface{}{"name": "allow-dns", "order": 1})
+	require.NoError(t, err)
+	code, out, errOut := runFake(t, fake, "", "zia", "list", "firewallFilteringRules", "-o", "json", "-query", "[].name")
+	require.Equal(t, exitOK, code, errOut)
+	assert.JSONEq(t, `["allow-dns"]`, out)
+
+	var path string
+	a := newApp(strings.NewReader(""), &bytes.Buffer{}, &bytes.Buffer{})
+	a.newService = func(setters ...zscaler.ConfigSetter) (*zscaler.Service, error) {
+		return fake.NewService(append(setters, zscaler.WithInterceptor(zscaler.Interceptor{
+			BeforeRequest: func(_ context.Context, info *zscaler.RequestInfo) error {
+				path = info.Request.URL.Path
+				return nil
+			},
+		}))...)
+	}
+	a.run(context.Background(), []string{"zia", "list", "webApplicationRules", "STREAMING_MEDIA"})
+	assert.Equal(t, "/zia/api/v1/webApplicationRules/STREAMING_MEDIA", path)
+}
+
+func TestUsageErrors(t *testing.T) {
+	fake := zscalertest.NewServer()
+	for _, args := range [][]string{
+		{},
+		{"zxx", "list", "x"},
+		{"zia", "list", "noSuchResource"},
+		{"zia", "list", "webApplicationRules", "STREAMING_MEDIA", "x"},
+		{"zia", "get", "ruleLabels"},
+		{"zia", "create", "ruleLabels"},
+		{"-o", "xml", "resources"},
+		{"zpa", "list", "segmentGroup", "-param", "page=2"},
+		{"zcc", "create", "removeDevices", "-f", "-"},
+		{"ztw", "create", "ecgroup", "-f", "-"},
+		{"zwa", "list", "incidents"},
+		{"-profile", "prod", "zwa", "get", "incidents", "42"},
+	} {
+		code, _, errOut := runFake(t, fake, "", args...)
+		assert.Equal(t, exitUsage, code, "%q: %s", args, errOut)
+	}
+
+	code, _, errOut := runFake(t, fake, "", "zia", "get", "ruleLabels", "404")
+	assert.Equal(t, exitError, code, errOut)
+}
+
+func TestZWAGet(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/auth/api-key/token", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"token_type":"Bearer","token":"zwa-token","expires_in":3600}`)
+	})
+	mux.HandleFunc("GET /dlp/v1/incidents/{id}", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer zwa-token", r.Header.Get("Authorization"))
+		fmt.Fprintf(w, `{"internalId":%q,"status":"OPEN"}`, r.PathValue("id"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	baseURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+	a := newApp(strings.NewReader(""), &stdout, &stderr)
+	a.newZwaService = func(setters ...zwa.ConfigSetter) (*zwaservices.Service, error) {
+		cfg := &zwa.Configuration{
+			Logger:     logger.GetDefaultLogger("zwa-logger: "),
+			HTTPClient: server.Client(),
+			BaseURL:    baseURL,
+			Context:    context.Background(),
+		}
+		setters = append(setters, zwa.WithZWAAPIKeyID("key"), zwa.WithZWAAPISecret("secret"), zwa.WithRateLimitMaxRetries(1))
+		for _, set := range setters {
+			set(cfg)
+		}
+		client, err := zwa.NewClient(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return zwaservices.New(client), nil
+	}
+	code := a.run(context.Background(), []string{"zwa", "get", "incidents", "42", "-o", "json"})
+	require.Equal(t, exitOK, code, stderr.String())
+	assert.JSONEq(t, `{"internalId":"42","status":"OPEN"}`, stdout.String())
+}
+
+func TestRender(t *testing.T) {
+	var b bytes.Buffer
+	items, err := decodeJSON([]byte(`[{"name":"a","id":72057594037928001,"tags":["x"]}]`))
+	require.NoError(t, err)
+	require.NoError(t, render(&b, "table", nil, items))
+	header := strings.SplitN(b.String(), "\n", 3)[1]
+	assert.Less(t, strings.Index(header, "id"), strings.Index(header, "name"))
+	assert.NotContains(t, header, "tags", "nested fields are left out of list tables")
+
+	b.Reset()
+	require.NoError(t, render(&b, "yaml", nil, items))
+	assert.Contains(t, b.String(), "id: 72057594037928001")
+}
+
+func jsonID(v interface{}) string {
+	b, _ := json.Marshal(v)
+	return strings.Trim(string(b), `"`)
+}
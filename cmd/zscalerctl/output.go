@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"gopkg.in/yaml.v3"
+)
+
+// maxTableColumns bounds the columns picked for list tables when -columns is
+// not set.
+const maxTableColumns = 6
+
+// render writes v, decoded JSON, as a table, JSON or YAML.
+func render(w io.Writer, format string, columns []string, v interface{}) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	case "yaml":
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(integers(v)); err != nil {
+			return err
+		}
+		return enc.Close()
+	case "table", "":
+		renderTable(w, columns, v)
+		return nil
+	}
+	return fmt.Errorf("unknown output format %q: use table, json or yaml", format)
+}
+
+func renderTable(w io.Writer, columns []string, v interface{}) {
+	table := tablewriter.NewWriter(w)
+	table.SetAutoWrapText(false)
+	table.SetAutoFormatHeaders(false)
+	switch v := v.(type) {
+	case []interface{}:
+		if len(columns) == 0 {
+			columns = tableColumns(v)
+		}
+		if len(columns) == 0 {
+			table.SetHeader([]string{"VALUE"})
+			for _, item := range v {
+				table.Append([]string{cell(item)})
+			}
+			break
+		}
+		table.SetHeader(columns)
+		for _, item := range v {
+			obj, _ := item.(map[string]interface{})
+			row := make([]string, len(columns))
+			for i, c := range columns {
+				row[i] = cell(obj[c])
+			}
+			table.Append(row)
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			if len(columns) == 0 || contains(columns, k) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		table.SetHeader([]string{"FIELD", "VALUE"})
+		for _, k := range keys {
+			table.Append([]string{k, cell(v[k])})
+		}
+	case nil:
+		return
+	default:
+		fmt.Fprintln(w, cell(v))
+		return
+	}
+	table.Render()
+}
+
+// tableColumns picks the scalar fields of items: id and name first, then the
+// others by name, up to maxTableColumns.
+func tableColumns(items []interface{}) []string {
+	seen := map[string]bool{}
+	for _, item := range items {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		for k, v := range obj {
+			switch v.(type) {
+			case map[string]interface{}, []interface{}:
+			default:
+				seen[k] = true
+			}
+		}
+	}
+	var columns []string
+	for _, k := range []string{"id", "name"} {
+		if seen[k] {
+			columns = append(columns, k)
+			delete(seen, k)
+		}
+	}
+	rest := make([]string, 0, len(seen))
+	for k := range seen {
+		rest = append(rest, k)
+	}
+	sort.Strings(rest)
+	columns = append(columns, rest...)
+	if len(columns) > maxTableColumns {
+		columns = columns[:maxTableColumns]
+	}
+	return columns
+}
+
+func cell(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case float64:
+		if isInteger(v) {
+			return fmt.Sprintf("%d", int64(v))
+		}
+		return fmt.Sprint(v)
+	case map[string]interface{}, []interface{}:
+		b, _ := json.Marshal(v)
+		s := string(b)
+		if len(s) > 60 {
+			s = s[:57] + "..."
+		}
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+// integers converts the numbers of decoded JSON to int64 or float64, so YAML
+// neither quotes them nor prints IDs in exponent notation.
+func integers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case float64:
+		if isInteger(v) {
+			return int64(v)
+		}
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = integers(item)
+		}
+		return out
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			out[k] = integers(item)
+		}
+		return out
+	}
+	return v
+}
+
+// isInteger reports whether f is integral and exactly representable, which
+// holds for the IDs decoded by JMESPath queries.
+func isInteger(f float64) bool {
+	return f == math.Trunc(f) && math.Abs(f) <= 1<<53
+}
+
+// decodeJSON decodes data keeping numbers as json.Number, so 64-bit IDs are
+// printed exactly.
+func decodeJSON(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// toJSONValue round-trips v through JSON, so typed SDK results render like
+// raw responses.
+func toJSONValue(v interface{}) (interface{}, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return decodeJSON(b)
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+// Package credentials resolves OneAPI client credentials when the client
+// authenticates, from sources such as a secrets file, a helper command or a
+// secret endpoint. Secrets therefore need not be written into configuration
+// files, and rotated secrets are picked up without restarting the process.
+package credentials
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Credentials are the OneAPI client credentials returned by a Provider. Empty
+// fields leave the configured value in place, so a provider may return just
+// the secret for a client ID set in the configuration.
+type Credentials struct {
+	ClientID     string `json:"clientId,omitempty" yaml:"clientId,omitempty"`
+	ClientSecret string `json:"clientSecret,omitempty" yaml:"clientSecret,omitempty"`
+	// PrivateKey is a PEM encoded RSA private key, used instead of the
+	// client secret when set.
+	PrivateKey string `json:"privateKey,omitempty" yaml:"privateKey,omitempty"`
+}
+
+// Empty reports whether c holds neither a client secret nor a private key.
+func (c *Credentials) Empty() bool {
+	return c == nil || (c.ClientSecret == "" && c.PrivateKey == "")
+}
+
+// Provider retrieves client credentials. Retrieve is called before the first
+// authentication and again whenever authentication fails, so it should fetch
+// the current secret rather than cache it. Implementations must be safe for
+// concurrent use.
+type Provider interface {
+	Retrieve(ctx context.Context) (*Credentials, error)
+}
+
+// ProviderFunc adapts a function to a Provider.
+type ProviderFunc func(ctx context.Context) (*Credentials, error)
+
+// Retrieve calls f.
+func (f ProviderFunc) Retrieve(ctx context.Context) (*Credentials, error) {
+	return f(ctx)
+}
+
+// ErrNoCredentials is returned, possibly wrapped, by a provider that has no
+// credentials to offer, e.g. because its environment variables are unset or
+// its file does not exist. Chain moves on to the next provider.
+var ErrNoCredentials = errors.New("credentials: no credentials available")
+
+// Chain returns a provider that tries providers in order and returns the first
+// credentials holding a secret or private key. Providers failing with
+// ErrNoCredentials are skipped; any other error stops the chain, so a broken
+// secret source is reported rather than silently bypassed.
+func Chain(providers ...Provider) Provider {
+	return ProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		var skipped []string
+		for _, p := range providers {
+			creds, err := p.Retrieve(ctx)
+			if errors.Is(err, ErrNoCredentials) || (err == nil && creds.Empty()) {
+				if err != nil {
+					skipped = append(skipped, err.Error())
+				}
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			return creds, nil
+		}
+		if len(skipped) == 0 {
+			return nil, ErrNoCredentials
+		}
+		return nil, fmt.Errorf("%w: %s", ErrNoCredentials, strings.Join(skipped, "; "))
+	})
+}
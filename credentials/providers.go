@@ -0,0 +1,186 @@
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultExecTimeout bounds a credential command when the context passed to
+// Retrieve has no deadline.
+const DefaultExecTimeout = 30 * time.Second
+
+// Env returns a provider reading ZSCALER_CLIENT_ID, ZSCALER_CLIENT_SECRET and
+// ZSCALER_PRIVATE_KEY on every call.
+func Env() Provider {
+	return ProviderFunc(func(context.Context) (*Credentials, error) {
+		creds := &Credentials{
+			ClientID:     os.Getenv("ZSCALER_CLIENT_ID"),
+			ClientSecret: os.Getenv("ZSCALER_CLIENT_SECRET"),
+			PrivateKey:   os.Getenv("ZSCALER_PRIVATE_KEY"),
+		}
+		if creds.Empty() {
+			return nil, fmt.Errorf("%w: ZSCALER_CLIENT_SECRET and ZSCALER_PRIVATE_KEY are unset", ErrNoCredentials)
+		}
+		return creds, nil
+	})
+}
+
+// File returns a provider reading credentials from the file at path on every
+// call. The file holds a JSON or YAML document with clientId, clientSecret
+// and privateKey fields, or just a PEM encoded private key. A missing file
+// yields ErrNoCredentials.
+func File(path string) Provider {
+	return ProviderFunc(func(context.Context) (*Credentials, error) {
+		data, err := os.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s does not exist", ErrNoCredentials, path)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("credentials: reading %s: %w", path, err)
+		}
+		if strings.HasPrefix(strings.TrimSpace(string(data)), "-----BEGIN") {
+			return &Credentials{PrivateKey: string(data)}, nil
+		}
+		creds := &Credentials{}
+		// YAML is a superset of JSON, so one decoder reads both.
+		if err := yaml.Unmarshal(data, creds); err != nil {
+			return nil, fmt.Errorf("credentials: parsing %s: %w", path, err)
+		}
+		return creds, nil
+	})
+}
+
+// Exec returns a provider running the command name with args on every call.
+// The command must print the credentials as a JSON object with clientId,
+// clientSecret and privateKey fields to standard output and exit with status
+// zero. The command is not run through a shell.
+func Exec(name string, args ...string) Provider {
+	return ProviderFunc(func(ctx context.Context) (*Credentials, error) {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, DefaultExecTimeout)
+			defer cancel()
+		}
+		var stdout, stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, name, args...)
+		cmd.Stdout, cmd.Stderr = &stdout, &stderr
+		if err := cmd.Run(); err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return nil, fmt.Errorf("credentials: running %s: %w: %s", name, err, msg)
+			}
+			return nil, fmt.Errorf("credentials: running %s: %w", name, err)
+		}
+		creds := &Credentials{}
+		if err := json.Unmarshal(stdout.Bytes(), creds); err != nil {
+			return nil, fmt.Errorf("credentials: parsing output of %s: %w", name, err)
+		}
+		return creds, nil
+	})
+}
+
+// HTTPOption configures the provider returned by HTTP.
+type HTTPOption func(*httpProvider)
+
+// WithHTTPClient sets the client used to fetch credentials. It defaults to a
+// client with a 30 second timeout.
+func WithHTTPClient(c *http.Client) HTTPOption {
+	return func(p *httpProvider) {
+		p.client = c
+	}
+}
+
+// WithHeader adds a header to every request, e.g. the token of a secrets
+// manager.
+func WithHeader(name, value string) HTTPOption {
+	return func(p *httpProvider) {
+		p.header.Add(name, value)
+	}
+}
+
+// WithResponsePath selects the object holding the credentials in the
+// response by a dot separated path of field names, e.g. "data.data" for a
+// HashiCorp Vault KV version 2 secret.
+func WithResponsePath(path string) HTTPOption {
+	return func(p *httpProvider) {
+		p.path = strings.Split(path, ".")
+	}
+}
+
+type httpProvider struct {
+	url    string
+	client *http.Client
+	header http.Header
+	path   []string
+}
+
+// HTTP returns a provider fetching credentials with a GET request to url on
+// every call. The response must be a JSON object with clientId, clientSecret
+// and privateKey fields, or hold one at the path set by WithResponsePath. A
+// 404 response yields ErrNoCredentials.
+func HTTP(url string, opts ...HTTPOption) Provider {
+	p := &httpProvider{
+		url:    url,
+		client: &http.Client{Timeout: 30 * time.Second},
+		header: http.Header{},
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+func (p *httpProvider) Retrieve(ctx context.Context) (*Credentials, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("credentials: %w", err)
+	}
+	for name, values := range p.header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("credentials: fetching %s: %w", req.URL.Redacted(), err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("credentials: fetching %s: %w", req.URL.Redacted(), err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s returned 404", ErrNoCredentials, req.URL.Redacted())
+	}
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("credentials: fetching %s: unexpected status %d", req.URL.Redacted(), resp.StatusCode)
+	}
+
+	raw := json.RawMessage(body)
+	for _, field := range p.path {
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			return nil, fmt.Errorf("credentials: parsing response of %s: %w", req.URL.Redacted(), err)
+		}
+		next, ok := obj[field]
+		if !ok {
+			return nil, fmt.Errorf("credentials: response of %s has no field %q", req.URL.Redacted(), field)
+		}
+		raw = next
+	}
+	creds := &Credentials{}
+	if err := json.Unmarshal(raw, creds); err != nil {
+		return nil, fmt.Errorf("credentials: parsing response of %s: %w", req.URL.Redacted(), err)
+	}
+	return creds, nil
+}
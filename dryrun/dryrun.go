@@ -0,0 +1,307 @@
+// Package dryrun captures the changes a program would make to a tenant
+// instead of sending them. A Plan is an http.RoundTripper middleware: GET,
+// HEAD and OPTIONS requests and authentication requests go through, and every
+// other request is recorded as a Change and answered with a synthetic
+// response. Review pipelines print or diff the plan before applying it.
+package dryrun
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/zscaler/zscaler-sdk-go/v3/logger"
+	rl "github.com/zscaler/zscaler-sdk-go/v3/ratelimiter"
+)
+
+// Header is set on every synthetic response.
+const Header = "X-Zscaler-Dry-Run"
+
+// Change is a mutation captured by a Plan.
+type Change struct {
+	// Seq numbers the changes of a plan from 1 in the order they were made.
+	Seq    int    `json:"seq"`
+	Method string `json:"method"`
+	// Product is zia, zpa, ztw, zcc, zdx, zid or zwa, or empty if the
+	// endpoint is not recognised.
+	Product string `json:"product,omitempty"`
+	// Endpoint is the request path and query.
+	Endpoint string `json:"endpoint"`
+	// ResourceType is the collection the request addresses, e.g.
+	// urlCategories or application. ResourceID is the object ID in the path,
+	// empty for creations and collection-wide actions.
+	ResourceType string `json:"resourceType,omitempty"`
+	ResourceID   string `json:"resourceId,omitempty"`
+	// Body is the request body with secrets redacted. JSON bodies are
+	// normalized (compact, keys sorted); other bodies are kept as a string.
+	Body json.RawMessage `json:"body,omitempty"`
+}
+
+// Action returns create, update or delete for POST, PUT/PATCH and DELETE
+// requests on a resource, or the request method otherwise.
+func (c Change) Action() string {
+	switch {
+	case c.Method == http.MethodPost && c.ResourceID == "":
+		return "create"
+	case c.Method == http.MethodPut || c.Method == http.MethodPatch:
+		return "update"
+	case c.Method == http.MethodDelete:
+		return "delete"
+	}
+	return strings.ToLower(c.Method)
+}
+
+// defaultPassthrough are the path suffixes of the authentication endpoints of
+// the OneAPI and legacy clients, which are sent even though they are not GETs.
+var defaultPassthrough = []string{
+	"/oauth2/v1/token",      // OneAPI
+	"/authenticatedSession", // legacy ZIA
+	"/auth",                 // legacy ZTW
+	"/signin",               // legacy ZPA
+	"/oauth/token",          // legacy ZDX
+	"/auth/v1/login",        // legacy ZCC
+	"/auth/api-key/token",   // legacy ZWA
+}
+
+// Option configures a Plan.
+type Option func(*Plan)
+
+// WithRedactor replaces the redactor applied to recorded bodies, by default
+// one with logger.DefaultRedactionRules.
+func WithRedactor(r *logger.Redactor) Option {
+	return func(p *Plan) {
+		p.redactor = r
+	}
+}
+
+// WithPassthrough sends non-GET requests whose path ends with one of
+// suffixes instead of recording them, e.g. for read-only POST search
+// endpoints.
+func WithPassthrough(suffixes ...string) Option {
+	return func(p *Plan) {
+		p.passthrough = append(p.passthrough, suffixes...)
+	}
+}
+
+// Plan records the changes made through its transports. A Plan is safe for
+// concurrent use and may be shared by several HTTP clients.
+type Plan struct {
+	redactor    *logger.Redactor
+	passthrough []string
+
+	mu      sync.Mutex
+	changes []Change
+}
+
+// NewPlan returns an empty plan.
+func NewPlan(opts ...Option) *Plan {
+	p := &Plan{
+		redactor:    logger.MustNewRedactor(logger.DefaultRedactionRules()),
+		passthrough: append([]string(nil), defaultPassthrough...),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Changes returns a copy of the recorded changes in order.
+func (p *Plan) Changes() []Change {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]Change(nil), p.changes...)
+}
+
+// Len returns the number of recorded changes.
+func (p *Plan) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.changes)
+}
+
+// Reset discards the recorded changes.
+func (p *Plan) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.changes = nil
+}
+
+// WriteJSON writes the changes to w as an indented JSON array.
+func (p *Plan) WriteJSON(w io.Writer) error {
+	changes := p.Changes()
+	if changes == nil {
+		changes = []Change{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(changes)
+}
+
+// String renders the plan for review, one change per line followed by its
+// body:
+//
+//  1. create zia urlCategories (POST /zia/api/v1/urlCategories)
+//     {"configuredName":"Blocked","urls":["example.com"]}
+func (p *Plan) String() string {
+	var b strings.Builder
+	for _, c := range p.Changes() {
+		target := strings.TrimSpace(c.Product + " " + c.ResourceType)
+		if c.ResourceID != "" {
+			target += " " + c.ResourceID
+		}
+		fmt.Fprintf(&b, "%d. %s %s (%s %s)\n", c.Seq, c.Action(), target, c.Method, c.Endpoint)
+		if len(c.Body) > 0 {
+			fmt.Fprintf(&b, "   %s\n", c.Body)
+		}
+	}
+	return b.String()
+}
+
+// Transport wraps base, which receives the requests that are not recorded. A
+// nil base uses http.DefaultTransport.
+func (p *Plan) Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{plan: p, base: base}
+}
+
+// Client returns a copy of base whose transport goes through the plan. A nil
+// base uses a zero http.Client. Clients already wrapped by p are returned as
+// is.
+func (p *Plan) Client(base *http.Client) *http.Client {
+	if base == nil {
+		base = &http.Client{}
+	}
+	if t, ok := base.Transport.(*transport); ok && t.plan == p {
+		return base
+	}
+	c := *base
+	c.Transport = p.Transport(base.Transport)
+	return &c
+}
+
+type transport struct {
+	plan *Plan
+	base http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.plan.captures(req) {
+		return t.base.RoundTrip(req)
+	}
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	t.plan.record(req, body)
+	return syntheticResponse(req, body), nil
+}
+
+func (p *Plan) captures(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	for _, suffix := range p.passthrough {
+		if strings.HasSuffix(req.URL.Path, suffix) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *Plan) record(req *http.Request, body []byte) {
+	product, resourceType, resourceID := classify(req.URL.Path)
+	c := Change{
+		Method:       req.Method,
+		Product:      product,
+		Endpoint:     p.redactor.RedactString(req.URL.RequestURI()),
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		Body:         p.normalize(body),
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	c.Seq = len(p.changes) + 1
+	p.changes = append(p.changes, c)
+}
+
+// normalize redacts body and compacts JSON with sorted keys, so equal
+// requests produce equal plans.
+func (p *Plan) normalize(body []byte) json.RawMessage {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	redacted := p.redactor.RedactBody(body)
+	dec := json.NewDecoder(strings.NewReader(redacted))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err == nil {
+		if out, err := json.Marshal(v); err == nil {
+			return out
+		}
+	}
+	out, _ := json.Marshal(redacted)
+	return out
+}
+
+// classify derives the product, resource type and ID from an API path such
+// as /zia/api/v1/urlCategories/42 or
+// /mgmtconfig/v1/admin/customers/{id}/application/{id}.
+func classify(path string) (product, resourceType, resourceID string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 0 {
+		switch segments[0] {
+		case "zia", "zpa", "ztw", "zcc", "zdx", "zwa":
+			product = segments[0]
+		case "zscsb":
+			product = "zia"
+		case "admin":
+			product = "zid"
+		case "mgmtconfig":
+			product = "zpa"
+		}
+	}
+	templated := strings.Split(strings.Trim(rl.EndpointTemplate(path), "/"), "/")
+	last := len(segments) - 1
+	if templated[last] == "{id}" && last > 0 {
+		return product, segments[last-1], segments[last]
+	}
+	return product, segments[last], ""
+}
+
+// syntheticResponse answers a captured request: DELETE and bodiless requests
+// with 204 No Content, others with 200 OK echoing the request body, which is
+// what most create and update endpoints return. IDs of created objects are
+// therefore not known during a dry run.
+func syntheticResponse(req *http.Request, body []byte) *http.Response {
+	status := http.StatusOK
+	if req.Method == http.MethodDelete || len(bytes.TrimSpace(body)) == 0 {
+		status, body = http.StatusNoContent, nil
+	}
+	header := http.Header{Header: {"true"}}
+	if body != nil {
+		header.Set("Content-Type", "application/json")
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
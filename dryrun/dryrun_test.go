@@ -0,0 +1,123 @@
+package dryrun
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanCapturesMutations(t *testing.T) {
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent = append(sent, r.Method+" "+r.URL.Path)
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	plan := NewPlan()
+	client := plan.Client(server.Client())
+	assert.Same(t, client, plan.Client(client), "clients are wrapped once")
+
+	do := func(method, path, body string) *http.Response {
+		t.Helper()
+		req, err := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		data, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+		return resp
+	}
+
+	resp := do(http.MethodGet, "/zia/api/v1/urlCategories", "")
+	assert.Empty(t, resp.Header.Get(Header))
+	do(http.MethodPost, "/oauth2/v1/token", "grant_type=client_credentials")
+
+	resp = do(http.MethodPost, "/zia/api/v1/urlCategories", `{"urls":["b.com"], "configuredName":"Blocked"}`)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "true", resp.Header.Get(Header))
+	body, _ := io.ReadAll(resp.Body)
+	assert.JSONEq(t, `{"urls":["b.com"],"configuredName":"Blocked"}`, string(body))
+
+	do(http.MethodPut, "/zpa/mgmtconfig/v1/admin/customers/100/application/200?forceDelete=true", `{"id":"200","enabled":false}`)
+	resp = do(http.MethodDelete, "/zia/api/v1/urlCategories/42", "")
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	do(http.MethodPost, "/zia/api/v1/users", `{"name":"jdoe","password":"hunter2"}`)
+
+	assert.Equal(t, []string{"GET /zia/api/v1/urlCategories", "POST /oauth2/v1/token"}, sent)
+
+	changes := plan.Changes()
+	require.Len(t, changes, 4)
+	assert.Equal(t, Change{
+		Seq:          1,
+		Method:       http.MethodPost,
+		Product:      "zia",
+		Endpoint:     "/zia/api/v1/urlCategories",
+		ResourceType: "urlCategories",
+		Body:         json.RawMessage(`{"configuredName":"Blocked","urls":["b.com"]}`),
+	}, changes[0])
+	assert.Equal(t, "create", changes[0].Action())
+
+	assert.Equal(t, "zpa", changes[1].Product)
+	assert.Equal(t, "application", changes[1].ResourceType)
+	assert.Equal(t, "200", changes[1].ResourceID)
+	assert.Equal(t, "/zpa/mgmtconfig/v1/admin/customers/100/application/200?forceDelete=true", changes[1].Endpoint)
+	assert.Equal(t, "update", changes[1].Action())
+
+	assert.Equal(t, "delete", changes[2].Action())
+	assert.Equal(t, "42", changes[2].ResourceID)
+	assert.Empty(t, changes[2].Body)
+
+	assert.NotContains(t, string(changes[3].Body), "hunter2", "secrets are redacted")
+
+	assert.Contains(t, plan.String(), "1. create zia urlCategories (POST /zia/api/v1/urlCategories)\n   {\"configuredName\"")
+	var buf bytes.Buffer
+	require.NoError(t, plan.WriteJSON(&buf))
+	var decoded []Change
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Len(t, decoded, len(changes))
+	for i := range decoded {
+		assert.Equal(t, changes[i].Endpoint, decoded[i].Endpoint)
+		if len(changes[i].Body) > 0 {
+			assert.JSONEq(t, string(changes[i].Body), string(decoded[i].Body))
+		}
+	}
+
+	plan.Reset()
+	assert.Zero(t, plan.Len())
+	buf.Reset()
+	require.NoError(t, plan.WriteJSON(&buf))
+	assert.Equal(t, "[]\n", buf.String())
+}
+
+func TestPlanPassthrough(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	plan := NewPlan(WithPassthrough("/lookup"))
+	client := plan.Client(nil)
+	for _, path := range []string{"/zia/api/v1/urlCategories/lookup", "/zia/api/v1/authenticatedSession", "/signin"} {
+		resp, err := client.Post(server.URL+path, "application/json", strings.NewReader(`[]`))
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusAccepted, resp.StatusCode, path)
+	}
+	assert.Zero(t, plan.Len())
+
+	resp, err := client.Post(server.URL+"/zia/api/v1/status/activate", "application/json", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "activate", plan.Changes()[0].ResourceType)
+}
@@ -0,0 +1,93 @@
+// Package filelock provides a portable cross-process lock based on exclusive
+// file creation, and atomic file replacement.
+package filelock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const retryInterval = 20 * time.Millisecond
+
+// Acquire takes a cross-process lock by exclusively creating path.
+// O_CREATE|O_EXCL behaves the same on every OS Go supports, so no platform
+// specific locking calls are needed. A lock file older than staleAfter is
+// treated as abandoned by a crashed process and removed. Acquire blocks until
+// the lock is taken or ctx is done; the returned function releases the lock.
+func Acquire(ctx context.Context, path string, staleAfter time.Duration) (func(), error) {
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			_, _ = fmt.Fprintf(f, "%d\n", os.Getpid())
+			_ = f.Close()
+			var once sync.Once
+			return func() { once.Do(func() { _ = os.Remove(path) }) }, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, fmt.Errorf("acquiring lock %s: %w", path, err)
+		}
+		if info, statErr := os.Stat(path); statErr == nil && time.Since(info.ModTime()) > staleAfter {
+			breakStale(path, info)
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// breakStale removes the lock file at path if it is still the one stale
+// describes. Another process may take the lock between the staleness check and
+// the removal, so the file is first renamed to a unique name, which only one
+// process can do, and compared with stale; a live lock is put back.
+func breakStale(path string, stale os.FileInfo) {
+	taken := fmt.Sprintf("%s.stale-%d-%d", path, os.Getpid(), time.Now().UnixNano())
+	if err := os.Rename(path, taken); err != nil {
+		return
+	}
+	// Inode numbers are reused, so the modification time is compared too.
+	if info, err := os.Stat(taken); err == nil && os.SameFile(info, stale) && info.ModTime().Equal(stale.ModTime()) {
+		_ = os.Remove(taken)
+		return
+	}
+	// Link fails, leaving the newer lock in place, if yet another process took
+	// path in the meantime.
+	_ = os.Link(taken, path)
+	_ = os.Remove(taken)
+}
+
+// WriteAtomic writes data to a temporary file in the same directory and
+// renames it over path, so concurrent readers never observe a partial file.
+func WriteAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmpName)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return err
+	}
+	if err := os.Chmod(tmpName, 0o600); err != nil {
+		_ = os.Remove(tmpName)
+		return err
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		_ = os.Remove(tmpName)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package filelock
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcquireBreaksStaleLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "k.lock")
+	require.NoError(t, os.WriteFile(path, []byte("1\n"), 0o600))
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(path, old, old))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	unlock, err := Acquire(ctx, path, time.Minute)
+	require.NoError(t, err)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotEqual(t, "1\n", string(data))
+	unlock()
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestBreakStaleKeepsLiveLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "k.lock")
+	require.NoError(t, os.WriteFile(path, []byte("1\n"), 0o600))
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(path, old, old))
+	stale, err := os.Stat(path)
+	require.NoError(t, err)
+
+	// Another process breaks the stale lock and takes it before this one does.
+	require.NoError(t, os.Remove(path))
+	require.NoError(t, os.WriteFile(path, []byte("2\n"), 0o600))
+
+	breakStale(path, stale)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "2\n", string(data), "a lock taken since the staleness check is not removed")
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
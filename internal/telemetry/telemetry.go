@@ -0,0 +1,250 @@
+// Package telemetry records the OpenTelemetry spans and metrics of SDK
+// requests. It is shared by the OneAPI client and the legacy ZIA and ZPA
+// clients so that they report the same instruments and attributes.
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	rl "github.com/zscaler/zscaler-sdk-go/v3/ratelimiter"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+const instrumentationName = "github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+
+// Attribute keys recorded on SDK spans and metrics.
+const (
+	AttrProduct          = attribute.Key("zscaler.product")
+	AttrEndpointTemplate = attribute.Key("url.template")
+	AttrMethod           = attribute.Key("http.request.method")
+	AttrStatusCode       = attribute.Key("http.response.status_code")
+	AttrAttempt          = attribute.Key("zscaler.attempt")
+	AttrRetryReason      = attribute.Key("zscaler.retry.reason")
+	AttrWaitSource       = attribute.Key("zscaler.ratelimit.source")
+	AttrCacheHit         = attribute.Key("zscaler.cache.hit")
+)
+
+// Rate-limit wait sources. Waits driven by a server response are recorded
+// with the retry reason instead.
+const (
+	WaitSourceClient      = "client"
+	WaitSourceRateLimited = "RATE_LIMITED"
+)
+
+// Request identifies an API call on spans and metrics.
+type Request struct {
+	// Product is the product tag, e.g. "zia" or "zpa".
+	Product string
+	Method  string
+	// Endpoint is the request path. Identifiers and the query string are
+	// removed before it is recorded.
+	Endpoint string
+	// Attempt is the number of wire attempts made so far.
+	Attempt int
+	// StartTime is when the call started.
+	StartTime time.Time
+}
+
+func (r Request) attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		AttrProduct.String(r.Product),
+		AttrMethod.String(r.Method),
+		AttrEndpointTemplate.String(EndpointTemplate(r.Endpoint)),
+	}
+}
+
+// EndpointTemplate replaces identifier path segments (numeric IDs and UUIDs)
+// with "{id}" so spans and metrics have a bounded cardinality, e.g.
+// /zia/api/v1/urlCategories/42 becomes /zia/api/v1/urlCategories/{id}.
+func EndpointTemplate(endpoint string) string {
+	return rl.EndpointTemplate(endpoint)
+}
+
+// Telemetry holds the instruments built from the configured providers. When no
+// provider is configured the no-op implementations are used so that call sites
+// never need to check whether telemetry is enabled.
+type Telemetry struct {
+	tracer        trace.Tracer
+	duration      metric.Float64Histogram
+	rateLimitWait metric.Float64Histogram
+	cacheRequests metric.Int64Counter
+	cacheInvalid  metric.Int64Counter
+}
+
+// Noop records nothing. It is used by configurations without providers.
+var Noop = New(nil, nil, "")
+
+// New builds the SDK instruments from tp and mp. A nil provider disables
+// tracing or metrics. version is recorded as the instrumentation version.
+func New(tp trace.TracerProvider, mp metric.MeterProvider, version string) *Telemetry {
+	if tp == nil {
+		tp = tracenoop.NewTracerProvider()
+	}
+	if mp == nil {
+		mp = metricnoop.NewMeterProvider()
+	}
+	meter := mp.Meter(instrumentationName, metric.WithInstrumentationVersion(version))
+
+	t := &Telemetry{
+		tracer: tp.Tracer(instrumentationName, trace.WithInstrumentationVersion(version)),
+	}
+	// Instrument creation only fails for invalid names; fall back to no-op
+	// instruments rather than failing the configuration.
+	noopMeter := metricnoop.NewMeterProvider().Meter(instrumentationName)
+	var err error
+	if t.duration, err = meter.Float64Histogram("zscaler.sdk.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of Zscaler API calls, including retries and rate-limit waits.")); err != nil {
+		t.duration, _ = noopMeter.Float64Histogram("zscaler.sdk.request.duration")
+	}
+	if t.rateLimitWait, err = meter.Float64Histogram("zscaler.sdk.ratelimit.wait.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Time spent waiting on client-side rate limits and server retry hints.")); err != nil {
+		t.rateLimitWait, _ = noopMeter.Float64Histogram("zscaler.sdk.ratelimit.wait.duration")
+	}
+	if t.cacheRequests, err = meter.Int64Counter("zscaler.sdk.cache.requests",
+		metric.WithUnit("{request}"),
+		metric.WithDescription("Cache lookups for GET requests, split by hit/miss.")); err != nil {
+		t.cacheRequests, _ = noopMeter.Int64Counter("zscaler.sdk.cache.requests")
+	}
+	if t.cacheInvalid, err = meter.Int64Counter("zscaler.sdk.cache.invalidations",
+		metric.WithUnit("{entry}"),
+		metric.WithDescription("Cached responses removed because a request changed the resources they depend on.")); err != nil {
+		t.cacheInvalid, _ = noopMeter.Int64Counter("zscaler.sdk.cache.invalidations")
+	}
+	return t
+}
+
+type attemptsKey struct{}
+
+// StartRequest opens the span covering a whole API call. The returned context
+// also counts the attempts made through Transport.
+func (t *Telemetry) StartRequest(ctx context.Context, r Request) (context.Context, trace.Span) {
+	ctx = context.WithValue(ctx, attemptsKey{}, new(atomic.Int32))
+	return t.tracer.Start(ctx, r.Method+" "+EndpointTemplate(r.Endpoint),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(r.attributes()...))
+}
+
+// Attempts returns the number of attempts Transport sent with ctx, a context
+// returned by StartRequest.
+func Attempts(ctx context.Context) int {
+	if n, ok := ctx.Value(attemptsKey{}).(*atomic.Int32); ok {
+		return int(n.Load())
+	}
+	return 0
+}
+
+// EndRequest closes the request span and records the call latency.
+func (t *Telemetry) EndRequest(ctx context.Context, span trace.Span, r Request, resp *http.Response, err error) {
+	attrs := r.attributes()
+	if resp != nil {
+		attrs = append(attrs, AttrStatusCode.Int(resp.StatusCode))
+	}
+	span.SetAttributes(AttrAttempt.Int(r.Attempt))
+	if resp != nil {
+		span.SetAttributes(AttrStatusCode.Int(resp.StatusCode))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+	t.duration.Record(ctx, time.Since(r.StartTime).Seconds(), metric.WithAttributes(attrs...))
+}
+
+// StartAttempt opens a child span for a single wire attempt.
+func (t *Telemetry) StartAttempt(ctx context.Context, r Request) trace.Span {
+	_, span := t.tracer.Start(ctx, "attempt "+r.Method+" "+EndpointTemplate(r.Endpoint),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(append(r.attributes(), AttrAttempt.Int(r.Attempt))...))
+	return span
+}
+
+// EndAttempt closes an attempt span, tagging it with the response status and,
+// when the SDK decided to retry, the retry reason.
+func (t *Telemetry) EndAttempt(span trace.Span, resp *http.Response, retryReason string, err error) {
+	if span == nil {
+		return
+	}
+	if resp != nil {
+		span.SetAttributes(AttrStatusCode.Int(resp.StatusCode))
+	}
+	if retryReason != "" {
+		span.SetAttributes(AttrRetryReason.String(retryReason))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// RecordRateLimitWait records time spent sleeping before a request was sent.
+// source is WaitSourceClient for the SDK rate limiter or the retry reason for
+// waits driven by server responses.
+func (t *Telemetry) RecordRateLimitWait(ctx context.Context, product, source string, wait time.Duration) {
+	t.rateLimitWait.Record(ctx, wait.Seconds(), metric.WithAttributes(
+		AttrProduct.String(product),
+		AttrWaitSource.String(source),
+	))
+}
+
+// RecordCacheInvalidations counts cached responses invalidated by a
+// mutating request.
+func (t *Telemetry) RecordCacheInvalidations(ctx context.Context, r Request, n int) {
+	t.cacheInvalid.Add(ctx, int64(n), metric.WithAttributes(
+		AttrProduct.String(r.Product),
+		AttrEndpointTemplate.String(EndpointTemplate(r.Endpoint)),
+	))
+}
+
+// RecordCacheLookup counts a cache lookup for a GET request.
+func (t *Telemetry) RecordCacheLookup(ctx context.Context, r Request, hit bool) {
+	t.cacheRequests.Add(ctx, 1, metric.WithAttributes(
+		AttrProduct.String(r.Product),
+		AttrEndpointTemplate.String(EndpointTemplate(r.Endpoint)),
+		AttrCacheHit.Bool(hit),
+	))
+}
+
+// Transport returns a RoundTripper that opens an attempt span for every
+// request sent with a context returned by StartRequest, so retries made below
+// the caller, e.g. by go-retryablehttp, are traced one by one. Other requests,
+// such as session sign-ins, are passed to base untouched.
+func (t *Telemetry) Transport(base http.RoundTripper, product string) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base, telemetry: t, product: product}
+}
+
+type transport struct {
+	base      http.RoundTripper
+	telemetry *Telemetry
+	product   string
+}
+
+func (tr *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attempts, ok := req.Context().Value(attemptsKey{}).(*atomic.Int32)
+	if !ok {
+		return tr.base.RoundTrip(req)
+	}
+	span := tr.telemetry.StartAttempt(req.Context(), Request{
+		Product:  tr.product,
+		Method:   req.Method,
+		Endpoint: req.URL.Path,
+		Attempt:  int(attempts.Add(1)),
+	})
+	resp, err := tr.base.RoundTrip(req)
+	tr.telemetry.EndAttempt(span, resp, "", err)
+	return resp, err
+}
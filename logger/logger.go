@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"net/http/httputil"
@@ -18,6 +19,8 @@ type nopLogger struct{}
 
 func (l *nopLogger) Printf(format string, v ...interface{}) {}
 
+func (l *nopLogger) Log(ctx context.Context, level Level, msg string, fields ...Field) {}
+
 func NewNopLogger() Logger {
 	return &nopLogger{}
 }
@@ -28,14 +31,20 @@ type defaultLogger struct {
 }
 
 func (l *defaultLogger) Printf(format string, v ...interface{}) {
-	trimedF := strings.TrimSpace(format)
-	if (strings.HasPrefix(trimedF, "[DEBUG]") || strings.HasPrefix(trimedF, "[TRACE]")) && !l.Verbose {
+	if level, _ := ParseLevel(format); level < LevelInfo && !l.Verbose {
 		return
 	}
 
 	l.logger.Printf(format, v...)
 }
 
+func (l *defaultLogger) Log(ctx context.Context, level Level, msg string, fields ...Field) {
+	if level < LevelInfo && !l.Verbose {
+		return
+	}
+	_ = l.logger.Output(2, formatLine(level, msg, append(FieldsFromContext(ctx), fields...)))
+}
+
 func GetDefaultLogger(loggerPrefix string) Logger {
 	loggingEnabled, _ := strconv.ParseBool(os.Getenv("ZSCALER_SDK_LOG"))
 	if !loggingEnabled {
@@ -73,11 +82,19 @@ func LogRequestSensitive(logger Logger, req *http.Request, reqID string, sensiti
 			out = []byte(strings.ReplaceAll(string(out), s, "********"))
 		}
 		if err == nil {
-			WriteLog(logger, logReqMsg, req.Method, req.URL, reqID, string(out))
+			r := GetRedactor()
+			WriteLog(logger, logReqMsg, req.Method, r.RedactString(req.URL.String()), reqID, r.RedactDump(out))
 		}
 	}
 }
 
+// LogResponseBody logs a raw response body after redacting sensitive fields.
+func LogResponseBody(logger Logger, body []byte) {
+	if logger != nil {
+		WriteLog(logger, "Response Body: %s", GetRedactor().RedactBody(body))
+	}
+}
+
 func LogRequest(logger Logger, req *http.Request, reqID string, otherHeaderParams map[string]string, body bool) {
 	if logger != nil && req != nil {
 		l, ok := logger.(*defaultLogger)
@@ -88,7 +105,19 @@ func LogRequest(logger Logger, req *http.Request, reqID string, otherHeaderParam
 		}
 		out, err := httputil.DumpRequestOut(req, body)
 		if err == nil {
-			WriteLog(logger, logReqMsg, req.Method, req.URL, reqID, string(out))
+			r := GetRedactor()
+			reqURL := r.RedactString(req.URL.String())
+			dump := r.RedactDump(out)
+			if sl, ok := logger.(StructuredLogger); ok && !isBuiltin(logger) {
+				sl.Log(req.Context(), LevelDebug, "zscaler sdk request",
+					F("request_id", reqID),
+					F("method", req.Method),
+					F("url", reqURL),
+					F("dump", dump),
+				)
+				return
+			}
+			WriteLog(logger, logReqMsg, req.Method, reqURL, reqID, dump)
 		}
 	}
 }
@@ -97,10 +126,33 @@ func LogResponse(logger Logger, resp *http.Response, start time.Time, reqID stri
 	if logger != nil && resp != nil {
 		// Dump the entire response
 		out, err := httputil.DumpResponse(resp, true)
-		if err == nil {
-			WriteLog(logger, logRespMsg, resp.Request.Method, resp.Request.URL, reqID, time.Since(start).String(), string(out))
-		} else {
-			WriteLog(logger, logRespMsg, resp.Request.Method, resp.Request.URL, reqID, time.Since(start).String(), "Got error:"+err.Error())
+		r := GetRedactor()
+		respURL := r.RedactString(resp.Request.URL.String())
+		dump := r.RedactDump(out)
+		if err != nil {
+			dump = "Got error:" + err.Error()
+		}
+		if sl, ok := logger.(StructuredLogger); ok && !isBuiltin(logger) {
+			sl.Log(resp.Request.Context(), LevelDebug, "zscaler sdk response",
+				F("request_id", reqID),
+				F("method", resp.Request.Method),
+				F("url", respURL),
+				F("status", resp.StatusCode),
+				F("duration", time.Since(start)),
+				F("dump", dump),
+			)
+			return
 		}
+		WriteLog(logger, logRespMsg, resp.Request.Method, respURL, reqID, time.Since(start).String(), dump)
+	}
+}
+
+// isBuiltin reports whether l is one of the package's own loggers, which keep
+// the multi-line request/response dump format for readability.
+func isBuiltin(l Logger) bool {
+	switch l.(type) {
+	case *defaultLogger, *nopLogger:
+		return true
 	}
+	return false
 }
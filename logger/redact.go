@@ -0,0 +1,323 @@
+package logger
+
+import (
+	"encoding/json"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Redacted replaces every secret removed from logged requests and responses.
+const Redacted = "[REDACTED]"
+
+// RedactionRules configures a Redactor.
+type RedactionRules struct {
+	// Headers are HTTP header names whose values are redacted (case-insensitive).
+	Headers []string
+	// JSONFields are body field names to redact, matched case-insensitively.
+	// A plain name ("password") or glob ("*Otp") matches that key at any depth;
+	// a dotted path ("credentials.psk") matches only at that path, with array
+	// indices omitted. Fields in application/x-www-form-urlencoded bodies are
+	// matched by name as well.
+	JSONFields []string
+	// Patterns are regular expressions applied to the whole logged text. When a
+	// pattern has capture groups, only the text after the first group is
+	// redacted, so "(api_token=)[^&]+" keeps the parameter name.
+	Patterns []string
+}
+
+// DefaultRedactionRules returns the rules used unless SetRedactor is called.
+// They cover OAuth credentials and bearer tokens, the ZCC and ZWA API
+// tokens, legacy session cookies, ZCC OTPs and passwords, VPN credential
+// pre-shared keys and ZPA provisioning keys. Append to the returned value to
+// extend the defaults.
+func DefaultRedactionRules() RedactionRules {
+	return RedactionRules{
+		Headers: []string{
+			"Authorization",
+			"Proxy-Authorization",
+			"Cookie",
+			"Set-Cookie",
+			"JSessionID",
+			"auth-token",
+			"X-Api-Key",
+		},
+		JSONFields: []string{
+			"password",
+			"*password",
+			"exitPass",
+			"logoutPass",
+			"uninstallPass",
+			"zdSettingsAccessPass",
+			"*DisablePass",
+			"otp",
+			"*otp",
+			"psk",
+			"preSharedKey",
+			"provisioningKey",
+			"client_secret",
+			"clientSecret",
+			"*secret",
+			"client_assertion",
+			"access_token",
+			"accessToken",
+			"refresh_token",
+			"id_token",
+			"token",
+			"jwtToken",
+			"apiKey",
+			"api_key",
+			"privateKey",
+			"private_key",
+		},
+		Patterns: []string{
+			`(?i)(bearer\s+)[A-Za-z0-9\-._~+/]+=*`,
+			`(?i)([?&]api_token=)[^&\s"]+`,
+			`(?i)([?&]client_secret=)[^&\s"]+`,
+			`eyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]+`,
+		},
+	}
+}
+
+// Redactor removes secrets from request and response dumps before they are
+// logged. A Redactor is safe for concurrent use.
+type Redactor struct {
+	headers  map[string]struct{}
+	names    []string
+	paths    []string
+	patterns []*regexp.Regexp
+}
+
+// NewRedactor compiles rules into a Redactor.
+func NewRedactor(rules RedactionRules) (*Redactor, error) {
+	r := &Redactor{headers: make(map[string]struct{}, len(rules.Headers))}
+	for _, h := range rules.Headers {
+		r.headers[strings.ToLower(strings.TrimSpace(h))] = struct{}{}
+	}
+	for _, f := range rules.JSONFields {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if f == "" {
+			continue
+		}
+		if strings.Contains(f, ".") {
+			r.paths = append(r.paths, f)
+		} else {
+			r.names = append(r.names, f)
+		}
+	}
+	for _, p := range rules.Patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, err
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// MustNewRedactor is like NewRedactor but panics if a pattern does not compile.
+func MustNewRedactor(rules RedactionRules) *Redactor {
+	r, err := NewRedactor(rules)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+var (
+	redactorMu      sync.RWMutex
+	currentRedactor = MustNewRedactor(DefaultRedactionRules())
+)
+
+// SetRedactor replaces the redactor applied by LogRequest, LogResponse and
+// LogRequestSensitive. The logging helpers are shared by every product client
+// (OneAPI, ZIA, ZPA, ZTW, ZCC, ZDX, ZWA and SCIM), so the setting is process
+// wide. Passing nil disables redaction.
+func SetRedactor(r *Redactor) {
+	redactorMu.Lock()
+	defer redactorMu.Unlock()
+	currentRedactor = r
+}
+
+// GetRedactor returns the redactor currently applied to logged traffic, or nil
+// if redaction is disabled.
+func GetRedactor() *Redactor {
+	redactorMu.RLock()
+	defer redactorMu.RUnlock()
+	return currentRedactor
+}
+
+// RedactString applies the configured patterns to s.
+func (r *Redactor) RedactString(s string) string {
+	if r == nil {
+		return s
+	}
+	for _, re := range r.patterns {
+		if re.NumSubexp() > 0 {
+			s = re.ReplaceAllString(s, "${1}"+Redacted)
+		} else {
+			s = re.ReplaceAllString(s, Redacted)
+		}
+	}
+	return s
+}
+
+// RedactBody redacts sensitive fields in a JSON or form-encoded body and then
+// applies the configured patterns.
+func (r *Redactor) RedactBody(body []byte) string {
+	if r == nil {
+		return string(body)
+	}
+	return r.RedactString(r.redactFields(string(body), false))
+}
+
+// RedactForm is like RedactBody for application/x-www-form-urlencoded bodies.
+func (r *Redactor) RedactForm(body []byte) string {
+	if r == nil {
+		return string(body)
+	}
+	return r.RedactString(r.redactFields(string(body), true))
+}
+
+// RedactHeader returns Redacted if name is a sensitive header, and value with
+// the configured patterns applied otherwise.
+func (r *Redactor) RedactHeader(name, value string) string {
+	if r == nil {
+		return value
+	}
+	if _, sensitive := r.headers[strings.ToLower(strings.TrimSpace(name))]; sensitive {
+		return Redacted
+	}
+	return r.RedactString(value)
+}
+
+// RedactDump redacts an HTTP request or response dump as produced by
+// net/http/httputil: sensitive header values, sensitive body fields and any
+// text matching the configured patterns.
+func (r *Redactor) RedactDump(dump []byte) string {
+	if r == nil {
+		return string(dump)
+	}
+	head, body, hasBody := strings.Cut(string(dump), "\r\n\r\n")
+	lines := strings.Split(head, "\r\n")
+	isForm := false
+	for i, line := range lines {
+		if i == 0 {
+			continue // request or status line
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		lname := strings.ToLower(strings.TrimSpace(name))
+		if _, sensitive := r.headers[lname]; sensitive {
+			lines[i] = name + ": " + Redacted
+		}
+		if lname == "content-type" && strings.Contains(value, "application/x-www-form-urlencoded") {
+			isForm = true
+		}
+	}
+	out := strings.Join(lines, "\r\n")
+	if hasBody {
+		out += "\r\n\r\n" + r.redactFields(body, isForm)
+	}
+	return r.RedactString(out)
+}
+
+func (r *Redactor) redactFields(body string, isForm bool) string {
+	trimmed := strings.TrimSpace(body)
+	if trimmed == "" || (len(r.names) == 0 && len(r.paths) == 0) {
+		return body
+	}
+	if trimmed[0] == '{' || trimmed[0] == '[' {
+		var v interface{}
+		dec := json.NewDecoder(strings.NewReader(trimmed))
+		dec.UseNumber() // keep large numeric IDs intact when re-encoding
+		if err := dec.Decode(&v); err == nil {
+			if r.walk(v, "") {
+				if out, err := json.Marshal(v); err == nil {
+					return string(out)
+				}
+			}
+			return body
+		}
+	}
+	if isForm {
+		if values, err := url.ParseQuery(trimmed); err == nil {
+			changed := false
+			for k := range values {
+				if r.matchName(strings.ToLower(k)) {
+					values[k] = []string{Redacted}
+					changed = true
+				}
+			}
+			if changed {
+				return values.Encode()
+			}
+			return body
+		}
+	}
+	// Not parseable as a whole (e.g. chunked or truncated dumps): fall back to
+	// redacting "key": "value" pairs textually by field name.
+	return jsonPairPattern.ReplaceAllStringFunc(body, func(m string) string {
+		sub := jsonPairPattern.FindStringSubmatch(m)
+		if !r.matchName(strings.ToLower(sub[2])) {
+			return m
+		}
+		return sub[1] + `"` + Redacted + `"`
+	})
+}
+
+var jsonPairPattern = regexp.MustCompile(`("([^"\\]+)"\s*:\s*)("(?:[^"\\]|\\.)*"|-?[0-9][0-9.eE+-]*)`)
+
+// walk redacts matching fields in place and reports whether anything changed.
+func (r *Redactor) walk(v interface{}, prefix string) bool {
+	changed := false
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			lk := strings.ToLower(k)
+			p := lk
+			if prefix != "" {
+				p = prefix + "." + lk
+			}
+			if r.matchName(lk) || r.matchPath(p) {
+				if child != nil {
+					t[k] = Redacted
+					changed = true
+				}
+				continue
+			}
+			if r.walk(child, p) {
+				changed = true
+			}
+		}
+	case []interface{}:
+		for _, child := range t {
+			if r.walk(child, prefix) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+func (r *Redactor) matchName(name string) bool {
+	for _, n := range r.names {
+		if ok, _ := path.Match(n, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Redactor) matchPath(p string) bool {
+	for _, rule := range r.paths {
+		if ok, _ := path.Match(rule, p); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package logger
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactor_Headers(t *testing.T) {
+	r := MustNewRedactor(DefaultRedactionRules())
+	dump := "GET /zia/api/v1/status HTTP/1.1\r\nHost: api.zsapi.net\r\nAuthorization: Bearer abc.def\r\nJSessionID: 1234\r\nUser-Agent: sdk\r\n\r\n"
+
+	out := r.RedactDump([]byte(dump))
+
+	require.Contains(t, out, "Authorization: "+Redacted)
+	require.Contains(t, out, "JSessionID: "+Redacted)
+	require.Contains(t, out, "User-Agent: sdk")
+	require.NotContains(t, out, "abc.def")
+}
+
+func TestRedactor_JSONFields(t *testing.T) {
+	r := MustNewRedactor(DefaultRedactionRules())
+	body := `{"id":9007199254740993,"name":"vpn","preSharedKey":"s3cr3t","nested":{"exitOtp":"111","uninstallPass":"p@ss","bypass":true},"items":[{"provisioningKey":"pk-1"}]}`
+
+	out := r.RedactBody([]byte(body))
+
+	for _, secret := range []string{"s3cr3t", "111", "p@ss", "pk-1"} {
+		require.NotContains(t, out, secret)
+	}
+	require.Contains(t, out, `"name":"vpn"`)
+	require.Contains(t, out, `"bypass":true`)
+	require.Contains(t, out, `9007199254740993`, "numeric IDs must survive re-encoding")
+}
+
+func TestRedactor_JSONPathRule(t *testing.T) {
+	r := MustNewRedactor(RedactionRules{JSONFields: []string{"credentials.token"}})
+
+	out := r.RedactBody([]byte(`{"credentials":{"token":"abc"},"token":"keep"}`))
+
+	require.Contains(t, out, `"token":"keep"`)
+	require.NotContains(t, out, "abc")
+}
+
+func TestRedactor_FormBody(t *testing.T) {
+	r := MustNewRedactor(DefaultRedactionRules())
+	form := url.Values{"client_id": {"id"}, "client_secret": {"topsecret"}, "grant_type": {"client_credentials"}}.Encode()
+	dump := "POST /oauth2/v1/token HTTP/1.1\r\nContent-Type: application/x-www-form-urlencoded\r\n\r\n" + form
+
+	out := r.RedactDump([]byte(dump))
+
+	require.NotContains(t, out, "topsecret")
+	require.Contains(t, out, "client_id=id")
+}
+
+func TestRedactor_FormAndHeaderValues(t *testing.T) {
+	r := MustNewRedactor(DefaultRedactionRules())
+
+	out := r.RedactForm([]byte("client_id=id&client_secret=topsecret"))
+	require.NotContains(t, out, "topsecret")
+	require.Contains(t, out, "client_id=id")
+
+	require.Equal(t, Redacted, r.RedactHeader("set-cookie", "JSESSIONID=abc"))
+	require.Equal(t, "application/json", r.RedactHeader("Content-Type", "application/json"))
+}
+
+func TestRedactor_TextualFallbackAndPatterns(t *testing.T) {
+	r := MustNewRedactor(DefaultRedactionRules())
+	// Chunked dumps are not valid JSON as a whole.
+	dump := "HTTP/1.1 200 OK\r\nTransfer-Encoding: chunked\r\n\r\n2a\r\n{\"otp\": \"987654\", \"udid\": \"dev-1\"}\r\n0\r\n\r\n"
+
+	out := r.RedactDump([]byte(dump))
+	require.NotContains(t, out, "987654")
+	require.Contains(t, out, `"udid": "dev-1"`)
+
+	require.Equal(t, "https://csbapi.zscaler.net/zscsb/submit?api_token="+Redacted+"&force=1",
+		r.RedactString("https://csbapi.zscaler.net/zscsb/submit?api_token=tok123&force=1"))
+}
+
+func TestRedactor_CustomPatternAndInvalidPattern(t *testing.T) {
+	r, err := NewRedactor(RedactionRules{Patterns: []string{`ssn-[0-9]+`}})
+	require.NoError(t, err)
+	require.Equal(t, "id "+Redacted, r.RedactString("id ssn-42"))
+
+	_, err = NewRedactor(RedactionRules{Patterns: []string{`(`}})
+	require.Error(t, err)
+}
+
+func TestLogRequest_AppliesRedactor(t *testing.T) {
+	var buf bytes.Buffer
+	l := &defaultLogger{logger: log.New(&buf, "", 0), Verbose: true}
+
+	req := httptest.NewRequest(http.MethodPost, "https://api.zsapi.net/zia/api/v1/vpnCredentials", strings.NewReader(`{"fqdn":"a.b","preSharedKey":"hunter2"}`))
+	req.Header.Set("Authorization", "Bearer token-value")
+	req.Header.Set("Content-Type", "application/json")
+	LogRequest(l, req, "req-1", nil, true)
+
+	require.NotContains(t, buf.String(), "hunter2")
+	require.NotContains(t, buf.String(), "token-value")
+	require.Contains(t, buf.String(), `"fqdn":"a.b"`)
+
+	prev := GetRedactor()
+	t.Cleanup(func() { SetRedactor(prev) })
+	SetRedactor(nil)
+	buf.Reset()
+	resp := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"preSharedKey":"visible"}`)),
+		Request:    req,
+	}
+	LogResponse(l, resp, time.Now(), "req-1")
+	require.Contains(t, buf.String(), "visible", "SetRedactor(nil) disables redaction")
+}
@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+// slogLogger adapts a *slog.Logger to StructuredLogger.
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+// NewSlogLogger returns a StructuredLogger backed by l. Structured entries map
+// their fields to slog attributes; legacy Printf calls are parsed for their
+// "[LEVEL]" prefix and logged at the matching slog level, so SDK output can be
+// shipped as JSON with, for example, slog.NewJSONHandler.
+func NewSlogLogger(l *slog.Logger) StructuredLogger {
+	if l == nil {
+		l = slog.Default()
+	}
+	return &slogLogger{logger: l}
+}
+
+func (s *slogLogger) Printf(format string, v ...interface{}) {
+	level, msg := ParseLevel(fmt.Sprintf(format, v...))
+	s.Log(context.Background(), level, strings.TrimRight(msg, "\n"))
+}
+
+func (s *slogLogger) Log(ctx context.Context, level Level, msg string, fields ...Field) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !s.logger.Enabled(ctx, slog.Level(level)) {
+		return
+	}
+	ctxFields := FieldsFromContext(ctx)
+	attrs := make([]slog.Attr, 0, len(ctxFields)+len(fields))
+	for _, f := range ctxFields {
+		attrs = append(attrs, slog.Any(f.Key, f.Value))
+	}
+	for _, f := range fields {
+		attrs = append(attrs, slog.Any(f.Key, f.Value))
+	}
+	s.logger.LogAttrs(ctx, slog.Level(level), msg, attrs...)
+}
@@ -0,0 +1,147 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Level is the severity of a log entry. The values line up with log/slog so
+// that levels convert directly when using the slog adapter.
+type Level int
+
+const (
+	LevelTrace Level = -8
+	LevelDebug Level = -4
+	LevelInfo  Level = 0
+	LevelWarn  Level = 4
+	LevelError Level = 8
+)
+
+func (l Level) String() string {
+	switch {
+	case l <= LevelTrace:
+		return "TRACE"
+	case l <= LevelDebug:
+		return "DEBUG"
+	case l < LevelWarn:
+		return "INFO"
+	case l < LevelError:
+		return "WARN"
+	default:
+		return "ERROR"
+	}
+}
+
+// Field is a key/value pair attached to a structured log entry.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// F is shorthand for constructing a Field.
+func F(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// StructuredLogger is a Logger that also accepts leveled entries with
+// key/value fields. Printf remains available so that existing SDK code and
+// user loggers keep working; implementations should parse the "[LEVEL]"
+// prefix used throughout the SDK (see ParseLevel).
+type StructuredLogger interface {
+	Logger
+	Log(ctx context.Context, level Level, msg string, fields ...Field)
+}
+
+var levelPrefixes = []struct {
+	prefix string
+	level  Level
+}{
+	{"[TRACE]", LevelTrace},
+	{"[DEBUG]", LevelDebug},
+	{"[INFO]", LevelInfo},
+	{"[WARNING]", LevelWarn},
+	{"[WARN]", LevelWarn},
+	{"[ERROR]", LevelError},
+}
+
+// ParseLevel extracts the "[LEVEL]" prefix used by SDK log lines and returns
+// the level together with the remaining message. Lines without a recognised
+// prefix are reported at LevelInfo.
+func ParseLevel(msg string) (Level, string) {
+	trimmed := strings.TrimSpace(msg)
+	for _, p := range levelPrefixes {
+		if strings.HasPrefix(trimmed, p.prefix) {
+			return p.level, strings.TrimSpace(strings.TrimPrefix(trimmed, p.prefix))
+		}
+	}
+	return LevelInfo, trimmed
+}
+
+// Log writes a leveled entry to l. Structured loggers receive the fields as-is;
+// plain Printf loggers receive a "[LEVEL] msg key=value ..." line, so callers
+// can log structurally regardless of which logger was configured.
+func Log(ctx context.Context, l Logger, level Level, msg string, fields ...Field) {
+	if l == nil {
+		return
+	}
+	if sl, ok := l.(StructuredLogger); ok {
+		sl.Log(ctx, level, msg, fields...)
+		return
+	}
+	l.Printf("%s", formatLine(level, msg, append(FieldsFromContext(ctx), fields...)))
+}
+
+func formatLine(level Level, msg string, fields []Field) string {
+	var b strings.Builder
+	b.WriteString("[")
+	b.WriteString(level.String())
+	b.WriteString("] ")
+	b.WriteString(msg)
+	for _, f := range fields {
+		fmt.Fprintf(&b, " %s=%v", f.Key, f.Value)
+	}
+	return b.String()
+}
+
+type fieldsContextKey struct{}
+
+// ContextWithFields returns a copy of ctx carrying fields that structured
+// loggers add to every entry logged with that context, e.g. a correlation ID.
+func ContextWithFields(ctx context.Context, fields ...Field) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	existing := FieldsFromContext(ctx)
+	merged := make([]Field, 0, len(existing)+len(fields))
+	merged = append(merged, existing...)
+	merged = append(merged, fields...)
+	return context.WithValue(ctx, fieldsContextKey{}, merged)
+}
+
+// FieldsFromContext returns the fields attached with ContextWithFields.
+func FieldsFromContext(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(fieldsContextKey{}).([]Field)
+	return fields
+}
+
+// printfLogger adapts a Printf-only Logger to StructuredLogger.
+type printfLogger struct {
+	Logger
+}
+
+// NewPrintfLogger wraps a Printf-only Logger so it satisfies StructuredLogger.
+// Structured entries are rendered as "[LEVEL] msg key=value ..." lines.
+func NewPrintfLogger(l Logger) StructuredLogger {
+	if sl, ok := l.(StructuredLogger); ok {
+		return sl
+	}
+	return &printfLogger{Logger: l}
+}
+
+func (p *printfLogger) Log(ctx context.Context, level Level, msg string, fields ...Field) {
+	p.Printf("%s", formatLine(level, msg, append(FieldsFromContext(ctx), fields...)))
+}
@@ -0,0 +1,111 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type printfRecorder struct {
+	lines []string
+}
+
+func (p *printfRecorder) Printf(format string, v ...interface{}) {
+	p.lines = append(p.lines, strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func TestParseLevel(t *testing.T) {
+	cases := []struct {
+		in    string
+		level Level
+		msg   string
+	}{
+		{"[DEBUG] token expiry set", LevelDebug, "token expiry set"},
+		{"  [TRACE] raw", LevelTrace, "raw"},
+		{"[INFO] served from cache", LevelInfo, "served from cache"},
+		{"[WARN] approaching limit", LevelWarn, "approaching limit"},
+		{"[WARNING] approaching limit", LevelWarn, "approaching limit"},
+		{"[ERROR] failed", LevelError, "failed"},
+		{"no prefix", LevelInfo, "no prefix"},
+	}
+	for _, c := range cases {
+		level, msg := ParseLevel(c.in)
+		require.Equal(t, c.level, level, c.in)
+		require.Equal(t, c.msg, msg, c.in)
+	}
+}
+
+func TestSlogLogger_PrintfShim(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewSlogLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	l.Printf("[WARN] Approaching rate limit: %d requests remaining", 1)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	require.Equal(t, "WARN", entry["level"])
+	require.Equal(t, "Approaching rate limit: 1 requests remaining", entry["msg"])
+}
+
+func TestSlogLogger_StructuredFields(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewSlogLogger(slog.New(slog.NewJSONHandler(&buf, nil)))
+
+	ctx := ContextWithFields(context.Background(), F("correlation_id", "abc-123"))
+	l.Log(ctx, LevelInfo, "request completed", F("status", 200))
+	l.Log(ctx, LevelDebug, "filtered out by handler level")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 1)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	require.Equal(t, "INFO", entry["level"])
+	require.Equal(t, "request completed", entry["msg"])
+	require.Equal(t, "abc-123", entry["correlation_id"])
+	require.Equal(t, float64(200), entry["status"])
+}
+
+func TestLog_FallsBackToPrintf(t *testing.T) {
+	rec := &printfRecorder{}
+	ctx := ContextWithFields(context.Background(), F("product", "zia"))
+
+	Log(ctx, rec, LevelError, "request failed", F("status", 500))
+
+	require.Equal(t, []string{"[ERROR] request failed product=zia status=500"}, rec.lines)
+}
+
+func TestNewPrintfLogger(t *testing.T) {
+	rec := &printfRecorder{}
+	sl := NewPrintfLogger(rec)
+
+	sl.Log(context.Background(), LevelWarn, "slow", F("duration", "2s"))
+	sl.Printf("[INFO] %s", "plain")
+
+	require.Equal(t, []string{"[WARN] slow duration=2s", "[INFO] plain"}, rec.lines)
+	require.Same(t, sl, NewPrintfLogger(sl), "already-structured loggers are returned unchanged")
+}
+
+func TestDefaultLogger_LevelFiltering(t *testing.T) {
+	var buf bytes.Buffer
+	l := &defaultLogger{logger: log.New(&buf, "", 0)}
+
+	l.Printf("[DEBUG] hidden")
+	l.Log(context.Background(), LevelDebug, "hidden too")
+	l.Printf("[INFO] shown")
+	l.Log(context.Background(), LevelWarn, "also shown", F("k", "v"))
+
+	require.Equal(t, "[INFO] shown\n[WARN] also shown k=v\n", buf.String())
+
+	buf.Reset()
+	l.Verbose = true
+	l.Log(context.Background(), LevelDebug, "visible when verbose")
+	require.Equal(t, "[DEBUG] visible when verbose\n", buf.String())
+}
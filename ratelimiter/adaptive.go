@@ -0,0 +1,213 @@
+package ratelimiter
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// epochThreshold separates X-RateLimit-Reset values given as seconds until
+// reset from values given as a Unix timestamp.
+const epochThreshold = 1000000000
+
+var (
+	numericSegment = regexp.MustCompile(`^-?[0-9]+$`)
+	uuidSegment    = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+// EndpointTemplate replaces numeric and UUID path segments with {id}, so that
+// e.g. /zia/api/v1/urlCategories/42 and /zia/api/v1/urlCategories/43 share a
+// bucket. Any query string is dropped.
+func EndpointTemplate(path string) string {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if numericSegment.MatchString(s) || uuidSegment.MatchString(s) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// EndpointBucketKey is the default AdaptiveLimiter bucket key: host, method
+// and endpoint template. ZIA enforces its limits per endpoint, so requests to
+// different endpoints do not throttle each other.
+func EndpointBucketKey(req *http.Request) string {
+	return req.URL.Host + " " + req.Method + " " + EndpointTemplate(req.URL.Path)
+}
+
+// adaptiveBucket is what the limiter last learned about one bucket.
+type adaptiveBucket struct {
+	remaining    int // -1 while unknown
+	limit        int
+	resetAt      time.Time
+	blockedUntil time.Time
+}
+
+// AdaptiveLimiter throttles requests using the rate-limit state reported by
+// the server instead of fixed windows. It learns from X-RateLimit-Remaining,
+// X-RateLimit-Limit and X-RateLimit-Reset on every response and from
+// Retry-After on 429/503 responses, and keeps that state per bucket (by
+// default per endpoint, see EndpointBucketKey).
+//
+// One AdaptiveLimiter can be shared by several Clients in a process: every
+// request reserves one unit of the remaining budget, so parallel workers stop
+// sending once the server-reported budget is used up instead of all running
+// into 429s.
+type AdaptiveLimiter struct {
+	// KeyFunc maps a request to its bucket. Defaults to EndpointBucketKey.
+	KeyFunc func(req *http.Request) string
+	// Reserve is the number of requests kept in reserve from the server-reported
+	// remaining budget, e.g. for other tools using the same credentials.
+	Reserve int
+
+	mu      sync.Mutex
+	buckets map[string]*adaptiveBucket
+	now     func() time.Time
+}
+
+// NewAdaptiveLimiter returns an AdaptiveLimiter keyed by EndpointBucketKey
+// that keeps reserve requests of every bucket's budget unused.
+func NewAdaptiveLimiter(reserve int) *AdaptiveLimiter {
+	return &AdaptiveLimiter{
+		KeyFunc: EndpointBucketKey,
+		Reserve: reserve,
+		buckets: make(map[string]*adaptiveBucket),
+		now:     time.Now,
+	}
+}
+
+func (a *AdaptiveLimiter) key(req *http.Request) string {
+	if a.KeyFunc != nil {
+		return a.KeyFunc(req)
+	}
+	return EndpointBucketKey(req)
+}
+
+func (a *AdaptiveLimiter) bucket(key string) *adaptiveBucket {
+	if a.buckets == nil {
+		a.buckets = make(map[string]*adaptiveBucket)
+	}
+	b, ok := a.buckets[key]
+	if !ok {
+		b = &adaptiveBucket{remaining: -1}
+		a.buckets[key] = b
+	}
+	return b
+}
+
+func (a *AdaptiveLimiter) clock() time.Time {
+	if a.now != nil {
+		return a.now()
+	}
+	return time.Now()
+}
+
+// Wait reserves budget for req. It returns (false, 0) when req may be sent
+// now, or (true, d) when the bucket is exhausted and the caller should wait d
+// and call Wait again.
+func (a *AdaptiveLimiter) Wait(req *http.Request) (bool, time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.clock()
+	b := a.bucket(a.key(req))
+
+	if now.Before(b.blockedUntil) {
+		return true, b.blockedUntil.Sub(now)
+	}
+	if b.remaining < 0 {
+		return false, 0
+	}
+	if !now.Before(b.resetAt) {
+		// The window has rolled over; budget is unknown until the next response.
+		b.remaining = -1
+		return false, 0
+	}
+	if b.remaining <= a.Reserve {
+		return true, b.resetAt.Sub(now)
+	}
+	b.remaining--
+	return false, 0
+}
+
+// Observe updates the bucket of req from the rate-limit headers of resp.
+func (a *AdaptiveLimiter) Observe(req *http.Request, resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.clock()
+	b := a.bucket(a.key(req))
+
+	if reset, ok := ParseReset(resp.Header.Get("X-Ratelimit-Reset"), now); ok {
+		b.resetAt = now.Add(reset)
+	}
+	if limit, err := strconv.Atoi(resp.Header.Get("X-Ratelimit-Limit")); err == nil {
+		b.limit = limit
+	}
+	if remaining, err := strconv.Atoi(resp.Header.Get("X-Ratelimit-Remaining")); err == nil {
+		b.remaining = remaining
+		if b.resetAt.IsZero() || !now.Before(b.resetAt) {
+			// No usable reset: assume the budget refreshes within a second
+			// rather than blocking on stale state.
+			b.resetAt = now.Add(time.Second)
+		}
+	}
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		if wait, ok := ParseRetryAfter(resp.Header.Get("Retry-After"), now); ok {
+			if until := now.Add(wait); until.After(b.blockedUntil) {
+				b.blockedUntil = until
+			}
+		}
+	}
+}
+
+// ParseRetryAfter parses a Retry-After header given as seconds, as an HTTP
+// date, or as a Go duration string (e.g. "1.5s").
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return d, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// ParseReset parses X-RateLimit-Reset given either as seconds until reset or
+// as a Unix timestamp.
+func ParseReset(value string, now time.Time) (time.Duration, bool) {
+	secs, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || secs < 0 {
+		return 0, false
+	}
+	if secs >= epochThreshold {
+		d := time.Unix(secs, 0).Sub(now)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return time.Duration(secs) * time.Second, true
+}
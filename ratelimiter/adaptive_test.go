@@ -0,0 +1,216 @@
+package ratelimiter
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zscaler/zscaler-sdk-go/v3/logger"
+)
+
+// fakeClock is a manually advanced clock for AdaptiveLimiter tests.
+type fakeClock struct{ t time.Time }
+
+func (c *fakeClock) now() time.Time          { return c.t }
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func newTestAdaptiveLimiter(reserve int) (*AdaptiveLimiter, *fakeClock) {
+	clock := &fakeClock{t: time.Unix(1700000000, 0)}
+	a := NewAdaptiveLimiter(reserve)
+	a.now = clock.now
+	return a, clock
+}
+
+func response(status int, headers map[string]string) *http.Response {
+	resp := &http.Response{StatusCode: status, Header: http.Header{}}
+	for k, v := range headers {
+		resp.Header.Set(k, v)
+	}
+	return resp
+}
+
+// ---------------------------------------------------------------------------
+// AdaptiveLimiter
+// ---------------------------------------------------------------------------
+
+func TestAdaptiveLimiter_UnknownBudgetDoesNotWait(t *testing.T) {
+	a, _ := newTestAdaptiveLimiter(0)
+	req := httptest.NewRequest(http.MethodGet, "https://api.zsapi.net/zia/api/v1/urlCategories", nil)
+
+	for i := 0; i < 100; i++ {
+		wait, _ := a.Wait(req)
+		require.False(t, wait)
+	}
+}
+
+func TestAdaptiveLimiter_RemainingBudgetIsShared(t *testing.T) {
+	a, clock := newTestAdaptiveLimiter(0)
+	req := httptest.NewRequest(http.MethodGet, "https://api.zsapi.net/zia/api/v1/urlCategories", nil)
+
+	a.Observe(req, response(http.StatusOK, map[string]string{
+		"X-RateLimit-Remaining": "2",
+		"X-RateLimit-Reset":     "10",
+	}))
+
+	for i := 0; i < 2; i++ {
+		wait, _ := a.Wait(req)
+		require.False(t, wait, "request #%d within the reported budget should not wait", i+1)
+	}
+	wait, delay := a.Wait(req)
+	require.True(t, wait)
+	require.Equal(t, 10*time.Second, delay)
+
+	clock.advance(10 * time.Second)
+	wait, _ = a.Wait(req)
+	require.False(t, wait, "budget should be released once the window resets")
+}
+
+func TestAdaptiveLimiter_Reserve(t *testing.T) {
+	a, _ := newTestAdaptiveLimiter(2)
+	req := httptest.NewRequest(http.MethodPost, "https://api.zsapi.net/zia/api/v1/firewallFilteringRules", nil)
+
+	a.Observe(req, response(http.StatusOK, map[string]string{
+		"X-RateLimit-Remaining": "3",
+		"X-RateLimit-Reset":     "5",
+	}))
+
+	wait, _ := a.Wait(req)
+	require.False(t, wait)
+	wait, _ = a.Wait(req)
+	require.True(t, wait, "the last requests of the budget are kept in reserve")
+}
+
+func TestAdaptiveLimiter_RetryAfterBlocksBucket(t *testing.T) {
+	a, clock := newTestAdaptiveLimiter(0)
+	req := httptest.NewRequest(http.MethodPut, "https://api.zsapi.net/zia/api/v1/locations/42", nil)
+
+	a.Observe(req, response(http.StatusTooManyRequests, map[string]string{"Retry-After": "3"}))
+
+	// Another ID on the same endpoint shares the bucket.
+	other := httptest.NewRequest(http.MethodPut, "https://api.zsapi.net/zia/api/v1/locations/43", nil)
+	wait, delay := a.Wait(other)
+	require.True(t, wait)
+	require.Equal(t, 3*time.Second, delay)
+
+	clock.advance(3 * time.Second)
+	wait, _ = a.Wait(other)
+	require.False(t, wait)
+}
+
+func TestAdaptiveLimiter_BucketsArePerEndpoint(t *testing.T) {
+	a, _ := newTestAdaptiveLimiter(0)
+	categories := httptest.NewRequest(http.MethodGet, "https://api.zsapi.net/zia/api/v1/urlCategories", nil)
+	locations := httptest.NewRequest(http.MethodGet, "https://api.zsapi.net/zia/api/v1/locations", nil)
+
+	a.Observe(categories, response(http.StatusTooManyRequests, map[string]string{"Retry-After": "30"}))
+
+	wait, _ := a.Wait(locations)
+	require.False(t, wait)
+	wait, _ = a.Wait(categories)
+	require.True(t, wait)
+}
+
+func TestAdaptiveLimiter_ResetAsUnixTimestamp(t *testing.T) {
+	a, clock := newTestAdaptiveLimiter(0)
+	req := httptest.NewRequest(http.MethodGet, "https://api.zsapi.net/zpa/mgmtconfig/v1/admin/customers/1/segmentGroup", nil)
+
+	reset := clock.now().Add(7 * time.Second).Unix()
+	a.Observe(req, response(http.StatusOK, map[string]string{
+		"X-RateLimit-Remaining": "0",
+		"X-RateLimit-Reset":     strconv.FormatInt(reset, 10),
+	}))
+
+	wait, delay := a.Wait(req)
+	require.True(t, wait)
+	require.Equal(t, 7*time.Second, delay)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Unix(1700000000, 0).UTC()
+
+	d, ok := ParseRetryAfter("2", now)
+	require.True(t, ok)
+	require.Equal(t, 2*time.Second, d)
+
+	d, ok = ParseRetryAfter("1.5s", now)
+	require.True(t, ok)
+	require.Equal(t, 1500*time.Millisecond, d)
+
+	d, ok = ParseRetryAfter(now.Add(4*time.Second).Format(http.TimeFormat), now)
+	require.True(t, ok)
+	require.Equal(t, 4*time.Second, d)
+
+	_, ok = ParseRetryAfter("soon", now)
+	require.False(t, ok)
+}
+
+func TestEndpointTemplate(t *testing.T) {
+	require.Equal(t, "/zia/api/v1/urlCategories/{id}", EndpointTemplate("/zia/api/v1/urlCategories/42?page=2"))
+	require.Equal(t, "/zpa/mgmtconfig/v1/admin/customers/{id}/application/{id}",
+		EndpointTemplate("/zpa/mgmtconfig/v1/admin/customers/216196257331281920/application/0d4b8a7e-2f1c-4a8e-9a55-3c1c1ef3c2a1"))
+}
+
+// ---------------------------------------------------------------------------
+// RateLimitTransport with an AdaptiveLimiter
+// ---------------------------------------------------------------------------
+
+func TestRateLimitTransport_AdaptiveWaitsForReset(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", "1")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var waited time.Duration
+	transport := &RateLimitTransport{
+		Adaptive: NewAdaptiveLimiter(0),
+		Logger:   logger.NewNopLogger(),
+		OnWait:   func(req *http.Request, delay time.Duration) { waited += delay },
+	}
+	client := &http.Client{Transport: transport}
+
+	resp, err := client.Get(server.URL + "/zia/api/v1/urlCategories")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	start := time.Now()
+	resp, err = client.Get(server.URL + "/zia/api/v1/urlCategories")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	require.Greater(t, waited, time.Duration(0))
+	require.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+}
+
+func TestRateLimitTransport_AdaptiveWaitHonoursContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &RateLimitTransport{
+		Adaptive: NewAdaptiveLimiter(0),
+		Logger:   logger.NewNopLogger(),
+	}}
+
+	resp, err := client.Get(server.URL + "/zia/api/v1/urlCategories")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/zia/api/v1/urlCategories", nil)
+	require.NoError(t, err)
+	_, err = client.Do(req)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
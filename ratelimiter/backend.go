@@ -0,0 +1,302 @@
+package ratelimiter
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/zscaler/zscaler-sdk-go/v3/internal/filelock"
+)
+
+// Limit is one sliding-window budget a request counts against.
+type Limit struct {
+	// Key names the counter within a scope, e.g. "get" or "delete:hourly".
+	Key string
+	// Max is the number of requests allowed per Window.
+	Max int
+	// Window is the length of the sliding window.
+	Window time.Duration
+}
+
+// Backend stores the request history behind a RateLimiter, so that limiters in
+// one or more processes draw from one budget per scope (typically one scope per
+// tenant and product).
+type Backend interface {
+	// Acquire records one request at now against every limit of scope if none of
+	// them is exhausted, and returns (false, 0). Otherwise nothing is recorded
+	// and it returns (true, d), where d is how long until every limit has room.
+	Acquire(ctx context.Context, scope string, limits []Limit, now time.Time) (bool, time.Duration, error)
+}
+
+// windowState is the request history of one scope: request timestamps in Unix
+// nanoseconds per limit key, oldest first.
+type windowState map[string][]int64
+
+// acquire applies the sliding-window check to state and records the request
+// when it fits.
+func (s windowState) acquire(limits []Limit, now time.Time) (bool, time.Duration) {
+	nowNanos := now.UnixNano()
+	var delay time.Duration
+	for _, l := range limits {
+		if l.Max <= 0 || l.Window <= 0 {
+			continue
+		}
+		cutoff := nowNanos - int64(l.Window)
+		entries := s[l.Key]
+		i := 0
+		for i < len(entries) && entries[i] <= cutoff {
+			i++
+		}
+		entries = entries[i:]
+		s[l.Key] = entries
+		if len(entries) >= l.Max {
+			free := time.Duration(entries[len(entries)-l.Max] - cutoff)
+			if free > delay {
+				delay = free
+			}
+		}
+	}
+	if delay > 0 {
+		return true, delay
+	}
+	for _, l := range limits {
+		if l.Max <= 0 || l.Window <= 0 {
+			continue
+		}
+		s[l.Key] = append(s[l.Key], nowNanos)
+	}
+	return false, 0
+}
+
+// maxWindow returns the longest window among limits, used to expire idle state.
+func maxWindow(limits []Limit) time.Duration {
+	var longest time.Duration
+	for _, l := range limits {
+		if l.Window > longest {
+			longest = l.Window
+		}
+	}
+	return longest
+}
+
+// scopeFileName maps a scope to a file name that is safe on every OS.
+func scopeFileName(scope string) string {
+	sum := sha256.Sum256([]byte(scope))
+	return hex.EncodeToString(sum[:16])
+}
+
+// ---------------------------------------------------------------------------
+// In-process backend
+// ---------------------------------------------------------------------------
+
+type memoryBackend struct {
+	mu     sync.Mutex
+	scopes map[string]windowState
+}
+
+// NewMemoryBackend returns a Backend shared by the limiters of one process.
+func NewMemoryBackend() Backend {
+	return &memoryBackend{scopes: make(map[string]windowState)}
+}
+
+func (m *memoryBackend) Acquire(ctx context.Context, scope string, limits []Limit, now time.Time) (bool, time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state, ok := m.scopes[scope]
+	if !ok {
+		state = windowState{}
+		m.scopes[scope] = state
+	}
+	shouldWait, delay := state.acquire(limits, now)
+	return shouldWait, delay, nil
+}
+
+// ---------------------------------------------------------------------------
+// File backend
+// ---------------------------------------------------------------------------
+
+// fileLockStaleAfter is how old a backend lock file must be before it is
+// treated as abandoned. The lock is only held while one state file is updated.
+const fileLockStaleAfter = 10 * time.Second
+
+type fileBackend struct {
+	dir string
+}
+
+// NewFileBackend returns a Backend that keeps each scope's request history in
+// a file under dir, serialised with a lock file. All processes on a host that
+// use the same dir (e.g. concurrent CI jobs) share one budget per scope.
+func NewFileBackend(dir string) (Backend, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("ratelimiter: creating %s: %w", dir, err)
+	}
+	return &fileBackend{dir: dir}, nil
+}
+
+func (f *fileBackend) Acquire(ctx context.Context, scope string, limits []Limit, now time.Time) (bool, time.Duration, error) {
+	path := filepath.Join(f.dir, scopeFileName(scope)+".json")
+	unlock, err := filelock.Acquire(ctx, path+".lock", fileLockStaleAfter)
+	if err != nil {
+		return false, 0, err
+	}
+	defer unlock()
+
+	state := windowState{}
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return false, 0, err
+	default:
+		if err := json.Unmarshal(data, &state); err != nil {
+			// A corrupt state file only loses history; start over.
+			state = windowState{}
+		}
+	}
+
+	shouldWait, delay := state.acquire(limits, now)
+	if shouldWait {
+		return true, delay, nil
+	}
+	data, err = json.Marshal(state)
+	if err != nil {
+		return false, 0, err
+	}
+	if err := filelock.WriteAtomic(path, data); err != nil {
+		return false, 0, err
+	}
+	return false, 0, nil
+}
+
+// ---------------------------------------------------------------------------
+// Remote backend
+// ---------------------------------------------------------------------------
+
+// RemoteStore is the minimal key/value API a shared store must provide for
+// NewRemoteBackend: a versioned read and a compare-and-swap write. It maps
+// directly onto Redis (WATCH/MULTI), etcd and Consul (revisions/ModifyIndex)
+// or DynamoDB (conditional writes).
+type RemoteStore interface {
+	// Get returns the value stored under key and its version. A missing key
+	// returns a nil value and version 0.
+	Get(ctx context.Context, key string) (value []byte, version int64, err error)
+	// CompareAndSwap stores value under key only if the key's current version
+	// is version (0 meaning the key must not exist), and reports whether it did.
+	// ttl is how long the value may be kept without further writes.
+	CompareAndSwap(ctx context.Context, key string, version int64, value []byte, ttl time.Duration) (bool, error)
+}
+
+// maxCASAttempts bounds retries when concurrent writers keep winning.
+const maxCASAttempts = 16
+
+// ErrContention is returned when the remote state could not be updated
+// because other writers kept modifying it.
+var ErrContention = errors.New("ratelimiter: too much contention on shared rate limit state")
+
+type remoteBackend struct {
+	store  RemoteStore
+	prefix string
+}
+
+// NewRemoteBackend returns a Backend that keeps each scope's request history
+// in store under prefix+scope, so processes on different hosts share one
+// budget. Updates use optimistic concurrency through CompareAndSwap.
+func NewRemoteBackend(store RemoteStore, prefix string) Backend {
+	return &remoteBackend{store: store, prefix: prefix}
+}
+
+func (r *remoteBackend) Acquire(ctx context.Context, scope string, limits []Limit, now time.Time) (bool, time.Duration, error) {
+	key := r.prefix + scope
+	for attempt := 0; attempt < maxCASAttempts; attempt++ {
+		data, version, err := r.store.Get(ctx, key)
+		if err != nil {
+			return false, 0, err
+		}
+		state := windowState{}
+		if len(data) > 0 {
+			if err := json.Unmarshal(data, &state); err != nil {
+				state = windowState{}
+			}
+		}
+		shouldWait, delay := state.acquire(limits, now)
+		if shouldWait {
+			return true, delay, nil
+		}
+		data, err = json.Marshal(state)
+		if err != nil {
+			return false, 0, err
+		}
+		ok, err := r.store.CompareAndSwap(ctx, key, version, data, maxWindow(limits))
+		if err != nil {
+			return false, 0, err
+		}
+		if ok {
+			return false, 0, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return false, 0, err
+		}
+	}
+	return false, 0, ErrContention
+}
+
+// localRemoteStore is an in-memory RemoteStore.
+type localRemoteStore struct {
+	mu      sync.Mutex
+	entries map[string]localRemoteEntry
+	now     func() time.Time
+}
+
+type localRemoteEntry struct {
+	value     []byte
+	version   int64
+	expiresAt time.Time
+}
+
+// NewLocalRemoteStore returns an in-memory RemoteStore with the same
+// versioning and expiry semantics a real shared store provides. It stands in
+// for Redis or etcd in tests and single-host setups.
+func NewLocalRemoteStore() RemoteStore {
+	return &localRemoteStore{entries: make(map[string]localRemoteEntry), now: time.Now}
+}
+
+func (s *localRemoteStore) Get(ctx context.Context, key string) ([]byte, int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[key]
+	if !ok || (!e.expiresAt.IsZero() && !s.now().Before(e.expiresAt)) {
+		return nil, 0, nil
+	}
+	return append([]byte(nil), e.value...), e.version, nil
+}
+
+func (s *localRemoteStore) CompareAndSwap(ctx context.Context, key string, version int64, value []byte, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.entries[key]
+	if ok && !e.expiresAt.IsZero() && !s.now().Before(e.expiresAt) {
+		ok = false
+	}
+	current := int64(0)
+	if ok {
+		current = e.version
+	}
+	if current != version {
+		return false, nil
+	}
+	// Versions keep increasing across expiry so a stale writer cannot win.
+	next := localRemoteEntry{value: append([]byte(nil), value...), version: e.version + 1}
+	if ttl > 0 {
+		next.expiresAt = s.now().Add(ttl)
+	}
+	s.entries[key] = next
+	return true, nil
+}
@@ -0,0 +1,229 @@
+package ratelimiter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/zscaler/zscaler-sdk-go/v3/logger"
+)
+
+func testBackends(t *testing.T) map[string]func() Backend {
+	dir := t.TempDir()
+	store := NewLocalRemoteStore()
+	return map[string]func() Backend{
+		"memory": NewMemoryBackend,
+		"file": func() Backend {
+			b, err := NewFileBackend(dir)
+			require.NoError(t, err)
+			return b
+		},
+		"remote": func() Backend { return NewRemoteBackend(store, "zscaler:ratelimit:") },
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Backends
+// ---------------------------------------------------------------------------
+
+func TestBackend_SlidingWindow(t *testing.T) {
+	limits := []Limit{{Key: "get", Max: 2, Window: 10 * time.Second}}
+	now := time.Unix(1700000000, 0)
+
+	for name, newBackend := range testBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			b := newBackend()
+			ctx := context.Background()
+
+			for i := 0; i < 2; i++ {
+				wait, _, err := b.Acquire(ctx, "tenant/zia", limits, now.Add(time.Duration(i)*time.Second))
+				require.NoError(t, err)
+				require.False(t, wait)
+			}
+			wait, delay, err := b.Acquire(ctx, "tenant/zia", limits, now.Add(2*time.Second))
+			require.NoError(t, err)
+			require.True(t, wait)
+			require.Equal(t, 8*time.Second, delay)
+
+			wait, _, err = b.Acquire(ctx, "other/zia", limits, now.Add(2*time.Second))
+			require.NoError(t, err)
+			require.False(t, wait, "scopes have independent budgets")
+
+			wait, _, err = b.Acquire(ctx, "tenant/zia", limits, now.Add(10*time.Second+time.Millisecond))
+			require.NoError(t, err)
+			require.False(t, wait, "the oldest request left the window")
+		})
+	}
+}
+
+func TestBackend_AllOrNothing(t *testing.T) {
+	limits := []Limit{
+		{Key: "write", Max: 10, Window: time.Second},
+		{Key: "delete:hourly", Max: 1, Window: time.Hour},
+	}
+	now := time.Unix(1700000000, 0)
+
+	for name, newBackend := range testBackends(t) {
+		t.Run(name, func(t *testing.T) {
+			b := newBackend()
+			ctx := context.Background()
+
+			wait, _, err := b.Acquire(ctx, "all-or-nothing", limits, now)
+			require.NoError(t, err)
+			require.False(t, wait)
+
+			for i := 0; i < 5; i++ {
+				wait, delay, err := b.Acquire(ctx, "all-or-nothing", limits, now.Add(2*time.Second))
+				require.NoError(t, err)
+				require.True(t, wait)
+				require.Greater(t, delay, 59*time.Minute)
+			}
+
+			// Rejected requests are not recorded, so the per-second window is free.
+			wait, _, err = b.Acquire(ctx, "all-or-nothing", limits[:1], now.Add(2*time.Second))
+			require.NoError(t, err)
+			require.False(t, wait)
+		})
+	}
+}
+
+// TestBackend_SharedAcrossInstances simulates several processes, each with
+// its own backend instance pointing at the same shared state.
+func TestBackend_SharedAcrossInstances(t *testing.T) {
+	limits := []Limit{{Key: "get:hourly", Max: 10, Window: time.Hour}}
+
+	for name, newBackend := range testBackends(t) {
+		if name == "memory" {
+			continue // in-process only
+		}
+		t.Run(name, func(t *testing.T) {
+			var granted int32
+			var wg sync.WaitGroup
+			for i := 0; i < 25; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					wait, _, err := newBackend().Acquire(context.Background(), "shared", limits, time.Now())
+					if !errors.Is(err, ErrContention) && !wait && err == nil {
+						atomic.AddInt32(&granted, 1)
+					}
+				}()
+			}
+			wg.Wait()
+			require.LessOrEqual(t, atomic.LoadInt32(&granted), int32(10))
+			require.Greater(t, atomic.LoadInt32(&granted), int32(0))
+		})
+	}
+}
+
+func TestLocalRemoteStore_CompareAndSwap(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	store := NewLocalRemoteStore().(*localRemoteStore)
+	store.now = func() time.Time { return now }
+
+	value, version, err := store.Get(ctx, "k")
+	require.NoError(t, err)
+	require.Nil(t, value)
+	require.Zero(t, version)
+
+	ok, err := store.CompareAndSwap(ctx, "k", 0, []byte("a"), time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = store.CompareAndSwap(ctx, "k", 0, []byte("b"), time.Minute)
+	require.NoError(t, err)
+	require.False(t, ok, "a stale version must not overwrite")
+
+	value, version, err = store.Get(ctx, "k")
+	require.NoError(t, err)
+	require.Equal(t, "a", string(value))
+
+	ok, err = store.CompareAndSwap(ctx, "k", version, []byte("c"), time.Minute)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	now = now.Add(2 * time.Minute)
+	value, version, err = store.Get(ctx, "k")
+	require.NoError(t, err)
+	require.Nil(t, value, "expired entries read as missing")
+	require.Zero(t, version)
+}
+
+// ---------------------------------------------------------------------------
+// RateLimiter with a backend
+// ---------------------------------------------------------------------------
+
+func TestRateLimiter_SharedBackendBudget(t *testing.T) {
+	backend := NewMemoryBackend()
+	first := NewRateLimiterWithHourly(100, 100, 1, 1, 3, 100, 100)
+	second := NewRateLimiterWithHourly(100, 100, 1, 1, 3, 100, 100)
+	first.SetBackend(backend, "acme/zia")
+	second.SetBackend(backend, "acme/zia")
+
+	for i, limiter := range []*RateLimiter{first, second, first} {
+		wait, _ := limiter.Wait(http.MethodGet)
+		require.False(t, wait, "GET #%d is within the shared hourly budget", i+1)
+	}
+
+	wait, delay := second.Wait(http.MethodGet)
+	require.True(t, wait, "the hourly budget is shared by both limiters")
+	require.Greater(t, delay, 59*time.Minute)
+}
+
+func TestRateLimitTransport_SharedBackendCountsWaitedRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	backend := NewMemoryBackend()
+	limiters := make([]*RateLimiter, 3)
+	for i := range limiters {
+		limiters[i] = NewRateLimiter(1, 1, 1, 1)
+		limiters[i].SetBackend(backend, "acme/zia")
+	}
+
+	for _, limiter := range limiters[:2] {
+		client := &http.Client{Transport: &RateLimitTransport{Limiter: limiter, Logger: logger.NewNopLogger()}}
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	wait, _ := limiters[2].Wait(http.MethodGet)
+	require.True(t, wait, "the request sent after waiting is counted in the shared budget")
+}
+
+type failingBackend struct{}
+
+func (failingBackend) Acquire(context.Context, string, []Limit, time.Time) (bool, time.Duration, error) {
+	return false, 0, errors.New("backend unavailable")
+}
+
+func TestRateLimiter_BackendFailureFallsBackToLocal(t *testing.T) {
+	limiter := NewRateLimiter(1, 1, 10, 10)
+	limiter.SetBackend(failingBackend{}, "acme/zpa")
+
+	wait, _ := limiter.Wait(http.MethodGet)
+	require.False(t, wait)
+	wait, _ = limiter.Wait(http.MethodGet)
+	require.True(t, wait, "local windows still apply when the backend fails")
+}
+
+func TestRateLimiter_Limits(t *testing.T) {
+	limiter := NewRateLimiterWithHourly(20, 10, 10, 10, 950, 950, 380)
+
+	require.Equal(t, []Limit{
+		{Key: "write", Max: 10, Window: 10 * time.Second},
+		{Key: "delete:hourly", Max: 380, Window: time.Hour},
+	}, limiter.Limits(http.MethodDelete))
+	require.Nil(t, limiter.Limits(http.MethodPatch))
+}
@@ -1,6 +1,7 @@
 package ratelimiter
 
 import (
+	"context"
 	"net/http"
 	"sync"
 	"time"
@@ -22,6 +23,9 @@ type RateLimiter struct {
 	getHourlyLimit     int
 	postPutHourlyLimit int
 	deleteHourlyLimit  int
+	// Shared request history, see SetBackend
+	backend Backend
+	scope   string
 }
 
 func NewRateLimiter(getLimit, postPutDeleteLimit, getFreq, postPutDeleteFreq int) *RateLimiter {
@@ -57,7 +61,56 @@ func NewRateLimiterWithHourly(getLimit, postPutDeleteLimit, getFreq, postPutDele
 	}
 }
 
+// SetBackend makes the limiter count requests in backend under scope instead
+// of in process memory, so that every limiter using the same backend and scope
+// (in this or other processes) shares one budget. Use one scope per tenant and
+// product. If the backend fails, the limiter falls back to its local windows.
+func (rl *RateLimiter) SetBackend(backend Backend, scope string) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.backend = backend
+	rl.scope = scope
+}
+
+// Limits returns the windows a request with method counts against.
+func (rl *RateLimiter) Limits(method string) []Limit {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	perSecond := Limit{Key: "write", Max: rl.postPutDeleteLimit, Window: time.Duration(rl.postPutDeleteFreq) * time.Second}
+	switch method {
+	case http.MethodGet:
+		return []Limit{
+			{Key: "get", Max: rl.getLimit, Window: time.Duration(rl.getFreq) * time.Second},
+			{Key: "get:hourly", Max: rl.getHourlyLimit, Window: time.Hour},
+		}
+	case http.MethodPost, http.MethodPut:
+		return []Limit{perSecond, {Key: "postput:hourly", Max: rl.postPutHourlyLimit, Window: time.Hour}}
+	case http.MethodDelete:
+		return []Limit{perSecond, {Key: "delete:hourly", Max: rl.deleteHourlyLimit, Window: time.Hour}}
+	}
+	return nil
+}
+
 func (rl *RateLimiter) Wait(method string) (bool, time.Duration) {
+	return rl.WaitContext(context.Background(), method)
+}
+
+// WaitContext is Wait with a context bounding access to a shared backend.
+func (rl *RateLimiter) WaitContext(ctx context.Context, method string) (bool, time.Duration) {
+	rl.mu.Lock()
+	backend, scope := rl.backend, rl.scope
+	rl.mu.Unlock()
+	if backend != nil {
+		shouldWait, delay, err := backend.Acquire(ctx, scope, rl.Limits(method), time.Now())
+		if err == nil {
+			return shouldWait, delay
+		}
+	}
+	return rl.waitLocal(method)
+}
+
+func (rl *RateLimiter) waitLocal(method string) (bool, time.Duration) {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
@@ -241,6 +294,15 @@ type RateLimitTransport struct {
 	WaitFunc        func() (bool, time.Duration) // Wait function reference (optional, overrides Limiter)
 	Logger          logger.Logger
 	AdditionalDelay time.Duration // Optional constant delay
+	// OnWait, if set, is called with the delay whenever a request has to wait
+	// for the limiter, e.g. to record the wait in metrics.
+	OnWait func(req *http.Request, delay time.Duration)
+	// MaxWait, if positive, makes RoundTrip fail fast with a *WaitError
+	// wrapping ErrWaitTooLong instead of sleeping when a wait would exceed it.
+	MaxWait time.Duration
+	// Adaptive, if set, additionally throttles requests based on the rate-limit
+	// headers of earlier responses and learns from every response.
+	Adaptive *AdaptiveLimiter
 }
 
 // RoundTrip implements the http.RoundTripper interface for rate limiting.
@@ -253,8 +315,20 @@ func (rlt *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, err
 		// Use custom wait function if provided
 		shouldWait, delay = rlt.WaitFunc()
 	} else if rlt.Limiter != nil {
-		// Use the standard rate limiter with method-based limits
-		shouldWait, delay = rlt.Limiter.Wait(req.Method)
+		// Use the standard rate limiter with method-based limits. The limiter
+		// only counts a request once it grants it, and other processes sharing
+		// its backend may take the slot freed while this one waited, so ask
+		// again after every wait.
+		for {
+			shouldWait, delay = rlt.Limiter.WaitContext(req.Context(), req.Method)
+			if !shouldWait {
+				break
+			}
+			rlt.Logger.Printf("[INFO] Rate limit exceeded for %s request. Waiting for %v before proceeding.", req.Method, delay)
+			if err := rlt.wait(req, delay+rlt.AdditionalDelay); err != nil {
+				return nil, err
+			}
+		}
 	} else if rlt.GlobalLimiter != nil {
 		// Use global rate limiter
 		shouldWait, delay = rlt.GlobalLimiter.Wait()
@@ -262,12 +336,41 @@ func (rlt *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, err
 
 	if shouldWait {
 		rlt.Logger.Printf("[INFO] Rate limit exceeded for %s request. Waiting for %v before proceeding.", req.Method, delay)
-		time.Sleep(delay + rlt.AdditionalDelay)
+		if err := rlt.wait(req, delay+rlt.AdditionalDelay); err != nil {
+			return nil, err
+		}
+	}
+
+	if rlt.Adaptive != nil {
+		for {
+			shouldWait, delay := rlt.Adaptive.Wait(req)
+			if !shouldWait {
+				break
+			}
+			rlt.Logger.Printf("[INFO] Server rate limit budget exhausted for %s %s. Waiting for %v before proceeding.", req.Method, req.URL.Path, delay)
+			if err := rlt.wait(req, delay); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	// Execute the actual HTTP request
 	if rlt.Base == nil {
 		rlt.Base = http.DefaultTransport
 	}
-	return rlt.Base.RoundTrip(req)
+	resp, err := rlt.Base.RoundTrip(req)
+	if rlt.Adaptive != nil && err == nil {
+		rlt.Adaptive.Observe(req, resp)
+	}
+	return resp, err
+}
+
+// wait sleeps for delay, honouring the request context and MaxWait.
+func (rlt *RateLimitTransport) wait(req *http.Request, delay time.Duration) error {
+	if ExceedsMax(delay, rlt.MaxWait) {
+		rlt.Logger.Printf("[WARN] Rate limit wait of %v for %s request exceeds the maximum of %v; failing fast.", delay, req.Method, rlt.MaxWait)
+	} else if rlt.OnWait != nil {
+		rlt.OnWait(req, delay)
+	}
+	return Sleep(req.Context(), delay, rlt.MaxWait)
 }
@@ -0,0 +1,67 @@
+package ratelimiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrWaitTooLong is wrapped by a WaitError when a required wait exceeds the
+// configured ceiling and the caller chose to fail fast instead of sleeping.
+var ErrWaitTooLong = errors.New("rate limit wait exceeds the configured maximum")
+
+// WaitError is returned when a rate-limit or retry wait does not complete,
+// either because the context was done first or because the wait exceeded the
+// fail-fast ceiling. It unwraps to the context error or to ErrWaitTooLong, so
+// errors.Is(err, context.DeadlineExceeded) keeps working.
+type WaitError struct {
+	// Wait is how long the caller had to wait in total.
+	Wait time.Duration
+	// Remaining is the part of Wait that had not elapsed when the wait ended.
+	Remaining time.Duration
+	// Max is the fail-fast ceiling that was exceeded, if any.
+	Max time.Duration
+	// Err is the context error or ErrWaitTooLong.
+	Err error
+}
+
+func (e *WaitError) Error() string {
+	if errors.Is(e.Err, ErrWaitTooLong) {
+		return fmt.Sprintf("rate limit: would wait %s, exceeding the maximum of %s", e.Wait, e.Max)
+	}
+	return fmt.Sprintf("rate limit: %v with %s of a %s wait remaining", e.Err, e.Remaining.Round(time.Millisecond), e.Wait)
+}
+
+func (e *WaitError) Unwrap() error {
+	return e.Err
+}
+
+// ExceedsMax reports whether d is above the fail-fast ceiling max. A max of
+// zero or less disables the ceiling.
+func ExceedsMax(d, max time.Duration) bool {
+	return max > 0 && d > max
+}
+
+// Sleep waits for d unless ctx is done first or d exceeds max (when max > 0),
+// in which case it returns a *WaitError without waiting out the delay.
+func Sleep(ctx context.Context, d, max time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	if ExceedsMax(d, max) {
+		return &WaitError{Wait: d, Remaining: d, Max: max, Err: ErrWaitTooLong}
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	start := time.Now()
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return &WaitError{Wait: d, Remaining: d - time.Since(start), Err: ctx.Err()}
+	case <-timer.C:
+		return nil
+	}
+}
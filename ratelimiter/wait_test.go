@@ -0,0 +1,102 @@
+package ratelimiter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/zscaler/zscaler-sdk-go/v3/logger"
+)
+
+func TestSleep_Completes(t *testing.T) {
+	require.NoError(t, Sleep(context.Background(), 10*time.Millisecond, 0))
+	require.NoError(t, Sleep(context.Background(), 0, time.Nanosecond))
+}
+
+func TestSleep_ContextDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := Sleep(ctx, time.Minute, 0)
+	require.Less(t, time.Since(start), 5*time.Second)
+
+	var waitErr *WaitError
+	require.True(t, errors.As(err, &waitErr))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, time.Minute, waitErr.Wait)
+	require.Greater(t, waitErr.Remaining, 50*time.Second)
+}
+
+func TestSleep_FailsFastAboveMax(t *testing.T) {
+	start := time.Now()
+	err := Sleep(context.Background(), time.Minute, time.Second)
+	require.Less(t, time.Since(start), time.Second)
+
+	var waitErr *WaitError
+	require.True(t, errors.As(err, &waitErr))
+	require.ErrorIs(t, err, ErrWaitTooLong)
+	require.Equal(t, time.Minute, waitErr.Wait)
+	require.Equal(t, time.Second, waitErr.Max)
+}
+
+func TestRateLimitTransport_StaticWaitHonoursContext(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &RateLimitTransport{
+		Limiter:         NewRateLimiter(1, 1, 1, 1),
+		Logger:          logger.NewNopLogger(),
+		AdditionalDelay: time.Minute,
+	}}
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	_, err = client.Do(req)
+
+	var waitErr *WaitError
+	require.True(t, errors.As(err, &waitErr))
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Greater(t, waitErr.Wait, time.Minute)
+	require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRateLimitTransport_MaxWaitFailsFast(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	var waits int32
+	client := &http.Client{Transport: &RateLimitTransport{
+		Adaptive: NewAdaptiveLimiter(0),
+		Logger:   logger.NewNopLogger(),
+		MaxWait:  time.Second,
+		OnWait:   func(req *http.Request, delay time.Duration) { atomic.AddInt32(&waits, 1) },
+	}}
+
+	resp, err := client.Get(server.URL + "/zia/api/v1/urlCategories")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	start := time.Now()
+	_, err = client.Get(server.URL + "/zia/api/v1/urlCategories")
+	require.ErrorIs(t, err, ErrWaitTooLong)
+	require.Less(t, time.Since(start), time.Second)
+	require.Equal(t, int32(0), atomic.LoadInt32(&waits))
+}
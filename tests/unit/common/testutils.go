@@ -64,7 +64,10 @@ func (t *MockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 // `readConfigFromEnvironment`, etc.), we explicitly clamp the config to
 // the OneAPI path immediately after `NewConfiguration` returns and
 // BEFORE `NewOneAPIClient` builds the service.
-func CreateTestService(ctx context.Context, server *TestServer, customerID string) (*zscaler.Service, error) {
+//
+// Extra ConfigSetters (interceptors, rate limit tweaks, …) are applied
+// after the test defaults, so they can override them.
+func CreateTestService(ctx context.Context, server *TestServer, customerID string, setters ...zscaler.ConfigSetter) (*zscaler.Service, error) {
 	// Create custom HTTP client with mock transport
 	httpClient := &http.Client{
 		Transport: &MockTransport{TestServerURL: server.URL},
@@ -74,14 +77,14 @@ func CreateTestService(ctx context.Context, server *TestServer, customerID strin
 	// Create configuration with pre-populated auth token to skip OAuth
 	// The key insight is that if AuthToken is valid (not expired),
 	// authenticate() will skip actual authentication
-	cfg, err := zscaler.NewConfiguration(
+	cfg, err := zscaler.NewConfiguration(append([]zscaler.ConfigSetter{
 		zscaler.WithZPACustomerID(customerID),
 		zscaler.WithVanityDomain("test"),
 		zscaler.WithZscalerCloud(""),
 		zscaler.WithHttpClientPtr(httpClient),
 		zscaler.WithTestingDisableHttpsCheck(true),
 		zscaler.WithCache(false), // Disable cache for testing
-	)
+	}, setters...)...)
 	if err != nil {
 		return nil, err
 	}
@@ -106,7 +109,11 @@ func CreateTestService(ctx context.Context, server *TestServer, customerID strin
 		Expiry:      time.Now().Add(time.Hour), // Valid for 1 hour
 	}
 
-	// Update all HTTP clients to use our mock transport
+	// Update all HTTP clients to use our mock transport, keeping any
+	// cassette configured through the setters in front of it.
+	if cfg.Cassette != nil {
+		httpClient = cfg.Cassette.Client(httpClient)
+	}
 	cfg.HTTPClient = httpClient
 	cfg.ZPAHTTPClient = httpClient
 	cfg.ZIAHTTPClient = httpClient
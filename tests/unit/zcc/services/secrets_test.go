@@ -4,15 +4,41 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/zscaler/zscaler-sdk-go/v3/tests/unit/common"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zcc"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zcc/services/secrets/getotp"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zcc/services/secrets/getpasswords"
 )
 
+// recordingLogger keeps every line logged through it.
+type recordingLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *recordingLogger) Printf(format string, v ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, fmt.Sprintf(format, v...))
+}
+
+func (l *recordingLogger) String() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return strings.Join(l.lines, "\n")
+}
+
 // =====================================================
 // SDK Function Tests - Exercise actual SDK code paths
 // =====================================================
@@ -49,6 +75,42 @@ func TestSecrets_GetOtp_SDK(t *testing.T) {
 	assert.Equal(t, "logout-456", result.LogoutOtp)
 }
 
+func TestSecrets_GetOtp_LegacyClientRedactsLog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/papi/auth/v1/login" {
+			_, _ = w.Write([]byte(`{"token_type":"Bearer","jwtToken":"zcc-jwt","expires_in":"3600"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(getotp.OtpResponse{Otp: "otp-secret-1", ExitOtp: "otp-secret-2"})
+	}))
+	defer server.Close()
+
+	baseURL, err := url.Parse(server.URL + "/papi")
+	require.NoError(t, err)
+	log := &recordingLogger{}
+	cfg := &zcc.Configuration{
+		Logger:     log,
+		HTTPClient: server.Client(),
+		BaseURL:    baseURL,
+		Context:    context.Background(),
+	}
+	cfg.ZCC.Client.ZCCClientID = "client-id"
+	cfg.ZCC.Client.ZCCClientSecret = "client-secret"
+
+	service, err := zscaler.NewLegacyZccClient(cfg)
+	require.NoError(t, err)
+
+	result, err := getotp.GetOtp(context.Background(), service, "")
+	require.NoError(t, err)
+	assert.Equal(t, "otp-secret-1", result.Otp)
+
+	logged := log.String()
+	assert.Contains(t, logged, "Response Body")
+	assert.NotContains(t, logged, "otp-secret")
+	assert.NotContains(t, logged, "zcc-jwt")
+}
+
 func TestSecrets_GetOtp_Empty_SDK(t *testing.T) {
 	server := common.NewTestServer()
 	defer server.Close()
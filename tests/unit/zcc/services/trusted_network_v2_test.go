@@ -7,12 +7,14 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	commontests "github.com/zscaler/zscaler-sdk-go/v3/tests/unit/common"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zcc/services/common"
 	tn "github.com/zscaler/zscaler-sdk-go/v3/zscaler/zcc/services/trusted_network_v2"
 )
@@ -201,6 +203,32 @@ func TestTrustedNetworkV2_GetAll_SinglePage_SDK(t *testing.T) {
 	assert.Equal(t, "gamma", all[2].Name)
 }
 
+// All streams the same items as GetAll and forwards the filter options.
+func TestTrustedNetworkV2_All_SDK(t *testing.T) {
+	server := commontests.NewTestServer()
+	defer server.Close()
+
+	listPath := "/zcc/papi/public/v2/trusted-networks"
+	server.On("GET", listPath, commontests.SuccessResponse(common.PaginatedResponseV2[tn.TrustedNetworkV2]{
+		Items: []tn.TrustedNetworkV2{{ID: 1, Name: "alpha"}, {ID: 2, Name: "beta"}},
+		Total: 2, Offset: 0, Limit: 50, Count: 2,
+	}))
+
+	service, err := commontests.CreateTestService(context.Background(), server, "123456")
+	require.NoError(t, err)
+
+	var names []string
+	for network, err := range tn.All(context.Background(), service, &tn.GetAllFilterOptions{Keyword: "al"}) {
+		require.NoError(t, err)
+		names = append(names, network.Name)
+	}
+	assert.Equal(t, []string{"alpha", "beta"}, names)
+
+	req := server.LastRequest()
+	require.NotNil(t, req)
+	assert.Contains(t, req.Query, "keyword=al")
+}
+
 // GetAll must send `skip` / `perPage` (the documented v2 names) — not the
 // older `page` / `pageSize`. This is the regression guard for the bug
 // where the SDK was sending unknown params, the server ignored them, and
@@ -290,6 +318,46 @@ func TestTrustedNetworkV2_GetAll_MultiPage_SDK(t *testing.T) {
 	assert.Contains(t, queries[1], "perPage=50")
 }
 
+// With page concurrency set on the context, GetAll uses the total from the
+// first page to fetch the remaining skips in parallel and still returns the
+// items in order.
+func TestTrustedNetworkV2_GetAll_Parallel_SDK(t *testing.T) {
+	var calls int32
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		skip, _ := strconv.Atoi(r.URL.Query().Get("skip"))
+		count := 50
+		if skip == 100 {
+			count = 20
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(common.PaginatedResponseV2[tn.TrustedNetworkV2]{
+			Items:  buildItems(skip+1, count),
+			Total:  120,
+			Offset: skip,
+			Limit:  50,
+			Count:  count,
+		})
+	}))
+	defer upstream.Close()
+
+	service, err := commontests.CreateTestService(context.Background(),
+		&commontests.TestServer{Server: upstream, Handler: commontests.NewMockHandler()},
+		"123456")
+	require.NoError(t, err)
+
+	ctx := zscaler.ContextWithPageConcurrency(context.Background(), 3)
+	all, err := tn.GetAll(ctx, service, nil)
+	require.NoError(t, err)
+	require.Len(t, all, 120)
+	for i, item := range all {
+		assert.Equal(t, i+1, item.ID)
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
 func TestTrustedNetworkV2_GetByName_SDK(t *testing.T) {
 	server := commontests.NewTestServer()
 	defer server.Close()
@@ -5,11 +5,15 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/zscaler/zscaler-sdk-go/v3/tests/unit/common"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/activation"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/rule_labels"
 )
 
 // =====================================================
@@ -109,6 +113,111 @@ func TestActivation_UpdateEusaStatus_SDK(t *testing.T) {
 	assert.True(t, result.AcceptedStatus)
 }
 
+func newSessionTestService(t *testing.T, server *common.TestServer) *zscaler.Service {
+	t.Helper()
+	service, err := common.CreateTestService(context.Background(), server, "123456",
+		zscaler.WithRateLimitMaxRetries(1),
+		zscaler.WithRateLimitMinWait(time.Millisecond),
+		zscaler.WithRateLimitMaxWait(time.Millisecond),
+	)
+	require.NoError(t, err)
+	return service
+}
+
+func createLabel(service *zscaler.Service, name string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		_, _, err := rule_labels.Create(ctx, service, &rule_labels.RuleLabels{Name: name})
+		return err
+	}
+}
+
+func TestActivation_Session_CommitCoalescesAndPolls(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+	server.On("POST", "/zia/api/v1/ruleLabels", common.SuccessResponse(rule_labels.RuleLabels{ID: 1}))
+	server.On("POST", "/zia/api/v1/status/activate", common.SuccessResponse(activation.Activation{Status: activation.StatusPending}))
+	server.OnSequence("GET", "/zia/api/v1/status",
+		common.SuccessResponse(activation.Activation{Status: activation.StatusInProgress}),
+		common.SuccessResponse(activation.Activation{Status: activation.StatusActive}),
+	)
+	service := newSessionTestService(t, server)
+	ctx := context.Background()
+
+	session := activation.NewSession(service, activation.WithQuietPeriod(0), activation.WithPollInterval(time.Millisecond))
+	require.NoError(t, session.Do(ctx, createLabel(service, "a")))
+	require.NoError(t, session.Do(ctx, createLabel(service, "b")))
+	assert.Equal(t, 2, session.Pending())
+	assert.Zero(t, server.GetCallCount("POST", "/zia/api/v1/status/activate"))
+
+	require.NoError(t, session.Commit(ctx))
+	assert.Zero(t, session.Pending())
+	assert.Equal(t, 1, server.GetCallCount("POST", "/zia/api/v1/status/activate"), "changes are activated together")
+	assert.Equal(t, 2, server.GetCallCount("GET", "/zia/api/v1/status"))
+
+	require.NoError(t, session.Commit(ctx))
+	assert.Equal(t, 1, server.GetCallCount("POST", "/zia/api/v1/status/activate"), "nothing left to activate")
+}
+
+func TestActivation_Session_QuietPeriod(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+	server.On("POST", "/zia/api/v1/status/activate", common.SuccessResponse(activation.Activation{Status: activation.StatusActive}))
+	service := newSessionTestService(t, server)
+
+	session := activation.NewSession(service, activation.WithQuietPeriod(20*time.Millisecond))
+	for i := 0; i < 3; i++ {
+		session.Track()
+	}
+	assert.Eventually(t, func() bool { return session.Pending() == 0 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, 1, server.GetCallCount("POST", "/zia/api/v1/status/activate"))
+	assert.NoError(t, session.Err())
+}
+
+func TestActivation_Session_EditLock(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+	server.OnSequence("POST", "/zia/api/v1/ruleLabels",
+		common.EditLockResponse(),
+		common.EditLockResponse(),
+		common.SuccessResponse(rule_labels.RuleLabels{ID: 1}),
+	)
+	service := newSessionTestService(t, server)
+	ctx := context.Background()
+
+	session := activation.NewSession(service, activation.WithQuietPeriod(0), activation.WithPollInterval(time.Millisecond))
+	require.NoError(t, session.Do(ctx, createLabel(service, "a")), "retried until the edit lock is released")
+	assert.Equal(t, 1, session.Pending())
+
+	server.On("POST", "/zia/api/v1/ruleLabels", common.EditLockResponse())
+	server.On("POST", "/zia/api/v1/status/activate", common.EditLockResponse())
+	session = activation.NewSession(service, activation.WithQuietPeriod(0), activation.WithPollInterval(time.Millisecond), activation.WithEditLockAttempts(2))
+	err := session.Do(ctx, createLabel(service, "b"))
+	var lockErr *activation.EditLockError
+	require.ErrorAs(t, err, &lockErr)
+	assert.Equal(t, 2, lockErr.Attempts)
+	assert.ErrorIs(t, err, errorx.ErrEditLock)
+	assert.Zero(t, session.Pending())
+
+	session.Track()
+	assert.ErrorIs(t, session.Commit(ctx), errorx.ErrEditLock)
+	assert.Equal(t, 1, session.Pending(), "failed activations are retried by the next one")
+}
+
+func TestActivation_Session_CloseAfterInterrupt(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+	server.On("POST", "/zia/api/v1/status/activate", common.SuccessResponse(activation.Activation{Status: activation.StatusActive}))
+	service := newSessionTestService(t, server)
+
+	session := activation.NewSession(service, activation.WithQuietPeriod(time.Hour))
+	session.Track()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, session.Close(ctx), "the final activation runs although ctx is done")
+	assert.Equal(t, 1, server.GetCallCount("POST", "/zia/api/v1/status/activate"))
+	assert.ErrorIs(t, session.Do(ctx, createLabel(service, "late")), activation.ErrSessionClosed)
+}
+
 // =====================================================
 // Structure Tests
 // =====================================================
@@ -47,6 +47,33 @@ func TestCloudApplications_GetCloudApplicationPolicy_SDK(t *testing.T) {
 	assert.Equal(t, "Webmail", result[0].ParentName)
 }
 
+func TestCloudApplications_AllCloudApplicationPolicy_SDK(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+
+	server.OnFunc("GET", cloudAppPolicyPath, func(r *http.Request, _ []byte) common.MockResponse {
+		assert.Contains(t, r.URL.RawQuery, "appClass=WEB_MAIL")
+		return common.SuccessResponse([]cloudapplications.CloudApplications{
+			{App: "GMAIL", AppName: "Gmail"},
+			{App: "OUTLOOK", AppName: "Outlook"},
+		})
+	})
+
+	service, err := common.CreateTestService(context.Background(), server, "123456")
+	require.NoError(t, err)
+
+	params := map[string]interface{}{
+		"appClass": []interface{}{"WEB_MAIL"},
+	}
+
+	var apps []string
+	for app, err := range cloudapplications.AllCloudApplicationPolicy(context.Background(), service, params) {
+		require.NoError(t, err)
+		apps = append(apps, app.App)
+	}
+	assert.Equal(t, []string{"GMAIL", "OUTLOOK"}, apps)
+}
+
 func TestCloudApplications_GetCloudApplicationPolicy_WithGroupResults_SDK(t *testing.T) {
 	server := common.NewTestServer()
 	defer server.Close()
@@ -319,6 +319,91 @@ func TestZIACommon_ReadAllPages(t *testing.T) {
 	})
 }
 
+func TestZIACommon_AllPages(t *testing.T) {
+	t.Parallel()
+
+	pages := func(t *testing.T, api *common.APITest, path string) {
+		api.OnFunc("GET", path, func(r *http.Request, _ []byte) common.MockResponse {
+			assert.Equal(t, "2", r.URL.Query().Get("pageSize"))
+			switch r.URL.Query().Get("page") {
+			case "1":
+				return common.SuccessResponse([]pageItem{{ID: 1, Name: "one"}, {ID: 2, Name: "two"}})
+			case "2":
+				return common.SuccessResponse([]pageItem{{ID: 3, Name: "three"}, {ID: 4, Name: "four"}})
+			case "3":
+				return common.SuccessResponse([]pageItem{{ID: 5, Name: "five"}})
+			default:
+				t.Fatalf("unexpected page %q", r.URL.Query().Get("page"))
+				return common.NotFoundResponse()
+			}
+		})
+	}
+
+	t.Run("streams every page with callback", func(t *testing.T) {
+		api := common.NewZIATest(t)
+		path := common.ZIAPath("testResources")
+		pages(t, api, path)
+
+		var infos []zscaler.PageInfo
+		var ids []int
+		for item, err := range ziacommon.AllPages[pageItem](context.Background(), api.Service.Client, path,
+			zscaler.WithPageSize(2),
+			zscaler.WithPageCallback(func(info zscaler.PageInfo) error {
+				infos = append(infos, info)
+				return nil
+			}),
+		) {
+			require.NoError(t, err)
+			ids = append(ids, item.ID)
+		}
+		assert.Equal(t, []int{1, 2, 3, 4, 5}, ids)
+		require.Len(t, infos, 3)
+		assert.Equal(t, zscaler.PageToken("2"), infos[0].Next)
+		assert.Equal(t, zscaler.PageToken(""), infos[2].Next)
+	})
+
+	t.Run("early termination stops fetching", func(t *testing.T) {
+		api := common.NewZIATest(t)
+		path := common.ZIAPath("testResources")
+		pages(t, api, path)
+
+		for item, err := range ziacommon.AllPages[pageItem](context.Background(), api.Service.Client, path, zscaler.WithPageSize(2)) {
+			require.NoError(t, err)
+			if item.ID == 2 {
+				break
+			}
+		}
+		assert.Equal(t, 1, api.Server.GetCallCount("GET", path))
+	})
+
+	t.Run("resumes from token", func(t *testing.T) {
+		api := common.NewZIATest(t)
+		path := common.ZIAPath("testResources")
+		pages(t, api, path)
+
+		var ids []int
+		for item, err := range ziacommon.AllPages[pageItem](context.Background(), api.Service.Client, path,
+			zscaler.WithPageSize(2), zscaler.WithStartToken("3")) {
+			require.NoError(t, err)
+			ids = append(ids, item.ID)
+		}
+		assert.Equal(t, []int{5}, ids)
+	})
+
+	t.Run("error is yielded", func(t *testing.T) {
+		api := common.NewZIATest(t)
+		path := common.ZIAPath("testResources")
+		api.On("GET", path, common.NotFoundResponse())
+
+		var errs []error
+		for _, err := range ziacommon.AllPages[pageItem](context.Background(), api.Service.Client, path) {
+			errs = append(errs, err)
+		}
+		require.Len(t, errs, 1)
+		assert.Error(t, errs[0])
+	})
+}
+
 func TestZIACommon_ReadPage(t *testing.T) {
 	t.Parallel()
 
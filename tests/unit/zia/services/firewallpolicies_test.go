@@ -4,12 +4,19 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/zscaler/zscaler-sdk-go/v3/tests/unit/common"
+	ziacommon "github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/firewallpolicies/filteringrules"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/firewallpolicies/ipdestinationgroups"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/firewallpolicies/ipsourcegroups"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/firewallpolicies/networkservicegroups"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/firewallpolicies/networkservices"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/firewallpolicies/ruleanalyzer"
 )
 
 // =====================================================
@@ -211,6 +218,39 @@ func TestFirewallFilteringRules_GetAll_WithFilters_SDK(t *testing.T) {
 	assert.Len(t, result, 1)
 }
 
+func TestFirewallFilteringRules_All_WithFilters_SDK(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+
+	path := "/zia/api/v1/firewallFilteringRules"
+	server.On("GET", path, common.SuccessResponse([]filteringrules.FirewallFilteringRules{
+		{ID: 1, Name: "Filtered Rule 1", Action: "BLOCK"},
+		{ID: 2, Name: "Filtered Rule 2", Action: "BLOCK"},
+	}))
+
+	service, err := common.CreateTestService(context.Background(), server, "123456")
+	require.NoError(t, err)
+
+	var ids []int
+	for rule, err := range filteringrules.All(context.Background(), service, &filteringrules.GetAllFilterOptions{
+		RuleName:   "Filtered",
+		RuleAction: "BLOCK",
+	}) {
+		require.NoError(t, err)
+		ids = append(ids, rule.ID)
+	}
+	assert.Equal(t, []int{1, 2}, ids)
+
+	req := server.LastRequest()
+	require.NotNil(t, req)
+	query, err := url.ParseQuery(req.Query)
+	require.NoError(t, err)
+	assert.Equal(t, "Filtered", query.Get("ruleName"))
+	assert.Equal(t, "BLOCK", query.Get("ruleAction"))
+	assert.Equal(t, "5000", query.Get("pageSize"))
+	assert.Equal(t, "1", query.Get("page"))
+}
+
 func TestFirewallFilteringRules_GetFirewallFilteringRuleCount_WithFilters_SDK(t *testing.T) {
 	server := common.NewTestServer()
 	defer server.Close()
@@ -416,3 +456,129 @@ func TestFirewallFilteringRules_ResponseParsing(t *testing.T) {
 	})
 }
 
+// =====================================================
+// Rule Analyzer Tests
+// =====================================================
+
+func ruleAnalyzerSnapshot() *ruleanalyzer.Snapshot {
+	ref := func(id int) []ziacommon.IDNameExtensions {
+		return []ziacommon.IDNameExtensions{{ID: id}}
+	}
+	return &ruleanalyzer.Snapshot{
+		Rules: []filteringrules.FirewallFilteringRules{
+			{ID: 1, Name: "Allow web", Order: 1, State: "ENABLED", Action: "ALLOW", SrcIpGroups: ref(10), DestIpGroups: ref(20), NwServiceGroups: ref(40)},
+			{ID: 2, Name: "Block HTTPS subnet", Order: 2, State: "ENABLED", Action: "BLOCK_DROP", SrcIps: []string{"10.1.2.0/24"}, DestAddresses: []string{"203.0.113.10"}, NwServices: ref(30)},
+			{ID: 3, Name: "Allow example", Order: 3, State: "ENABLED", Action: "ALLOW", SrcIps: []string{"10.1.5.5"}, DestAddresses: []string{"www.example.com"}, NwServices: ref(31)},
+			{ID: 4, Name: "Block partners", Order: 4, State: "ENABLED", Action: "BLOCK_RESET", SrcIps: []string{"10.0.0.0/8"}, DestAddresses: []string{"203.0.113.0/25"}, NwServices: ref(30)},
+			{ID: 5, Name: "Allow all", Order: 5, State: "ENABLED", Action: "ALLOW"},
+			{ID: 6, Name: "Disabled", Order: 6, State: "DISABLED", Action: "ALLOW"},
+			{ID: 7, Name: "Block unknown", Order: 7, State: "ENABLED", Action: "BLOCK_DROP", DestIpGroups: ref(99)},
+			{ID: 8, Name: "Default Firewall Filtering Rule", Order: 8, State: "ENABLED", Action: "BLOCK_DROP", DefaultRule: true},
+		},
+		SourceIPGroups: []ipsourcegroups.IPSourceGroups{
+			{ID: 10, Name: "Office", IPAddresses: []string{"10.1.0.0-10.1.127.255", "10.1.128.0/17"}},
+		},
+		DestinationIPGroups: []ipdestinationgroups.IPDestinationGroups{
+			{ID: 20, Name: "Web", Addresses: []string{"203.0.113.0/24", "*.example.com"}},
+		},
+		NetworkServices: []networkservices.NetworkServices{
+			{ID: 30, Name: "HTTPS", DestTCPPorts: []networkservices.NetworkPorts{{Start: 443}}},
+			{ID: 31, Name: "HTTP", DestTCPPorts: []networkservices.NetworkPorts{{Start: 80}}},
+		},
+		NetworkServiceGroups: []networkservicegroups.NetworkServiceGroups{
+			{ID: 40, Name: "Web services", Services: []networkservicegroups.Services{{ID: 30}, {ID: 31}}},
+		},
+	}
+}
+
+func TestFirewallRuleAnalyzer_Analyze(t *testing.T) {
+	report := ruleanalyzer.Analyze(ruleAnalyzerSnapshot())
+
+	assert.Equal(t, 7, report.RulesAnalyzed)
+	assert.Equal(t, 2, report.Count(ruleanalyzer.FindingShadowed))
+	assert.Equal(t, 1, report.Count(ruleanalyzer.FindingRedundant))
+	assert.Equal(t, 1, report.Count(ruleanalyzer.FindingOverlyBroad))
+	assert.Equal(t, 3, report.Count(ruleanalyzer.FindingConflict))
+	assert.Equal(t, 1, report.Count(ruleanalyzer.FindingUnresolved))
+
+	byRule := map[int][]ruleanalyzer.Finding{}
+	for _, f := range report.Findings {
+		byRule[f.RuleID] = append(byRule[f.RuleID], f)
+	}
+	require.Len(t, byRule[2], 1)
+	assert.Equal(t, ruleanalyzer.FindingShadowed, byRule[2][0].Type)
+	assert.Equal(t, 1, byRule[2][0].RelatedRuleID)
+	require.Len(t, byRule[3], 1)
+	assert.Equal(t, ruleanalyzer.FindingRedundant, byRule[3][0].Type)
+	require.Len(t, byRule[4], 1)
+	assert.Equal(t, ruleanalyzer.FindingConflict, byRule[4][0].Type)
+	assert.Equal(t, 1, byRule[4][0].RelatedRuleID)
+	assert.Empty(t, byRule[6])
+	assert.Empty(t, byRule[8])
+
+	high := report.AtLeast(ruleanalyzer.SeverityHigh)
+	require.Len(t, high, 2)
+	assert.Equal(t, 5, high[1].RelatedRuleID)
+
+	data, err := json.Marshal(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"type":"SHADOWED"`)
+	assert.Contains(t, string(data), `"relatedRuleName":"Allow web"`)
+}
+
+func TestFirewallRuleAnalyzer_AppSegmentsAndBlockActions(t *testing.T) {
+	report := ruleanalyzer.Analyze(&ruleanalyzer.Snapshot{Rules: []filteringrules.FirewallFilteringRules{
+		{ID: 1, Name: "ZPA CRM", Order: 1, State: "ENABLED", Action: "ALLOW", ZPAAppSegments: []ziacommon.ZPAAppSegments{{ID: 11, Name: "CRM", ExternalID: "72058"}}},
+		{ID: 2, Name: "Drop web", Order: 2, State: "ENABLED", Action: "BLOCK_DROP", DestAddresses: []string{"203.0.113.0/24"}},
+		{ID: 3, Name: "Reset web", Order: 3, State: "ENABLED", Action: "BLOCK_RESET", DestAddresses: []string{"203.0.113.10"}},
+	}})
+
+	assert.Zero(t, report.Count(ruleanalyzer.FindingOverlyBroad), "a rule limited to ZPA app segments is not broad")
+	assert.Zero(t, report.Count(ruleanalyzer.FindingShadowed))
+	require.Equal(t, 1, report.Count(ruleanalyzer.FindingRedundant))
+	for _, f := range report.Findings {
+		if f.Type == ruleanalyzer.FindingRedundant {
+			assert.Equal(t, 3, f.RuleID)
+			assert.Equal(t, 2, f.RelatedRuleID, "BLOCK_DROP and BLOCK_RESET are the same decision")
+		}
+	}
+	assert.Empty(t, report.AtLeast(ruleanalyzer.SeverityHigh))
+}
+
+func TestFirewallRuleAnalyzer_Options(t *testing.T) {
+	report := ruleanalyzer.Analyze(ruleAnalyzerSnapshot(),
+		ruleanalyzer.WithFindingTypes(ruleanalyzer.FindingShadowed, ruleanalyzer.FindingOverlyBroad),
+		ruleanalyzer.WithIgnoredRules(5, 7),
+	)
+	require.Len(t, report.Findings, 1)
+	assert.Equal(t, 2, report.Findings[0].RuleID)
+
+	report = ruleanalyzer.Analyze(&ruleanalyzer.Snapshot{})
+	assert.NotNil(t, report.Findings)
+	assert.Empty(t, report.AtLeast(ruleanalyzer.SeverityInfo))
+}
+
+func TestFirewallRuleAnalyzer_GetSnapshot_SDK(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+
+	snapshot := ruleAnalyzerSnapshot()
+	server.On("GET", "/zia/api/v1/firewallFilteringRules", common.SuccessResponse(snapshot.Rules))
+	server.On("GET", "/zia/api/v1/ipSourceGroups", common.SuccessResponse(snapshot.SourceIPGroups))
+	server.On("GET", "/zia/api/v1/ipDestinationGroups", common.SuccessResponse(snapshot.DestinationIPGroups))
+	server.On("GET", "/zia/api/v1/networkServices", common.SuccessResponse(snapshot.NetworkServices))
+	server.On("GET", "/zia/api/v1/networkServiceGroups", common.SuccessResponse(snapshot.NetworkServiceGroups))
+
+	service, err := common.CreateTestService(context.Background(), server, "123456")
+	require.NoError(t, err)
+
+	got, err := ruleanalyzer.GetSnapshot(context.Background(), service)
+	require.NoError(t, err)
+	assert.Len(t, got.Rules, 8)
+	assert.Len(t, got.SourceIPGroups, 1)
+	assert.Len(t, got.DestinationIPGroups, 1)
+	assert.Len(t, got.NetworkServices, 2)
+	assert.Len(t, got.NetworkServiceGroups, 1)
+
+	assert.Equal(t, 2, ruleanalyzer.Analyze(got).Count(ruleanalyzer.FindingShadowed))
+}
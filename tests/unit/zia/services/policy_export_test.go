@@ -5,6 +5,7 @@ import (
 	"archive/zip"
 	"bytes"
 	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
@@ -13,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/zscaler/zscaler-sdk-go/v3/tests/unit/common"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/policy_export"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscalertest"
 )
 
 func testPolicyExportZIP(t *testing.T) []byte {
@@ -161,3 +163,173 @@ func TestPolicyExport_ExportPolicies_DirectoryZipSlip_SDK(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "zip slip")
 }
+
+func writePolicyExportZIP(t *testing.T, files map[string]string) []byte {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		require.NoError(t, err)
+		_, err = f.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+// sourceTenantExport is an export of a tenant whose objects have other IDs
+// than those of the target tenant.
+var sourceTenantExport = map[string]string{
+	"firewallFilteringRules.json": `{"rules":[
+		{"id":11,"name":"Block Partners","order":2,"action":"BLOCK_DROP","state":"ENABLED",
+		 "locations":[{"id":901,"name":"HQ"}],"labels":[{"id":77,"name":"prod"}],
+		 "urlCategories":["CUSTOM_05","NEWS_AND_MEDIA"],"lastModifiedTime":1700000000},
+		{"id":10,"name":"Allow DNS","order":1,"action":"ALLOW","state":"ENABLED"},
+		{"id":12,"name":"Branch Only","order":3,"action":"ALLOW","locations":[{"id":902,"name":"Branch"}]},
+		{"id":13,"name":"Default Firewall Filtering Rule","order":-1,"defaultRule":true,"action":"ALLOW"}
+	]}`,
+	"urlCategories.json": `[{"id":"CUSTOM_05","configuredName":"Partners","customCategory":true},{"id":"NEWS_AND_MEDIA","customCategory":false}]`,
+	"summary.json":       `{}`,
+}
+
+func seedTargetTenant(t *testing.T, fake *zscalertest.Server) {
+	t.Helper()
+	_, err := fake.Seed(zscalertest.ZIALocations, map[string]interface{}{"name": "HQ"})
+	require.NoError(t, err)
+	_, err = fake.Seed(zscalertest.ZIARuleLabels, map[string]interface{}{"name": "prod"})
+	require.NoError(t, err)
+	_, err = fake.Seed(zscalertest.ZIAURLCategories, map[string]interface{}{"configuredName": "Partners", "customCategory": true})
+	require.NoError(t, err)
+	_, err = fake.Seed(zscalertest.ZIAFirewallRules, map[string]interface{}{"name": "Allow DNS", "order": 1, "action": "BLOCK_DROP"})
+	require.NoError(t, err)
+}
+
+func TestPolicyExport_ImportPolicies_RoundTrip_SDK(t *testing.T) {
+	ctx := context.Background()
+	server := common.NewTestServer()
+	defer server.Close()
+	server.On("POST", "/zia/api/v1/exportPolicies", common.RawResponse(writePolicyExportZIP(t, sourceTenantExport), 200, map[string]string{
+		"Content-Type": "application/zip",
+	}))
+	source, err := common.CreateTestService(ctx, server, "123456")
+	require.NoError(t, err)
+	exportDir := t.TempDir()
+	require.NoError(t, policy_export.ExportPolicies(ctx, source, []string{"FIREWALL"}, exportDir))
+
+	fake := zscalertest.NewServer()
+	seedTargetTenant(t, fake)
+	target, err := fake.NewService()
+	require.NoError(t, err)
+
+	report, err := policy_export.ImportPolicies(ctx, target, exportDir)
+	require.NoError(t, err)
+
+	var statuses []string
+	for _, r := range report.Rules {
+		statuses = append(statuses, r.Name+":"+r.Status)
+	}
+	assert.Equal(t, []string{
+		"Default Firewall Filtering Rule:SKIPPED",
+		"Allow DNS:UPDATED",
+		"Block Partners:CREATED",
+		"Branch Only:FAILED",
+	}, statuses, "rules are imported in order")
+	assert.Equal(t, []string{"summary.json"}, report.IgnoredFiles)
+	assert.Equal(t, []string{"locations/Branch"}, report.Rules[3].Unresolved)
+	require.Error(t, report.Err())
+	assert.Contains(t, report.Err().Error(), `FIREWALL rule "Branch Only": unresolved references: locations/Branch`)
+
+	location := fake.Items(zscalertest.ZIALocations)[0]
+	label := fake.Items(zscalertest.ZIARuleLabels)[0]
+	category := fake.Items(zscalertest.ZIAURLCategories)[0]
+	rules := fake.Items(zscalertest.ZIAFirewallRules)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "ALLOW", rules[0]["action"], "the existing rule is updated")
+	created := rules[1]
+	assert.Equal(t, "Block Partners", created["name"])
+	assert.EqualValues(t, report.Rules[2].ID, toInt(t, created["id"]))
+	assert.Equal(t, toInt(t, location["id"]), toInt(t, created["locations"].([]interface{})[0].(map[string]interface{})["id"]))
+	assert.Equal(t, toInt(t, label["id"]), toInt(t, created["labels"].([]interface{})[0].(map[string]interface{})["id"]))
+	assert.Equal(t, []interface{}{category["id"], "NEWS_AND_MEDIA"}, created["urlCategories"])
+	assert.NotContains(t, created, "lastModifiedTime")
+}
+
+func TestPolicyExport_ImportPolicies_ZIPOptions_SDK(t *testing.T) {
+	ctx := context.Background()
+	archive := filepath.Join(t.TempDir(), "export.zip")
+	require.NoError(t, os.WriteFile(archive, writePolicyExportZIP(t, sourceTenantExport), 0o600))
+
+	fake := zscalertest.NewServer()
+	seedTargetTenant(t, fake)
+	target, err := fake.NewService()
+	require.NoError(t, err)
+
+	report, err := policy_export.ImportPolicies(ctx, target, archive, policy_export.WithImportPolicyTypes("URL_FILTERING"))
+	require.NoError(t, err)
+	assert.Empty(t, report.Rules, "no URL filtering rules in the export")
+
+	report, err = policy_export.ImportPolicies(ctx, target, archive, policy_export.WithUpdateExisting(false), policy_export.WithStopOnError())
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Count(policy_export.ImportSkipped))
+	assert.Equal(t, 0, report.Count(policy_export.ImportUpdated))
+	assert.Equal(t, policy_export.ImportSkipped, report.Rules[1].Status)
+	assert.Equal(t, "rule exists", report.Rules[1].Reason)
+	assert.Equal(t, policy_export.ImportFailed, report.Rules[len(report.Rules)-1].Status, "the import stops at the first failure")
+	assert.Equal(t, "BLOCK_DROP", fake.Items(zscalertest.ZIAFirewallRules)[0]["action"], "existing rules are left alone")
+
+	_, err = policy_export.ImportPolicies(ctx, target, filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+}
+
+func TestPolicyExport_ImportPolicies_References_SDK(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "firewallFilteringRules.json"), []byte(`[
+		{"id":20,"name":"Office Web","order":1,"action":"ALLOW",
+		 "users":[{"id":501,"name":"alice"}],"srcIpGroups":[{"id":601,"name":"Office"}],
+		 "destIpGroups":[{"id":602,"name":"Partners"}],"nwServices":[{"id":701,"name":"HTTPS"}],
+		 "nwServiceGroups":[{"id":702,"name":"Web"}]},
+		{"id":21,"name":"Isolate","order":2,"action":"ALLOW","cbiProfile":{"id":"abc","name":"Default"}},
+		{"id":22,"name":"Unknown User","order":3,"action":"ALLOW","users":[{"id":502,"name":"bob"}]}
+	]`), 0o600))
+
+	server := common.NewTestServer()
+	defer server.Close()
+	server.On("GET", "/zia/api/v1/firewallFilteringRules", common.SuccessResponse([]interface{}{}))
+	server.On("POST", "/zia/api/v1/firewallFilteringRules", common.SuccessResponse(map[string]interface{}{"id": 9001}))
+	server.On("GET", "/zia/api/v1/users", common.SuccessResponse([]map[string]interface{}{{"id": 1501, "name": "Alice"}}))
+	server.On("GET", "/zia/api/v1/ipSourceGroups", common.SuccessResponse([]map[string]interface{}{{"id": 1601, "name": "Office"}}))
+	server.On("GET", "/zia/api/v1/ipDestinationGroups", common.SuccessResponse([]map[string]interface{}{{"id": 1602, "name": "Partners"}}))
+	server.On("GET", "/zia/api/v1/networkServices", common.SuccessResponse([]map[string]interface{}{{"id": 1701, "name": "HTTPS"}}))
+	server.On("GET", "/zia/api/v1/networkServiceGroups", common.SuccessResponse([]map[string]interface{}{{"id": 1702, "name": "Web"}}))
+	service, err := common.CreateTestService(ctx, server, "123456")
+	require.NoError(t, err)
+
+	report, err := policy_export.ImportPolicies(ctx, service, dir)
+	require.NoError(t, err)
+	require.Len(t, report.Rules, 3)
+	assert.Equal(t, policy_export.ImportCreated, report.Rules[0].Status)
+	assert.Equal(t, policy_export.ImportFailed, report.Rules[1].Status, "references the importer cannot resolve fail the rule")
+	assert.Equal(t, []string{"cbiProfile/Default"}, report.Rules[1].Unresolved)
+	assert.Equal(t, []string{"users/bob"}, report.Rules[2].Unresolved)
+
+	var created map[string]interface{}
+	for _, r := range server.AllRequests() {
+		if r.Method == "POST" {
+			require.NoError(t, json.Unmarshal(r.Body, &created))
+		}
+	}
+	for field, id := range map[string]int64{"users": 1501, "srcIpGroups": 1601, "destIpGroups": 1602, "nwServices": 1701, "nwServiceGroups": 1702} {
+		assert.Equal(t, id, toInt(t, created[field].([]interface{})[0].(map[string]interface{})["id"]), field)
+	}
+}
+
+func toInt(t *testing.T, v interface{}) int64 {
+	t.Helper()
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	var n int64
+	require.NoError(t, json.Unmarshal(b, &n))
+	return n
+}
@@ -5,11 +5,14 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/zscaler/zscaler-sdk-go/v3/tests/unit/common"
 	ziacommon "github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/firewallpolicies/timewindow"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/urlcategories"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/urlfilteringpolicies"
 )
 
@@ -384,3 +387,123 @@ func TestURLFilteringPolicies_ResponseParsing(t *testing.T) {
 		assert.Equal(t, "CAUTION", rules[2].Action)
 	})
 }
+
+func simulatorSnapshot() urlfilteringpolicies.Snapshot {
+	return urlfilteringpolicies.Snapshot{
+		Rules: []urlfilteringpolicies.URLFilteringRule{
+			{ID: 5, Name: "Block uploads", Order: 5, State: "ENABLED", Action: "BLOCK",
+				URLCategories: []string{"ANY"}, RequestMethods: []string{"POST", "PUT"},
+				Devices: []ziacommon.IDNameExtensions{{ID: 1, Name: "laptop"}}},
+			{ID: 1, Name: "Disabled", Order: 1, State: "DISABLED", Action: "BLOCK", URLCategories: []string{"ANY"}},
+			{ID: 2, Name: "Block social for contractors", Order: 2, State: "ENABLED", Action: "BLOCK",
+				URLCategories: []string{"SOCIAL_NETWORKING"}, Groups: []ziacommon.IDNameExtensions{{ID: 10, Name: "Contractors"}}},
+			{ID: 3, Name: "Caution streaming after hours", Order: 3, State: "ENABLED", Action: "CAUTION",
+				URLCategories: []string{"STREAMING_MEDIA"}, TimeWindows: []ziacommon.IDNameExtensions{{ID: 50, Name: "After hours"}}},
+			{ID: 4, Name: "Isolate partners", Order: 4, State: "ENABLED", Action: "ISOLATE",
+				URLCategories: []string{"CUSTOM_01"}, Protocols: []string{"HTTPS_RULE"},
+				Locations: []ziacommon.IDNameExtensions{{ID: 7, Name: "HQ"}}},
+		},
+		CustomCategories: []urlcategories.URLCategory{
+			{ID: "CUSTOM_01", ConfiguredName: "Partners", CustomCategory: true, Urls: []string{".partner.example"}},
+			{ID: "CUSTOM_02", ConfiguredName: "Partner news", CustomCategory: true, DBCategorizedUrls: []string{"news.example/partners"}},
+		},
+		TimeWindows: []timewindow.TimeWindow{
+			{ID: 50, Name: "After hours", StartTime: 18 * 60, EndTime: 8 * 60, DayOfWeek: []string{"MON", "TUE", "WED", "THU", "FRI"}},
+		},
+		Classifications: map[string][]string{
+			"www.facebook.com": {"SOCIAL_NETWORKING"},
+			"video.example":    {"STREAMING_MEDIA"},
+			"news.example":     {"NEWS_AND_MEDIA"},
+		},
+	}
+}
+
+func TestURLFilteringPolicies_Simulator(t *testing.T) {
+	t.Parallel()
+	sim := urlfilteringpolicies.NewSimulator(simulatorSnapshot())
+	tuesday := func(hour int) time.Time { return time.Date(2024, 6, 4, hour, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name   string
+		req    urlfilteringpolicies.SimulationRequest
+		rule   string
+		action string
+	}{
+		{"group member", urlfilteringpolicies.SimulationRequest{URL: "https://www.facebook.com/", Groups: []string{"contractors"}, Time: tuesday(12)}, "Block social for contractors", "BLOCK"},
+		{"group by ID", urlfilteringpolicies.SimulationRequest{URL: "www.facebook.com", Groups: []string{"10"}, Time: tuesday(12)}, "Block social for contractors", "BLOCK"},
+		{"other group", urlfilteringpolicies.SimulationRequest{URL: "https://www.facebook.com/", Groups: []string{"Employees"}, Time: tuesday(12)}, "", "ALLOW"},
+		{"in time window", urlfilteringpolicies.SimulationRequest{URL: "https://video.example/watch", Time: tuesday(23)}, "Caution streaming after hours", "CAUTION"},
+		{"window past midnight", urlfilteringpolicies.SimulationRequest{URL: "https://video.example/watch", Time: tuesday(26)}, "Caution streaming after hours", "CAUTION"},
+		{"outside time window", urlfilteringpolicies.SimulationRequest{URL: "https://video.example/watch", Time: tuesday(12)}, "", "ALLOW"},
+		{"weekend", urlfilteringpolicies.SimulationRequest{URL: "https://video.example/watch", Time: tuesday(4*24 + 23)}, "", "ALLOW"},
+		{"custom category subdomain", urlfilteringpolicies.SimulationRequest{URL: "https://app.partner.example/login", Location: "HQ", Time: tuesday(12)}, "Isolate partners", "ISOLATE"},
+		{"protocol", urlfilteringpolicies.SimulationRequest{URL: "http://app.partner.example/login", Location: "HQ", Time: tuesday(12)}, "", "ALLOW"},
+		{"other location", urlfilteringpolicies.SimulationRequest{URL: "https://partner.example", Location: "Branch", Time: tuesday(12)}, "", "ALLOW"},
+		{"request method", urlfilteringpolicies.SimulationRequest{URL: "https://news.example/partners/2024", RequestMethod: "POST", Time: tuesday(12)}, "Block uploads", "BLOCK"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := sim.Evaluate(tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.action, result.Action)
+			if tt.rule == "" {
+				assert.Nil(t, result.Rule)
+				assert.Len(t, result.Trace, 5, "every rule is evaluated")
+				return
+			}
+			require.NotNil(t, result.Rule)
+			assert.Equal(t, tt.rule, result.Rule.Name)
+			last := result.Trace[len(result.Trace)-1]
+			assert.True(t, last.Matched)
+			assert.Equal(t, tt.rule, last.Name)
+		})
+	}
+
+	result, err := sim.Evaluate(urlfilteringpolicies.SimulationRequest{URL: "https://www.facebook.com/", Time: tuesday(12)})
+	require.NoError(t, err)
+	assert.Equal(t, "Disabled", result.Trace[0].Name)
+	assert.Equal(t, "rule is disabled", result.Trace[0].Reason)
+	assert.Equal(t, "user, groups and department not in the rule", result.Trace[1].Reason)
+
+	result, err = sim.Evaluate(urlfilteringpolicies.SimulationRequest{URL: "https://news.example/partners/2024", RequestMethod: "PUT", Time: tuesday(12)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"NEWS_AND_MEDIA", "CUSTOM_02"}, result.Categories, "dbCategorizedUrls keep the predefined category")
+	assert.Equal(t, []string{"devices"}, result.Trace[len(result.Trace)-1].Unevaluated)
+
+	result, err = sim.Evaluate(urlfilteringpolicies.SimulationRequest{URL: "https://app.partner.example", Categories: []string{"BUSINESS"}, Time: tuesday(12)})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"CUSTOM_01"}, result.Categories, "urls replace the predefined category")
+
+	_, err = sim.Evaluate(urlfilteringpolicies.SimulationRequest{URL: "https://"})
+	assert.Error(t, err)
+}
+
+func TestURLFilteringPolicies_GetSnapshot_SDK(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+
+	server.On("GET", "/zia/api/v1/urlFilteringRules", common.SuccessResponse([]urlfilteringpolicies.URLFilteringRule{
+		{ID: 1, Name: "Block social", Order: 1, State: "ENABLED", Action: "BLOCK", URLCategories: []string{"SOCIAL_NETWORKING"}},
+	}))
+	server.On("GET", "/zia/api/v1/urlCategories", common.SuccessResponse([]urlcategories.URLCategory{
+		{ID: "CUSTOM_01", ConfiguredName: "Partners", CustomCategory: true, Urls: []string{".partner.example"}},
+	}))
+	server.On("GET", "/zia/api/v1/timeWindows", common.SuccessResponse([]timewindow.TimeWindow{{ID: 50, Name: "Work hours"}}))
+	server.On("POST", "/zia/api/v1/urlLookup", common.SuccessResponse([]urlcategories.URLClassification{
+		{URL: "www.Facebook.com/", URLClassifications: []string{"SOCIAL_NETWORKING"}},
+	}))
+
+	service, err := common.CreateTestService(context.Background(), server, "123456")
+	require.NoError(t, err)
+
+	snapshot, err := urlfilteringpolicies.GetSnapshot(context.Background(), service, "www.facebook.com")
+	require.NoError(t, err)
+	assert.Len(t, snapshot.Rules, 1)
+	assert.Len(t, snapshot.CustomCategories, 1)
+	assert.Len(t, snapshot.TimeWindows, 1)
+	assert.Equal(t, []string{"SOCIAL_NETWORKING"}, snapshot.Classifications["www.facebook.com"])
+
+	result, err := urlfilteringpolicies.NewSimulator(*snapshot).Evaluate(urlfilteringpolicies.SimulationRequest{URL: "https://www.facebook.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "BLOCK", result.Action)
+}
@@ -0,0 +1,99 @@
+// Package zia provides unit tests for the ZIA v2 client
+package zia_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zscaler/zscaler-sdk-go/v3/logger"
+	"github.com/zscaler/zscaler-sdk-go/v3/tests/unit/common"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// =====================================================
+// Telemetry Tests
+// =====================================================
+
+func TestClient_Telemetry(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+
+	server.On("POST", "/api/v1/authenticatedSession", common.MockResponse{
+		StatusCode: http.StatusOK,
+		Body:       map[string]interface{}{"authType": "ADMIN_LOGIN", "passwordExpiryTime": 0},
+		Headers:    map[string]string{"Set-Cookie": "JSESSIONID=session-id; Path=/; HttpOnly"},
+	})
+	server.OnSequence("GET", "/api/v1/urlCategories/42",
+		common.EditLockResponse(),
+		common.SuccessResponse(map[string]interface{}{"id": "42"}),
+	)
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	baseURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	cfg := &zia.Configuration{
+		Logger:  logger.GetDefaultLogger("zia-logger: "),
+		Context: context.Background(),
+		BaseURL: baseURL,
+	}
+	for _, set := range []zia.ConfigSetter{
+		zia.WithZiaUsername("admin@example.com"),
+		zia.WithZiaPassword("password"),
+		zia.WithZiaAPIKey("0123456789ab"),
+		zia.WithZiaCloud("zscaler"),
+		zia.WithRateLimitMinWait(10 * time.Millisecond),
+		zia.WithRateLimitMaxWait(20 * time.Millisecond),
+		zia.WithTracerProvider(tp),
+		zia.WithMeterProvider(mp),
+	} {
+		set(cfg)
+	}
+
+	client, err := zia.NewClient(cfg)
+	require.NoError(t, err)
+
+	var category map[string]interface{}
+	require.NoError(t, client.Read(context.Background(), "/api/v1/urlCategories/42", &category))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3, "expected two attempt spans and one request span; sign-ins are not traced")
+	first, second, parent := spans[0], spans[1], spans[2]
+	assert.Equal(t, "GET /api/v1/urlCategories/{id}", parent.Name())
+	for _, s := range []sdktrace.ReadOnlySpan{first, second} {
+		assert.Equal(t, parent.SpanContext().SpanID(), s.Parent().SpanID())
+	}
+	assert.Contains(t, first.Attributes(), attribute.Int("http.response.status_code", http.StatusConflict))
+	assert.Contains(t, parent.Attributes(), attribute.String("zscaler.product", "zia"))
+	assert.Contains(t, parent.Attributes(), attribute.Int("zscaler.attempt", 2))
+	assert.Contains(t, parent.Attributes(), attribute.Int("http.response.status_code", http.StatusOK))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	var found bool
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == "zscaler.sdk.request.duration" {
+				hist, ok := m.Data.(metricdata.Histogram[float64])
+				require.True(t, ok)
+				require.Len(t, hist.DataPoints, 1)
+				assert.Equal(t, uint64(1), hist.DataPoints[0].Count)
+				found = true
+			}
+		}
+	}
+	assert.True(t, found, "request duration histogram was not recorded")
+}
@@ -279,6 +279,33 @@ func TestUsers_GetAll_SDK(t *testing.T) {
 	assert.Equal(t, "User One", results[0].DisplayName)
 }
 
+func TestUsers_All_SDK(t *testing.T) {
+	server := testcommon.NewTestServer()
+	defer server.Close()
+
+	path := "/admin/api/v1/users"
+
+	server.On("GET", path, testcommon.SuccessResponse(common.PaginationResponse[users.Users]{
+		ResultsTotal: 2,
+		PageOffset:   0,
+		PageSize:     100,
+		Records: []users.Users{
+			{ID: "user-1", DisplayName: "User One", Status: true},
+			{ID: "user-2", DisplayName: "User Two", Status: true},
+		},
+	}))
+
+	service, err := testcommon.CreateTestService(context.Background(), server, "123456")
+	require.NoError(t, err)
+
+	var names []string
+	for user, err := range users.All(context.Background(), service, nil) {
+		require.NoError(t, err)
+		names = append(names, user.DisplayName)
+	}
+	assert.Equal(t, []string{"User One", "User Two"}, names)
+}
+
 func TestUsers_Create_SDK(t *testing.T) {
 	server := testcommon.NewTestServer()
 	defer server.Close()
@@ -70,6 +70,38 @@ func TestApplicationSegmentBrowserAccess_GetAll_SDK(t *testing.T) {
 	assert.Len(t, result, 2)
 }
 
+func TestApplicationSegmentBrowserAccess_All_SDK(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+
+	path := "/zpa/mgmtconfig/v1/admin/customers/" + testCustomerID + "/application"
+
+	// Like GetAll, All skips application segments without clientless apps
+	server.On("GET", path, common.SuccessResponse(map[string]interface{}{
+		"list": []applicationsegmentbrowseraccess.BrowserAccess{
+			{
+				ID:   "ba-001",
+				Name: "Browser Access 1",
+				ClientlessApps: []applicationsegmentbrowseraccess.ClientlessApps{
+					{ID: "clientless-1", Name: "Clientless App 1"},
+				},
+			},
+			{ID: "app-002", Name: "Regular Application"},
+		},
+		"totalPages": 1,
+	}))
+
+	service, err := common.CreateTestService(context.Background(), server, testCustomerID)
+	require.NoError(t, err)
+
+	var ids []string
+	for app, err := range applicationsegmentbrowseraccess.All(context.Background(), service) {
+		require.NoError(t, err)
+		ids = append(ids, app.ID)
+	}
+	assert.Equal(t, []string{"ba-001"}, ids)
+}
+
 func TestApplicationSegmentBrowserAccess_GetByName_SDK(t *testing.T) {
 	server := common.NewTestServer()
 	defer server.Close()
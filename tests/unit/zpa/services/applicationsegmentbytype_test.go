@@ -38,6 +38,38 @@ func TestApplicationSegmentByType_GetByApplicationType_SDK(t *testing.T) {
 	// GetByApplicationType filters results
 }
 
+func TestApplicationSegmentByType_AllByApplicationType_SDK(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+
+	path := "/zpa/mgmtconfig/v1/admin/customers/" + testCustomerID + "/application/getAppsByType"
+	server.On("GET", path, common.SuccessResponse(map[string]interface{}{
+		"list": []applicationsegmentbytype.AppSegmentBaseAppDto{
+			{ID: "app-001", Name: "TestApp"},
+			{ID: "app-002", Name: "OtherApp"},
+		},
+		"totalPages": 1,
+	}))
+
+	service, err := common.CreateTestService(context.Background(), server, testCustomerID)
+	require.NoError(t, err)
+
+	var ids []string
+	for app, err := range applicationsegmentbytype.AllByApplicationType(context.Background(), service, "", "INSPECT", true) {
+		require.NoError(t, err)
+		ids = append(ids, app.ID)
+	}
+	assert.Equal(t, []string{"app-001", "app-002"}, ids)
+
+	req := server.LastRequest()
+	require.NotNil(t, req)
+	assert.Contains(t, req.Query, "applicationType=INSPECT")
+
+	for _, err := range applicationsegmentbytype.AllByApplicationType(context.Background(), service, "", "UNKNOWN", false) {
+		assert.ErrorContains(t, err, "invalid applicationType")
+	}
+}
+
 func TestApplicationSegmentByType_DeleteByApplicationType_SDK(t *testing.T) {
 	server := common.NewTestServer()
 	defer server.Close()
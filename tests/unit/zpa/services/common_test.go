@@ -306,6 +306,120 @@ func TestZPACommon_GetAllPagesGeneric(t *testing.T) {
 	})
 }
 
+func TestZPACommon_GetAllPagesGeneric_Parallel(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fans out remaining pages in order", func(t *testing.T) {
+		api := common.NewZPATest(t)
+		path := common.ZPAPath(api.CustomerID, "application")
+
+		api.OnFunc("GET", path, func(r *http.Request, _ []byte) common.MockResponse {
+			page := r.URL.Query().Get("page")
+			return common.SuccessResponse(common.ZPAListPaged([]zpaListItem{{ID: "app-" + page}}, 5))
+		})
+
+		ctx := zscaler.ContextWithPageConcurrency(context.Background(), 3)
+		got, _, err := zpacommon.GetAllPagesGeneric[zpaListItem](ctx, api.Service.Client, path, "")
+		require.NoError(t, err)
+		var ids []string
+		for _, item := range got {
+			ids = append(ids, item.ID)
+		}
+		assert.Equal(t, []string{"app-1", "app-2", "app-3", "app-4", "app-5"}, ids)
+		assert.Equal(t, 5, api.Server.GetCallCount("GET", path))
+	})
+
+	t.Run("error on a later page fails the call", func(t *testing.T) {
+		api := common.NewZPATest(t)
+		path := common.ZPAPath(api.CustomerID, "application")
+
+		api.OnFunc("GET", path, func(r *http.Request, _ []byte) common.MockResponse {
+			page := r.URL.Query().Get("page")
+			if page == "3" {
+				return common.NotFoundResponse()
+			}
+			return common.SuccessResponse(common.ZPAListPaged([]zpaListItem{{ID: "app-" + page}}, 4))
+		})
+
+		ctx := zscaler.ContextWithPageConcurrency(context.Background(), 2)
+		got, _, err := zpacommon.GetAllPagesGenericWithCustomFilters[zpaListItem](ctx, api.Service.Client, path, zpacommon.Filter{})
+		require.Error(t, err)
+		assert.Nil(t, got)
+	})
+}
+
+func TestZPACommon_AllPages(t *testing.T) {
+	t.Parallel()
+
+	t.Run("streams pages until totalPages", func(t *testing.T) {
+		api := common.NewZPATest(t)
+		path := common.ZPAPath(api.CustomerID, "application")
+
+		api.OnFunc("GET", path, func(r *http.Request, _ []byte) common.MockResponse {
+			assert.Equal(t, "500", r.URL.Query().Get("pagesize"))
+			switch r.URL.Query().Get("page") {
+			case "1":
+				return common.SuccessResponse(common.ZPAListPaged([]zpaListItem{{ID: "app-1", Name: "One"}}, 2))
+			case "2":
+				return common.SuccessResponse(common.ZPAListPaged([]zpaListItem{{ID: "app-2", Name: "Two"}}, 2))
+			default:
+				t.Fatalf("unexpected page %q", r.URL.Query().Get("page"))
+				return common.NotFoundResponse()
+			}
+		})
+
+		var ids []string
+		var tokens []zscaler.PageToken
+		for item, err := range zpacommon.AllPages[zpaListItem](context.Background(), api.Service.Client, path, zpacommon.Filter{},
+			zscaler.WithPageCallback(func(info zscaler.PageInfo) error {
+				tokens = append(tokens, info.Next)
+				return nil
+			}),
+		) {
+			require.NoError(t, err)
+			ids = append(ids, item.ID)
+		}
+		assert.Equal(t, []string{"app-1", "app-2"}, ids)
+		assert.Equal(t, []zscaler.PageToken{"2", ""}, tokens)
+	})
+
+	t.Run("converts search to filter", func(t *testing.T) {
+		api := common.NewZPATest(t)
+		path := common.ZPAPath(api.CustomerID, "application")
+
+		api.OnFunc("GET", path, func(r *http.Request, _ []byte) common.MockResponse {
+			assert.Contains(t, r.URL.Query().Get("search"), "EQ")
+			return common.SuccessResponse(common.ZPAListPaged([]zpaListItem{{ID: "app-1", Name: "My App"}}, 1))
+		})
+
+		for _, err := range zpacommon.AllPages[zpaListItem](context.Background(), api.Service.Client, path, zpacommon.Filter{Search: "My App"}) {
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("callback error stops iteration", func(t *testing.T) {
+		api := common.NewZPATest(t)
+		path := common.ZPAPath(api.CustomerID, "application")
+		api.On("GET", path, common.SuccessResponse(common.ZPAListPaged([]zpaListItem{{ID: "app-1"}}, 5)))
+
+		stop := errors.New("stop")
+		var items, errs int
+		for _, err := range zpacommon.AllPages[zpaListItem](context.Background(), api.Service.Client, path, zpacommon.Filter{},
+			zscaler.WithPageCallback(func(zscaler.PageInfo) error { return stop }),
+		) {
+			if err != nil {
+				assert.ErrorIs(t, err, stop)
+				errs++
+				continue
+			}
+			items++
+		}
+		assert.Equal(t, 0, items)
+		assert.Equal(t, 1, errs)
+		assert.Equal(t, 1, api.Server.GetCallCount("GET", path))
+	})
+}
+
 func TestZPACommon_GetAllPagesGenericWithCustomFilters(t *testing.T) {
 	t.Parallel()
 
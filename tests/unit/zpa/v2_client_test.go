@@ -3,18 +3,26 @@ package unit
 
 import (
 	"bytes"
+	"context"
 	"io"
 	"math"
 	"net/http"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/zscaler/zscaler-sdk-go/v3/logger"
 	"github.com/zscaler/zscaler-sdk-go/v3/tests/unit/common"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 // =============================================================================
@@ -480,3 +488,78 @@ func TestZPAClient_RateLimiter(t *testing.T) {
 	})
 }
 
+
+// =============================================================================
+// Telemetry Tests
+// =============================================================================
+
+func TestZPAClient_Telemetry(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+
+	const path = "/mgmtconfig/v1/admin/customers/123456/segmentGroup/7"
+	server.On("POST", "/signin", common.MockZPAOAuthResponse())
+	server.OnSequence("GET", path,
+		common.ConflictResponse(`{"id": "api.concurrent.access.error"}`),
+		common.SuccessResponse(common.MockSegmentGroupResponse("7", "group")),
+	)
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	baseURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	cfg := &zpa.Configuration{
+		Logger:  logger.GetDefaultLogger("zpa-logger: "),
+		Context: context.Background(),
+		BaseURL: baseURL,
+	}
+	for _, set := range []zpa.ConfigSetter{
+		zpa.WithZPAClientID("client-id"),
+		zpa.WithZPAClientSecret("client-secret"),
+		zpa.WithZPACustomerID("123456"),
+		zpa.WithRateLimitMinWait(10 * time.Millisecond),
+		zpa.WithRateLimitMaxWait(20 * time.Millisecond),
+		zpa.WithTracerProvider(tp),
+		zpa.WithMeterProvider(mp),
+	} {
+		set(cfg)
+	}
+
+	client, err := zpa.NewClient(cfg)
+	require.NoError(t, err)
+
+	var group map[string]interface{}
+	_, err = client.NewRequestDoWithContext(context.Background(), "GET", path, nil, nil, &group)
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3, "expected two attempt spans and one request span; sign-ins are not traced")
+	first, second, parent := spans[0], spans[1], spans[2]
+	assert.Equal(t, "GET /mgmtconfig/v1/admin/customers/{id}/segmentGroup/{id}", parent.Name())
+	for _, s := range []sdktrace.ReadOnlySpan{first, second} {
+		assert.Equal(t, parent.SpanContext().SpanID(), s.Parent().SpanID())
+	}
+	assert.Contains(t, first.Attributes(), attribute.Int("http.response.status_code", http.StatusConflict))
+	assert.Contains(t, parent.Attributes(), attribute.String("zscaler.product", "zpa"))
+	assert.Contains(t, parent.Attributes(), attribute.Int("zscaler.attempt", 2))
+	assert.Contains(t, parent.Attributes(), attribute.Int("http.response.status_code", http.StatusOK))
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	var found bool
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == "zscaler.sdk.request.duration" {
+				hist, ok := m.Data.(metricdata.Histogram[float64])
+				require.True(t, ok)
+				require.Len(t, hist.DataPoints, 1)
+				assert.Equal(t, uint64(1), hist.DataPoints[0].Count)
+				found = true
+			}
+		}
+	}
+	assert.True(t, found, "request duration histogram was not recorded")
+}
@@ -0,0 +1,64 @@
+// Package zscaler provides unit tests for core zscaler SDK request functions
+package zscaler
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zscaler/zscaler-sdk-go/v3/cassette"
+	"github.com/zscaler/zscaler-sdk-go/v3/tests/unit/common"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/usermanagement/users"
+)
+
+// =====================================================
+// Cassette Record/Replay Tests
+// =====================================================
+
+func TestCassette_RecordThenReplay(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+
+	server.On("GET", "/zia/api/v1/users/42", common.SuccessResponse(users.Users{ID: 42, Name: "alice", Email: "alice@example.com"}))
+	path := filepath.Join(t.TempDir(), "users.yaml")
+
+	service, err := common.CreateTestService(context.Background(), server, "123456", zscaler.WithRecordMode(path))
+	require.NoError(t, err)
+	recorded, err := users.Get(context.Background(), service, 42)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", recorded.Name)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "/zia/api/v1/users/42")
+	assert.NotContains(t, string(data), "mock-test-token-12345", "bearer token must be scrubbed")
+
+	// Replay against a server with no routes: every answer comes from the cassette.
+	empty := common.NewTestServer()
+	defer empty.Close()
+	rec, err := cassette.New(path, cassette.ModeReplay)
+	require.NoError(t, err)
+	replayService, err := common.CreateTestService(context.Background(), empty, "123456", zscaler.WithCassette(rec))
+	require.NoError(t, err)
+
+	replayed, err := users.Get(context.Background(), replayService, 42)
+	require.NoError(t, err)
+	assert.Equal(t, recorded, replayed)
+	assert.Equal(t, 0, empty.GetCallCount("GET", "/zia/api/v1/users/42"))
+	assert.Empty(t, rec.Unused())
+
+	_, err = users.Get(context.Background(), replayService, 43)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, cassette.ErrUnmatchedRequest))
+}
+
+func TestCassette_ReplayMissingCassette(t *testing.T) {
+	_, err := zscaler.NewConfiguration(zscaler.WithReplayMode(filepath.Join(t.TempDir(), "missing.yaml")))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, cassette.ErrCassetteNotFound)
+}
@@ -0,0 +1,69 @@
+// Package zscaler provides unit tests for core zscaler SDK request functions
+package zscaler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zscaler/zscaler-sdk-go/v3/tests/unit/common"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
+)
+
+// =====================================================
+// Typed Error Tests
+// =====================================================
+
+func TestExecuteRequest_TypedErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		response common.MockResponse
+		kind     error
+	}{
+		{"not found", common.NotFoundResponse(), errorx.ErrNotFound},
+		{"validation", common.SuccessResponseWithStatus(http.StatusBadRequest, map[string]interface{}{"id": "invalid.argument", "params": []string{"name"}}), errorx.ErrValidation},
+		{"permission denied", common.SuccessResponseWithStatus(http.StatusForbidden, map[string]interface{}{"code": "ACCESS_DENIED"}), errorx.ErrPermissionDenied},
+		{"limit exceeded", common.SuccessResponseWithStatus(http.StatusForbidden, map[string]interface{}{"code": "LIMIT_EXCEEDED", "message": "Limit has exceeded."}), errorx.ErrLimitExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := common.NewTestServer()
+			defer server.Close()
+
+			server.On("GET", "/zpa/mgmtconfig/v1/admin/customers/123456/segmentGroup/1", tt.response)
+
+			service, err := common.CreateTestService(context.Background(), server, "123456")
+			require.NoError(t, err)
+
+			_, _, _, err = service.Client.ExecuteRequest(context.Background(), http.MethodGet, "/zpa/mgmtconfig/v1/admin/customers/123456/segmentGroup/1", nil, nil, "")
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tt.kind)
+
+			var errResp *errorx.ErrorResponse
+			assert.True(t, errors.As(err, &errResp))
+		})
+	}
+}
+
+func TestExecuteRequest_ValidationFields(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+
+	server.On("POST", "/zia/api/v1/urlCategories", common.SuccessResponseWithStatus(http.StatusBadRequest, map[string]interface{}{
+		"code":   "INVALID_INPUT_ARGUMENT",
+		"errors": []map[string]string{{"field": "urls", "message": "invalid URL"}},
+	}))
+
+	service, err := common.CreateTestService(context.Background(), server, "123456")
+	require.NoError(t, err)
+
+	_, _, _, err = service.Client.ExecuteRequest(context.Background(), http.MethodPost, "/zia/api/v1/urlCategories", nil, nil, "")
+
+	var validation *errorx.ValidationError
+	require.True(t, errors.As(err, &validation))
+	assert.Equal(t, []errorx.FieldError{{Field: "urls", Message: "invalid URL"}}, validation.Fields)
+}
@@ -0,0 +1,148 @@
+// Package zscaler provides unit tests for core zscaler SDK request functions
+package zscaler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zscaler/zscaler-sdk-go/v3/tests/unit/common"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+)
+
+// =====================================================
+// Interceptor Tests
+// =====================================================
+
+// interceptorRecorder captures every hook invocation so tests can assert on
+// the order and payload of the calls made by ExecuteRequest.
+type interceptorRecorder struct {
+	mu       sync.Mutex
+	events   []string
+	attempts []int
+	services []string
+	reasons  []string
+	errs     []error
+}
+
+func (r *interceptorRecorder) record(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *interceptorRecorder) interceptor() zscaler.Interceptor {
+	return zscaler.Interceptor{
+		BeforeRequest: func(ctx context.Context, info *zscaler.RequestInfo) error {
+			r.record("before")
+			r.mu.Lock()
+			r.attempts = append(r.attempts, info.Attempt)
+			r.services = append(r.services, info.ServiceType)
+			r.mu.Unlock()
+			return nil
+		},
+		AfterResponse: func(ctx context.Context, info *zscaler.RequestInfo, resp *http.Response) {
+			r.record("after")
+		},
+		OnRetry: func(ctx context.Context, info *zscaler.RequestInfo, reason string, wait time.Duration) {
+			r.record("retry")
+			r.mu.Lock()
+			r.reasons = append(r.reasons, reason)
+			r.mu.Unlock()
+		},
+		OnError: func(ctx context.Context, info *zscaler.RequestInfo, err error) {
+			r.record("error")
+			r.mu.Lock()
+			r.errs = append(r.errs, err)
+			r.mu.Unlock()
+		},
+	}
+}
+
+func TestInterceptor_BeforeAndAfterHooks(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+
+	server.On("GET", "/zia/api/v1/urlCategories", common.SuccessResponse([]map[string]interface{}{}))
+
+	rec := &interceptorRecorder{}
+	service, err := common.CreateTestService(context.Background(), server, "123456",
+		zscaler.WithInterceptor(rec.interceptor()),
+		zscaler.WithInterceptor(zscaler.Interceptor{
+			BeforeRequest: func(ctx context.Context, info *zscaler.RequestInfo) error {
+				info.Request.Header.Set("X-Audit-Id", "audit-123")
+				return nil
+			},
+		}),
+	)
+	require.NoError(t, err)
+
+	_, _, _, err = service.Client.ExecuteRequest(context.Background(), http.MethodGet, "/zia/api/v1/urlCategories", nil, nil, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"before", "after"}, rec.events)
+	assert.Equal(t, []int{1}, rec.attempts)
+	assert.Equal(t, []string{"zia"}, rec.services)
+	require.NotNil(t, server.LastRequest())
+	assert.Equal(t, "audit-123", server.LastRequest().Headers.Get("X-Audit-Id"))
+}
+
+func TestInterceptor_OnRetryReportsReasonAndAttempt(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+
+	server.OnSequence("PUT", "/zia/api/v1/urlCategories/1",
+		common.EditLockResponse(),
+		common.SuccessResponse(map[string]interface{}{"id": "1"}),
+	)
+
+	rec := &interceptorRecorder{}
+	service, err := common.CreateTestService(context.Background(), server, "123456",
+		zscaler.WithRateLimitMinWait(10*time.Millisecond),
+		zscaler.WithRateLimitMaxWait(20*time.Millisecond),
+		zscaler.WithInterceptor(rec.interceptor()),
+	)
+	require.NoError(t, err)
+
+	_, _, _, err = service.Client.ExecuteRequest(context.Background(), http.MethodPut, "/zia/api/v1/urlCategories/1", nil, nil, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"before", "after", "retry", "before", "after"}, rec.events)
+	assert.Equal(t, []int{1, 2}, rec.attempts)
+	assert.Equal(t, []string{zscaler.RetryReasonEditLock}, rec.reasons)
+}
+
+func TestInterceptor_BeforeRequestErrorAbortsCall(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+
+	server.On("DELETE", "/zpa/mgmtconfig/v1/admin/customers/123456/segmentGroup/1", common.NoContentResponse())
+
+	denied := errors.New("deletes are not allowed by policy")
+	rec := &interceptorRecorder{}
+	service, err := common.CreateTestService(context.Background(), server, "123456",
+		zscaler.WithInterceptor(zscaler.Interceptor{
+			BeforeRequest: func(ctx context.Context, info *zscaler.RequestInfo) error {
+				if info.Method == http.MethodDelete {
+					return denied
+				}
+				return nil
+			},
+		}),
+		zscaler.WithInterceptor(rec.interceptor()),
+	)
+	require.NoError(t, err)
+
+	_, _, _, err = service.Client.ExecuteRequest(context.Background(), http.MethodDelete, "/zpa/mgmtconfig/v1/admin/customers/123456/segmentGroup/1", nil, nil, "")
+	require.ErrorIs(t, err, denied)
+
+	assert.Equal(t, []string{"error"}, rec.events, "later BeforeRequest hooks must not run once the chain is aborted")
+	require.Len(t, rec.errs, 1)
+	assert.ErrorIs(t, rec.errs[0], denied)
+	assert.Equal(t, 0, server.GetCallCount("DELETE", "/zpa/mgmtconfig/v1/admin/customers/123456/segmentGroup/1"))
+}
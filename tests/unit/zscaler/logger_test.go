@@ -0,0 +1,56 @@
+// Package zscaler provides unit tests for core zscaler SDK request functions
+package zscaler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zscaler/zscaler-sdk-go/v3/logger"
+	"github.com/zscaler/zscaler-sdk-go/v3/tests/unit/common"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+)
+
+// =====================================================
+// Structured Logger Tests
+// =====================================================
+
+func TestWithLogger_SlogReceivesRequestFields(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+
+	server.On("GET", "/zia/api/v1/ruleLabels", common.SuccessResponse([]map[string]interface{}{}))
+
+	var buf bytes.Buffer
+	l := logger.NewSlogLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	service, err := common.CreateTestService(context.Background(), server, "123456",
+		zscaler.WithLogger(l),
+	)
+	require.NoError(t, err)
+
+	_, _, _, err = service.Client.ExecuteRequest(context.Background(), http.MethodGet, "/zia/api/v1/ruleLabels", nil, nil, "")
+	require.NoError(t, err)
+
+	var response map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry), "every line must be JSON: %s", line)
+		if entry["msg"] == "zscaler sdk response" {
+			response = entry
+		}
+	}
+	require.NotNil(t, response, "response entry not logged")
+	assert.Equal(t, "DEBUG", response["level"])
+	assert.Equal(t, "zia", response["product"])
+	assert.Equal(t, "/zia/api/v1/ruleLabels", response["endpoint"])
+	assert.Equal(t, float64(http.StatusOK), response["status"])
+	assert.NotEmpty(t, response["request_id"])
+	assert.Contains(t, response, "duration")
+}
@@ -0,0 +1,64 @@
+// Package zscaler provides unit tests for core zscaler SDK request functions
+package zscaler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rl "github.com/zscaler/zscaler-sdk-go/v3/ratelimiter"
+	"github.com/zscaler/zscaler-sdk-go/v3/tests/unit/common"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+)
+
+// =====================================================
+// Context-aware Retry Wait Tests
+// =====================================================
+
+func TestRetryWait_FailsFastAboveCeiling(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+
+	server.On("GET", "/zia/api/v1/urlCategories", common.TooManyRequestsResponse(60))
+
+	service, err := common.CreateTestService(context.Background(), server, "123456",
+		zscaler.WithRateLimitWaitCeiling(time.Second),
+	)
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, _, _, err = service.Client.ExecuteRequest(context.Background(), http.MethodGet, "/zia/api/v1/urlCategories", nil, nil, "")
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	var waitErr *rl.WaitError
+	require.True(t, errors.As(err, &waitErr))
+	assert.ErrorIs(t, err, rl.ErrWaitTooLong)
+	assert.GreaterOrEqual(t, waitErr.Wait, time.Second)
+	assert.Equal(t, 1, server.GetCallCount("GET", "/zia/api/v1/urlCategories"))
+}
+
+func TestRetryWait_HonoursContextDeadline(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+
+	server.On("GET", "/zia/api/v1/urlCategories", common.TooManyRequestsResponse(60))
+
+	service, err := common.CreateTestService(context.Background(), server, "123456")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, _, _, err = service.Client.ExecuteRequest(ctx, http.MethodGet, "/zia/api/v1/urlCategories", nil, nil, "")
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	var waitErr *rl.WaitError
+	assert.True(t, errors.As(err, &waitErr), "want a *ratelimiter.WaitError, got %T", err)
+}
@@ -0,0 +1,124 @@
+// Package zscaler provides unit tests for core zscaler SDK request functions
+package zscaler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zscaler/zscaler-sdk-go/v3/tests/unit/common"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// =====================================================
+// OpenTelemetry Tests
+// =====================================================
+
+func spanAttr(span sdktrace.ReadOnlySpan, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestTelemetry_SpansPerRequestAndAttempt(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+
+	server.OnSequence("PUT", "/zia/api/v1/urlCategories/42",
+		common.EditLockResponse(),
+		common.SuccessResponse(map[string]interface{}{"id": "42"}),
+	)
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	service, err := common.CreateTestService(context.Background(), server, "123456",
+		zscaler.WithRateLimitMinWait(10*time.Millisecond),
+		zscaler.WithRateLimitMaxWait(20*time.Millisecond),
+		zscaler.WithTracerProvider(tp),
+	)
+	require.NoError(t, err)
+
+	_, _, _, err = service.Client.ExecuteRequest(context.Background(), http.MethodPut, "/zia/api/v1/urlCategories/42", nil, nil, "")
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3, "expected two attempt spans and one request span")
+
+	first, second, parent := spans[0], spans[1], spans[2]
+	assert.Equal(t, "PUT /zia/api/v1/urlCategories/{id}", parent.Name())
+	for _, s := range []sdktrace.ReadOnlySpan{first, second} {
+		assert.Equal(t, parent.SpanContext().SpanID(), s.Parent().SpanID())
+	}
+
+	product, ok := spanAttr(parent, zscaler.AttrProduct)
+	require.True(t, ok)
+	assert.Equal(t, "zia", product.AsString())
+	tmpl, ok := spanAttr(parent, zscaler.AttrEndpointTemplate)
+	require.True(t, ok)
+	assert.Equal(t, "/zia/api/v1/urlCategories/{id}", tmpl.AsString())
+	status, ok := spanAttr(parent, zscaler.AttrStatusCode)
+	require.True(t, ok)
+	assert.Equal(t, int64(http.StatusOK), status.AsInt64())
+
+	reason, ok := spanAttr(first, zscaler.AttrRetryReason)
+	require.True(t, ok)
+	assert.Equal(t, zscaler.RetryReasonEditLock, reason.AsString())
+	status, ok = spanAttr(first, zscaler.AttrStatusCode)
+	require.True(t, ok)
+	assert.Equal(t, int64(http.StatusConflict), status.AsInt64())
+	_, ok = spanAttr(second, zscaler.AttrRetryReason)
+	assert.False(t, ok, "final attempt was not retried")
+}
+
+func TestTelemetry_LatencyHistogram(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+
+	server.On("GET", "/zpa/mgmtconfig/v1/admin/customers/123456/segmentGroup/7", common.SuccessResponse(map[string]interface{}{"id": "7"}))
+
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	service, err := common.CreateTestService(context.Background(), server, "123456",
+		zscaler.WithMeterProvider(mp),
+	)
+	require.NoError(t, err)
+
+	_, _, _, err = service.Client.ExecuteRequest(context.Background(), http.MethodGet, "/zpa/mgmtconfig/v1/admin/customers/123456/segmentGroup/7", nil, nil, "")
+	require.NoError(t, err)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	var found bool
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "zscaler.sdk.request.duration" {
+				continue
+			}
+			hist, ok := m.Data.(metricdata.Histogram[float64])
+			require.True(t, ok)
+			require.Len(t, hist.DataPoints, 1)
+			dp := hist.DataPoints[0]
+			assert.Equal(t, uint64(1), dp.Count)
+			product, _ := dp.Attributes.Value(zscaler.AttrProduct)
+			assert.Equal(t, "zpa", product.AsString())
+			tmpl, _ := dp.Attributes.Value(zscaler.AttrEndpointTemplate)
+			assert.Equal(t, "/zpa/mgmtconfig/v1/admin/customers/{id}/segmentGroup/{id}", tmpl.AsString())
+			found = true
+		}
+	}
+	assert.True(t, found, "request duration histogram was not recorded")
+}
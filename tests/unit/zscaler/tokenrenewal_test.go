@@ -0,0 +1,88 @@
+// Package zscaler provides unit tests for core zscaler SDK request functions
+package zscaler
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zscaler/zscaler-sdk-go/v3/tests/unit/common"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+)
+
+// =====================================================
+// On-demand Token Refresh Tests
+// =====================================================
+
+func TestUnauthorized_RefreshesTokenOnDemand(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+
+	server.On("POST", "/oauth2/v1/token", common.MockOAuthResponse())
+	server.OnSequence("GET", "/zia/api/v1/urlCategories",
+		common.UnauthorizedResponse(`{"code": "UNAUTHORIZED", "message": "Token expired"}`),
+		common.SuccessResponse([]map[string]interface{}{}),
+	)
+
+	var reasons []string
+	service, err := common.CreateTestService(context.Background(), server, "123456",
+		zscaler.WithClientID("client-id"),
+		zscaler.WithClientSecret("client-secret"),
+		zscaler.WithInterceptor(zscaler.Interceptor{
+			OnRetry: func(ctx context.Context, info *zscaler.RequestInfo, reason string, wait time.Duration) {
+				reasons = append(reasons, reason)
+			},
+		}),
+	)
+	require.NoError(t, err)
+
+	_, _, _, err = service.Client.ExecuteRequest(context.Background(), http.MethodGet, "/zia/api/v1/urlCategories", nil, nil, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, server.GetCallCount("POST", "/oauth2/v1/token"))
+	assert.Equal(t, "Bearer mock-access-token-12345", server.LastRequest().Headers.Get("Authorization"))
+	assert.Equal(t, []string{zscaler.RetryReasonUnauthorized}, reasons)
+}
+
+func TestUnauthorized_RefreshesOnlyOncePerCall(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+
+	server.On("POST", "/oauth2/v1/token", common.MockOAuthResponse())
+	server.On("GET", "/zia/api/v1/urlCategories", common.UnauthorizedResponse(`{"code": "UNAUTHORIZED", "message": "Forbidden"}`))
+
+	service, err := common.CreateTestService(context.Background(), server, "123456",
+		zscaler.WithClientID("client-id"),
+		zscaler.WithClientSecret("client-secret"),
+	)
+	require.NoError(t, err)
+
+	_, _, _, err = service.Client.ExecuteRequest(context.Background(), http.MethodGet, "/zia/api/v1/urlCategories", nil, nil, "")
+	require.Error(t, err)
+
+	assert.Equal(t, 1, server.GetCallCount("POST", "/oauth2/v1/token"))
+	assert.Equal(t, 2, server.GetCallCount("GET", "/zia/api/v1/urlCategories"))
+}
+
+func TestUnauthorized_ContextAccessTokenIsNotReplaced(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+
+	server.On("POST", "/oauth2/v1/token", common.MockOAuthResponse())
+	server.On("GET", "/zia/api/v1/urlCategories", common.UnauthorizedResponse(`{"code": "UNAUTHORIZED", "message": "Token expired"}`))
+
+	service, err := common.CreateTestService(context.Background(), server, "123456",
+		zscaler.WithClientID("client-id"),
+		zscaler.WithClientSecret("client-secret"),
+	)
+	require.NoError(t, err)
+
+	ctx := context.WithValue(context.Background(), zscaler.ContextAccessToken, "caller-token")
+	_, _, _, err = service.Client.ExecuteRequest(ctx, http.MethodGet, "/zia/api/v1/urlCategories", nil, nil, "")
+	require.Error(t, err)
+
+	assert.Equal(t, 0, server.GetCallCount("POST", "/oauth2/v1/token"))
+}
@@ -0,0 +1,110 @@
+// Package zscaler provides unit tests for core zscaler SDK request functions
+package zscaler
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zscaler/zscaler-sdk-go/v3/tests/unit/common"
+	"github.com/zscaler/zscaler-sdk-go/v3/tokenstore"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+)
+
+// =====================================================
+// Token Store Tests
+// =====================================================
+
+func TestTokenStore_SharedAcrossClients(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+
+	server.On("POST", "/oauth2/v1/token", common.MockOAuthResponse())
+	server.OnSequence("GET", "/zia/api/v1/urlCategories",
+		common.SessionInvalidResponse(),
+		common.SuccessResponse([]map[string]interface{}{}),
+		common.SessionInvalidResponse(),
+		common.SuccessResponse([]map[string]interface{}{}),
+	)
+
+	store := tokenstore.NewMemoryStore()
+	var mu sync.Mutex
+	var events []zscaler.TokenRefreshEvent
+	hook := zscaler.WithTokenRefreshHook(func(e zscaler.TokenRefreshEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, e)
+	})
+	newService := func() *zscaler.Service {
+		service, err := common.CreateTestService(context.Background(), server, "123456",
+			zscaler.WithClientID("client-id"),
+			zscaler.WithClientSecret("client-secret"),
+			zscaler.WithTokenStore(store),
+			hook,
+		)
+		require.NoError(t, err)
+		return service
+	}
+
+	// The first client has its token rejected, authenticates and saves the new token.
+	first := newService()
+	_, _, _, err := first.Client.ExecuteRequest(context.Background(), http.MethodGet, "/zia/api/v1/urlCategories", nil, nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer mock-access-token-12345", server.LastRequest().Headers.Get("Authorization"))
+
+	// The second client reuses the stored token instead of authenticating again.
+	second := newService()
+	_, _, _, err = second.Client.ExecuteRequest(context.Background(), http.MethodGet, "/zia/api/v1/urlCategories", nil, nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer mock-access-token-12345", server.LastRequest().Headers.Get("Authorization"))
+
+	assert.Equal(t, 1, server.GetCallCount("POST", "/oauth2/v1/token"))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, events, 2)
+	assert.Equal(t, zscaler.TokenSourceAuthenticate, events[0].Source)
+	assert.Equal(t, zscaler.TokenSourceStore, events[1].Source)
+	assert.NoError(t, events[0].Err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), events[1].Expiry, time.Minute)
+}
+
+func TestTokenStore_RejectedTokenIsNotReused(t *testing.T) {
+	server := common.NewTestServer()
+	defer server.Close()
+
+	server.On("POST", "/oauth2/v1/token", common.MockOAuthResponse())
+	server.OnSequence("GET", "/zia/api/v1/urlCategories",
+		common.SessionInvalidResponse(),
+		common.SuccessResponse([]map[string]interface{}{}),
+	)
+
+	// Seed the store with the token the API is about to reject.
+	store := tokenstore.NewMemoryStore()
+	key := tokenstore.Key("https://test.zslogin.net/oauth2/v1/token", "client-id")
+	require.NoError(t, store.Save(context.Background(), key, &tokenstore.Token{
+		TokenType:   "Bearer",
+		AccessToken: "mock-test-token-12345",
+		Expiry:      time.Now().Add(time.Hour),
+	}))
+
+	service, err := common.CreateTestService(context.Background(), server, "123456",
+		zscaler.WithClientID("client-id"),
+		zscaler.WithClientSecret("client-secret"),
+		zscaler.WithTokenStore(store),
+	)
+	require.NoError(t, err)
+
+	_, _, _, err = service.Client.ExecuteRequest(context.Background(), http.MethodGet, "/zia/api/v1/urlCategories", nil, nil, "")
+	require.NoError(t, err)
+	assert.Equal(t, 1, server.GetCallCount("POST", "/oauth2/v1/token"))
+
+	stored, err := store.Load(context.Background(), key)
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, "mock-access-token-12345", stored.AccessToken)
+}
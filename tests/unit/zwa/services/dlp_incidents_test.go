@@ -415,6 +415,49 @@ func TestDLPIncidents_UpdateIncidentStatus_EmptyID(t *testing.T) {
 	assert.Contains(t, err.Error(), "DLP incident ID is required")
 }
 
+func TestDLPIncidents_AllDLPIncidentTickets_SDK(t *testing.T) {
+	server := testcommon.NewTestServer()
+	defer server.Close()
+
+	mockZWAAuth(server)
+
+	incidentID := "inc-12345"
+	path := "/dlp/v1/incidents/tickets/" + incidentID
+
+	server.On("GET", path, testcommon.SuccessResponse(map[string]interface{}{
+		"logs": []dlp_incidents.Ticket{
+			{TicketType: "JIRA", ProjectID: "proj-1"},
+			{TicketType: "SERVICENOW", ProjectID: "proj-2"},
+		},
+		"cursor": common.Cursor{TotalPages: 1, CurrentPageNumber: 1, CurrentPageSize: 2, TotalElements: 2},
+	}))
+
+	service, err := testcommon.CreateZWATestService(context.Background(), server)
+	require.NoError(t, err)
+
+	var projects []string
+	for ticket, err := range dlp_incidents.AllDLPIncidentTickets(context.Background(), service, incidentID, nil) {
+		require.NoError(t, err)
+		projects = append(projects, ticket.ProjectID)
+	}
+	assert.Equal(t, []string{"proj-1", "proj-2"}, projects)
+}
+
+func TestDLPIncidents_AllDLPIncidentTickets_EmptyID(t *testing.T) {
+	server := testcommon.NewTestServer()
+	defer server.Close()
+
+	service, err := testcommon.CreateZWATestService(context.Background(), server)
+	require.NoError(t, err)
+
+	var errs []error
+	for _, err := range dlp_incidents.AllDLPIncidentTickets(context.Background(), service, "", nil) {
+		errs = append(errs, err)
+	}
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "DLP incident ID is required")
+}
+
 func TestDLPIncidents_AssignLabels_SDK(t *testing.T) {
 	server := testcommon.NewTestServer()
 	defer server.Close()
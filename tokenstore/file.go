@@ -0,0 +1,82 @@
+package tokenstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+type encryptedFileStore struct {
+	dir string
+	key []byte
+}
+
+// NewEncryptedFileStore returns a TokenStore that keeps each token in its own
+// AES-GCM encrypted file under dir. key must be 16, 24 or 32 bytes long
+// (AES-128/192/256). Processes sharing dir and key share tokens; refreshes are
+// serialised with lock files so only one process authenticates at a time.
+func NewEncryptedFileStore(dir string, key []byte) (TokenStore, error) {
+	switch len(key) {
+	case 16, 24, 32:
+	default:
+		return nil, ErrInvalidKey
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("tokenstore: creating %s: %w", dir, err)
+	}
+	return &encryptedFileStore{dir: dir, key: append([]byte(nil), key...)}, nil
+}
+
+func (s *encryptedFileStore) path(key string) string {
+	return filepath.Join(s.dir, key+".token")
+}
+
+func (s *encryptedFileStore) Load(ctx context.Context, key string) (*Token, error) {
+	data, err := os.ReadFile(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := open(s.key, data)
+	if err != nil {
+		return nil, fmt.Errorf("tokenstore: decrypting %s: %w", s.path(key), err)
+	}
+	var tok Token
+	if err := json.Unmarshal(plaintext, &tok); err != nil {
+		return nil, err
+	}
+	return &tok, nil
+}
+
+func (s *encryptedFileStore) Save(ctx context.Context, key string, tok *Token) error {
+	if tok == nil {
+		return s.Delete(ctx, key)
+	}
+	plaintext, err := json.Marshal(tok)
+	if err != nil {
+		return err
+	}
+	data, err := seal(s.key, plaintext)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path(key), data)
+}
+
+func (s *encryptedFileStore) Delete(ctx context.Context, key string) error {
+	err := os.Remove(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (s *encryptedFileStore) Lock(ctx context.Context, key string) (func(), error) {
+	return acquireFileLock(ctx, filepath.Join(s.dir, key+".lock"))
+}
@@ -0,0 +1,174 @@
+package tokenstore
+
+import (
+	"context"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	keyringVersion    = 1
+	keyringIterations = 600000
+	keyringSaltSize   = 16
+)
+
+// keyringFile is the on-disk format of a keyring store. Only the salt is
+// stored in clear; all entries are encrypted together.
+type keyringFile struct {
+	Version int    `json:"version"`
+	Salt    []byte `json:"salt"`
+	Data    []byte `json:"data"`
+}
+
+type keyringStore struct {
+	path       string
+	passphrase string
+
+	mu       sync.Mutex
+	derived  []byte
+	saltUsed []byte
+}
+
+// NewKeyringFileStore returns a TokenStore that keeps all tokens in a single
+// file at path, encrypted with a key derived from passphrase (PBKDF2-SHA256).
+// It works the same on every OS and is intended for hosts without a system
+// keyring, such as CI runners and containers.
+func NewKeyringFileStore(path, passphrase string) (TokenStore, error) {
+	if passphrase == "" {
+		return nil, ErrInvalidKey
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("tokenstore: creating %s: %w", filepath.Dir(path), err)
+	}
+	return &keyringStore{path: path, passphrase: passphrase}, nil
+}
+
+// deriveKey derives the encryption key for salt, caching it because PBKDF2 is
+// intentionally slow.
+func (s *keyringStore) deriveKey(salt []byte) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.derived != nil && string(s.saltUsed) == string(salt) {
+		return s.derived, nil
+	}
+	key, err := pbkdf2.Key(sha256.New, s.passphrase, salt, keyringIterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	s.derived, s.saltUsed = key, append([]byte(nil), salt...)
+	return key, nil
+}
+
+// read returns all entries and the salt of the keyring file. A missing file
+// yields no entries and a nil salt.
+func (s *keyringStore) read() (map[string]Token, []byte, error) {
+	raw, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]Token{}, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	var file keyringFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return nil, nil, fmt.Errorf("tokenstore: reading keyring %s: %w", s.path, err)
+	}
+	if file.Version != keyringVersion {
+		return nil, nil, fmt.Errorf("tokenstore: unsupported keyring version %d", file.Version)
+	}
+	key, err := s.deriveKey(file.Salt)
+	if err != nil {
+		return nil, nil, err
+	}
+	plaintext, err := open(key, file.Data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("tokenstore: decrypting keyring %s: %w", s.path, err)
+	}
+	entries := map[string]Token{}
+	if err := json.Unmarshal(plaintext, &entries); err != nil {
+		return nil, nil, err
+	}
+	return entries, file.Salt, nil
+}
+
+func (s *keyringStore) write(entries map[string]Token, salt []byte) error {
+	if salt == nil {
+		salt = make([]byte, keyringSaltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return err
+		}
+	}
+	key, err := s.deriveKey(salt)
+	if err != nil {
+		return err
+	}
+	plaintext, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	data, err := seal(key, plaintext)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(keyringFile{Version: keyringVersion, Salt: salt, Data: data})
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path, raw)
+}
+
+// update applies fn to the entries under the file-wide write lock. This lock
+// is separate from the per-key lock returned by Lock, so a caller holding a
+// key lock can still save.
+func (s *keyringStore) update(ctx context.Context, fn func(map[string]Token)) error {
+	unlock, err := acquireFileLock(ctx, s.path+".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	entries, salt, err := s.read()
+	if err != nil {
+		return err
+	}
+	fn(entries)
+	return s.write(entries, salt)
+}
+
+func (s *keyringStore) Load(ctx context.Context, key string) (*Token, error) {
+	entries, _, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+	tok, ok := entries[key]
+	if !ok {
+		return nil, nil
+	}
+	return &tok, nil
+}
+
+func (s *keyringStore) Save(ctx context.Context, key string, tok *Token) error {
+	if tok == nil {
+		return s.Delete(ctx, key)
+	}
+	return s.update(ctx, func(entries map[string]Token) {
+		entries[key] = *tok
+	})
+}
+
+func (s *keyringStore) Delete(ctx context.Context, key string) error {
+	return s.update(ctx, func(entries map[string]Token) {
+		delete(entries, key)
+	})
+}
+
+func (s *keyringStore) Lock(ctx context.Context, key string) (func(), error) {
+	return acquireFileLock(ctx, s.path+"."+key+".lock")
+}
@@ -0,0 +1,21 @@
+package tokenstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/zscaler/zscaler-sdk-go/v3/internal/filelock"
+)
+
+// lockStaleAfter is how old a lock file must be before it is treated as
+// abandoned by a crashed process and removed. It comfortably exceeds the time a
+// token request takes.
+const lockStaleAfter = 2 * time.Minute
+
+func acquireFileLock(ctx context.Context, path string) (func(), error) {
+	return filelock.Acquire(ctx, path, lockStaleAfter)
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	return filelock.WriteAtomic(path, data)
+}
@@ -0,0 +1,66 @@
+package tokenstore
+
+import (
+	"context"
+	"sync"
+)
+
+type memoryStore struct {
+	mu     sync.Mutex
+	tokens map[string]Token
+	locks  map[string]chan struct{}
+}
+
+// NewMemoryStore returns a TokenStore that keeps tokens in memory. It lets
+// several clients in one process share a token; it does not persist anything.
+func NewMemoryStore() TokenStore {
+	return &memoryStore{
+		tokens: make(map[string]Token),
+		locks:  make(map[string]chan struct{}),
+	}
+}
+
+func (m *memoryStore) Load(ctx context.Context, key string) (*Token, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tok, ok := m.tokens[key]
+	if !ok {
+		return nil, nil
+	}
+	return &tok, nil
+}
+
+func (m *memoryStore) Save(ctx context.Context, key string, tok *Token) error {
+	if tok == nil {
+		return m.Delete(ctx, key)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokens[key] = *tok
+	return nil
+}
+
+func (m *memoryStore) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.tokens, key)
+	return nil
+}
+
+func (m *memoryStore) Lock(ctx context.Context, key string) (func(), error) {
+	m.mu.Lock()
+	ch, ok := m.locks[key]
+	if !ok {
+		ch = make(chan struct{}, 1)
+		m.locks[key] = ch
+	}
+	m.mu.Unlock()
+
+	select {
+	case ch <- struct{}{}:
+		var once sync.Once
+		return func() { once.Do(func() { <-ch }) }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
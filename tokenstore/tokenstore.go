@@ -0,0 +1,51 @@
+// Package tokenstore persists OAuth2 access tokens so that several clients,
+// CLI invocations or processes on one host can share a valid token instead of
+// re-authenticating against the identity provider every time.
+package tokenstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Token is the persisted form of an OAuth2 access token.
+type Token struct {
+	TokenType   string    `json:"token_type"`
+	AccessToken string    `json:"access_token"`
+	Expiry      time.Time `json:"expiry"`
+}
+
+// Valid reports whether the token is usable for at least minValidity.
+func (t *Token) Valid(minValidity time.Duration) bool {
+	return t != nil && t.AccessToken != "" && !t.Expiry.IsZero() && time.Until(t.Expiry) > minValidity
+}
+
+// TokenStore loads and saves tokens by key. Implementations must be safe for
+// concurrent use; file-backed implementations also coordinate across processes.
+type TokenStore interface {
+	// Load returns the token stored under key, or nil (and no error) if there is none.
+	Load(ctx context.Context, key string) (*Token, error)
+	// Save stores tok under key, replacing any existing token.
+	Save(ctx context.Context, key string, tok *Token) error
+	// Delete removes the token stored under key. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+	// Lock acquires an exclusive lock for key, blocking until it is available or
+	// ctx is done. Callers hold the lock while refreshing a token so that only
+	// one client authenticates at a time; the returned function releases it.
+	Lock(ctx context.Context, key string) (unlock func(), err error)
+}
+
+// ErrInvalidKey is returned when an encryption key or passphrase is unusable.
+var ErrInvalidKey = errors.New("tokenstore: invalid encryption key")
+
+// Key derives a stable store key from the parts identifying a credential, e.g.
+// cloud, vanity domain and client ID. The parts are hashed so that the key can
+// be used as a file name and does not disclose the identity.
+func Key(parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,159 @@
+package tokenstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testStores(t *testing.T) map[string]TokenStore {
+	dir := t.TempDir()
+	file, err := NewEncryptedFileStore(filepath.Join(dir, "files"), []byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+	keyring, err := NewKeyringFileStore(filepath.Join(dir, "keyring.json"), "correct horse battery staple")
+	require.NoError(t, err)
+	return map[string]TokenStore{
+		"memory":  NewMemoryStore(),
+		"file":    file,
+		"keyring": keyring,
+	}
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			key := Key("zscaler", "acme", "client-id")
+
+			tok, err := store.Load(ctx, key)
+			require.NoError(t, err)
+			assert.Nil(t, tok)
+
+			want := &Token{TokenType: "Bearer", AccessToken: "abc", Expiry: time.Now().Add(time.Hour).Truncate(time.Second)}
+			require.NoError(t, store.Save(ctx, key, want))
+
+			got, err := store.Load(ctx, key)
+			require.NoError(t, err)
+			require.NotNil(t, got)
+			assert.Equal(t, want.AccessToken, got.AccessToken)
+			assert.True(t, want.Expiry.Equal(got.Expiry))
+
+			require.NoError(t, store.Delete(ctx, key))
+			got, err = store.Load(ctx, key)
+			require.NoError(t, err)
+			assert.Nil(t, got)
+			require.NoError(t, store.Delete(ctx, key))
+		})
+	}
+}
+
+func TestStoreLockSerializes(t *testing.T) {
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			var active, maxActive int32
+			var wg sync.WaitGroup
+			for i := 0; i < 5; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					unlock, err := store.Lock(context.Background(), "k")
+					if !assert.NoError(t, err) {
+						return
+					}
+					n := atomic.AddInt32(&active, 1)
+					for {
+						m := atomic.LoadInt32(&maxActive)
+						if n <= m || atomic.CompareAndSwapInt32(&maxActive, m, n) {
+							break
+						}
+					}
+					time.Sleep(10 * time.Millisecond)
+					atomic.AddInt32(&active, -1)
+					unlock()
+				}()
+			}
+			wg.Wait()
+			assert.Equal(t, int32(1), maxActive)
+		})
+	}
+}
+
+func TestStoreLockHonoursContext(t *testing.T) {
+	for name, store := range testStores(t) {
+		t.Run(name, func(t *testing.T) {
+			unlock, err := store.Lock(context.Background(), "k")
+			require.NoError(t, err)
+			defer unlock()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			_, err = store.Lock(ctx, "k")
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+		})
+	}
+}
+
+func TestEncryptedFileStoreDoesNotStorePlaintext(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewEncryptedFileStore(dir, []byte("0123456789abcdef"))
+	require.NoError(t, err)
+	require.NoError(t, store.Save(context.Background(), "k", &Token{AccessToken: "super-secret-token", Expiry: time.Now().Add(time.Hour)}))
+
+	data, err := os.ReadFile(filepath.Join(dir, "k.token"))
+	require.NoError(t, err)
+	assert.False(t, strings.Contains(string(data), "super-secret-token"))
+
+	other, err := NewEncryptedFileStore(dir, []byte("fedcba9876543210"))
+	require.NoError(t, err)
+	_, err = other.Load(context.Background(), "k")
+	assert.Error(t, err)
+}
+
+func TestKeyringFileStoreWrongPassphrase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keyring.json")
+	store, err := NewKeyringFileStore(path, "right")
+	require.NoError(t, err)
+	require.NoError(t, store.Save(context.Background(), "k", &Token{AccessToken: "abc", Expiry: time.Now().Add(time.Hour)}))
+
+	other, err := NewKeyringFileStore(path, "wrong")
+	require.NoError(t, err)
+	_, err = other.Load(context.Background(), "k")
+	assert.Error(t, err)
+}
+
+func TestInvalidKeys(t *testing.T) {
+	_, err := NewEncryptedFileStore(t.TempDir(), []byte("short"))
+	assert.ErrorIs(t, err, ErrInvalidKey)
+	_, err = NewKeyringFileStore(filepath.Join(t.TempDir(), "k"), "")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
+
+func TestStaleLockIsRemoved(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "k.lock")
+	require.NoError(t, os.WriteFile(path, []byte("1\n"), 0o600))
+	old := time.Now().Add(-2 * lockStaleAfter)
+	require.NoError(t, os.Chtimes(path, old, old))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	unlock, err := acquireFileLock(ctx, path)
+	require.NoError(t, err)
+	unlock()
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestTokenValid(t *testing.T) {
+	assert.False(t, (*Token)(nil).Valid(0))
+	assert.False(t, (&Token{AccessToken: "a"}).Valid(0))
+	assert.False(t, (&Token{AccessToken: "a", Expiry: time.Now().Add(time.Minute)}).Valid(2*time.Minute))
+	assert.True(t, (&Token{AccessToken: "a", Expiry: time.Now().Add(time.Hour)}).Valid(2*time.Minute))
+}
@@ -0,0 +1,252 @@
+package zscaler
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zscaler/zscaler-sdk-go/v3/cache"
+	rl "github.com/zscaler/zscaler-sdk-go/v3/ratelimiter"
+)
+
+// CacheRule sets how the responses of matching GET endpoints are cached.
+// The first matching rule added wins.
+type CacheRule struct {
+	// Pattern matches request paths by prefix, segment by segment. Segments
+	// may use path.Match wildcards, e.g. /zia/api/v1/urlCategories or
+	// /zpa/mgmtconfig/v1/admin/customers/*/application.
+	Pattern string `yaml:"pattern"`
+	// TTL of matching responses; zero keeps the default TTL.
+	TTL time.Duration `yaml:"ttl"`
+	// Disabled keeps matching responses out of the cache.
+	Disabled bool `yaml:"disabled"`
+	// Tags are added to matching entries besides their resource tag, so
+	// that WithCacheDependency and PurgeCacheResources can refer to them.
+	Tags []string `yaml:"tags"`
+	// StaleWhileRevalidate overrides the stale-while-revalidate window of
+	// matching responses; see WithCacheStaleWhileRevalidate.
+	StaleWhileRevalidate time.Duration `yaml:"staleWhileRevalidate"`
+}
+
+func (r CacheRule) matches(p string) bool {
+	pattern := strings.Split(strings.Trim(r.Pattern, "/"), "/")
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	if len(pattern) > len(segments) {
+		return false
+	}
+	for i, s := range pattern {
+		if ok, err := path.Match(s, segments[i]); err != nil || !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// defaultCacheDependencies lists, per resource tag, the resources whose
+// cached responses embed it and go stale when it changes.
+var defaultCacheDependencies = map[string][]string{
+	"zia:urlCategories":     {"zia:urlFilteringRules", "zia:sslInspectionRules"},
+	"zia:ruleLabels":        {"zia:urlFilteringRules", "zia:firewallFilteringRules", "zia:sslInspectionRules"},
+	"zpa:segmentGroup":      {"zpa:application"},
+	"zpa:serverGroup":       {"zpa:application"},
+	"zpa:application":       {"zpa:segmentGroup"},
+	"zpa:appConnectorGroup": {"zpa:serverGroup"},
+}
+
+// DefaultCacheDependencies returns a copy of the built-in invalidation graph:
+// for each resource tag, the tags whose entries are invalidated when an
+// object of that resource is created, changed or deleted.
+func DefaultCacheDependencies() map[string][]string {
+	deps := make(map[string][]string, len(defaultCacheDependencies))
+	for k, v := range defaultCacheDependencies {
+		deps[k] = append([]string(nil), v...)
+	}
+	return deps
+}
+
+var (
+	apiVersionSegment = regexp.MustCompile(`^v\d+$`)
+	// apiPrefixSegments are the path segments between the product and the
+	// resource, e.g. /zpa/mgmtconfig/v1/admin/customers/{id}/application.
+	apiPrefixSegments = map[string]bool{
+		"api": true, "mgmtconfig": true, "userconfig": true, "admin": true,
+		"customers": true, "papi": true, "public": true, "{id}": true,
+	}
+)
+
+// CacheResourceTag returns the resource tag of an API path: the product and
+// the first path segment after the API prefix, e.g. "zia:urlCategories" for
+// /zia/api/v1/urlCategories/12 and "zpa:application" for
+// /zpa/mgmtconfig/v1/admin/customers/123/application. Every cached response
+// carries the tag of its endpoint.
+func CacheResourceTag(p string) string {
+	if i := strings.IndexByte(p, '?'); i >= 0 {
+		p = p[:i]
+	}
+	segments := strings.Split(strings.Trim(rl.EndpointTemplate(p), "/"), "/")
+	if len(segments) == 0 || segments[0] == "" {
+		return ""
+	}
+	product := segments[0]
+	if product == "admin" {
+		product = "zid"
+	}
+	for _, s := range segments[1:] {
+		if !apiPrefixSegments[s] && !apiVersionSegment.MatchString(s) {
+			return product + ":" + s
+		}
+	}
+	return product
+}
+
+// WithCacheRule adds a per-endpoint caching rule. Rules are matched in the
+// order they were added.
+//
+//	zscaler.WithCacheRule(zscaler.CacheRule{Pattern: "/zia/api/v1/urlCategories", TTL: time.Hour})
+//	zscaler.WithCacheRule(zscaler.CacheRule{Pattern: "/zia/api/v1/status", Disabled: true})
+func WithCacheRule(rule CacheRule) ConfigSetter {
+	return func(c *Configuration) {
+		c.Zscaler.Client.Cache.Rules = append(c.Zscaler.Client.Cache.Rules, rule)
+	}
+}
+
+// WithCacheDependency declares that cached responses tagged with one of
+// dependents go stale when an object of resource changes, e.g.
+// WithCacheDependency("zia:urlCategories", "zia:urlFilteringRules"). Tags are
+// resource tags as returned by CacheResourceTag or tags set by a CacheRule.
+// Dependencies are transitive and add to DefaultCacheDependencies.
+func WithCacheDependency(resource string, dependents ...string) ConfigSetter {
+	return func(c *Configuration) {
+		if c.Zscaler.Client.Cache.Dependencies == nil {
+			c.Zscaler.Client.Cache.Dependencies = make(map[string][]string)
+		}
+		deps := c.Zscaler.Client.Cache.Dependencies
+		deps[resource] = append(deps[resource], dependents...)
+	}
+}
+
+// cacheEntryOptions returns how the GET response of path is cached, and
+// whether it is cached at all.
+func (c *Configuration) cacheEntryOptions(p string) (cache.EntryOptions, bool) {
+	opts := cache.EntryOptions{}
+	if tag := CacheResourceTag(p); tag != "" {
+		opts.Tags = append(opts.Tags, tag)
+	}
+	if rule, ok := c.cacheRule(p); ok {
+		if rule.Disabled {
+			return opts, false
+		}
+		opts.TTL = rule.TTL
+		opts.Tags = append(opts.Tags, rule.Tags...)
+	}
+	return opts, true
+}
+
+// cacheRule returns the first rule matching path.
+func (c *Configuration) cacheRule(p string) (CacheRule, bool) {
+	for _, rule := range c.Zscaler.Client.Cache.Rules {
+		if rule.matches(p) {
+			return rule, true
+		}
+	}
+	return CacheRule{}, false
+}
+
+// invalidatedTags returns the tags of the entries that go stale when the
+// object at path changes: its resource tag, the tags of matching rules and,
+// transitively, their dependents.
+func (c *Configuration) invalidatedTags(p string) []string {
+	opts, _ := c.cacheEntryOptions(p)
+	seen := map[string]bool{}
+	queue := opts.Tags
+	for len(queue) > 0 {
+		tag := queue[0]
+		queue = queue[1:]
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		queue = append(queue, defaultCacheDependencies[tag]...)
+		queue = append(queue, c.Zscaler.Client.Cache.Dependencies[tag]...)
+	}
+	tags := make([]string, 0, len(seen))
+	for tag := range seen {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// maxCacheTTL is the longest TTL of the default and the cache rules.
+func (c *Configuration) maxCacheTTL() time.Duration {
+	ttl := c.Zscaler.Client.Cache.DefaultTtl
+	for _, rule := range c.Zscaler.Client.Cache.Rules {
+		if rule.TTL > ttl {
+			ttl = rule.TTL
+		}
+	}
+	return ttl
+}
+
+// PurgeCacheResources removes the cached responses tagged with any of
+// resources, e.g. "zia:urlCategories", and returns how many were removed.
+// Dependents are not purged. Caches set with WithCacheManager that do not
+// implement cache.TaggedCache are cleared entirely.
+func (client *Client) PurgeCacheResources(resources ...string) int {
+	cm := client.oauth2Credentials.CacheManager
+	if tc, ok := cm.(cache.TaggedCache); ok {
+		return tc.InvalidateTags(resources...)
+	}
+	cm.Clear()
+	return 0
+}
+
+// PurgeCache removes every cached response.
+func (client *Client) PurgeCache() {
+	client.oauth2Credentials.CacheManager.Clear()
+}
+
+// CacheStats returns the hit, miss, expiration, eviction and invalidation
+// counters of the response cache. They are zero for caches that do not
+// implement cache.TaggedCache.
+func (client *Client) CacheStats() cache.Stats {
+	if tc, ok := client.oauth2Credentials.CacheManager.(cache.TaggedCache); ok {
+		return tc.Stats()
+	}
+	return cache.Stats{}
+}
+
+// storeInCache caches the GET response of path under key according to the
+// cache rules.
+func (client *Client) storeInCache(key, p string, resp *http.Response) {
+	cfg := client.oauth2Credentials
+	opts, ok := cfg.cacheEntryOptions(p)
+	if !ok {
+		return
+	}
+	cfg.Logger.Printf("[INFO] saving to cache, key:%s\n", key)
+	if tc, ok := cfg.CacheManager.(cache.TaggedCache); ok {
+		tc.SetWithOptions(key, cache.CopyResponse(resp), opts)
+		return
+	}
+	cfg.CacheManager.Set(key, cache.CopyResponse(resp))
+}
+
+// invalidateCache removes the cached responses that a change to the object
+// at path makes stale, following the dependency graph.
+func (client *Client) invalidateCache(ctx context.Context, info *RequestInfo, p string) {
+	cfg := client.oauth2Credentials
+	tc, ok := cfg.CacheManager.(cache.TaggedCache)
+	if !ok {
+		return
+	}
+	tags := cfg.invalidatedTags(p)
+	if n := tc.InvalidateTags(tags...); n > 0 {
+		cfg.Logger.Printf("[INFO] invalidated %d cached responses tagged %s\n", n, strings.Join(tags, ", "))
+		cfg.getTelemetry().RecordCacheInvalidations(ctx, info.telemetryRequest(), n)
+	}
+}
@@ -0,0 +1,81 @@
+package zscaler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zscaler/zscaler-sdk-go/v3/cache"
+)
+
+func TestCacheResourceTag(t *testing.T) {
+	for path, want := range map[string]string{
+		"/zia/api/v1/urlCategories":                                "zia:urlCategories",
+		"/zia/api/v1/urlCategories/42?customOnly=true":             "zia:urlCategories",
+		"/zia/api/v1/firewallFilteringRules/7":                     "zia:firewallFilteringRules",
+		"/zpa/mgmtconfig/v1/admin/customers/123/application/9":     "zpa:application",
+		"/zpa/mgmtconfig/v2/admin/customers/123/policySet/rules/1": "zpa:policySet",
+		"/zcc/papi/public/v1/getDevices":                           "zcc:getDevices",
+		"/admin/api/v1/users":                                      "zid:users",
+		"/zia/api/v1":                                              "zia",
+		"":                                                         "",
+	} {
+		assert.Equal(t, want, CacheResourceTag(path), path)
+	}
+}
+
+func TestCacheRules(t *testing.T) {
+	cfg, err := NewConfiguration(
+		WithCache(false),
+		WithCacheTtl(10*time.Minute),
+		WithCacheRule(CacheRule{Pattern: "/zia/api/v1/status", Disabled: true}),
+		WithCacheRule(CacheRule{Pattern: "/zia/api/v1/urlCategories", TTL: time.Hour, Tags: []string{"categories"}}),
+		WithCacheRule(CacheRule{Pattern: "/zpa/mgmtconfig/*/admin/customers/*/application", TTL: time.Minute}),
+		WithCacheRule(CacheRule{Pattern: "/zia/api/v1", TTL: 2 * time.Minute}),
+		WithCacheDependency("categories", "zia:dlpDictionaries"),
+		WithCacheDependency("zia:dlpDictionaries", "zia:dlpEngines"),
+	)
+	require.NoError(t, err)
+
+	_, ok := cfg.cacheEntryOptions("/zia/api/v1/status")
+	assert.False(t, ok)
+
+	opts, ok := cfg.cacheEntryOptions("/zia/api/v1/urlCategories/42")
+	assert.True(t, ok)
+	assert.Equal(t, cache.EntryOptions{TTL: time.Hour, Tags: []string{"zia:urlCategories", "categories"}}, opts)
+
+	opts, _ = cfg.cacheEntryOptions("/zpa/mgmtconfig/v1/admin/customers/123/application")
+	assert.Equal(t, time.Minute, opts.TTL)
+	opts, _ = cfg.cacheEntryOptions("/zia/api/v1/locations")
+	assert.Equal(t, 2*time.Minute, opts.TTL, "the first matching rule wins")
+	opts, _ = cfg.cacheEntryOptions("/zdx/v1/devices")
+	assert.Zero(t, opts.TTL)
+
+	assert.Equal(t, time.Hour, cfg.maxCacheTTL())
+
+	assert.Equal(t, []string{
+		"categories",
+		"zia:dlpDictionaries",
+		"zia:dlpEngines",
+		"zia:sslInspectionRules",
+		"zia:urlCategories",
+		"zia:urlFilteringRules",
+	}, cfg.invalidatedTags("/zia/api/v1/urlCategories/42"))
+	assert.Equal(t, []string{"zpa:application", "zpa:segmentGroup"},
+		cfg.invalidatedTags("/zpa/mgmtconfig/v1/admin/customers/123/segmentGroup/5"), "cycles are followed once")
+
+	deps := DefaultCacheDependencies()
+	deps["zia:urlCategories"][0] = "changed"
+	assert.Equal(t, "zia:urlFilteringRules", defaultCacheDependencies["zia:urlCategories"][0])
+}
+
+func TestCacheSettersOrder(t *testing.T) {
+	cfg, err := NewConfiguration(WithCache(true), WithCacheTtl(time.Minute), WithCacheMaxSizeMB(4))
+	require.NoError(t, err)
+	assert.NotEqual(t, cache.NewNopCache(), cfg.CacheManager, "WithCache(true) enables the cache regardless of the setters order")
+
+	cfg, err = NewConfiguration(WithCache(true), WithCacheManager(cache.NewNopCache()))
+	require.NoError(t, err)
+	assert.Equal(t, cache.NewNopCache(), cfg.CacheManager)
+}
@@ -0,0 +1,161 @@
+package zscaler
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/zscaler/zscaler-sdk-go/v3/cache"
+)
+
+// revalidationKey marks the context of a background revalidation, which must
+// reach the API instead of being served stale again.
+type revalidationKey struct{}
+
+// WithCacheRevalidation keeps expired cache entries and revalidates them
+// with a conditional GET (If-None-Match, If-Modified-Since) instead of
+// fetching them again. Entries of APIs that send no ETag or Last-Modified
+// are compared by body hash. Either way an unchanged entry is kept for
+// another TTL, and a changed one is replaced and invalidates its dependents.
+func WithCacheRevalidation(enabled bool) ConfigSetter {
+	return func(c *Configuration) {
+		c.Zscaler.Client.Cache.Revalidate = enabled
+	}
+}
+
+// WithCacheStaleWhileRevalidate serves expired entries for up to d after
+// they expire while revalidating them in the background, so read-heavy
+// callers never wait for the API. It enables revalidation. CacheRule
+// StaleWhileRevalidate overrides d per endpoint. Client.Close cancels the
+// revalidations under way and waits for them.
+func WithCacheStaleWhileRevalidate(d time.Duration) ConfigSetter {
+	return func(c *Configuration) {
+		c.Zscaler.Client.Cache.StaleWhileRevalidate = d
+	}
+}
+
+// revalidates reports whether expired entries are revalidated.
+func (c *Configuration) revalidates() bool {
+	if c.Zscaler.Client.Cache.Revalidate || c.Zscaler.Client.Cache.StaleWhileRevalidate > 0 {
+		return true
+	}
+	for _, rule := range c.Zscaler.Client.Cache.Rules {
+		if rule.StaleWhileRevalidate > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// staleRetention is how long expired entries are kept for revalidation: at
+// least one TTL, and the longest stale-while-revalidate window.
+func (c *Configuration) staleRetention() time.Duration {
+	if !c.revalidates() {
+		return 0
+	}
+	retention := c.maxCacheTTL()
+	if swr := c.Zscaler.Client.Cache.StaleWhileRevalidate; swr > retention {
+		retention = swr
+	}
+	for _, rule := range c.Zscaler.Client.Cache.Rules {
+		if rule.StaleWhileRevalidate > retention {
+			retention = rule.StaleWhileRevalidate
+		}
+	}
+	return retention
+}
+
+// staleWhileRevalidate returns the stale-while-revalidate window of path.
+func (c *Configuration) staleWhileRevalidate(p string) time.Duration {
+	if rule, ok := c.cacheRule(p); ok && rule.StaleWhileRevalidate > 0 {
+		return rule.StaleWhileRevalidate
+	}
+	return c.Zscaler.Client.Cache.StaleWhileRevalidate
+}
+
+// staleCacheEntry returns the expired entry of key when it can be
+// revalidated.
+func (client *Client) staleCacheEntry(key string) *cache.Entry {
+	cfg := client.oauth2Credentials
+	if !cfg.revalidates() {
+		return nil
+	}
+	rc, ok := cfg.CacheManager.(cache.RevalidatingCache)
+	if !ok {
+		return nil
+	}
+	entry, ok := rc.Lookup(key)
+	if !ok || entry.Fresh() {
+		return nil
+	}
+	return entry
+}
+
+// servesStale reports whether stale is still within the
+// stale-while-revalidate window of path.
+func (client *Client) servesStale(ctx context.Context, stale *cache.Entry, p string) bool {
+	if ctx.Value(revalidationKey{}) != nil {
+		return false
+	}
+	swr := client.oauth2Credentials.staleWhileRevalidate(p)
+	return swr > 0 && time.Now().Before(stale.Expires.Add(swr))
+}
+
+// revalidateInBackground revalidates the entry of key unless that is already
+// under way or the client is closed. The request outlives ctx but keeps its
+// values; Close cancels it and waits for it to return.
+func (client *Client) revalidateInBackground(ctx context.Context, key, endpoint string, urlParams url.Values, contentType string) {
+	client.backgroundMu.Lock()
+	defer client.backgroundMu.Unlock()
+	if client.background == nil || client.background.Err() != nil {
+		return
+	}
+	if _, running := client.revalidating.LoadOrStore(key, struct{}{}); running {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.WithValue(context.WithoutCancel(ctx), revalidationKey{}, true))
+	stop := context.AfterFunc(client.background, cancel)
+	client.backgroundWG.Add(1)
+	go func() {
+		defer client.backgroundWG.Done()
+		defer client.revalidating.Delete(key)
+		defer stop()
+		defer cancel()
+		if _, _, _, err := client.ExecuteRequest(ctx, http.MethodGet, endpoint, nil, urlParams, contentType); err != nil {
+			client.oauth2Credentials.Logger.Printf("[WARN] background revalidation failed, key:%s: %v\n", key, err)
+		}
+	}()
+}
+
+// setCacheValidators makes req conditional on the validators of stale.
+func setCacheValidators(req *http.Request, stale *cache.Entry) {
+	if stale.ETag != "" {
+		req.Header.Set("If-None-Match", stale.ETag)
+	}
+	if stale.LastModified != "" {
+		req.Header.Set("If-Modified-Since", stale.LastModified)
+	}
+}
+
+// revalidated handles the response to the revalidation of stale. When the API
+// answered 304 Not Modified or the same body, the entry is kept for another
+// TTL and returned. Otherwise the object changed out of band: the entries
+// that depend on it are invalidated and the caller caches the new response.
+func (client *Client) revalidated(ctx context.Context, info *RequestInfo, key, p string, stale *cache.Entry, resp *http.Response, body []byte) ([]byte, *http.Response, bool) {
+	cfg := client.oauth2Credentials
+	if resp.StatusCode != http.StatusNotModified && cache.BodyHash(body) != stale.Hash {
+		client.invalidateCache(ctx, info, p)
+		return nil, nil, false
+	}
+	opts, _ := cfg.cacheEntryOptions(p)
+	if rc, ok := cfg.CacheManager.(cache.RevalidatingCache); ok {
+		rc.Refresh(key, opts.TTL)
+	}
+	data, _ := io.ReadAll(stale.Response.Body)
+	stale.Response.Body = io.NopCloser(bytes.NewReader(data))
+	cfg.Logger.Printf("[INFO] revalidated cache entry, key:%s\n", key)
+	return data, stale.Response, true
+}
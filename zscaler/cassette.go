@@ -0,0 +1,83 @@
+package zscaler
+
+import (
+	"net/http"
+
+	"github.com/zscaler/zscaler-sdk-go/v3/cassette"
+)
+
+// WithRecordMode records every request made by the client, including OAuth2
+// token requests, to the cassette at path with secrets scrubbed. Files ending
+// in .json are written as JSON, anything else as YAML. Replay the cassette in
+// tests with WithReplayMode.
+func WithRecordMode(path string, opts ...cassette.Option) ConfigSetter {
+	return func(c *Configuration) {
+		c.Cassette, c.cassetteErr = cassette.New(path, cassette.ModeRecord, opts...)
+	}
+}
+
+// WithReplayMode answers every request made by the client from the cassette
+// at path instead of the API. Requests are matched on method, path, query and
+// normalised body; a request without a recorded match fails with an error
+// matching cassette.ErrUnmatchedRequest. NewConfiguration fails if the
+// cassette does not exist.
+func WithReplayMode(path string, opts ...cassette.Option) ConfigSetter {
+	return func(c *Configuration) {
+		c.Cassette, c.cassetteErr = cassette.New(path, cassette.ModeReplay, opts...)
+	}
+}
+
+// WithCassette routes the client's traffic through rec, e.g. to share one
+// cassette between the OneAPI client and legacy clients built with
+// rec.Client.
+func WithCassette(rec *cassette.Recorder) ConfigSetter {
+	return func(c *Configuration) {
+		c.Cassette, c.cassetteErr = rec, nil
+	}
+}
+
+// applyCassette wraps the OneAPI HTTP clients, and those of any legacy client
+// already set, with the configured cassette recorder. Legacy clients that
+// authenticate on construction should be built with Cassette.Client passed to
+// their WithHttpClientPtr setter so the sign-in is recorded too.
+func applyCassette(cfg *Configuration) {
+	if cfg.Cassette == nil {
+		return
+	}
+	wrapHTTPClients(cfg, cfg.Cassette.Client)
+}
+
+// wrapHTTPClients replaces the OneAPI HTTP clients, and those of any legacy
+// client already set, with wrap applied to them.
+func wrapHTTPClients(cfg *Configuration, wrap func(*http.Client) *http.Client) {
+	for _, hc := range []**http.Client{
+		&cfg.HTTPClient,
+		&cfg.ZIAHTTPClient,
+		&cfg.ZTWHTTPClient,
+		&cfg.ZPAHTTPClient,
+		&cfg.ZCCHTTPClient,
+		&cfg.ZDXHTTPClient,
+	} {
+		*hc = wrap(*hc)
+	}
+
+	legacy := cfg.LegacyClient
+	if legacy == nil {
+		return
+	}
+	if legacy.ZiaClient != nil {
+		legacy.ZiaClient.HTTPClient = wrap(legacy.ZiaClient.HTTPClient)
+	}
+	if legacy.ZtwClient != nil {
+		legacy.ZtwClient.HTTPClient = wrap(legacy.ZtwClient.HTTPClient)
+	}
+	if legacy.ZpaClient != nil && legacy.ZpaClient.Config != nil {
+		legacy.ZpaClient.Config.HTTPClient = wrap(legacy.ZpaClient.Config.HTTPClient)
+	}
+	if legacy.ZccClient != nil && legacy.ZccClient.Config != nil {
+		legacy.ZccClient.Config.HTTPClient = wrap(legacy.ZccClient.Config.HTTPClient)
+	}
+	if legacy.ZdxClient != nil && legacy.ZdxClient.Config != nil {
+		legacy.ZdxClient.Config.HTTPClient = wrap(legacy.ZdxClient.Config.HTTPClient)
+	}
+}
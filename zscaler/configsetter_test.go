@@ -1,11 +1,16 @@
 package zscaler
 
 import (
+	"context"
 	"net/http"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zscaler/zscaler-sdk-go/v3/cassette"
+	rl "github.com/zscaler/zscaler-sdk-go/v3/ratelimiter"
 )
 
 func TestConfigSetters(t *testing.T) {
@@ -179,6 +184,57 @@ func TestConfigSetters(t *testing.T) {
 		assert.Equal(t, int32(3), cfg.Zscaler.Client.RateLimit.RetryRemainingThreshold)
 	})
 
+	t.Run("WithAdaptiveRateLimiter", func(t *testing.T) {
+		cfg := &Configuration{}
+		limiter := rl.NewAdaptiveLimiter(1)
+		setter := WithAdaptiveRateLimiter(limiter)
+		setter(cfg)
+		assert.Same(t, limiter, cfg.AdaptiveRateLimiter)
+		assert.NotNil(t, cfg.ZIAHTTPClient)
+	})
+
+	t.Run("WithRateLimitBackend", func(t *testing.T) {
+		cfg := &Configuration{}
+		backend := rl.NewMemoryBackend()
+		setter := WithRateLimitBackend(backend)
+		setter(cfg)
+		assert.Equal(t, backend, cfg.RateLimitBackend)
+		assert.Equal(t, backend, rateLimitBackend(cfg))
+	})
+
+	t.Run("WithRateLimitSharedStateDir", func(t *testing.T) {
+		cfg := &Configuration{}
+		dir := t.TempDir()
+		setter := WithRateLimitSharedStateDir(dir)
+		setter(cfg)
+		assert.Equal(t, dir, cfg.Zscaler.Client.RateLimit.SharedStateDir)
+		assert.NotNil(t, rateLimitBackend(cfg))
+	})
+
+	t.Run("WithRateLimitWaitCeiling", func(t *testing.T) {
+		cfg := &Configuration{}
+		setter := WithRateLimitWaitCeiling(30 * time.Second)
+		setter(cfg)
+		assert.Equal(t, 30*time.Second, cfg.Zscaler.Client.RateLimit.WaitCeiling)
+	})
+
+	t.Run("WithRecordMode", func(t *testing.T) {
+		cfg := &Configuration{}
+		setter := WithRecordMode(filepath.Join(t.TempDir(), "cassette.yaml"))
+		setter(cfg)
+		assert.NoError(t, cfg.cassetteErr)
+		if assert.NotNil(t, cfg.Cassette) {
+			assert.Equal(t, cassette.ModeRecord, cfg.Cassette.Mode())
+		}
+	})
+
+	t.Run("WithReplayMode", func(t *testing.T) {
+		cfg := &Configuration{}
+		setter := WithReplayMode(filepath.Join(t.TempDir(), "missing.yaml"))
+		setter(cfg)
+		assert.ErrorIs(t, cfg.cassetteErr, cassette.ErrCassetteNotFound)
+	})
+
 	t.Run("WithRateLimitMaxSessionNotValidRetries", func(t *testing.T) {
 		cfg := &Configuration{}
 		setter := WithRateLimitMaxSessionNotValidRetries(5)
@@ -227,3 +283,25 @@ func TestAddDefaultHeader(t *testing.T) {
 	})
 }
 
+func TestRateLimitBackendKeepsCustomHTTPClient(t *testing.T) {
+	custom := &http.Client{}
+	backend := rl.NewMemoryBackend()
+	cfg, err := NewConfiguration(WithHttpClientPtr(custom), WithRateLimitBackend(backend), WithVanityDomain("acme"), WithCache(false))
+	require.NoError(t, err)
+	assert.Same(t, custom, cfg.HTTPClient)
+
+	wait, _ := cfg.rateLimiters["zia"].Wait(http.MethodGet)
+	require.False(t, wait)
+	wait, _, err = backend.Acquire(context.Background(), "PRODUCTION/acme/zia", []rl.Limit{{Key: "get", Max: 1, Window: time.Minute}}, time.Now())
+	require.NoError(t, err)
+	assert.True(t, wait, "the ZIA limiter counts requests in the backend under the tenant scope")
+}
+
+func TestRateLimitScope(t *testing.T) {
+	cfg := &Configuration{}
+	cfg.Zscaler.Client.VanityDomain = "Acme"
+	assert.Equal(t, "PRODUCTION/acme/zia", rateLimitScope(cfg, "zia"))
+
+	cfg.Zscaler.Client.Cloud = "beta"
+	assert.Equal(t, "BETA/acme/zpa", rateLimitScope(cfg, "zpa"))
+}
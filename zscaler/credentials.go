@@ -0,0 +1,77 @@
+package zscaler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zscaler/zscaler-sdk-go/v3/credentials"
+)
+
+// WithCredentialProvider makes the OneAPI client fetch its client secret or
+// private key, and optionally its client ID, from p instead of the
+// configuration. p is called before the first authentication and again
+// whenever authentication fails, so rotated secrets are picked up without
+// restarting. Without this setter, the credentialProcess, credentialFile and
+// credentialUrl settings (ZSCALER_CREDENTIAL_PROCESS, ZSCALER_CREDENTIAL_FILE
+// and ZSCALER_CREDENTIAL_URL) are tried in that order.
+//
+//	zscaler.WithCredentialProvider(credentials.Chain(
+//		credentials.Env(),
+//		credentials.Exec("vault-zscaler-helper", "--tenant", "acme"),
+//	))
+func WithCredentialProvider(p credentials.Provider) ConfigSetter {
+	return func(c *Configuration) {
+		c.CredentialProvider = p
+		c.credentialsResolved = false
+	}
+}
+
+// credentialProviderFromConfig chains the credential sources named in the
+// configuration, or returns nil if there are none. The credential process is
+// split on spaces and run without a shell.
+func credentialProviderFromConfig(cfg *Configuration) credentials.Provider {
+	client := cfg.Zscaler.Client
+	var providers []credentials.Provider
+	if args := strings.Fields(client.CredentialProcess); len(args) > 0 {
+		providers = append(providers, credentials.Exec(args[0], args[1:]...))
+	}
+	if client.CredentialFile != "" {
+		providers = append(providers, credentials.File(client.CredentialFile))
+	}
+	if client.CredentialURL != "" {
+		providers = append(providers, credentials.HTTP(client.CredentialURL))
+	}
+	if len(providers) == 0 {
+		return nil
+	}
+	return credentials.Chain(providers...)
+}
+
+// resolveCredentials copies the credentials returned by the provider into the
+// configuration and reports whether they changed.
+func resolveCredentials(ctx context.Context, cfg *Configuration) (bool, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	creds, err := cfg.CredentialProvider.Retrieve(ctx)
+	if err != nil {
+		return false, err
+	}
+	if creds == nil {
+		return false, credentials.ErrNoCredentials
+	}
+	cfg.credentialsResolved = true
+
+	client := &cfg.Zscaler.Client
+	before := client.ClientID + "\x00" + client.ClientSecret + "\x00" + string(client.PrivateKey)
+	if creds.ClientID != "" {
+		client.ClientID = creds.ClientID
+	}
+	if creds.PrivateKey != "" {
+		client.PrivateKey, client.ClientSecret = []byte(creds.PrivateKey), ""
+	} else if creds.ClientSecret != "" {
+		client.ClientSecret, client.PrivateKey = creds.ClientSecret, nil
+	}
+	after := client.ClientID + "\x00" + client.ClientSecret + "\x00" + string(client.PrivateKey)
+	return before != after, nil
+}
@@ -0,0 +1,107 @@
+package zscaler
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zscaler/zscaler-sdk-go/v3/credentials"
+)
+
+// rotatingTransport accepts token requests carrying the current secret only.
+type rotatingTransport struct {
+	mu      sync.Mutex
+	secret  string
+	secrets []string
+}
+
+func (t *rotatingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, _ := io.ReadAll(req.Body)
+	form, _ := url.ParseQuery(string(body))
+	secret := form.Get("client_secret")
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.secrets = append(t.secrets, secret)
+	status, payload := http.StatusUnauthorized, `{"error":"invalid_client"}`
+	if secret == t.secret {
+		status, payload = http.StatusOK, `{"access_token":"token","token_type":"Bearer","expires_in":3600}`
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(payload)),
+		Request:    req,
+	}, nil
+}
+
+func TestCredentialProviderRotation(t *testing.T) {
+	transport := &rotatingTransport{secret: "first"}
+	var calls int
+	provider := credentials.ProviderFunc(func(context.Context) (*credentials.Credentials, error) {
+		calls++
+		transport.mu.Lock()
+		defer transport.mu.Unlock()
+		return &credentials.Credentials{ClientSecret: transport.secret}, nil
+	})
+
+	cfg, err := NewConfiguration(
+		WithClientID("client"),
+		WithVanityDomain("acme"),
+		WithCache(false),
+		WithHttpClientPtr(&http.Client{Transport: transport}),
+		WithCredentialProvider(provider),
+	)
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Zscaler.Client.ClientSecret, "credentials are not fetched before authentication")
+
+	_, err = Authenticate(context.Background(), cfg, cfg.Logger)
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, []string{"first"}, transport.secrets)
+
+	// The secret is rotated: the cached one is rejected and fetched again.
+	transport.secret = "second"
+	_, err = Authenticate(context.Background(), cfg, cfg.Logger)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, []string{"first", "first", "second"}, transport.secrets)
+
+	// A failure with unchanged credentials is not retried.
+	transport.secret = "unknown"
+	provider = func(context.Context) (*credentials.Credentials, error) {
+		return &credentials.Credentials{ClientSecret: "second"}, nil
+	}
+	cfg.CredentialProvider = provider
+	_, err = Authenticate(context.Background(), cfg, cfg.Logger)
+	assert.ErrorContains(t, err, "401")
+	assert.Len(t, transport.secrets, 4)
+}
+
+func TestCredentialProviderFromConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secret.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("clientSecret: from-file\n"), 0o600))
+	t.Setenv("ZSCALER_CREDENTIAL_FILE", path)
+
+	cfg, err := NewConfiguration(WithClientID("client"), WithCache(false))
+	require.NoError(t, err)
+	require.NotNil(t, cfg.CredentialProvider)
+
+	changed, err := resolveCredentials(context.Background(), cfg)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, "client", cfg.Zscaler.Client.ClientID, "the configured client ID is kept")
+	assert.Equal(t, "from-file", cfg.Zscaler.Client.ClientSecret)
+
+	t.Setenv("ZSCALER_CREDENTIAL_FILE", "")
+	cfg, err = NewConfiguration(WithClientID("client"), WithClientSecret("secret"), WithCache(false))
+	require.NoError(t, err)
+	assert.Nil(t, cfg.CredentialProvider)
+}
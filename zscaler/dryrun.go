@@ -0,0 +1,56 @@
+package zscaler
+
+import (
+	"github.com/zscaler/zscaler-sdk-go/v3/dryrun"
+)
+
+// WithDryRun records every request that would change the tenant, i.e. all
+// but GETs and authentication requests, into plan instead of sending it, and
+// answers it with a synthetic response. GETs still reach the API, so code
+// that looks objects up before changing them behaves as in a real run. Legacy
+// ZIA, ZTW, ZPA, ZCC and ZDX clients set on the configuration are put in
+// dry-run mode too. ZWA is not part of LegacyClient and a zwa.Client builds
+// its own HTTP client for every request, so its changes are never held back:
+// do not use one in a dry run.
+func WithDryRun(plan *dryrun.Plan) ConfigSetter {
+	return func(c *Configuration) {
+		c.DryRunPlan = plan
+	}
+}
+
+// EnableDryRun puts the service in dry-run mode after it was built, e.g. by
+// one of the NewLegacy*Client constructors; see WithDryRun. The mode applies
+// to every Service sharing the same clients and cannot be turned off.
+func (service *Service) EnableDryRun(plan *dryrun.Plan) {
+	var cfg *Configuration
+	if service.Client != nil {
+		cfg = service.Client.oauth2Credentials
+	}
+	if cfg == nil {
+		cfg = &Configuration{}
+	}
+	if cfg.LegacyClient == nil {
+		cfg.LegacyClient = service.LegacyClient
+	}
+	cfg.DryRunPlan = plan
+	applyDryRun(cfg)
+}
+
+// DryRunPlan returns the plan the service records changes into, or nil if it
+// is not in dry-run mode.
+func (service *Service) DryRunPlan() *dryrun.Plan {
+	if service.Client == nil || service.Client.oauth2Credentials == nil {
+		return nil
+	}
+	return service.Client.oauth2Credentials.DryRunPlan
+}
+
+// applyDryRun wraps the OneAPI HTTP clients, and those of any legacy client,
+// with the configured plan. It runs after applyCassette so that GETs are
+// still recorded or replayed while mutations never reach the cassette.
+func applyDryRun(cfg *Configuration) {
+	if cfg.DryRunPlan == nil {
+		return
+	}
+	wrapHTTPClients(cfg, cfg.DryRunPlan.Client)
+}
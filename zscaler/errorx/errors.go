@@ -177,25 +177,7 @@ func IsSessionInvalidError(res *http.Response) bool {
 	// Rewind the response body for potential reuse
 	res.Body = io.NopCloser(strings.NewReader(string(bodyBytes)))
 
-	bodyStr := string(bodyBytes)
-
-	// Only check for error messages we know for certain are returned by the API
-	// The API may return different formats depending on the service/endpoint
-	knownSessionInvalidMessages := []string{
-		"SESSION_NOT_VALID",                              // Legacy/direct error code
-		"getAttribute: Session already invalidated",      // Java exception message format (legacy)
-		"getAttributeNames: Session already invalidated", // Java exception message format (newer API variant)
-		"Session already invalidated",                    // Broad match for any future method name variants
-		"Resource Access Blocked",                        // Occurs under high concurrency/load - API returns 401 instead of 429
-	}
-
-	for _, msg := range knownSessionInvalidMessages {
-		if strings.Contains(bodyStr, msg) {
-			return true
-		}
-	}
-
-	return false
+	return containsAny(string(bodyBytes), sessionInvalidMessages)
 }
 
 // IsEditLockError checks if the response indicates an edit lock conflict error
@@ -213,20 +195,5 @@ func IsEditLockError(res *http.Response) bool {
 	// Rewind the response body for potential reuse
 	res.Body = io.NopCloser(strings.NewReader(string(bodyBytes)))
 
-	bodyStr := string(bodyBytes)
-
-	// Check for known edit lock error messages
-	editLockErrorMessages := []string{
-		"EDIT_LOCK_NOT_AVAILABLE",
-		"Resource Access Blocked",
-		"Failed during enter Org barrier",
-	}
-
-	for _, msg := range editLockErrorMessages {
-		if strings.Contains(bodyStr, msg) {
-			return true
-		}
-	}
-
-	return false
+	return containsAny(string(bodyBytes), editLockMessages)
 }
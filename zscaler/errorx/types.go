@@ -0,0 +1,256 @@
+package errorx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	rl "github.com/zscaler/zscaler-sdk-go/v3/ratelimiter"
+)
+
+// Sentinel errors for the error kinds returned by every product client. Use
+// errors.Is to test for a kind and errors.As with the matching *XxxError type
+// to inspect its details:
+//
+//	if errors.Is(err, errorx.ErrNotFound) { ... }
+//
+//	var rateLimited *errorx.RateLimitedError
+//	if errors.As(err, &rateLimited) { time.Sleep(rateLimited.RetryAfter) }
+var (
+	ErrNotFound         = errors.New("resource not found")
+	ErrConflict         = errors.New("conflict")
+	ErrEditLock         = errors.New("edit lock not available")
+	ErrRateLimited      = errors.New("rate limited")
+	ErrSessionInvalid   = errors.New("session invalid")
+	ErrValidation       = errors.New("validation failed")
+	ErrPermissionDenied = errors.New("permission denied")
+	ErrLimitExceeded    = errors.New("tenant resource limit exceeded")
+	ErrOneAPIOnly       = errors.New("only supported through Zscaler OneAPI")
+)
+
+var (
+	// sessionInvalidMessages are the bodies known to mean that the API session
+	// or token was invalidated. The API may return different formats depending
+	// on the service/endpoint.
+	sessionInvalidMessages = []string{
+		"SESSION_NOT_VALID",                              // Legacy/direct error code
+		"getAttribute: Session already invalidated",      // Java exception message format (legacy)
+		"getAttributeNames: Session already invalidated", // Java exception message format (newer API variant)
+		"Session already invalidated",                    // Broad match for any future method name variants
+		"Resource Access Blocked",                        // Occurs under high concurrency/load - API returns 401 instead of 429
+	}
+
+	// editLockMessages are the bodies known to mean a transient edit lock or
+	// org barrier conflict.
+	editLockMessages = []string{
+		"EDIT_LOCK_NOT_AVAILABLE",
+		"Resource Access Blocked",
+		"Failed during enter Org barrier",
+	}
+)
+
+func containsAny(s string, substrs []string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// describe returns message, or the API error when message is empty.
+func describe(message string, r *ErrorResponse, kind error) string {
+	if message != "" {
+		return message
+	}
+	if r != nil {
+		return r.Error()
+	}
+	return kind.Error()
+}
+
+// NotFoundError reports that a resource does not exist, either because the
+// API answered 404 / resource.not.found or because a lookup by name found no
+// match.
+type NotFoundError struct {
+	Message  string
+	Response *ErrorResponse
+}
+
+// NotFoundf returns a *NotFoundError with a formatted message, for lookups
+// that find no match in an otherwise successful response.
+func NotFoundf(format string, args ...interface{}) error {
+	return &NotFoundError{Message: fmt.Sprintf(format, args...)}
+}
+
+func (e *NotFoundError) Error() string        { return describe(e.Message, e.Response, ErrNotFound) }
+func (e *NotFoundError) Is(target error) bool { return target == ErrNotFound }
+
+// ConflictError reports a 409 or 412 response. EditLock is set when the
+// conflict is a transient edit lock / org barrier that can be retried.
+type ConflictError struct {
+	Response *ErrorResponse
+	EditLock bool
+}
+
+func (e *ConflictError) Error() string { return describe("", e.Response, ErrConflict) }
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict || (e.EditLock && target == ErrEditLock)
+}
+
+// RateLimitedError reports a 429 response. RetryAfter is how long the API asked
+// the caller to wait, from Retry-After or X-Ratelimit-Reset, if it said.
+type RateLimitedError struct {
+	Response   *ErrorResponse
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string        { return describe("", e.Response, ErrRateLimited) }
+func (e *RateLimitedError) Is(target error) bool { return target == ErrRateLimited }
+
+// SessionInvalidError reports a 401 response saying the session or token was
+// invalidated.
+type SessionInvalidError struct {
+	Response *ErrorResponse
+}
+
+func (e *SessionInvalidError) Error() string        { return describe("", e.Response, ErrSessionInvalid) }
+func (e *SessionInvalidError) Is(target error) bool { return target == ErrSessionInvalid }
+
+// FieldError is one invalid field reported by the API. Message may be empty
+// when the API only names the field.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// ValidationError reports a 400 or 422 response rejecting the request payload.
+// Fields lists the invalid fields when the API names them.
+type ValidationError struct {
+	Message  string
+	Response *ErrorResponse
+	Fields   []FieldError
+}
+
+func (e *ValidationError) Error() string        { return describe(e.Message, e.Response, ErrValidation) }
+func (e *ValidationError) Is(target error) bool { return target == ErrValidation }
+
+// PermissionDeniedError reports a 403 response. LimitExceeded is set when the
+// tenant's capacity for the resource type is exhausted (LIMIT_EXCEEDED), which
+// is not retryable.
+type PermissionDeniedError struct {
+	Response      *ErrorResponse
+	LimitExceeded bool
+}
+
+func (e *PermissionDeniedError) Error() string { return describe("", e.Response, ErrPermissionDenied) }
+func (e *PermissionDeniedError) Is(target error) bool {
+	return target == ErrPermissionDenied || (e.LimitExceeded && target == ErrLimitExceeded)
+}
+
+// OneAPIOnlyError reports that the endpoint is only available through Zscaler
+// OneAPI and cannot be used with legacy credentials.
+type OneAPIOnlyError struct {
+	Response *ErrorResponse
+}
+
+func (e *OneAPIOnlyError) Error() string        { return describe("", e.Response, ErrOneAPIOnly) }
+func (e *OneAPIOnlyError) Is(target error) bool { return target == ErrOneAPIOnly }
+
+// Unwrap exposes the typed error for the response (see Kind) and the
+// underlying error, so errors.Is and errors.As see both.
+func (r *ErrorResponse) Unwrap() []error {
+	var errs []error
+	if kind := r.Kind(); kind != nil {
+		errs = append(errs, kind)
+	}
+	if r.Err != nil {
+		errs = append(errs, r.Err)
+	}
+	return errs
+}
+
+// Kind classifies the response into one of the typed errors of this package,
+// or returns nil when it matches none of them.
+func (r *ErrorResponse) Kind() error {
+	if r == nil || r.Response == nil {
+		return nil
+	}
+	code := ""
+	if r.Parsed != nil {
+		code, _ = r.Parsed.Code.(string)
+	}
+	if code == "ONLY_ONEAPI_SUPPORTED" || strings.Contains(strings.ToLower(r.Message), "only through zscaler oneapi") {
+		return &OneAPIOnlyError{Response: r}
+	}
+	if r.IsObjectNotFound() || code == "RESOURCE_NOT_FOUND" {
+		return &NotFoundError{Response: r}
+	}
+	switch r.Response.StatusCode {
+	case http.StatusConflict, http.StatusPreconditionFailed:
+		return &ConflictError{Response: r, EditLock: containsAny(r.Message, editLockMessages)}
+	case http.StatusTooManyRequests:
+		return &RateLimitedError{Response: r, RetryAfter: retryAfter(r.Response.Header)}
+	case http.StatusUnauthorized:
+		if containsAny(r.Message, sessionInvalidMessages) {
+			return &SessionInvalidError{Response: r}
+		}
+	case http.StatusForbidden:
+		return &PermissionDeniedError{Response: r, LimitExceeded: r.IsLimitExceeded()}
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return &ValidationError{Response: r, Fields: parseFieldErrors(r.Message)}
+	}
+	return nil
+}
+
+// retryAfter reads the wait requested by a 429 response.
+func retryAfter(header http.Header) time.Duration {
+	now := time.Now()
+	if d, ok := rl.ParseRetryAfter(header.Get("Retry-After"), now); ok {
+		return d
+	}
+	if d, ok := rl.ParseReset(header.Get("X-Ratelimit-Reset"), now); ok {
+		return d
+	}
+	return 0
+}
+
+// parseFieldErrors extracts invalid fields from a validation error body. ZPA
+// names them in "params"; other APIs return an "errors" or "fieldErrors" list
+// of objects.
+func parseFieldErrors(body string) []FieldError {
+	var parsed struct {
+		Params      []interface{}            `json:"params"`
+		Errors      []map[string]interface{} `json:"errors"`
+		FieldErrors []map[string]interface{} `json:"fieldErrors"`
+	}
+	if json.Unmarshal([]byte(body), &parsed) != nil {
+		return nil
+	}
+	var fields []FieldError
+	for _, p := range parsed.Params {
+		if name, ok := p.(string); ok && name != "" {
+			fields = append(fields, FieldError{Field: name})
+		}
+	}
+	for _, e := range append(parsed.Errors, parsed.FieldErrors...) {
+		f := FieldError{Field: firstString(e, "field", "name", "param", "path")}
+		f.Message = firstString(e, "message", "reason", "defaultMessage")
+		if f.Field != "" || f.Message != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+func firstString(m map[string]interface{}, keys ...string) string {
+	for _, k := range keys {
+		if s, ok := m[k].(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
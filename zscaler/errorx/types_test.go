@@ -0,0 +1,127 @@
+package errorx
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func apiError(status int, body string, header http.Header) error {
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Content-Type", "application/json")
+	u, _ := url.Parse("https://api.zsapi.net/zia/api/v1/urlCategories/1")
+	res := &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    &http.Request{Method: http.MethodPut, URL: u},
+	}
+	return CheckErrorInResponse(res, fmt.Errorf("api responded with code: %d", status))
+}
+
+func TestKind_NotFound(t *testing.T) {
+	err := apiError(http.StatusNotFound, `{"code":"RESOURCE_NOT_FOUND","message":"not found"}`, nil)
+	require.ErrorIs(t, err, ErrNotFound)
+	require.NotErrorIs(t, err, ErrValidation)
+
+	var notFound *NotFoundError
+	require.True(t, errors.As(err, &notFound))
+	require.Equal(t, http.StatusNotFound, notFound.Response.Response.StatusCode)
+
+	var errResp *ErrorResponse
+	require.True(t, errors.As(err, &errResp), "ErrorResponse must stay reachable")
+}
+
+func TestKind_ConflictAndEditLock(t *testing.T) {
+	err := apiError(http.StatusConflict, `{"code":"EDIT_LOCK_NOT_AVAILABLE","message":"locked"}`, nil)
+	require.ErrorIs(t, err, ErrConflict)
+	require.ErrorIs(t, err, ErrEditLock)
+
+	err = apiError(http.StatusConflict, `{"code":"DUPLICATE_ITEM","message":"exists"}`, nil)
+	require.ErrorIs(t, err, ErrConflict)
+	require.NotErrorIs(t, err, ErrEditLock)
+}
+
+func TestKind_RateLimited(t *testing.T) {
+	err := apiError(http.StatusTooManyRequests, `{"code":"RATE_LIMIT_EXCEEDED"}`, http.Header{"Retry-After": []string{"17"}})
+	require.ErrorIs(t, err, ErrRateLimited)
+
+	var rateLimited *RateLimitedError
+	require.True(t, errors.As(err, &rateLimited))
+	require.Equal(t, 17*time.Second, rateLimited.RetryAfter)
+
+	reset := strconv.FormatInt(time.Now().Add(30*time.Second).Unix(), 10)
+	err = apiError(http.StatusTooManyRequests, `{}`, http.Header{"X-Ratelimit-Reset": []string{reset}})
+	require.True(t, errors.As(err, &rateLimited))
+	require.InDelta(t, 30*time.Second, rateLimited.RetryAfter, float64(2*time.Second), "an epoch reset is a point in time")
+
+	err = apiError(http.StatusTooManyRequests, `{}`, http.Header{"X-Ratelimit-Reset": []string{"5"}})
+	require.True(t, errors.As(err, &rateLimited))
+	require.Equal(t, 5*time.Second, rateLimited.RetryAfter)
+}
+
+func TestKind_SessionInvalid(t *testing.T) {
+	err := apiError(http.StatusUnauthorized, `{"code":"SESSION_NOT_VALID","message":"Session already invalidated"}`, nil)
+	require.ErrorIs(t, err, ErrSessionInvalid)
+
+	err = apiError(http.StatusUnauthorized, `{"code":"UNAUTHORIZED"}`, nil)
+	require.NotErrorIs(t, err, ErrSessionInvalid)
+}
+
+func TestKind_Validation(t *testing.T) {
+	err := apiError(http.StatusBadRequest, `{"id":"invalid.argument","reason":"bad","params":["name","domainNames"]}`, nil)
+	require.ErrorIs(t, err, ErrValidation)
+
+	var validation *ValidationError
+	require.True(t, errors.As(err, &validation))
+	require.Equal(t, []FieldError{{Field: "name"}, {Field: "domainNames"}}, validation.Fields)
+
+	err = apiError(http.StatusBadRequest, `{"code":"INVALID_INPUT_ARGUMENT","errors":[{"field":"urls","message":"invalid URL"}]}`, nil)
+	require.True(t, errors.As(err, &validation))
+	require.Equal(t, []FieldError{{Field: "urls", Message: "invalid URL"}}, validation.Fields)
+}
+
+func TestKind_PermissionDenied(t *testing.T) {
+	err := apiError(http.StatusForbidden, `{"code":"LIMIT_EXCEEDED","message":"Maximum 100 static IPs are allowed."}`, nil)
+	require.ErrorIs(t, err, ErrPermissionDenied)
+	require.ErrorIs(t, err, ErrLimitExceeded)
+
+	err = apiError(http.StatusForbidden, `{"code":"ACCESS_DENIED"}`, nil)
+	require.ErrorIs(t, err, ErrPermissionDenied)
+	require.NotErrorIs(t, err, ErrLimitExceeded)
+}
+
+func TestKind_OneAPIOnly(t *testing.T) {
+	err := NewOneAPIFallbackError([]byte("This API is available only through Zscaler OneAPI"), http.MethodGet, "/api/v1/x", "https://zsapi.net")
+	require.ErrorIs(t, err, ErrOneAPIOnly)
+}
+
+func TestKind_WrappedAndUnderlying(t *testing.T) {
+	cause := errors.New("client error")
+	u, _ := url.Parse("https://api.zsapi.net/zpa/x")
+	inner := CheckErrorInResponse(&http.Response{
+		StatusCode: http.StatusNotFound,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader("")),
+		Request:    &http.Request{Method: http.MethodGet, URL: u},
+	}, cause)
+	err := fmt.Errorf("reading segment group: %w", inner)
+	require.ErrorIs(t, err, ErrNotFound)
+	require.ErrorIs(t, err, cause)
+}
+
+func TestNotFoundf(t *testing.T) {
+	err := NotFoundf("no location found with name  %s", "hq")
+	require.EqualError(t, err, "no location found with name  hq")
+	require.ErrorIs(t, err, ErrNotFound)
+}
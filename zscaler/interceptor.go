@@ -0,0 +1,114 @@
+package zscaler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Retry reasons reported to Interceptor.OnRetry by ExecuteRequest.
+const (
+	RetryReasonSessionInvalid = "SESSION_NOT_VALID"
+	RetryReasonUnauthorized   = "UNAUTHORIZED"
+	RetryReasonRateLimited    = "RATE_LIMITED"
+	RetryReasonEditLock       = "EDIT_LOCK"
+	RetryReasonServerError    = "SERVER_ERROR"
+)
+
+// RequestInfo describes the OneAPI call an Interceptor is observing. The same
+// value is passed to every hook for a single ExecuteRequest call, so hooks can
+// correlate the attempts of one logical request.
+type RequestInfo struct {
+	// Method is the HTTP method of the call.
+	Method string
+	// Endpoint is the endpoint path passed to ExecuteRequest, without base URL or query.
+	Endpoint string
+	// ServiceType is the product detected from the endpoint (zia, zpa, ztw, zcc,
+	// zdx, admin) or empty when the endpoint does not map to a known product.
+	ServiceType string
+	// Attempt is the 1-based attempt number of the current wire request.
+	Attempt int
+	// Request is the request about to be sent (or last sent) on the wire.
+	// BeforeRequest hooks may modify its headers.
+	Request *http.Request
+	// StartTime is when ExecuteRequest was entered.
+	StartTime time.Time
+
+	attemptSpan trace.Span
+}
+
+// Interceptor groups the optional hooks invoked by Client.ExecuteRequest. Any
+// hook may be left nil. Interceptors run in the order they were registered
+// through WithInterceptor.
+type Interceptor struct {
+	// BeforeRequest runs before every wire attempt, after authentication headers
+	// are set. Returning an error aborts the call with that error.
+	BeforeRequest func(ctx context.Context, info *RequestInfo) error
+	// AfterResponse runs after every wire attempt that produced a response,
+	// before the SDK inspects the status code.
+	AfterResponse func(ctx context.Context, info *RequestInfo, resp *http.Response)
+	// OnRetry runs when ExecuteRequest decides to retry, with the reason and the
+	// time it is about to wait before the next attempt.
+	OnRetry func(ctx context.Context, info *RequestInfo, reason string, wait time.Duration)
+	// OnError runs once when ExecuteRequest returns an error.
+	OnError func(ctx context.Context, info *RequestInfo, err error)
+}
+
+// WithInterceptor registers an Interceptor on the OneAPI client. It may be
+// passed several times; interceptors are chained in registration order.
+func WithInterceptor(interceptor Interceptor) ConfigSetter {
+	return func(c *Configuration) {
+		c.Interceptors = append(c.Interceptors, interceptor)
+	}
+}
+
+func newRequestInfo(method, endpoint string) *RequestInfo {
+	serviceType, _ := detectServiceType(endpoint)
+	return &RequestInfo{
+		Method:      method,
+		Endpoint:    endpoint,
+		ServiceType: serviceType,
+		StartTime:   time.Now(),
+	}
+}
+
+func (c *Client) runBeforeRequest(ctx context.Context, info *RequestInfo) error {
+	for _, i := range c.oauth2Credentials.Interceptors {
+		if i.BeforeRequest == nil {
+			continue
+		}
+		if err := i.BeforeRequest(ctx, info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) runAfterResponse(ctx context.Context, info *RequestInfo, resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	for _, i := range c.oauth2Credentials.Interceptors {
+		if i.AfterResponse != nil {
+			i.AfterResponse(ctx, info, resp)
+		}
+	}
+}
+
+func (c *Client) runOnRetry(ctx context.Context, info *RequestInfo, reason string, wait time.Duration) {
+	for _, i := range c.oauth2Credentials.Interceptors {
+		if i.OnRetry != nil {
+			i.OnRetry(ctx, info, reason, wait)
+		}
+	}
+}
+
+func (c *Client) runOnError(ctx context.Context, info *RequestInfo, err error) {
+	for _, i := range c.oauth2Credentials.Interceptors {
+		if i.OnError != nil {
+			i.OnError(ctx, info, err)
+		}
+	}
+}
@@ -15,6 +15,8 @@ type jmespathContextKey struct{}
 // expression. Pagination helpers (ReadAllPages, GetAllPagesGeneric*, etc.)
 // automatically apply the expression to the aggregated result set before
 // returning, enabling transparent client-side filtering across all services.
+// Pagination iterators (AllPages etc.) apply it to each page instead; see
+// Paginate.
 //
 //	ctx := zscaler.ContextWithJMESPath(ctx, "[?enabled==`true`].{id: id, name: name}")
 //	locations, err := location.GetAll(ctx, service, nil)
@@ -21,13 +21,20 @@ import (
 	"github.com/google/uuid"
 	"github.com/kelseyhightower/envconfig"
 	"github.com/zscaler/zscaler-sdk-go/v3/cache"
+	"github.com/zscaler/zscaler-sdk-go/v3/cassette"
+	"github.com/zscaler/zscaler-sdk-go/v3/credentials"
+	"github.com/zscaler/zscaler-sdk-go/v3/dryrun"
+	"github.com/zscaler/zscaler-sdk-go/v3/internal/telemetry"
 	"github.com/zscaler/zscaler-sdk-go/v3/logger"
 	rl "github.com/zscaler/zscaler-sdk-go/v3/ratelimiter"
+	"github.com/zscaler/zscaler-sdk-go/v3/tokenstore"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zcc"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zdx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa"
 	ztw "github.com/zscaler/zscaler-sdk-go/v3/zscaler/ztw"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 	"gopkg.in/yaml.v3"
 )
 
@@ -96,11 +103,27 @@ type Configuration struct {
 			AccessToken   *AuthToken `yaml:"accessToken"`
 			SandboxToken  string     `yaml:"sandboxToken" envconfig:"ZSCALER_SANDBOX_TOKEN"`
 			SandboxCloud  string     `yaml:"sandboxCloud" envconfig:"ZSCALER_SANDBOX_CLOUD"`
-			Cache         struct {
+			// CredentialProcess, CredentialFile and CredentialURL name external
+			// sources for the client secret or private key; see
+			// WithCredentialProvider.
+			CredentialProcess string `yaml:"credentialProcess" envconfig:"ZSCALER_CREDENTIAL_PROCESS"`
+			CredentialFile    string `yaml:"credentialFile" envconfig:"ZSCALER_CREDENTIAL_FILE"`
+			CredentialURL     string `yaml:"credentialUrl" envconfig:"ZSCALER_CREDENTIAL_URL"`
+			Cache             struct {
 				Enabled               bool          `yaml:"enabled" envconfig:"ZSCALER_CLIENT_CACHE_ENABLED"`
 				DefaultTtl            time.Duration `yaml:"defaultTtl" envconfig:"ZSCALER_CLIENT_CACHE_DEFAULT_TTL"`
 				DefaultTti            time.Duration `yaml:"defaultTti" envconfig:"ZSCALER_CLIENT_CACHE_DEFAULT_TTI"`
 				DefaultCacheMaxSizeMB int64         `yaml:"defaultSize" envconfig:"ZSCALER_CLIENT_CACHE_DEFAULT_SIZE"`
+				// Revalidate sends conditional requests for expired entries
+				// instead of fetching them again; StaleWhileRevalidate serves
+				// expired entries for that long while they are revalidated in
+				// the background. See WithCacheRevalidation.
+				Revalidate           bool          `yaml:"revalidate" envconfig:"ZSCALER_CLIENT_CACHE_REVALIDATE"`
+				StaleWhileRevalidate time.Duration `yaml:"staleWhileRevalidate" envconfig:"ZSCALER_CLIENT_CACHE_STALE_WHILE_REVALIDATE"`
+				// Rules and Dependencies refine caching per endpoint; see
+				// WithCacheRule and WithCacheDependency.
+				Rules        []CacheRule         `yaml:"rules" ignored:"true"`
+				Dependencies map[string][]string `yaml:"dependencies" ignored:"true"`
 			} `yaml:"cache"`
 			Proxy struct {
 				Port     int32  `yaml:"port" envconfig:"ZSCALER_CLIENT_PROXY_PORT"`
@@ -115,16 +138,41 @@ type Configuration struct {
 				RetryWaitMax              time.Duration `yaml:"maxWait" envconfig:"ZSCALER_CLIENT_RATE_LIMIT_MAX_WAIT"`
 				RetryRemainingThreshold   int32         `yaml:"remainingThreshold" envconfig:"ZSCALER_CLIENT_REMAINING_THRESHOLD"`
 				MaxSessionNotValidRetries int32         `yaml:"maxSessionNotValidRetries" envconfig:"ZSCALER_CLIENT_MAX_SESSION_NOT_VALID_RETRIES"`
+				SharedStateDir            string        `yaml:"sharedStateDir" envconfig:"ZSCALER_CLIENT_RATE_LIMIT_SHARED_STATE_DIR"`
+				WaitCeiling               time.Duration `yaml:"waitCeiling" envconfig:"ZSCALER_CLIENT_RATE_LIMIT_WAIT_CEILING"`
 			} `yaml:"rateLimit"`
+			// Transport holds the TLS and connection pool settings of every
+			// product; ProductTransport overrides them per product.
+			Transport        TransportConfig            `yaml:"transport"`
+			ProductTransport map[string]TransportConfig `yaml:"productTransport" ignored:"true"`
 		} `yaml:"client"`
 		Testing struct {
 			DisableHttpsCheck bool `yaml:"disableHttpsCheck" envconfig:"ZSCALER_TESTING_DISABLE_HTTPS_CHECK"`
 		} `yaml:"testing"`
 	} `yaml:"zscaler"`
-	PrivateKeySigner jose.Signer
-	CacheManager     cache.Cache
-	UseLegacyClient  bool `yaml:"useLegacyClient" envconfig:"ZSCALER_USE_LEGACY_CLIENT"`
-	LegacyClient     *LegacyClient
+	PrivateKeySigner           jose.Signer
+	Interceptors               []Interceptor
+	TracerProvider             trace.TracerProvider
+	MeterProvider              metric.MeterProvider
+	telemetry                  *telemetry.Telemetry
+	TokenStore                 tokenstore.TokenStore
+	TokenRefreshHook           func(TokenRefreshEvent)
+	TokenRenewalContext        context.Context
+	TokenRenewalFailureHandler func(TokenRenewalFailure)
+	AdaptiveRateLimiter        *rl.AdaptiveLimiter
+	RateLimitBackend           rl.Backend
+	rateLimiters               map[string]*rl.RateLimiter
+	Cassette                   *cassette.Recorder `ignored:"true"`
+	cassetteErr                error
+	DryRunPlan                 *dryrun.Plan `ignored:"true"`
+	profileErr                 error
+	transportErr               error
+	CredentialProvider         credentials.Provider
+	credentialsResolved        bool
+	CacheManager               cache.Cache
+	customCacheManager         bool
+	UseLegacyClient            bool `yaml:"useLegacyClient" envconfig:"ZSCALER_USE_LEGACY_CLIENT"`
+	LegacyClient               *LegacyClient
 }
 
 // NewConfiguration is the main configuration function, implementing the ConfigSetter pattern.
@@ -159,6 +207,11 @@ func NewConfiguration(conf ...ConfigSetter) (*Configuration, error) {
 	cfg.Zscaler.Testing.DisableHttpsCheck = false
 
 	cfg = readConfigFromSystem(*cfg)
+	// A profile selected through the environment sits between the
+	// configuration file and the environment variables.
+	if name := os.Getenv(ProfileEnvVar); name != "" {
+		WithProfile(name)(cfg)
+	}
 	cfg = readConfigFromEnvironment(*cfg)
 
 	setHttpClients(cfg)
@@ -168,6 +221,31 @@ func NewConfiguration(conf ...ConfigSetter) (*Configuration, error) {
 		confSetter(cfg)
 	}
 
+	// Shared rate limit budgets are scoped by tenant, which setters may have
+	// changed after the HTTP clients were built.
+	attachRateLimitBackend(cfg)
+
+	if cfg.profileErr != nil {
+		return nil, cfg.profileErr
+	}
+	if cfg.transportErr != nil {
+		return nil, cfg.transportErr
+	}
+	if cfg.CredentialProvider == nil {
+		cfg.CredentialProvider = credentialProviderFromConfig(cfg)
+	}
+	if cfg.cassetteErr != nil {
+		return nil, cfg.cassetteErr
+	}
+	applyCassette(cfg)
+	applyDryRun(cfg)
+
+	// The cache is built once its settings are final, unless one was given.
+	if !cfg.customCacheManager {
+		cfg.CacheManager.Close()
+		cfg.CacheManager = newCache(cfg)
+	}
+
 	// Recheck and adjust defaults after setters are applied.
 	if cfg.Zscaler.Client.RateLimit.MaxRetries == 0 {
 		cfg.Zscaler.Client.RateLimit.MaxRetries = 4 // Default to 4 if user set it to zero.
@@ -223,17 +301,97 @@ func setHttpClients(cfg *Configuration) {
 	// Default case for unknown or unhandled services
 	defaultRateLimiter := rl.NewRateLimiter(2, 1, 1, 1) // Default limits
 
-	// Pass the config to getHTTPClient so it can access proxy settings
-	cfg.HTTPClient = getHTTPClient(cfg.Logger, defaultRateLimiter, cfg)
-	cfg.ZIAHTTPClient = getHTTPClient(cfg.Logger, ziaRateLimiter, cfg)
-	cfg.ZTWHTTPClient = getHTTPClient(cfg.Logger, ztwRateLimiter, cfg)
-	cfg.ZPAHTTPClient = getHTTPClient(cfg.Logger, zpaRateLimiter, cfg)
-	cfg.ZCCHTTPClient = getHTTPClient(cfg.Logger, zccRateLimiter, cfg)
-	cfg.ZDXHTTPClient = getHTTPClient(cfg.Logger, zdxRateLimiter, cfg)
+	cfg.rateLimiters = map[string]*rl.RateLimiter{
+		"zia":     ziaRateLimiter,
+		"ztw":     ztwRateLimiter,
+		"zpa":     zpaRateLimiter,
+		"zcc":     zccRateLimiter,
+		"zdx":     zdxRateLimiter,
+		"default": defaultRateLimiter,
+	}
+
+	// Pass the config to getHTTPClient so it can access proxy and transport settings
+	cfg.transportErr = nil
+	cfg.HTTPClient = getHTTPClient(cfg.Logger, defaultRateLimiter, cfg, "")
+	cfg.ZIAHTTPClient = getHTTPClient(cfg.Logger, ziaRateLimiter, cfg, "zia")
+	cfg.ZTWHTTPClient = getHTTPClient(cfg.Logger, ztwRateLimiter, cfg, "ztw")
+	cfg.ZPAHTTPClient = getHTTPClient(cfg.Logger, zpaRateLimiter, cfg, "zpa")
+	cfg.ZCCHTTPClient = getHTTPClient(cfg.Logger, zccRateLimiter, cfg, "zcc")
+	cfg.ZDXHTTPClient = getHTTPClient(cfg.Logger, zdxRateLimiter, cfg, "zdx")
+}
+
+// attachRateLimitBackend makes the rate limiters of the built-in HTTP clients
+// count requests in the shared backend, if one is configured. HTTP clients
+// supplied by the caller are left alone.
+func attachRateLimitBackend(cfg *Configuration) {
+	backend := rateLimitBackend(cfg)
+	if backend == nil {
+		return
+	}
+	for product, limiter := range cfg.rateLimiters {
+		limiter.SetBackend(backend, rateLimitScope(cfg, product))
+	}
+}
+
+// rateLimitBackend returns the shared rate limit backend configured through
+// WithRateLimitBackend or, failing that, a file backend in SharedStateDir.
+func rateLimitBackend(cfg *Configuration) rl.Backend {
+	if cfg.RateLimitBackend != nil {
+		return cfg.RateLimitBackend
+	}
+	dir := cfg.Zscaler.Client.RateLimit.SharedStateDir
+	if dir == "" {
+		return nil
+	}
+	backend, err := rl.NewFileBackend(dir)
+	if err != nil {
+		if cfg.Logger != nil {
+			cfg.Logger.Printf("[ERROR] Shared rate limit state disabled: %v", err)
+		}
+		return nil
+	}
+	return backend
+}
+
+// rateLimitScope identifies the budget shared through the rate limit backend:
+// one per tenant (cloud and vanity domain) and product.
+func rateLimitScope(cfg *Configuration, product string) string {
+	cloud := cfg.Zscaler.Client.Cloud
+	if cloud == "" {
+		cloud = "PRODUCTION"
+	}
+	return strings.ToUpper(cloud) + "/" + strings.ToLower(cfg.Zscaler.Client.VanityDomain) + "/" + product
 }
 
 // Authenticate performs OAuth2 authentication and retrieves an AuthToken.
+// Credentials from the configured CredentialProvider are fetched before the
+// first authentication and again when it fails, in case they were rotated.
 func Authenticate(ctx context.Context, cfg *Configuration, l logger.Logger) (*AuthToken, error) {
+	if cfg.CredentialProvider == nil {
+		return requestAccessToken(ctx, cfg, l)
+	}
+	if !cfg.credentialsResolved {
+		if _, err := resolveCredentials(ctx, cfg); err != nil {
+			return nil, err
+		}
+	}
+	token, err := requestAccessToken(ctx, cfg, l)
+	if err == nil {
+		return token, nil
+	}
+	changed, rerr := resolveCredentials(ctx, cfg)
+	if rerr != nil {
+		return nil, fmt.Errorf("%w (fetching fresh credentials also failed: %v)", err, rerr)
+	}
+	if !changed {
+		return nil, err
+	}
+	l.Printf("[INFO] Authentication failed, retrying with credentials fetched again from the credential provider")
+	return requestAccessToken(ctx, cfg, l)
+}
+
+// requestAccessToken requests a token with the configured credentials.
+func requestAccessToken(ctx context.Context, cfg *Configuration, l logger.Logger) (*AuthToken, error) {
 	creds := cfg.Zscaler.Client
 
 	if creds.ClientID == "" || (creds.ClientSecret == "" && len(creds.PrivateKey) == 0) {
@@ -355,6 +513,12 @@ func authenticateWithCert(cfg *Configuration) (*AuthToken, error) {
 		return nil, fmt.Errorf("error parsing response: %v", err)
 	}
 
+	seconds, err := strconv.ParseInt(tokenResponse.ExpiresIn.String(), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expires_in value: %v", err)
+	}
+	tokenResponse.Expiry = time.Now().Add(time.Duration(seconds) * time.Second)
+
 	return &tokenResponse, nil
 }
 
@@ -557,6 +721,7 @@ func WithCache(cache bool) ConfigSetter {
 func WithCacheManager(cacheManager cache.Cache) ConfigSetter {
 	return func(c *Configuration) {
 		c.CacheManager = cacheManager
+		c.customCacheManager = true
 	}
 }
 
@@ -564,7 +729,7 @@ func newCache(c *Configuration) cache.Cache {
 	if !c.Zscaler.Client.Cache.Enabled {
 		return cache.NewNopCache()
 	}
-	cche, err := cache.NewCache(time.Duration(c.Zscaler.Client.Cache.DefaultTtl), time.Duration(c.Zscaler.Client.Cache.DefaultTti), int(c.Zscaler.Client.Cache.DefaultCacheMaxSizeMB))
+	cche, err := cache.NewCache(time.Duration(c.Zscaler.Client.Cache.DefaultTtl), time.Duration(c.Zscaler.Client.Cache.DefaultTti), int(c.Zscaler.Client.Cache.DefaultCacheMaxSizeMB), cache.WithMaxTTL(c.maxCacheTTL()), cache.WithStaleRetention(c.staleRetention()))
 	if err != nil {
 		return cache.NewNopCache()
 	}
@@ -574,21 +739,18 @@ func newCache(c *Configuration) cache.Cache {
 func WithCacheTtl(i time.Duration) ConfigSetter {
 	return func(c *Configuration) {
 		c.Zscaler.Client.Cache.DefaultTtl = i
-		c.CacheManager = newCache(c)
 	}
 }
 
 func WithCacheMaxSizeMB(size int64) ConfigSetter {
 	return func(c *Configuration) {
 		c.Zscaler.Client.Cache.DefaultCacheMaxSizeMB = size
-		c.CacheManager = newCache(c)
 	}
 }
 
 func WithCacheTti(i time.Duration) ConfigSetter {
 	return func(c *Configuration) {
 		c.Zscaler.Client.Cache.DefaultTti = i
-		c.CacheManager = newCache(c)
 	}
 }
 
@@ -670,6 +832,58 @@ func WithRateLimitMaxSessionNotValidRetries(maxRetries int32) ConfigSetter {
 	}
 }
 
+// WithAdaptiveRateLimiter throttles requests using the rate-limit headers the
+// API returns (X-RateLimit-Remaining/Reset and Retry-After), in addition to the
+// built-in per-product limits. Pass the same limiter to several clients of one
+// tenant so that they share the server-reported budget.
+func WithAdaptiveRateLimiter(limiter *rl.AdaptiveLimiter) ConfigSetter {
+	return func(c *Configuration) {
+		c.AdaptiveRateLimiter = limiter
+		setHttpClients(c)
+	}
+}
+
+// WithRateLimitBackend makes the built-in per-product rate limiters count
+// requests in backend, so that clients in several processes or hosts working
+// against the same tenant share one budget, including the hourly limits. See
+// ratelimiter.NewFileBackend and ratelimiter.NewRemoteBackend.
+func WithRateLimitBackend(backend rl.Backend) ConfigSetter {
+	return func(c *Configuration) {
+		c.RateLimitBackend = backend
+	}
+}
+
+// WithRateLimitSharedStateDir shares the rate limit budget with every process
+// on this host using the same directory, through a file backend.
+func WithRateLimitSharedStateDir(dir string) ConfigSetter {
+	return func(c *Configuration) {
+		c.Zscaler.Client.RateLimit.SharedStateDir = dir
+	}
+}
+
+// WithRateLimitWaitCeiling makes rate limit and retry waits longer than max
+// fail fast with a *ratelimiter.WaitError wrapping ratelimiter.ErrWaitTooLong
+// instead of sleeping. Zero (the default) disables the ceiling.
+func WithRateLimitWaitCeiling(max time.Duration) ConfigSetter {
+	return func(c *Configuration) {
+		c.Zscaler.Client.RateLimit.WaitCeiling = max
+		setHttpClients(c)
+	}
+}
+
+// WithLogger sets the logger used by the OneAPI client. Loggers implementing
+// logger.StructuredLogger (e.g. logger.NewSlogLogger) receive leveled entries
+// with request ID, product, endpoint and duration fields.
+func WithLogger(l logger.Logger) ConfigSetter {
+	return func(c *Configuration) {
+		if l == nil {
+			l = logger.NewNopLogger()
+		}
+		c.Logger = l
+		setHttpClients(c)
+	}
+}
+
 // WithUserAgent sets the UserAgent in the Config.
 func WithUserAgentExtra(userAgent string) ConfigSetter {
 	return func(c *Configuration) {
@@ -3,8 +3,8 @@ package zscaler
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -20,6 +20,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/zscaler/zscaler-sdk-go/v3/cache"
+	"github.com/zscaler/zscaler-sdk-go/v3/internal/telemetry"
 	"github.com/zscaler/zscaler-sdk-go/v3/logger"
 	rl "github.com/zscaler/zscaler-sdk-go/v3/ratelimiter"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
@@ -61,9 +62,10 @@ func jitter(d time.Duration, frac float64) time.Duration {
 	return out
 }
 
-// inFlightRequest represents a request that's currently being processed
+// inFlightRequest represents a request that's currently being processed. done
+// is closed when it completes.
 type inFlightRequest struct {
-	wg     sync.WaitGroup
+	done   chan struct{}
 	result []byte
 	resp   *http.Response
 	req    *http.Request
@@ -76,7 +78,16 @@ type Client struct {
 	sync.Mutex
 	oauth2Credentials *Configuration
 	stopTicker        chan bool
+	renewalFailures   chan TokenRenewalFailure
 	inFlightRequests  sync.Map // Map[string]*inFlightRequest - tracks in-flight GET requests for deduplication
+	revalidating      sync.Map // Map[string]struct{} - cache keys being revalidated in the background
+
+	// background is the parent of the background revalidations; Close
+	// cancels it and waits for backgroundWG.
+	backgroundMu   sync.Mutex
+	background     context.Context
+	stopBackground context.CancelFunc
+	backgroundWG   sync.WaitGroup
 }
 
 // NewOneAPIClient creates a new client using OAuth2 authentication for any service.
@@ -84,7 +95,9 @@ func NewOneAPIClient(config *Configuration) (*Service, error) {
 	cli := &Client{
 		oauth2Credentials: config,
 		stopTicker:        make(chan bool),
+		renewalFailures:   make(chan TokenRenewalFailure, tokenRenewalFailureBuffer),
 	}
+	cli.background, cli.stopBackground = context.WithCancel(context.Background())
 
 	if !config.UseLegacyClient {
 		// Authenticate and start token renewal
@@ -97,59 +110,43 @@ func NewOneAPIClient(config *Configuration) (*Service, error) {
 	return NewService(cli, nil), nil
 }
 
-// startTokenRenewalTicker starts a ticker to renew the token before it expires.
+// startTokenRenewalTicker starts a goroutine that renews the token before it
+// expires. It stops on Close or when the TokenRenewalContext is done.
 func (c *Client) startTokenRenewalTicker() {
-	tokenExpiry := time.Now()
-	if c.oauth2Credentials.Zscaler.Client.AuthToken != nil {
-		tokenExpiry = c.oauth2Credentials.Zscaler.Client.AuthToken.Expiry
-	}
-	renewalInterval := time.Until(tokenExpiry) - (time.Minute * 1) // Renew 1 minute before expiration
-
-	if renewalInterval > 0 {
-		ticker := time.NewTicker(renewalInterval)
-		go func() {
-			for {
-				select {
-				case <-ticker.C:
-					// Refresh the token with proper locking to prevent race conditions
-					c.Lock()
-					authToken, err := Authenticate(c.oauth2Credentials.Context, c.oauth2Credentials, c.oauth2Credentials.Logger)
-					if err != nil {
-						c.oauth2Credentials.Logger.Printf("[ERROR] Failed to renew OAuth2 token: %v", err)
-					} else {
-						c.oauth2Credentials.Zscaler.Client.AuthToken = authToken
-						c.oauth2Credentials.Logger.Printf("[INFO] OAuth2 token successfully renewed")
-						// Reset the ticker for the next renewal
-						renewalInterval = time.Until(authToken.Expiry) - (time.Minute * 1)
-						ticker.Reset(renewalInterval)
-					}
-					c.Unlock()
-				case <-c.stopTicker:
-					ticker.Stop()
-					return
-				}
-			}
-		}()
+	ctx := c.oauth2Credentials.TokenRenewalContext
+	if ctx == nil {
+		ctx = context.Background()
 	}
+	go c.runTokenRenewal(ctx, c.stopTicker)
 }
 
-// Close stops the token renewal ticker and cleans up resources.
+// Close stops the token renewal ticker, cancels the background cache
+// revalidations and waits for them to return.
 func (c *Client) Close() {
 	c.Lock()
-	defer c.Unlock()
-
 	if c.stopTicker != nil {
 		close(c.stopTicker)
 		c.stopTicker = nil
 	}
+	// Revalidations may need the lock to refresh the token, so they are
+	// waited for after it is released.
+	c.Unlock()
+
+	c.backgroundMu.Lock()
+	if c.stopBackground != nil {
+		c.stopBackground()
+	}
+	c.backgroundMu.Unlock()
+	c.backgroundWG.Wait()
 }
 
 func (client *Client) GetLogger() logger.Logger {
 	return client.oauth2Credentials.Logger
 }
 
-// getHTTPClient sets up the retryable HTTP client with backoff and retry policies.
-func getHTTPClient(l logger.Logger, rateLimiter *rl.RateLimiter, cfg *Configuration) *http.Client {
+// getHTTPClient sets up the retryable HTTP client with backoff and retry
+// policies, using the transport settings of product.
+func getHTTPClient(l logger.Logger, rateLimiter *rl.RateLimiter, cfg *Configuration, product string) *http.Client {
 	retryableClient := retryablehttp.NewClient()
 
 	// Set the retry settings, allowing user to override defaults.
@@ -235,17 +232,9 @@ func getHTTPClient(l logger.Logger, rateLimiter *rl.RateLimiter, cfg *Configurat
 		}
 	}
 
-	// Setup transport with custom proxy, if applicable, and check for HTTPS certificate check disabling
-	transport := &http.Transport{
-		Proxy:               proxyFunc,
-		MaxIdleConnsPerHost: maxIdleConnections,
-	}
-
-	// Disable HTTPS check if the configuration requests it
+	// Setup transport with custom proxy, if applicable, and the TLS and connection settings
+	transport := cfg.newTransport(product, proxyFunc)
 	if cfg.Zscaler.Testing.DisableHttpsCheck {
-		transport.TLSClientConfig = &tls.Config{
-			InsecureSkipVerify: true, // This disables HTTPS certificate validation
-		}
 		l.Printf("[INFO] HTTPS certificate validation is disabled (testing mode).")
 	}
 
@@ -256,6 +245,12 @@ func getHTTPClient(l logger.Logger, rateLimiter *rl.RateLimiter, cfg *Configurat
 			Limiter:         rateLimiter,
 			Logger:          l,
 			AdditionalDelay: 0,
+			Adaptive:        cfg.AdaptiveRateLimiter,
+			MaxWait:         cfg.Zscaler.Client.RateLimit.WaitCeiling,
+			OnWait: func(req *http.Request, delay time.Duration) {
+				serviceType, _ := detectServiceType(req.URL.Path)
+				cfg.getTelemetry().RecordRateLimitWait(req.Context(), productName(serviceType), telemetry.WaitSourceClient, delay)
+			},
 		}
 		retryableClient.HTTPClient.Transport = rateLimitedTransport
 	} else {
@@ -358,6 +353,11 @@ func checkRetry(ctx context.Context, resp *http.Response, err error) (bool, erro
 	if ctx.Err() != nil {
 		return false, ctx.Err()
 	}
+	// A rate limit wait that failed fast or was cut short is final.
+	var waitErr *rl.WaitError
+	if errors.As(err, &waitErr) {
+		return false, err
+	}
 	if resp != nil && containsInt(getRetryOnStatusCodes(), resp.StatusCode) {
 		return true, nil
 	}
@@ -474,7 +474,46 @@ func (c *Client) buildRequest(ctx context.Context, method, endpoint string, body
 	return req, nil
 }
 
+// ExecuteRequest sends a OneAPI request, handling caching, in-flight deduplication,
+// session refresh and rate-limit retries. Registered Interceptors are notified
+// around every wire attempt, and the call is traced when a TracerProvider is set.
 func (c *Client) ExecuteRequest(ctx context.Context, method, endpoint string, body io.Reader, urlParams url.Values, contentType string) ([]byte, *http.Response, *http.Request, error) {
+	info := newRequestInfo(method, endpoint)
+	ctx = logger.ContextWithFields(ctx,
+		logger.F("product", productName(info.ServiceType)),
+		logger.F("endpoint", endpoint),
+	)
+	tel := c.oauth2Credentials.getTelemetry()
+	ctx, span := tel.StartRequest(ctx, info.telemetryRequest())
+	respBody, resp, req, err := c.executeRequest(ctx, info, method, endpoint, body, urlParams, contentType)
+	tel.EndAttempt(info.attemptSpan, resp, "", err)
+	info.attemptSpan = nil
+	if err != nil {
+		c.runOnError(ctx, info, err)
+	}
+	tel.EndRequest(ctx, span, info.telemetryRequest(), resp, err)
+	return respBody, resp, req, err
+}
+
+// sleep waits d before a retry, returning a *ratelimiter.WaitError when ctx
+// is done first or d exceeds the configured wait ceiling.
+func (c *Client) sleep(ctx context.Context, d time.Duration) error {
+	return rl.Sleep(ctx, d, c.oauth2Credentials.Zscaler.Client.RateLimit.WaitCeiling)
+}
+
+// retrying records a retry decision: it closes the current attempt span with
+// the retry reason, records rate-limit waits and notifies interceptors.
+func (c *Client) retrying(ctx context.Context, info *RequestInfo, resp *http.Response, reason string, wait time.Duration) {
+	tel := c.oauth2Credentials.getTelemetry()
+	tel.EndAttempt(info.attemptSpan, resp, reason, nil)
+	info.attemptSpan = nil
+	if reason == RetryReasonRateLimited {
+		tel.RecordRateLimitWait(ctx, productName(info.ServiceType), reason, wait)
+	}
+	c.runOnRetry(ctx, info, reason, wait)
+}
+
+func (c *Client) executeRequest(ctx context.Context, info *RequestInfo, method, endpoint string, body io.Reader, urlParams url.Values, contentType string) ([]byte, *http.Response, *http.Request, error) {
 	// Buffer the request body so we can retry with SESSION_NOT_VALID errors
 	var requestBodyBytes []byte
 	if body != nil {
@@ -499,9 +538,11 @@ func (c *Client) ExecuteRequest(ctx context.Context, method, endpoint string, bo
 
 	// For GET requests, check cache first and handle request deduplication
 	var ifr *inFlightRequest
+	var stale *cache.Entry
 	if method == http.MethodGet && c.oauth2Credentials.Zscaler.Client.Cache.Enabled && !isSandboxRequest {
 		// Check cache first
 		cachedResp := c.oauth2Credentials.CacheManager.Get(key)
+		c.oauth2Credentials.getTelemetry().RecordCacheLookup(ctx, info.telemetryRequest(), cachedResp != nil)
 		if cachedResp != nil {
 			respData, err := io.ReadAll(cachedResp.Body)
 			if err == nil {
@@ -511,11 +552,29 @@ func (c *Client) ExecuteRequest(ctx context.Context, method, endpoint string, bo
 			return respData, cachedResp, req, nil
 		}
 
+		// Revalidate an expired entry with a conditional request, or serve it
+		// while it is revalidated in the background.
+		if stale = c.staleCacheEntry(key); stale != nil {
+			if c.servesStale(ctx, stale, req.URL.Path) {
+				c.revalidateInBackground(ctx, key, endpoint, urlParams, contentType)
+				respData, _ := io.ReadAll(stale.Response.Body)
+				stale.Response.Body = io.NopCloser(bytes.NewReader(respData))
+				c.oauth2Credentials.Logger.Printf("[INFO] served stale from cache while revalidating, key:%s\n", key)
+				return respData, stale.Response, req, nil
+			}
+			setCacheValidators(req, stale)
+		}
+
 		// Check if there's an in-flight request for the same URL
 		if inFlight, exists := c.inFlightRequests.Load(key); exists {
 			if existingIfr, ok := inFlight.(*inFlightRequest); ok {
 				c.oauth2Credentials.Logger.Printf("[INFO] waiting for in-flight request to complete, key:%s\n", key)
-				existingIfr.wg.Wait() // Wait for the in-flight request to complete
+				// Wait for the in-flight request to complete, unless ctx is done first
+				select {
+				case <-existingIfr.done:
+				case <-ctx.Done():
+					return nil, nil, req, ctx.Err()
+				}
 				// Once the request completes, check cache again
 				cachedResp := c.oauth2Credentials.CacheManager.Get(key)
 				if cachedResp != nil {
@@ -535,21 +594,21 @@ func (c *Client) ExecuteRequest(ctx context.Context, method, endpoint string, bo
 		}
 
 		// Create new in-flight request and mark it as in-flight
-		ifr = &inFlightRequest{}
-		ifr.wg.Add(1)
+		ifr = &inFlightRequest{done: make(chan struct{})}
 		c.inFlightRequests.Store(key, ifr)
 
 		// Always clean up the in-flight request when done (success or error)
 		defer func() {
 			if ifr != nil {
 				ifr.once.Do(func() {
-					ifr.wg.Done()
+					close(ifr.done)
 					c.inFlightRequests.Delete(key)
 				})
 			}
 		}()
 	} else if method != http.MethodGet && c.oauth2Credentials.Zscaler.Client.Cache.Enabled && !isSandboxRequest {
 		// For non-GET requests, invalidate cache
+		c.invalidateCache(ctx, info, req.URL.Path)
 		c.oauth2Credentials.CacheManager.Delete(key)
 		baseKey := strings.Split(key, "?")[0]
 		c.oauth2Credentials.CacheManager.ClearAllKeysWithPrefix(baseKey)
@@ -569,11 +628,15 @@ func (c *Client) ExecuteRequest(ctx context.Context, method, endpoint string, bo
 
 	var resp *http.Response
 	sessionNotValidRetryCount := 0
+	unauthorizedRefreshed := false
 	maxSessionNotValidRetries := int(c.oauth2Credentials.Zscaler.Client.RateLimit.MaxSessionNotValidRetries)
 
 	for retry := 1; ; retry++ { // Infinite loop for retries if MaxRetries=0
 		// Check MaxRetries if non-zero
 		if c.oauth2Credentials.Zscaler.Client.RateLimit.MaxRetries > 0 && retry > int(c.oauth2Credentials.Zscaler.Client.RateLimit.MaxRetries) {
+			if resp != nil && resp.StatusCode >= 300 {
+				return nil, resp, nil, fmt.Errorf("max retries exceeded: %w", errorx.CheckErrorInResponse(resp, nil))
+			}
 			return nil, resp, nil, fmt.Errorf("max retries exceeded")
 		}
 
@@ -587,12 +650,20 @@ func (c *Client) ExecuteRequest(ctx context.Context, method, endpoint string, bo
 				elapsedTime, totalWaitTime)
 		}
 
+		info.Attempt = retry
+		info.Request = req
+		if err := c.runBeforeRequest(ctx, info); err != nil {
+			return nil, resp, req, err
+		}
+		info.attemptSpan = c.oauth2Credentials.getTelemetry().StartAttempt(ctx, info.telemetryRequest())
+
 		start := time.Now()
 		reqID := uuid.New().String()
 		logger.LogRequest(c.oauth2Credentials.Logger, req, reqID, nil, !isSandboxRequest)
 		httpClient := c.getServiceHTTPClient(endpoint)
 		resp, err = httpClient.Do(req)
 		logger.LogResponse(c.oauth2Credentials.Logger, resp, start, reqID)
+		c.runAfterResponse(ctx, info, resp)
 		if err != nil {
 			return nil, resp, nil, err
 		}
@@ -607,7 +678,7 @@ func (c *Client) ExecuteRequest(ctx context.Context, method, endpoint string, bo
 				if errorx.IsSessionInvalidError(resp) {
 					sessionNotValidRetryCount++
 					if sessionNotValidRetryCount > maxSessionNotValidRetries {
-						return nil, resp, req, fmt.Errorf("max SESSION_NOT_VALID retries exceeded (%d), possible authentication issue", maxSessionNotValidRetries)
+						return nil, resp, req, fmt.Errorf("max SESSION_NOT_VALID retries exceeded (%d), possible authentication issue: %w", maxSessionNotValidRetries, errorx.CheckErrorInResponse(resp, nil))
 					}
 
 					c.oauth2Credentials.Logger.Printf("[WARN] Session invalidation detected (attempt %d, session retry %d/%d), refreshing token and retrying...", retry, sessionNotValidRetryCount, maxSessionNotValidRetries)
@@ -631,19 +702,17 @@ func (c *Client) ExecuteRequest(ctx context.Context, method, endpoint string, bo
 
 					// Force token refresh regardless of client-side validation
 					// Session invalidation means the server considers the token invalid
-					c.Lock() // Prevent concurrent token refresh
-					authToken, err := Authenticate(c.oauth2Credentials.Context, c.oauth2Credentials, c.oauth2Credentials.Logger)
-					if err != nil {
-						c.Unlock()
+					if err := c.refreshRejectedToken(ctx, bearerToken(req)); err != nil {
 						return nil, resp, req, fmt.Errorf("token refresh failed after session invalidation: %w", err)
 					}
-					c.oauth2Credentials.Zscaler.Client.AuthToken = authToken
-					c.Unlock()
 					c.oauth2Credentials.Logger.Printf("[INFO] Token refreshed successfully, retrying request...")
 
 					// Add a small delay before retrying to avoid overwhelming the server
+					c.retrying(ctx, info, resp, RetryReasonSessionInvalid, time.Second*2)
 					beforeWait := time.Now()
-					time.Sleep(time.Second * 2)
+					if err := c.sleep(ctx, time.Second*2); err != nil {
+						return nil, resp, req, err
+					}
 					totalWaitTime += time.Since(beforeWait)
 
 					// Rebuild request with fresh body reader
@@ -653,6 +722,24 @@ func (c *Client) ExecuteRequest(ctx context.Context, method, endpoint string, bo
 					}
 					continue
 				}
+
+				// Any other 401 usually means the token expired or was revoked
+				// between background renewals (e.g. renewal is failing). Refresh
+				// on demand once per call and retry immediately.
+				if !unauthorizedRefreshed && !isSandboxRequest && !usesContextAccessToken(ctx) {
+					unauthorizedRefreshed = true
+					c.oauth2Credentials.Logger.Printf("[WARN] Request unauthorized (attempt %d), refreshing token and retrying...", retry)
+					if err := c.refreshRejectedToken(ctx, bearerToken(req)); err != nil {
+						return nil, resp, req, fmt.Errorf("token refresh failed after unauthorized response: %w", err)
+					}
+					c.retrying(ctx, info, resp, RetryReasonUnauthorized, 0)
+
+					req, err = c.buildRequest(ctx, method, endpoint, bytes.NewReader(requestBodyBytes), urlParams, contentType)
+					if err != nil {
+						return nil, nil, nil, err
+					}
+					continue
+				}
 			}
 			resp.Body = io.NopCloser(bytes.NewReader(bodyCopy)) // rewind even if not retrying
 		}
@@ -675,7 +762,7 @@ func (c *Client) ExecuteRequest(ctx context.Context, method, endpoint string, bo
 				// If Retry-After is very long (> 5 minutes), fail fast with clear message
 				if retryAfter > 5*time.Minute {
 					c.oauth2Credentials.Logger.Printf("[ERROR] Rate limit exceeded with very long Retry-After: %v. This typically indicates hourly rate limits have been reached.", retryAfter)
-					return nil, resp, nil, fmt.Errorf("rate limit exceeded: API requires waiting %v before retrying. This typically means hourly rate limits (GET: 1000/hr, POST/PUT: 1000/hr, DELETE: 400/hr) have been reached. Please wait before retrying", retryAfter)
+					return nil, resp, nil, fmt.Errorf("rate limit exceeded: API requires waiting %v before retrying. This typically means hourly rate limits (GET: 1000/hr, POST/PUT: 1000/hr, DELETE: 400/hr) have been reached. Please wait before retrying: %w", retryAfter, errorx.CheckErrorInResponse(resp, nil))
 				}
 
 				// Capped exponential growth on consecutive 429s for the same
@@ -694,24 +781,21 @@ func (c *Client) ExecuteRequest(ctx context.Context, method, endpoint string, bo
 				}
 				sleepFor := jitter(grown, retryJitterFraction)
 
-				// Check if context is still valid before sleeping
-				select {
-				case <-ctx.Done():
-					return nil, resp, nil, fmt.Errorf("context cancelled while waiting for rate limit: %w", ctx.Err())
-				default:
-					c.oauth2Credentials.Logger.Printf("[INFO] Rate limit hit, waiting %v (Retry-After=%v, attempt %d) before retry", sleepFor, retryAfter, retry)
-					// Track this wait time so it doesn't count against request timeout
-					beforeWait := time.Now()
-					time.Sleep(sleepFor)
-					totalWaitTime += time.Since(beforeWait)
-					continue
+				c.oauth2Credentials.Logger.Printf("[INFO] Rate limit hit, waiting %v (Retry-After=%v, attempt %d) before retry", sleepFor, retryAfter, retry)
+				c.retrying(ctx, info, resp, RetryReasonRateLimited, sleepFor)
+				// Track this wait time so it doesn't count against request timeout
+				beforeWait := time.Now()
+				if err := c.sleep(ctx, sleepFor); err != nil {
+					return nil, resp, nil, err
 				}
+				totalWaitTime += time.Since(beforeWait)
+				continue
 			}
 			// If no Retry-After header, fall through to exponential backoff
 		}
 
-		// Handle success
-		if resp.StatusCode < 300 {
+		// Handle success, including an expired cache entry found unchanged
+		if resp.StatusCode < 300 || (resp.StatusCode == http.StatusNotModified && stale != nil) {
 			break
 		}
 
@@ -730,10 +814,13 @@ func (c *Client) ExecuteRequest(ctx context.Context, method, endpoint string, bo
 						backoffDelay = maxBackoff
 					}
 					c.oauth2Credentials.Logger.Printf("[WARN] Edit lock/org barrier conflict detected (status %d, attempt %d), waiting %v before retry", resp.StatusCode, retry, backoffDelay)
+					c.retrying(ctx, info, resp, RetryReasonEditLock, backoffDelay)
 
 					// Track this wait time so it doesn't count against request timeout
 					beforeWait := time.Now()
-					time.Sleep(backoffDelay)
+					if err := c.sleep(ctx, backoffDelay); err != nil {
+						return nil, resp, req, err
+					}
 					totalWaitTime += time.Since(beforeWait)
 
 					// Rebuild request with fresh body reader
@@ -756,9 +843,12 @@ func (c *Client) ExecuteRequest(ctx context.Context, method, endpoint string, bo
 				backoffDelay = maxBackoff
 			}
 			c.oauth2Credentials.Logger.Printf("[INFO] Server error (status %d), retry %d: waiting %v before retry", resp.StatusCode, retry, backoffDelay)
+			c.retrying(ctx, info, resp, RetryReasonServerError, backoffDelay)
 			// Track this wait time so it doesn't count against request timeout
 			beforeWait := time.Now()
-			time.Sleep(backoffDelay)
+			if err := c.sleep(ctx, backoffDelay); err != nil {
+				return nil, resp, req, err
+			}
 			totalWaitTime += time.Since(beforeWait)
 			continue
 		}
@@ -809,9 +899,14 @@ func (c *Client) ExecuteRequest(ctx context.Context, method, endpoint string, bo
 
 	// Cache logic for successful GET requests
 	if !isSandboxRequest && c.oauth2Credentials.Zscaler.Client.Cache.Enabled && method == http.MethodGet {
+		if stale != nil {
+			if data, cached, ok := c.revalidated(ctx, info, key, req.URL.Path, stale, resp, bodyBytes); ok {
+				_ = tryDrainBody(resp.Body)
+				return data, cached, req, nil
+			}
+		}
 		resp.Body = io.NopCloser(bytes.NewReader(bodyBytes))
-		c.oauth2Credentials.Logger.Printf("[INFO] saving to cache, key:%s\n", key)
-		c.oauth2Credentials.CacheManager.Set(key, cache.CopyResponse(resp))
+		c.storeInCache(key, req.URL.Path, resp)
 	}
 	_ = tryDrainBody(resp.Body)
 	return bodyBytes, resp, req, nil
@@ -874,7 +969,7 @@ func (c *Client) authenticate() error {
 	// Check if the AuthToken is nil, empty, or expired
 	if !c.authValid() {
 		// Pass the context from the Configuration along with the other arguments
-		authToken, err := Authenticate(c.oauth2Credentials.Context, c.oauth2Credentials, c.oauth2Credentials.Logger)
+		authToken, err := c.obtainToken(c.oauth2Credentials.Context, "")
 		if err != nil {
 			return err
 		}
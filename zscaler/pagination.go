@@ -0,0 +1,153 @@
+package zscaler
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"strconv"
+)
+
+// PageToken is a position in a paginated listing: the page number, offset or
+// cursor the product API paginates by, rendered as a string. Tokens are safe to
+// persist and pass back through WithStartToken to resume a listing later. The
+// empty token is the first page.
+type PageToken string
+
+// PageNumberToken returns the token of a 1-based page number.
+func PageNumberToken(page int) PageToken {
+	return PageToken(strconv.Itoa(page))
+}
+
+// Int parses a page number or offset token, returning def for the empty token.
+func (t PageToken) Int(def int) (int, error) {
+	if t == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(string(t))
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid page token %q", string(t))
+	}
+	return n, nil
+}
+
+// Page is one page of results returned by a PageFetcher.
+type Page[T any] struct {
+	Items []T
+	// Next is the token of the following page, or empty on the last page.
+	Next PageToken
+	// Total is the total number of items reported by the API, or 0 if unknown.
+	Total int
+}
+
+// PageFetcher fetches the page at token.
+type PageFetcher[T any] func(ctx context.Context, token PageToken) (Page[T], error)
+
+// PageInfo describes a fetched page to a WithPageCallback callback.
+type PageInfo struct {
+	// Number counts the pages fetched by this iteration, starting at 1.
+	Number int
+	// Items is the number of items the page yields.
+	Items int
+	// Token fetches this page again. Resuming from it re-delivers the page.
+	Token PageToken
+	// Next resumes after this page. It is empty on the last page.
+	Next PageToken
+	// Total is the total number of items reported by the API, or 0 if unknown.
+	Total int
+}
+
+// PageOptions holds the settings applied by PageOption values.
+type PageOptions struct {
+	StartToken PageToken
+	OnPage     func(PageInfo) error
+	PageSize   int
+}
+
+// PageOption configures a pagination iterator.
+type PageOption func(*PageOptions)
+
+// WithStartToken resumes a listing at token, typically a PageInfo.Next saved
+// by an earlier iteration.
+func WithStartToken(token PageToken) PageOption {
+	return func(o *PageOptions) {
+		o.StartToken = token
+	}
+}
+
+// WithPageCallback calls fn after each page is fetched and before its items
+// are yielded, e.g. to report progress or checkpoint PageInfo.Next. Returning
+// an error stops the iteration, which then yields that error.
+func WithPageCallback(fn func(PageInfo) error) PageOption {
+	return func(o *PageOptions) {
+		o.OnPage = fn
+	}
+}
+
+// WithPageSize overrides the number of items requested per page. Product
+// limits still apply.
+func WithPageSize(size int) PageOption {
+	return func(o *PageOptions) {
+		o.PageSize = size
+	}
+}
+
+// NewPageOptions applies opts to a zero PageOptions.
+func NewPageOptions(opts ...PageOption) PageOptions {
+	var o PageOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	return o
+}
+
+// Paginate returns an iterator over the items of every page returned by
+// fetch, fetching each page only when the previous one has been consumed.
+// Breaking out of the range loop stops fetching. A fetch error is yielded once,
+// with the zero T, and ends the iteration.
+//
+// A JMESPath expression set with ContextWithJMESPath is applied to each page
+// on its own, so only expressions that filter or project one item at a time,
+// e.g. [?enabled] or [*].{id: id}, give the same items as on the whole list.
+// Expressions that need the whole list, such as sort_by(@, &name), length(@),
+// [0] or [:10], apply per page; use the GetAll functions for them.
+func Paginate[T any](ctx context.Context, fetch PageFetcher[T], opts ...PageOption) iter.Seq2[T, error] {
+	o := NewPageOptions(opts...)
+	return func(yield func(T, error) bool) {
+		var zero T
+		token := o.StartToken
+		for number := 1; ; number++ {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			page, err := fetch(ctx, token)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			items, err := ApplyJMESPathFromContext(ctx, page.Items)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			if o.OnPage != nil {
+				info := PageInfo{Number: number, Items: len(items), Token: token, Next: page.Next, Total: page.Total}
+				if err := o.OnPage(info); err != nil {
+					yield(zero, err)
+					return
+				}
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if page.Next == "" {
+				return
+			}
+			token = page.Next
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package zscaler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// numberPages serves pages of ints, size items per page, up to total items.
+func numberPages(total, size int, calls *int) PageFetcher[int] {
+	return func(ctx context.Context, token PageToken) (Page[int], error) {
+		*calls++
+		start, err := token.Int(0)
+		if err != nil {
+			return Page[int]{}, err
+		}
+		var items []int
+		for i := start; i < total && i < start+size; i++ {
+			items = append(items, i)
+		}
+		page := Page[int]{Items: items, Total: total}
+		if start+size < total {
+			page.Next = PageNumberToken(start + size)
+		}
+		return page, nil
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	t.Run("yields all items lazily", func(t *testing.T) {
+		calls := 0
+		var got []int
+		for v, err := range Paginate(context.Background(), numberPages(5, 2, &calls)) {
+			require.NoError(t, err)
+			got = append(got, v)
+		}
+		assert.Equal(t, []int{0, 1, 2, 3, 4}, got)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("break stops fetching", func(t *testing.T) {
+		calls := 0
+		for v := range Paginate(context.Background(), numberPages(100, 10, &calls)) {
+			if v == 12 {
+				break
+			}
+		}
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("page callback and resume", func(t *testing.T) {
+		calls := 0
+		var checkpoint PageToken
+		for v := range Paginate(context.Background(), numberPages(6, 2, &calls),
+			WithPageCallback(func(info PageInfo) error {
+				assert.Equal(t, 6, info.Total)
+				checkpoint = info.Next
+				return nil
+			}),
+		) {
+			if v == 1 {
+				break
+			}
+		}
+		require.Equal(t, PageToken("2"), checkpoint)
+
+		var rest []int
+		for v, err := range Paginate(context.Background(), numberPages(6, 2, &calls), WithStartToken(checkpoint)) {
+			require.NoError(t, err)
+			rest = append(rest, v)
+		}
+		assert.Equal(t, []int{2, 3, 4, 5}, rest)
+	})
+
+	t.Run("fetch error is yielded once", func(t *testing.T) {
+		boom := errors.New("boom")
+		fetch := func(ctx context.Context, token PageToken) (Page[int], error) {
+			if token == "" {
+				return Page[int]{Items: []int{1}, Next: "1"}, nil
+			}
+			return Page[int]{}, boom
+		}
+		var items []int
+		var errs []error
+		for v, err := range Paginate(context.Background(), fetch) {
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			items = append(items, v)
+		}
+		assert.Equal(t, []int{1}, items)
+		require.Len(t, errs, 1)
+		assert.ErrorIs(t, errs[0], boom)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		calls := 0
+		for _, err := range Paginate(ctx, numberPages(5, 2, &calls)) {
+			assert.ErrorIs(t, err, context.Canceled)
+		}
+		assert.Equal(t, 0, calls)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		calls := 0
+		for _, err := range Paginate(context.Background(), numberPages(5, 2, &calls), WithStartToken("abc")) {
+			assert.Error(t, err)
+		}
+	})
+
+	t.Run("JMESPath from context applies per page", func(t *testing.T) {
+		calls := 0
+		ctx := ContextWithJMESPath(context.Background(), "[?@ > `2`]")
+		var got []int
+		for v, err := range Paginate(ctx, numberPages(5, 2, &calls)) {
+			require.NoError(t, err)
+			got = append(got, v)
+		}
+		assert.Equal(t, []int{3, 4}, got)
+	})
+}
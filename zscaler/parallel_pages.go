@@ -0,0 +1,92 @@
+package zscaler
+
+import (
+	"context"
+	"sync"
+)
+
+type pageConcurrencyKey struct{}
+
+// ContextWithPageConcurrency opts GetAll-style list calls made with the
+// returned context into fetching up to n pages at once, when the first page
+// reports how many pages there are (ZPA totalPages, ZCC v2 total). Requests
+// still pass through the client's rate limiter, so n bounds concurrency but
+// not the request rate. Results keep the API's page order.
+func ContextWithPageConcurrency(ctx context.Context, n int) context.Context {
+	return context.WithValue(ctx, pageConcurrencyKey{}, n)
+}
+
+// PageConcurrencyFromContext returns the page concurrency set with
+// ContextWithPageConcurrency, or 1 (sequential) if none was set.
+func PageConcurrencyFromContext(ctx context.Context) int {
+	if n, ok := ctx.Value(pageConcurrencyKey{}).(int); ok && n > 1 {
+		return n
+	}
+	return 1
+}
+
+// FetchPages fetches pages first through last with up to concurrency
+// requests in flight and returns their items in page order. The first error
+// cancels the context passed to the remaining fetches and is returned.
+func FetchPages[T any](ctx context.Context, first, last, concurrency int, fetch func(ctx context.Context, page int) ([]T, error)) ([]T, error) {
+	if last < first {
+		return nil, nil
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if n := last - first + 1; concurrency > n {
+		concurrency = n
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([][]T, last-first+1)
+	pages := make(chan int)
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for page := range pages {
+				items, err := fetch(ctx, page)
+				if err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+					continue
+				}
+				results[page-first] = items
+			}
+		}()
+	}
+
+feed:
+	for page := first; page <= last; page++ {
+		select {
+		case pages <- page:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(pages)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var all []T
+	for _, items := range results {
+		all = append(all, items...)
+	}
+	return all, nil
+}
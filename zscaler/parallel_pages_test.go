@@ -0,0 +1,78 @@
+package zscaler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageConcurrencyFromContext(t *testing.T) {
+	assert.Equal(t, 1, PageConcurrencyFromContext(context.Background()))
+	assert.Equal(t, 1, PageConcurrencyFromContext(ContextWithPageConcurrency(context.Background(), 0)))
+	assert.Equal(t, 4, PageConcurrencyFromContext(ContextWithPageConcurrency(context.Background(), 4)))
+}
+
+func TestFetchPages(t *testing.T) {
+	t.Run("preserves page order", func(t *testing.T) {
+		got, err := FetchPages(context.Background(), 2, 9, 4, func(ctx context.Context, page int) ([]int, error) {
+			// Later pages finish first.
+			time.Sleep(time.Duration(10-page) * time.Millisecond)
+			return []int{page * 10, page*10 + 1}, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []int{20, 21, 30, 31, 40, 41, 50, 51, 60, 61, 70, 71, 80, 81, 90, 91}, got)
+	})
+
+	t.Run("bounds concurrency", func(t *testing.T) {
+		var inFlight, peak int32
+		_, err := FetchPages(context.Background(), 1, 20, 3, func(ctx context.Context, page int) ([]int, error) {
+			n := atomic.AddInt32(&inFlight, 1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&inFlight, -1)
+			return []int{page}, nil
+		})
+		require.NoError(t, err)
+		assert.LessOrEqual(t, atomic.LoadInt32(&peak), int32(3))
+	})
+
+	t.Run("first error cancels the rest", func(t *testing.T) {
+		boom := errors.New("boom")
+		var cancelled, started int32
+		_, err := FetchPages(context.Background(), 1, 100, 4, func(ctx context.Context, page int) ([]int, error) {
+			atomic.AddInt32(&started, 1)
+			if page == 2 {
+				return nil, boom
+			}
+			select {
+			case <-ctx.Done():
+				atomic.AddInt32(&cancelled, 1)
+				return nil, ctx.Err()
+			case <-time.After(5 * time.Second):
+				return []int{page}, nil
+			}
+		})
+		require.ErrorIs(t, err, boom)
+		assert.Greater(t, atomic.LoadInt32(&cancelled), int32(0))
+		assert.Less(t, atomic.LoadInt32(&started), int32(100))
+	})
+
+	t.Run("empty range", func(t *testing.T) {
+		got, err := FetchPages(context.Background(), 2, 1, 4, func(ctx context.Context, page int) ([]int, error) {
+			t.Fatal("unexpected fetch")
+			return nil, nil
+		})
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
@@ -0,0 +1,221 @@
+package zscaler
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ProfileEnvVar names the profile NewConfiguration applies when set.
+	ProfileEnvVar = "ZSCALER_PROFILE"
+	// ProfilesFileEnvVar overrides the file profiles are read from, which
+	// defaults to ~/.zscaler/zscaler.yaml.
+	ProfilesFileEnvVar = "ZSCALER_PROFILES_FILE"
+)
+
+// ErrProfileNotFound is returned for a profile missing from the profiles file.
+var ErrProfileNotFound = errors.New("zscaler: profile not found")
+
+// Profiles holds the named tenant profiles of a configuration file. Besides
+// the usual zscaler block, which serves as the defaults of every profile, the
+// file lists profiles under a top level profiles key. Each profile has the
+// shape of the zscaler block and may inherit from another profile; abstract
+// profiles only serve as parents:
+//
+//	zscaler:
+//	  client:
+//	    requestTimeout: 2m
+//	profiles:
+//	  beta:
+//	    abstract: true
+//	    client:
+//	      cloud: BETA
+//	  acme:
+//	    inherits: beta
+//	    client:
+//	      clientId: acme-client-id
+//	      clientSecret: acme-client-secret
+//	      vanityDomain: acme
+//	      customerId: "216196257331281920"
+//	      microtenantId: "216196257331282070"
+type Profiles struct {
+	path     string
+	defaults *yaml.Node
+	profiles map[string]*profile
+}
+
+type profile struct {
+	Inherits string `yaml:"inherits"`
+	Abstract bool   `yaml:"abstract"`
+	node     yaml.Node
+}
+
+type profilesFile struct {
+	Zscaler  yaml.Node            `yaml:"zscaler"`
+	Profiles map[string]yaml.Node `yaml:"profiles"`
+}
+
+// DefaultProfilesPath returns the file named by ZSCALER_PROFILES_FILE, or
+// ~/.zscaler/zscaler.yaml.
+func DefaultProfilesPath() string {
+	if path := os.Getenv(ProfilesFileEnvVar); path != "" {
+		return path
+	}
+	currUser, err := user.Current()
+	if err != nil || currUser.HomeDir == "" {
+		return ""
+	}
+	return filepath.Join(currUser.HomeDir, ".zscaler", "zscaler.yaml")
+}
+
+// LoadProfiles reads the profiles file at path, or at DefaultProfilesPath if
+// path is empty. It fails if a profile inherits from a missing profile or
+// inheritance is circular.
+func LoadProfiles(path string) (*Profiles, error) {
+	if path == "" {
+		path = DefaultProfilesPath()
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("zscaler: reading profiles: %w", err)
+	}
+	var f profilesFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("zscaler: parsing profiles in %s: %w", path, err)
+	}
+
+	p := &Profiles{path: path, profiles: make(map[string]*profile, len(f.Profiles))}
+	if !f.Zscaler.IsZero() {
+		p.defaults = &f.Zscaler
+	}
+	for name, node := range f.Profiles {
+		pr := &profile{node: node}
+		if err := node.Decode(pr); err != nil {
+			return nil, fmt.Errorf("zscaler: parsing profile %q in %s: %w", name, path, err)
+		}
+		p.profiles[name] = pr
+	}
+	for name := range p.profiles {
+		if _, err := p.chain(name); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// Names returns the names of the profiles that are not abstract, sorted.
+func (p *Profiles) Names() []string {
+	names := make([]string, 0, len(p.profiles))
+	for name, pr := range p.profiles {
+		if !pr.Abstract {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Profile returns a ConfigSetter applying the named profile. The tenant
+// identity (credentials and credential sources, cloud, vanity domain,
+// customer, microtenant and partner IDs) is replaced by the profile's, so
+// nothing set for another tenant carries over; other settings the profile
+// leaves out keep their value.
+// NewConfiguration fails if the profile does not exist.
+func (p *Profiles) Profile(name string) ConfigSetter {
+	return func(c *Configuration) {
+		if err := p.apply(name, c); err != nil {
+			c.profileErr = err
+		}
+	}
+}
+
+// WithProfile applies the named profile from the file at
+// DefaultProfilesPath, see Profiles.Profile. Setters after it override the
+// profile.
+func WithProfile(name string) ConfigSetter {
+	return func(c *Configuration) {
+		p, err := LoadProfiles("")
+		if err == nil {
+			err = p.apply(name, c)
+		}
+		if err != nil {
+			c.profileErr = err
+		}
+	}
+}
+
+// NewServices builds and authenticates one OneAPI service per profile
+// returned by Names, keyed by profile name. setters are applied after each
+// profile. If any service fails, the ones already built are closed and the
+// error names the profile.
+func (p *Profiles) NewServices(setters ...ConfigSetter) (map[string]*Service, error) {
+	services := make(map[string]*Service)
+	for _, name := range p.Names() {
+		service, err := p.newService(name, setters)
+		if err != nil {
+			for _, s := range services {
+				s.Client.Close()
+			}
+			return nil, fmt.Errorf("zscaler: profile %q: %w", name, err)
+		}
+		services[name] = service
+	}
+	return services, nil
+}
+
+func (p *Profiles) newService(name string, setters []ConfigSetter) (*Service, error) {
+	cfg, err := NewConfiguration(append([]ConfigSetter{p.Profile(name)}, setters...)...)
+	if err != nil {
+		return nil, err
+	}
+	return NewOneAPIClient(cfg)
+}
+
+// chain returns the named profile and its ancestors, root first.
+func (p *Profiles) chain(name string) ([]*profile, error) {
+	var chain []*profile
+	seen := map[string]bool{}
+	for name != "" {
+		if seen[name] {
+			return nil, fmt.Errorf("zscaler: profile %q in %s inherits from itself", name, p.path)
+		}
+		seen[name] = true
+		pr, ok := p.profiles[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q in %s", ErrProfileNotFound, name, p.path)
+		}
+		chain = append([]*profile{pr}, chain...)
+		name = pr.Inherits
+	}
+	return chain, nil
+}
+
+func (p *Profiles) apply(name string, c *Configuration) error {
+	chain, err := p.chain(name)
+	if err != nil {
+		return err
+	}
+	client := &c.Zscaler.Client
+	client.ClientID, client.ClientSecret, client.PrivateKey = "", "", nil
+	client.Cloud, client.VanityDomain, client.PartnerID = "", "", ""
+	client.CustomerID, client.MicrotenantID = "", ""
+	client.CredentialProcess, client.CredentialFile, client.CredentialURL = "", "", ""
+
+	if p.defaults != nil {
+		if err := p.defaults.Decode(&c.Zscaler); err != nil {
+			return fmt.Errorf("zscaler: parsing profiles in %s: %w", p.path, err)
+		}
+	}
+	for _, pr := range chain {
+		if err := pr.node.Decode(&c.Zscaler); err != nil {
+			return fmt.Errorf("zscaler: parsing profile %q in %s: %w", name, p.path, err)
+		}
+	}
+	return nil
+}
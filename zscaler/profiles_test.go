@@ -0,0 +1,144 @@
+package zscaler
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProfiles = `
+zscaler:
+  client:
+    requestTimeout: 2m
+    clientId: default-client
+profiles:
+  beta:
+    abstract: true
+    client:
+      cloud: BETA
+      clientSecret: beta-secret
+  acme:
+    inherits: beta
+    client:
+      clientId: acme-client
+      vanityDomain: acme
+      customerId: "1001"
+      microtenantId: "2001"
+  globex:
+    client:
+      clientId: globex-client
+      clientSecret: globex-secret
+      vanityDomain: globex
+      customerId: "1002"
+`
+
+func writeProfiles(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "zscaler.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadProfiles(t *testing.T) {
+	profiles, err := LoadProfiles(writeProfiles(t, testProfiles))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme", "globex"}, profiles.Names())
+
+	cfg := &Configuration{}
+	cfg.Zscaler.Client.PartnerID = "other-tenant"
+	cfg.Zscaler.Client.RateLimit.MaxRetries = 7
+	profiles.Profile("acme")(cfg)
+	require.NoError(t, cfg.profileErr)
+
+	client := cfg.Zscaler.Client
+	assert.Equal(t, "acme-client", client.ClientID)
+	assert.Equal(t, "beta-secret", client.ClientSecret, "inherited from beta")
+	assert.Equal(t, "BETA", client.Cloud)
+	assert.Equal(t, "acme", client.VanityDomain)
+	assert.Equal(t, "1001", client.CustomerID)
+	assert.Equal(t, "2001", client.MicrotenantID)
+	assert.Equal(t, 2*time.Minute, client.RequestTimeout, "from the zscaler defaults")
+	assert.Empty(t, client.PartnerID, "identity of another tenant is cleared")
+	assert.Equal(t, int32(7), client.RateLimit.MaxRetries, "other settings are kept")
+
+	cfg = &Configuration{}
+	profiles.Profile("missing")(cfg)
+	assert.ErrorIs(t, cfg.profileErr, ErrProfileNotFound)
+}
+
+func TestLoadProfilesErrors(t *testing.T) {
+	_, err := LoadProfiles(writeProfiles(t, "profiles:\n  a:\n    inherits: b\n  b:\n    inherits: a\n"))
+	assert.ErrorContains(t, err, "inherits from itself")
+
+	_, err = LoadProfiles(writeProfiles(t, "profiles:\n  a:\n    inherits: nope\n"))
+	assert.ErrorIs(t, err, ErrProfileNotFound)
+
+	_, err = LoadProfiles(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestProfileFromEnvironment(t *testing.T) {
+	t.Setenv(ProfilesFileEnvVar, writeProfiles(t, testProfiles))
+	t.Setenv(ProfileEnvVar, "globex")
+	t.Setenv("ZSCALER_CLIENT_SECRET", "from-env")
+
+	cfg, err := NewConfiguration()
+	require.NoError(t, err)
+	assert.Equal(t, "globex-client", cfg.Zscaler.Client.ClientID)
+	assert.Equal(t, "1002", cfg.Zscaler.Client.CustomerID)
+	assert.Equal(t, "from-env", cfg.Zscaler.Client.ClientSecret, "environment variables override the profile")
+
+	cfg, err = NewConfiguration(WithProfile("acme"))
+	require.NoError(t, err)
+	assert.Equal(t, "acme", cfg.Zscaler.Client.VanityDomain)
+	assert.Equal(t, "beta-secret", cfg.Zscaler.Client.ClientSecret, "explicit setters override the environment")
+
+	t.Setenv(ProfileEnvVar, "missing")
+	_, err = NewConfiguration()
+	assert.ErrorIs(t, err, ErrProfileNotFound)
+}
+
+type tokenTransport struct {
+	hosts []string
+}
+
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.hosts = append(t.hosts, req.URL.Host)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"access_token":"token","token_type":"Bearer","expires_in":3600}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestProfilesNewServices(t *testing.T) {
+	profiles, err := LoadProfiles(writeProfiles(t, testProfiles))
+	require.NoError(t, err)
+
+	transport := &tokenTransport{}
+	services, err := profiles.NewServices(WithHttpClientPtr(&http.Client{Transport: transport}))
+	require.NoError(t, err)
+	require.Len(t, services, 2)
+	defer func() {
+		for _, s := range services {
+			s.Client.Close()
+		}
+	}()
+
+	assert.Equal(t, "1001", services["acme"].Client.GetCustomerID())
+	assert.Equal(t, "1002", services["globex"].Client.GetCustomerID())
+	assert.ElementsMatch(t, []string{"acme.zsloginbeta.net", "globex.zslogin.net"}, transport.hosts)
+
+	broken, err := LoadProfiles(writeProfiles(t, "profiles:\n  empty:\n    client:\n      vanityDomain: x\n"))
+	require.NoError(t, err)
+	_, err = broken.NewServices(WithHttpClientPtr(&http.Client{Transport: transport}))
+	assert.ErrorContains(t, err, `profile "empty"`)
+}
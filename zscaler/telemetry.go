@@ -0,0 +1,76 @@
+package zscaler
+
+import (
+	"github.com/zscaler/zscaler-sdk-go/v3/internal/telemetry"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Attribute keys recorded on SDK spans and metrics.
+const (
+	AttrProduct          = telemetry.AttrProduct
+	AttrEndpointTemplate = telemetry.AttrEndpointTemplate
+	AttrMethod           = telemetry.AttrMethod
+	AttrStatusCode       = telemetry.AttrStatusCode
+	AttrAttempt          = telemetry.AttrAttempt
+	AttrRetryReason      = telemetry.AttrRetryReason
+	AttrWaitSource       = telemetry.AttrWaitSource
+	AttrCacheHit         = telemetry.AttrCacheHit
+)
+
+// WithTracerProvider enables OpenTelemetry tracing of OneAPI calls. One client
+// span is created per ExecuteRequest call and one child span per wire attempt.
+// The legacy ZIA and ZPA clients take the same option from their own package.
+func WithTracerProvider(tp trace.TracerProvider) ConfigSetter {
+	return func(c *Configuration) {
+		c.TracerProvider = tp
+		c.telemetry = newTelemetry(c)
+	}
+}
+
+// WithMeterProvider enables OpenTelemetry metrics for OneAPI calls: request
+// latency, rate-limit wait time, cache lookups and invalidations. The legacy
+// ZIA and ZPA clients take the same option from their own package.
+func WithMeterProvider(mp metric.MeterProvider) ConfigSetter {
+	return func(c *Configuration) {
+		c.MeterProvider = mp
+		c.telemetry = newTelemetry(c)
+	}
+}
+
+func newTelemetry(c *Configuration) *telemetry.Telemetry {
+	return telemetry.New(c.TracerProvider, c.MeterProvider, VERSION)
+}
+
+// getTelemetry returns the configured telemetry, or no-op telemetry for
+// configurations that were not built through NewConfiguration.
+func (c *Configuration) getTelemetry() *telemetry.Telemetry {
+	if c == nil || c.telemetry == nil {
+		return telemetry.Noop
+	}
+	return c.telemetry
+}
+
+// productName maps the detected service type to the product tag recorded on
+// spans and metrics. The ZIdentity admin API is reported as "zid".
+func productName(serviceType string) string {
+	switch serviceType {
+	case "admin":
+		return "zid"
+	case "":
+		return "unknown"
+	default:
+		return serviceType
+	}
+}
+
+// telemetryRequest identifies the call on spans and metrics.
+func (info *RequestInfo) telemetryRequest() telemetry.Request {
+	return telemetry.Request{
+		Product:   productName(info.ServiceType),
+		Method:    info.Method,
+		Endpoint:  info.Endpoint,
+		Attempt:   info.Attempt,
+		StartTime: info.StartTime,
+	}
+}
@@ -0,0 +1,175 @@
+package zscaler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// tokenRenewalLead is how long before expiry the background renewal runs.
+	tokenRenewalLead = time.Minute
+	// tokenRenewalMinBackoff and tokenRenewalMaxBackoff bound the jittered
+	// exponential backoff between failed renewal attempts.
+	tokenRenewalMinBackoff = 2 * time.Second
+	tokenRenewalMaxBackoff = 30 * time.Second
+	// tokenRenewalFailureBuffer is the capacity of the TokenRenewalFailures
+	// channel; failures are dropped rather than blocking renewal when it is full.
+	tokenRenewalFailureBuffer = 16
+)
+
+// TokenRenewalFailure reports a failed background token renewal attempt.
+type TokenRenewalFailure struct {
+	// Attempt is the number of consecutive failed attempts, starting at 1.
+	Attempt int
+	// Err is the error returned by the token endpoint or token store.
+	Err error
+	// TokenExpiry is the expiry of the token still in use. Once it has passed,
+	// API calls fail until a renewal succeeds.
+	TokenExpiry time.Time
+	// NextAttempt is how long the client waits before retrying.
+	NextAttempt time.Duration
+}
+
+// WithTokenRenewalContext sets the context that bounds background token
+// renewal. Cancelling it stops the renewal goroutine and aborts an in-flight
+// renewal; API calls still refresh the token on demand.
+func WithTokenRenewalContext(ctx context.Context) ConfigSetter {
+	return func(c *Configuration) {
+		c.TokenRenewalContext = ctx
+	}
+}
+
+// WithTokenRenewalFailureHandler registers fn to be called from the renewal
+// goroutine after every failed background renewal attempt, so long-running
+// processes can alert before the current token expires.
+func WithTokenRenewalFailureHandler(fn func(TokenRenewalFailure)) ConfigSetter {
+	return func(c *Configuration) {
+		c.TokenRenewalFailureHandler = fn
+	}
+}
+
+// TokenRenewalFailures returns a channel receiving every failed background
+// renewal attempt. Failures are dropped when the channel buffer is full.
+func (c *Client) TokenRenewalFailures() <-chan TokenRenewalFailure {
+	return c.renewalFailures
+}
+
+// tokenRenewalBackoff returns the jittered wait before renewal attempt
+// failures+1, doubling from tokenRenewalMinBackoff up to tokenRenewalMaxBackoff.
+func tokenRenewalBackoff(failures int) time.Duration {
+	wait := tokenRenewalMinBackoff
+	for i := 1; i < failures && wait < tokenRenewalMaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > tokenRenewalMaxBackoff {
+		wait = tokenRenewalMaxBackoff
+	}
+	return jitter(wait, retryJitterFraction)
+}
+
+// nextTokenRenewal returns how long to wait before renewing the current token.
+func (c *Client) nextTokenRenewal() time.Duration {
+	c.Lock()
+	defer c.Unlock()
+	tok := c.oauth2Credentials.Zscaler.Client.AuthToken
+	if tok == nil {
+		return tokenRenewalMinBackoff
+	}
+	wait := time.Until(tok.Expiry) - tokenRenewalLead
+	if wait < tokenRenewalMinBackoff {
+		wait = tokenRenewalMinBackoff
+	}
+	return wait
+}
+
+// renewToken replaces the client's token, reporting the expiry of the token
+// in use afterwards.
+func (c *Client) renewToken(ctx context.Context) (time.Time, error) {
+	c.Lock()
+	defer c.Unlock()
+	authToken, err := c.obtainToken(ctx, "")
+	if err == nil {
+		c.oauth2Credentials.Zscaler.Client.AuthToken = authToken
+	}
+	var expiry time.Time
+	if tok := c.oauth2Credentials.Zscaler.Client.AuthToken; tok != nil {
+		expiry = tok.Expiry
+	}
+	return expiry, err
+}
+
+// runTokenRenewal renews the token shortly before it expires until stop is
+// closed or ctx is done, retrying failed renewals with jittered backoff.
+func (c *Client) runTokenRenewal(ctx context.Context, stop <-chan bool) {
+	timer := time.NewTimer(c.nextTokenRenewal())
+	defer timer.Stop()
+
+	failures := 0
+	for {
+		select {
+		case <-timer.C:
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		}
+
+		expiry, err := c.renewToken(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			failures++
+			wait := tokenRenewalBackoff(failures)
+			c.oauth2Credentials.Logger.Printf("[ERROR] Failed to renew OAuth2 token (attempt %d), retrying in %s: %v", failures, wait, err)
+			c.reportRenewalFailure(TokenRenewalFailure{Attempt: failures, Err: err, TokenExpiry: expiry, NextAttempt: wait})
+			timer.Reset(wait)
+			continue
+		}
+
+		failures = 0
+		c.oauth2Credentials.Logger.Printf("[INFO] OAuth2 token successfully renewed")
+		timer.Reset(c.nextTokenRenewal())
+	}
+}
+
+func (c *Client) reportRenewalFailure(failure TokenRenewalFailure) {
+	if fn := c.oauth2Credentials.TokenRenewalFailureHandler; fn != nil {
+		fn(failure)
+	}
+	select {
+	case c.renewalFailures <- failure:
+	default:
+	}
+}
+
+// refreshRejectedToken replaces the token rejected by the API. If another
+// goroutine already replaced it, the current token is kept; the rejected token
+// is never reused, even if it is still in the TokenStore.
+func (c *Client) refreshRejectedToken(ctx context.Context, rejected string) error {
+	c.Lock() // Prevent concurrent token refresh
+	defer c.Unlock()
+	if tok := c.oauth2Credentials.Zscaler.Client.AuthToken; tok != nil && tok.AccessToken != rejected && c.authValid() {
+		return nil
+	}
+	authToken, err := c.obtainToken(ctx, rejected)
+	if err != nil {
+		return err
+	}
+	c.oauth2Credentials.Zscaler.Client.AuthToken = authToken
+	return nil
+}
+
+// bearerToken returns the access token sent in req's Authorization header.
+func bearerToken(req *http.Request) string {
+	return strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+}
+
+// usesContextAccessToken reports whether the caller supplied its own token
+// through ContextAccessToken, which the client must not replace.
+func usesContextAccessToken(ctx context.Context) bool {
+	token, ok := ctx.Value(ContextAccessToken).(string)
+	return ok && token != ""
+}
@@ -0,0 +1,98 @@
+package zscaler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zscaler/zscaler-sdk-go/v3/logger"
+)
+
+func TestTokenRenewalBackoff(t *testing.T) {
+	lo := func(d time.Duration) time.Duration { return time.Duration(float64(d) * (1 - retryJitterFraction)) }
+	hi := func(d time.Duration) time.Duration { return time.Duration(float64(d) * (1 + retryJitterFraction)) }
+
+	for failures, want := range map[int]time.Duration{
+		1:  tokenRenewalMinBackoff,
+		2:  2 * tokenRenewalMinBackoff,
+		3:  4 * tokenRenewalMinBackoff,
+		10: tokenRenewalMaxBackoff,
+	} {
+		got := tokenRenewalBackoff(failures)
+		assert.GreaterOrEqual(t, got, lo(want), "failures=%d", failures)
+		assert.LessOrEqual(t, got, hi(want), "failures=%d", failures)
+	}
+}
+
+func TestTokenRenewalReportsFailuresAndStopsOnCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	handled := make(chan TokenRenewalFailure, 4)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := &Configuration{Logger: logger.NewNopLogger(), Context: context.Background()}
+	cfg.Zscaler.Client.ClientID = "client-id"
+	cfg.Zscaler.Client.ClientSecret = "client-secret"
+	cfg.Zscaler.Client.AuthToken = &AuthToken{AccessToken: "current", Expiry: time.Now().Add(tokenRenewalLead)}
+	cfg.HTTPClient = &http.Client{Transport: rewriteHostTransport{host: serverURL.Host}}
+	WithTokenRenewalContext(ctx)(cfg)
+	WithTokenRenewalFailureHandler(func(f TokenRenewalFailure) { handled <- f })(cfg)
+
+	c := &Client{
+		oauth2Credentials: cfg,
+		stopTicker:        make(chan bool),
+		renewalFailures:   make(chan TokenRenewalFailure, tokenRenewalFailureBuffer),
+	}
+	done := make(chan struct{})
+	go func() {
+		c.runTokenRenewal(ctx, c.stopTicker)
+		close(done)
+	}()
+
+	select {
+	case f := <-c.TokenRenewalFailures():
+		assert.Equal(t, 1, f.Attempt)
+		assert.Error(t, f.Err)
+		assert.Equal(t, cfg.Zscaler.Client.AuthToken.Expiry, f.TokenExpiry)
+		assert.Greater(t, f.NextAttempt, time.Duration(0))
+	case <-time.After(10 * time.Second):
+		t.Fatal("renewal failure was not reported on the channel")
+	}
+	select {
+	case f := <-handled:
+		assert.Equal(t, 1, f.Attempt)
+	case <-time.After(time.Second):
+		t.Fatal("renewal failure handler was not called")
+	}
+	assert.Equal(t, "current", cfg.Zscaler.Client.AuthToken.AccessToken)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("renewal goroutine did not stop after the context was cancelled")
+	}
+}
+
+// rewriteHostTransport sends every request to host over plain HTTP.
+type rewriteHostTransport struct {
+	host string
+}
+
+func (t rewriteHostTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = "http"
+	req.URL.Host = t.host
+	return http.DefaultTransport.RoundTrip(req)
+}
@@ -0,0 +1,127 @@
+package zscaler
+
+import (
+	"context"
+	"time"
+
+	"github.com/zscaler/zscaler-sdk-go/v3/tokenstore"
+)
+
+// Token sources reported in TokenRefreshEvent.Source.
+const (
+	TokenSourceStore        = "store"
+	TokenSourceAuthenticate = "authenticate"
+)
+
+// storedTokenMinValidity is how long a stored token must remain valid to be
+// reused. It exceeds the renewal lead time of startTokenRenewalTicker so that a
+// client renewing its token does not pick up the one it is replacing.
+const storedTokenMinValidity = 2 * time.Minute
+
+// TokenRefreshEvent describes a token acquisition by the OneAPI client.
+type TokenRefreshEvent struct {
+	// Source is TokenSourceStore when a token was reused from the TokenStore and
+	// TokenSourceAuthenticate when a new token was requested from Zidentity.
+	Source string
+	// Expiry is the expiry of the acquired token; zero when Err is set.
+	Expiry time.Time
+	// Err is set when the token could not be acquired.
+	Err error
+}
+
+// WithTokenStore makes the OneAPI client load and save its OAuth2 token in
+// store, so that clients and processes sharing the store reuse a valid token
+// instead of re-authenticating. Tokens are keyed by identity provider URL and
+// client ID.
+func WithTokenStore(store tokenstore.TokenStore) ConfigSetter {
+	return func(c *Configuration) {
+		c.TokenStore = store
+	}
+}
+
+// WithTokenRefreshHook registers fn to be called every time the OneAPI client
+// acquires a token, whether reused from the TokenStore or newly requested. fn
+// is called while the client holds its token lock and must not call back into
+// the client.
+func WithTokenRefreshHook(fn func(TokenRefreshEvent)) ConfigSetter {
+	return func(c *Configuration) {
+		c.TokenRefreshHook = fn
+	}
+}
+
+func (c *Client) tokenStoreKey() string {
+	creds := c.oauth2Credentials.Zscaler.Client
+	return tokenstore.Key(getAuthURL(creds.VanityDomain, creds.Cloud), creds.ClientID)
+}
+
+// obtainToken returns a token for the client, reusing one from the TokenStore
+// when possible. rejected is an access token the API refused; it is never
+// reused. Callers must hold the client lock.
+func (c *Client) obtainToken(ctx context.Context, rejected string) (*AuthToken, error) {
+	cfg := c.oauth2Credentials
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	store := cfg.TokenStore
+	if store == nil {
+		return c.requestToken(ctx)
+	}
+
+	key := c.tokenStoreKey()
+	if tok := c.loadStoredToken(ctx, key, rejected); tok != nil {
+		return tok, nil
+	}
+
+	// Serialise refreshes so only one client sharing the store authenticates;
+	// the others pick up its token once the lock is released.
+	unlock, err := store.Lock(ctx, key)
+	if err != nil {
+		cfg.Logger.Printf("[WARN] Failed to lock token store, authenticating without it: %v", err)
+		return c.requestToken(ctx)
+	}
+	defer unlock()
+
+	if tok := c.loadStoredToken(ctx, key, rejected); tok != nil {
+		return tok, nil
+	}
+	authToken, err := c.requestToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stored := &tokenstore.Token{TokenType: authToken.TokenType, AccessToken: authToken.AccessToken, Expiry: authToken.Expiry}
+	if err := store.Save(ctx, key, stored); err != nil {
+		cfg.Logger.Printf("[WARN] Failed to save OAuth2 token to token store: %v", err)
+	}
+	return authToken, nil
+}
+
+func (c *Client) loadStoredToken(ctx context.Context, key, rejected string) *AuthToken {
+	cfg := c.oauth2Credentials
+	tok, err := cfg.TokenStore.Load(ctx, key)
+	if err != nil {
+		cfg.Logger.Printf("[WARN] Failed to load OAuth2 token from token store: %v", err)
+		return nil
+	}
+	if !tok.Valid(storedTokenMinValidity) || tok.AccessToken == rejected {
+		return nil
+	}
+	cfg.Logger.Printf("[DEBUG] Reusing OAuth2 token from token store, expires at %s", tok.Expiry.Format(time.RFC3339))
+	c.notifyTokenRefresh(TokenRefreshEvent{Source: TokenSourceStore, Expiry: tok.Expiry})
+	return &AuthToken{TokenType: tok.TokenType, AccessToken: tok.AccessToken, Expiry: tok.Expiry}
+}
+
+func (c *Client) requestToken(ctx context.Context) (*AuthToken, error) {
+	authToken, err := Authenticate(ctx, c.oauth2Credentials, c.oauth2Credentials.Logger)
+	if err != nil {
+		c.notifyTokenRefresh(TokenRefreshEvent{Source: TokenSourceAuthenticate, Err: err})
+		return nil, err
+	}
+	c.notifyTokenRefresh(TokenRefreshEvent{Source: TokenSourceAuthenticate, Expiry: authToken.Expiry})
+	return authToken, nil
+}
+
+func (c *Client) notifyTokenRefresh(event TokenRefreshEvent) {
+	if hook := c.oauth2Credentials.TokenRefreshHook; hook != nil {
+		hook(event)
+	}
+}
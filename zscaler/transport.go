@@ -0,0 +1,285 @@
+package zscaler
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// TransportConfig holds the TLS and connection pool settings of the HTTP
+// transports of the OneAPI client. Zero values keep the defaults.
+type TransportConfig struct {
+	// CACertFile and CACert (PEM encoded) add root certificates to the
+	// system pool, e.g. the CA of a TLS intercepting proxy.
+	CACertFile string `yaml:"caCertFile" envconfig:"ZSCALER_CLIENT_CA_CERT_FILE"`
+	CACert     string `yaml:"caCert" envconfig:"ZSCALER_CLIENT_CA_CERT"`
+	// ClientCertFile and ClientKeyFile, or ClientCert and ClientKey (PEM
+	// encoded), hold the client certificate presented for mutual TLS.
+	ClientCertFile string `yaml:"clientCertFile" envconfig:"ZSCALER_CLIENT_TLS_CERT_FILE"`
+	ClientKeyFile  string `yaml:"clientKeyFile" envconfig:"ZSCALER_CLIENT_TLS_KEY_FILE"`
+	ClientCert     string `yaml:"clientCert" envconfig:"ZSCALER_CLIENT_TLS_CERT"`
+	ClientKey      string `yaml:"clientKey" envconfig:"ZSCALER_CLIENT_TLS_KEY"`
+	// TLSMinVersion is "1.2" or "1.3" (older versions are accepted but not
+	// recommended).
+	TLSMinVersion string `yaml:"tlsMinVersion" envconfig:"ZSCALER_CLIENT_TLS_MIN_VERSION"`
+	// DisableHTTP2 restricts connections to HTTP/1.1. It is a pointer so that
+	// a product can enable HTTP/2 again when it is disabled globally.
+	DisableHTTP2        *bool         `yaml:"disableHttp2" envconfig:"ZSCALER_CLIENT_DISABLE_HTTP2"`
+	MaxIdleConns        int           `yaml:"maxIdleConns" envconfig:"ZSCALER_CLIENT_MAX_IDLE_CONNS"`
+	MaxIdleConnsPerHost int           `yaml:"maxIdleConnsPerHost" envconfig:"ZSCALER_CLIENT_MAX_IDLE_CONNS_PER_HOST"`
+	MaxConnsPerHost     int           `yaml:"maxConnsPerHost" envconfig:"ZSCALER_CLIENT_MAX_CONNS_PER_HOST"`
+	IdleConnTimeout     time.Duration `yaml:"idleConnTimeout" envconfig:"ZSCALER_CLIENT_IDLE_CONN_TIMEOUT"`
+}
+
+// merge returns c with the fields set in o replaced.
+func (c TransportConfig) merge(o TransportConfig) TransportConfig {
+	if o.CACertFile != "" || o.CACert != "" {
+		c.CACertFile, c.CACert = o.CACertFile, o.CACert
+	}
+	if o.ClientCertFile != "" || o.ClientCert != "" {
+		c.ClientCertFile, c.ClientKeyFile = o.ClientCertFile, o.ClientKeyFile
+		c.ClientCert, c.ClientKey = o.ClientCert, o.ClientKey
+	}
+	if o.TLSMinVersion != "" {
+		c.TLSMinVersion = o.TLSMinVersion
+	}
+	if o.DisableHTTP2 != nil {
+		c.DisableHTTP2 = o.DisableHTTP2
+	}
+	if o.MaxIdleConns != 0 {
+		c.MaxIdleConns = o.MaxIdleConns
+	}
+	if o.MaxIdleConnsPerHost != 0 {
+		c.MaxIdleConnsPerHost = o.MaxIdleConnsPerHost
+	}
+	if o.MaxConnsPerHost != 0 {
+		c.MaxConnsPerHost = o.MaxConnsPerHost
+	}
+	if o.IdleConnTimeout != 0 {
+		c.IdleConnTimeout = o.IdleConnTimeout
+	}
+	return c
+}
+
+// transportConfig returns the transport settings of product ("zia", "zpa",
+// "ztw", "zcc" or "zdx"), or the global ones for an empty product.
+func (cfg *Configuration) transportConfig(product string) TransportConfig {
+	tc := cfg.Zscaler.Client.Transport
+	if product != "" {
+		if o, ok := cfg.Zscaler.Client.ProductTransport[product]; ok {
+			tc = tc.merge(o)
+		}
+	}
+	return tc
+}
+
+// newTransport builds the transport of product. Invalid TLS settings are
+// recorded in transportErr, which NewConfiguration returns, and left out.
+func (cfg *Configuration) newTransport(product string, proxy func(*http.Request) (*url.URL, error)) *http.Transport {
+	tc := cfg.transportConfig(product)
+	transport := &http.Transport{
+		Proxy:               proxy,
+		MaxIdleConns:        tc.MaxIdleConns,
+		MaxIdleConnsPerHost: maxIdleConnections,
+		MaxConnsPerHost:     tc.MaxConnsPerHost,
+		IdleConnTimeout:     tc.IdleConnTimeout,
+		ForceAttemptHTTP2:   true,
+	}
+	if tc.MaxIdleConnsPerHost != 0 {
+		transport.MaxIdleConnsPerHost = tc.MaxIdleConnsPerHost
+	}
+	if tc.DisableHTTP2 != nil && *tc.DisableHTTP2 {
+		transport.Protocols = new(http.Protocols)
+		transport.Protocols.SetHTTP1(true)
+	}
+
+	tlsConfig, err := tc.tlsConfig()
+	if err != nil {
+		if product != "" {
+			err = fmt.Errorf("%s: %w", product, err)
+		}
+		if cfg.transportErr == nil {
+			cfg.transportErr = fmt.Errorf("zscaler: transport: %w", err)
+		}
+		tlsConfig = &tls.Config{}
+	}
+	if cfg.Zscaler.Testing.DisableHttpsCheck {
+		tlsConfig.InsecureSkipVerify = true // This disables HTTPS certificate validation
+	}
+	transport.TLSClientConfig = tlsConfig
+	return transport
+}
+
+func (c TransportConfig) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+	if c.TLSMinVersion != "" {
+		version, err := parseTLSVersion(c.TLSMinVersion)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.MinVersion = version
+	}
+
+	if c.CACertFile != "" || c.CACert != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		pemCerts := []byte(c.CACert)
+		if c.CACertFile != "" {
+			if pemCerts, err = os.ReadFile(c.CACertFile); err != nil {
+				return nil, fmt.Errorf("reading CA certificates: %w", err)
+			}
+		}
+		if !pool.AppendCertsFromPEM(pemCerts) {
+			return nil, errors.New("no CA certificates found in PEM data")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	var cert tls.Certificate
+	var err error
+	switch {
+	case c.ClientCertFile != "" || c.ClientKeyFile != "":
+		cert, err = tls.LoadX509KeyPair(c.ClientCertFile, c.ClientKeyFile)
+	case c.ClientCert != "" || c.ClientKey != "":
+		cert, err = tls.X509KeyPair([]byte(c.ClientCert), []byte(c.ClientKey))
+	default:
+		return tlsConfig, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading client certificate: %w", err)
+	}
+	tlsConfig.Certificates = []tls.Certificate{cert}
+	return tlsConfig, nil
+}
+
+func parseTLSVersion(v string) (uint16, error) {
+	switch strings.TrimSpace(strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(v)), "TLS")) {
+	case "1.0":
+		return tls.VersionTLS10, nil
+	case "1.1":
+		return tls.VersionTLS11, nil
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	}
+	return 0, fmt.Errorf("unsupported TLS version %q", v)
+}
+
+// WithCACertFile trusts the PEM encoded root certificates in path in
+// addition to the system pool, e.g. the CA of a TLS intercepting proxy.
+func WithCACertFile(path string) ConfigSetter {
+	return func(c *Configuration) {
+		c.Zscaler.Client.Transport.CACertFile, c.Zscaler.Client.Transport.CACert = path, ""
+		setHttpClients(c)
+	}
+}
+
+// WithCACertPEM trusts the PEM encoded root certificates in addition to the
+// system pool.
+func WithCACertPEM(pemCerts []byte) ConfigSetter {
+	return func(c *Configuration) {
+		c.Zscaler.Client.Transport.CACertFile, c.Zscaler.Client.Transport.CACert = "", string(pemCerts)
+		setHttpClients(c)
+	}
+}
+
+// WithClientCertificateFiles presents the certificate and key in the PEM
+// files certFile and keyFile for mutual TLS.
+func WithClientCertificateFiles(certFile, keyFile string) ConfigSetter {
+	return func(c *Configuration) {
+		t := &c.Zscaler.Client.Transport
+		t.ClientCertFile, t.ClientKeyFile, t.ClientCert, t.ClientKey = certFile, keyFile, "", ""
+		setHttpClients(c)
+	}
+}
+
+// WithClientCertificate presents the PEM encoded certificate and key for
+// mutual TLS.
+func WithClientCertificate(certPEM, keyPEM []byte) ConfigSetter {
+	return func(c *Configuration) {
+		t := &c.Zscaler.Client.Transport
+		t.ClientCertFile, t.ClientKeyFile, t.ClientCert, t.ClientKey = "", "", string(certPEM), string(keyPEM)
+		setHttpClients(c)
+	}
+}
+
+// WithTLSMinVersion sets the minimum TLS version, e.g. tls.VersionTLS13.
+func WithTLSMinVersion(version uint16) ConfigSetter {
+	return func(c *Configuration) {
+		c.Zscaler.Client.Transport.TLSMinVersion = tls.VersionName(version)
+		setHttpClients(c)
+	}
+}
+
+// WithDisableHTTP2 restricts connections to HTTP/1.1, for proxies that do
+// not handle HTTP/2.
+func WithDisableHTTP2(disable bool) ConfigSetter {
+	return func(c *Configuration) {
+		c.Zscaler.Client.Transport.DisableHTTP2 = &disable
+		setHttpClients(c)
+	}
+}
+
+// WithMaxIdleConns limits the idle connections kept across all hosts. Zero
+// means no limit.
+func WithMaxIdleConns(n int) ConfigSetter {
+	return func(c *Configuration) {
+		c.Zscaler.Client.Transport.MaxIdleConns = n
+		setHttpClients(c)
+	}
+}
+
+// WithMaxIdleConnsPerHost limits the idle connections kept per host. It
+// defaults to 40.
+func WithMaxIdleConnsPerHost(n int) ConfigSetter {
+	return func(c *Configuration) {
+		c.Zscaler.Client.Transport.MaxIdleConnsPerHost = n
+		setHttpClients(c)
+	}
+}
+
+// WithMaxConnsPerHost limits the connections per host, including those in
+// use. Zero means no limit.
+func WithMaxConnsPerHost(n int) ConfigSetter {
+	return func(c *Configuration) {
+		c.Zscaler.Client.Transport.MaxConnsPerHost = n
+		setHttpClients(c)
+	}
+}
+
+// WithIdleConnTimeout closes connections that stay idle longer than d. Zero
+// means no limit.
+func WithIdleConnTimeout(d time.Duration) ConfigSetter {
+	return func(c *Configuration) {
+		c.Zscaler.Client.Transport.IdleConnTimeout = d
+		setHttpClients(c)
+	}
+}
+
+// WithProductTransport overrides the transport settings of one product,
+// "zia", "zpa", "ztw", "zcc" or "zdx". Fields set in tc replace the global
+// settings; the others are inherited.
+//
+//	disable := true
+//	zscaler.WithProductTransport("zpa", zscaler.TransportConfig{
+//		DisableHTTP2:    &disable,
+//		MaxConnsPerHost: 10,
+//	})
+func WithProductTransport(product string, tc TransportConfig) ConfigSetter {
+	return func(c *Configuration) {
+		if c.Zscaler.Client.ProductTransport == nil {
+			c.Zscaler.Client.ProductTransport = make(map[string]TransportConfig)
+		}
+		c.Zscaler.Client.ProductTransport[strings.ToLower(product)] = tc
+		setHttpClients(c)
+	}
+}
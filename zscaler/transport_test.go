@@ -0,0 +1,161 @@
+package zscaler
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+func newTestCert(t *testing.T, template *x509.Certificate, parent *testCert) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func TestTransportMutualTLS(t *testing.T) {
+	ca := newTestCert(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "Corporate Root CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil)
+	server := newTestCert(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca)
+	client := newTestCert(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "sdk-client"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca)
+
+	serverCert, err := tls.X509KeyPair(server.certPEM, server.keyPEM)
+	require.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	ts.TLS = &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+	ts.StartTLS()
+	defer ts.Close()
+
+	cfg, err := NewConfiguration(WithCache(false), WithRateLimitMaxRetries(1))
+	require.NoError(t, err)
+	_, err = cfg.HTTPClient.Get(ts.URL)
+	assert.Error(t, err, "the server certificate is not trusted by default")
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, ca.certPEM, 0o600))
+	cfg, err = NewConfiguration(
+		WithCache(false),
+		WithCACertFile(caFile),
+		WithClientCertificate(client.certPEM, client.keyPEM),
+		WithTLSMinVersion(tls.VersionTLS13),
+	)
+	require.NoError(t, err)
+	resp, err := cfg.ZPAHTTPClient.Get(ts.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, uint16(tls.VersionTLS13), resp.TLS.Version)
+}
+
+func TestTransportProductOverrides(t *testing.T) {
+	var client struct {
+		Transport        TransportConfig            `yaml:"transport"`
+		ProductTransport map[string]TransportConfig `yaml:"productTransport"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(`
+transport:
+  tlsMinVersion: "1.2"
+  disableHttp2: true
+  maxIdleConnsPerHost: 8
+  idleConnTimeout: 90s
+productTransport:
+  zpa:
+    disableHttp2: false
+    maxConnsPerHost: 4
+`), &client))
+
+	cfg, err := NewConfiguration(func(c *Configuration) {
+		c.Zscaler.Client.Transport = client.Transport
+		c.Zscaler.Client.ProductTransport = client.ProductTransport
+	}, WithCache(false))
+	require.NoError(t, err)
+
+	zia := cfg.newTransport("zia", nil)
+	assert.Equal(t, uint16(tls.VersionTLS12), zia.TLSClientConfig.MinVersion)
+	assert.Equal(t, 8, zia.MaxIdleConnsPerHost)
+	assert.Equal(t, 90*time.Second, zia.IdleConnTimeout)
+	require.NotNil(t, zia.Protocols)
+	assert.False(t, zia.Protocols.HTTP2())
+
+	zpa := cfg.newTransport("zpa", nil)
+	assert.Nil(t, zpa.Protocols, "HTTP/2 is enabled again for ZPA")
+	assert.Equal(t, 4, zpa.MaxConnsPerHost)
+	assert.Equal(t, 8, zpa.MaxIdleConnsPerHost, "inherited from the global settings")
+
+	assert.Equal(t, maxIdleConnections, (&Configuration{}).newTransport("", nil).MaxIdleConnsPerHost)
+}
+
+func TestTransportErrors(t *testing.T) {
+	_, err := NewConfiguration(WithCache(false), WithCACertFile(filepath.Join(t.TempDir(), "missing.pem")))
+	assert.ErrorContains(t, err, "reading CA certificates")
+
+	_, err = NewConfiguration(WithCache(false), WithCACertPEM([]byte("not a certificate")))
+	assert.ErrorContains(t, err, "no CA certificates")
+
+	_, err = NewConfiguration(WithCache(false), WithProductTransport("ZDX", TransportConfig{TLSMinVersion: "2.0"}))
+	assert.ErrorContains(t, err, `zdx: unsupported TLS version "2.0"`)
+
+	_, err = NewConfiguration(WithCache(false), WithClientCertificate([]byte("cert"), []byte("key")))
+	assert.ErrorContains(t, err, "loading client certificate")
+}
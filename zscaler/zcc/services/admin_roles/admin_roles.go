@@ -2,6 +2,7 @@ package admin_roles
 
 import (
 	"context"
+	"iter"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zcc/services/common"
@@ -59,3 +60,9 @@ func GetAdminRoles(ctx context.Context, service *zscaler.Service, pageSize ...in
 	}
 	return common.ReadAllPages[AdminRole](ctx, service.Client, adminRolesEndpoint, common.QueryParams{}, effectivePageSize)
 }
+
+// AllAdminRoles is like GetAdminRoles, but returns an iterator that fetches
+// one page at a time. Use WithPageSize to set the page size.
+func AllAdminRoles(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[AdminRole, error] {
+	return common.AllPages[AdminRole](ctx, service.Client, adminRolesEndpoint, common.QueryParams{}, opts...)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
@@ -99,6 +100,15 @@ func GetAdminUsers(ctx context.Context, service *zscaler.Service, userType strin
 	return common.ReadAllPages[AdminUser](ctx, service.Client, getAdminUserEndpoint, params, effectivePageSize)
 }
 
+// AllAdminUsers is like GetAdminUsers, but returns an iterator that fetches
+// one page at a time. Use WithPageSize to set the page size.
+func AllAdminUsers(ctx context.Context, service *zscaler.Service, userType string, opts ...zscaler.PageOption) iter.Seq2[AdminUser, error] {
+	params := common.QueryParams{
+		UserType: userType,
+	}
+	return common.AllPages[AdminUser](ctx, service.Client, getAdminUserEndpoint, params, opts...)
+}
+
 func UpdateAdminUser(ctx context.Context, service *zscaler.Service, adminUser *AdminUser) (*AdminUser, error) {
 	if adminUser == nil {
 		return nil, errors.New("adminUser is required")
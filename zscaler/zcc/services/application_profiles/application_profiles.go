@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zcc/services/common"
 )
 
@@ -289,7 +290,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, name string) (*App
 		}
 		page++
 	}
-	return nil, nil, fmt.Errorf("no application profile found with name: %s", name)
+	return nil, nil, errorx.NotFoundf("no application profile found with name: %s", name)
 }
 
 // PatchApplicationProfile performs a partial update on an application profile.
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"strconv"
 	"strings"
 
@@ -282,6 +283,9 @@ type PaginatedResponseV2[T any] struct {
 //  2. last-page heuristic  — count < limit (server returned a short page)
 //  3. empty-page safety    — count == 0 or items is empty
 //
+// When the context opts in with zscaler.ContextWithPageConcurrency, pages
+// after the first are fetched in parallel using the first page's total.
+//
 // perPage is clamped to [DefaultPageSize, MaxPageSize]. JMESPath filtering
 // from context is applied after aggregation, matching the v1 helper.
 //
@@ -303,6 +307,26 @@ func ReadAllPagesV2[T any](ctx context.Context, client *zscaler.Client, endpoint
 
 		allResults = append(allResults, page.Items...)
 
+		// With the total known from the first page, fetch the rest in
+		// parallel when the caller opted in.
+		if n := zscaler.PageConcurrencyFromContext(ctx); n > 1 && params.Skip == 0 && page.Total > len(page.Items) && len(page.Items) == perPage {
+			lastPage := (page.Total - 1) / perPage
+			rest, err := zscaler.FetchPages(ctx, 1, lastPage, n, func(ctx context.Context, i int) ([]T, error) {
+				p := params
+				p.Skip = i * perPage
+				var page PaginatedResponseV2[T]
+				if _, err := client.NewZccRequestDo(ctx, "GET", endpoint, p, nil, &page); err != nil {
+					return nil, err
+				}
+				return page.Items, nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			allResults = append(allResults, rest...)
+			break
+		}
+
 		// Stop on any termination signal. Each guard is independent so a
 		// missing field (e.g. Total == 0) never traps us in a loop.
 		if page.Count == 0 || len(page.Items) == 0 {
@@ -343,3 +367,57 @@ func ReadPageV2[T any](ctx context.Context, client *zscaler.Client, endpoint str
 	}
 	return page, nil
 }
+
+// AllPages returns an iterator over every record of a paginated ZCC v1 GET
+// endpoint, fetching one page at a time instead of aggregating like
+// ReadAllPages. Page tokens are page numbers; params.Page and params.PageSize
+// are set by the iterator.
+func AllPages[T any](ctx context.Context, client *zscaler.Client, endpoint string, params QueryParams, opts ...zscaler.PageOption) iter.Seq2[T, error] {
+	params.PageSize = clampPageSize(zscaler.NewPageOptions(opts...).PageSize)
+	fetch := func(ctx context.Context, token zscaler.PageToken) (zscaler.Page[T], error) {
+		page, err := token.Int(1)
+		if err != nil {
+			return zscaler.Page[T]{}, err
+		}
+		p := params
+		p.Page = page
+		var pageResults []T
+		if _, err := client.NewZccRequestDo(ctx, "GET", endpoint, p, nil, &pageResults); err != nil {
+			return zscaler.Page[T]{}, err
+		}
+		result := zscaler.Page[T]{Items: pageResults}
+		if len(pageResults) >= p.PageSize {
+			result.Next = zscaler.PageNumberToken(page + 1)
+		}
+		return result, nil
+	}
+	return zscaler.Paginate(ctx, fetch, opts...)
+}
+
+// AllPagesV2 returns an iterator over every item of a paginated ZCC v2 GET
+// endpoint, stopping on the same signals as ReadAllPagesV2. Page tokens are
+// skip offsets; params.Skip and params.PerPage are set by the iterator.
+func AllPagesV2[T any](ctx context.Context, client *zscaler.Client, endpoint string, params QueryParamsV2, opts ...zscaler.PageOption) iter.Seq2[T, error] {
+	params.PerPage = clampPageSize(zscaler.NewPageOptions(opts...).PageSize)
+	fetch := func(ctx context.Context, token zscaler.PageToken) (zscaler.Page[T], error) {
+		skip, err := token.Int(0)
+		if err != nil {
+			return zscaler.Page[T]{}, err
+		}
+		p := params
+		p.Skip = skip
+		var page PaginatedResponseV2[T]
+		if _, err := client.NewZccRequestDo(ctx, "GET", endpoint, p, nil, &page); err != nil {
+			return zscaler.Page[T]{}, err
+		}
+		result := zscaler.Page[T]{Items: page.Items, Total: page.Total}
+		done := page.Count == 0 || len(page.Items) == 0 ||
+			(page.Limit > 0 && page.Count < page.Limit) ||
+			(page.Total > 0 && skip+len(page.Items) >= page.Total)
+		if !done {
+			result.Next = zscaler.PageNumberToken(skip + p.PerPage)
+		}
+		return result, nil
+	}
+	return zscaler.Paginate(ctx, fetch, opts...)
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zcc/services/common"
 )
 
@@ -92,5 +93,5 @@ func GetByName(ctx context.Context, service *zscaler.Service, name string) (*Cus
 		}
 		page++
 	}
-	return nil, nil, fmt.Errorf("no custom IP-based app found with name: %s", name)
+	return nil, nil, errorx.NotFoundf("no custom IP-based app found with name: %s", name)
 }
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 
@@ -95,6 +96,16 @@ func GetAll(ctx context.Context, service *zscaler.Service, username, osType stri
 	return common.ReadAllPages[GetDevices](ctx, service.Client, getDevicesEndpoint, params, 1000)
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, username, osType string, opts ...zscaler.PageOption) iter.Seq2[GetDevices, error] {
+	params := common.QueryParams{
+		Username: username,
+		OsType:   osType,
+	}
+	opts = append([]zscaler.PageOption{zscaler.WithPageSize(1000)}, opts...)
+	return common.AllPages[GetDevices](ctx, service.Client, getDevicesEndpoint, params, opts...)
+}
+
 func GetDeviceCleanupInfo(ctx context.Context, service *zscaler.Service) (*DeviceCleanupInfo, error) {
 	// Make the GET request
 	resp, err := service.Client.NewZccRequestDo(ctx, "GET", getDeviceCleanupEndpoint, nil, nil, nil)
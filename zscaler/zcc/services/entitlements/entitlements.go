@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
@@ -69,6 +70,16 @@ func GetZdxGroupEntitlements(ctx context.Context, service *zscaler.Service, sear
 	return common.ReadAllPages[ZdxGroupEntitlements](ctx, service.Client, getZdxGroupEndpoint, params, pageSize)
 }
 
+// AllZdxGroupEntitlements is like GetZdxGroupEntitlements, but returns an
+// iterator that fetches one page at a time. Use WithPageSize to set the page
+// size.
+func AllZdxGroupEntitlements(ctx context.Context, service *zscaler.Service, search string, opts ...zscaler.PageOption) iter.Seq2[ZdxGroupEntitlements, error] {
+	params := common.QueryParams{
+		Search: search,
+	}
+	return common.AllPages[ZdxGroupEntitlements](ctx, service.Client, getZdxGroupEndpoint, params, opts...)
+}
+
 func UpdateZdxGroupEntitlements(ctx context.Context, service *zscaler.Service, updateZdxGroup *ZdxGroupEntitlements) (*ZdxGroupEntitlements, error) {
 	if updateZdxGroup == nil {
 		return nil, errors.New("updateZdxGroup is required")
@@ -109,6 +120,16 @@ func GetZpaGroupEntitlements(ctx context.Context, service *zscaler.Service, sear
 	return common.ReadAllPages[ZpaGroupEntitlements](ctx, service.Client, getZpaGroupEndpoint, params, pageSize)
 }
 
+// AllZpaGroupEntitlements is like GetZpaGroupEntitlements, but returns an
+// iterator that fetches one page at a time. Use WithPageSize to set the page
+// size.
+func AllZpaGroupEntitlements(ctx context.Context, service *zscaler.Service, search string, opts ...zscaler.PageOption) iter.Seq2[ZpaGroupEntitlements, error] {
+	params := common.QueryParams{
+		Search: search,
+	}
+	return common.AllPages[ZpaGroupEntitlements](ctx, service.Client, getZpaGroupEndpoint, params, opts...)
+}
+
 func UpdateZpaGroupEntitlements(ctx context.Context, service *zscaler.Service, updateZpaGroup *ZpaGroupEntitlements) (*ZpaGroupEntitlements, error) {
 	if updateZpaGroup == nil {
 		return nil, errors.New("updateZpaGroup is required")
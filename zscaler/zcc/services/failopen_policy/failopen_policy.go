@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zcc/services/common"
 )
 
@@ -44,6 +46,13 @@ func GetFailOpenPolicy(ctx context.Context, service *zscaler.Service, pageSize i
 	return policies, nil
 }
 
+// AllFailOpenPolicies is like GetFailOpenPolicy, but returns an iterator that
+// fetches one page at a time. Use WithPageSize to set the page size.
+func AllFailOpenPolicies(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[WebFailOpenPolicy, error] {
+	endpoint := fmt.Sprintf("%s/listByCompany", baseFailOpenPolicy)
+	return common.AllPages[WebFailOpenPolicy](ctx, service.Client, endpoint, common.QueryParams{}, opts...)
+}
+
 func GetFailOpenPolicyByID(ctx context.Context, service *zscaler.Service, id string) (*WebFailOpenPolicy, error) {
 	policies, err := GetFailOpenPolicy(ctx, service, 1000)
 	if err != nil {
@@ -54,7 +63,7 @@ func GetFailOpenPolicyByID(ctx context.Context, service *zscaler.Service, id str
 			return &policies[i], nil
 		}
 	}
-	return nil, fmt.Errorf("fail open policy with ID %q not found", id)
+	return nil, errorx.NotFoundf("fail open policy with ID %q not found", id)
 }
 
 func UpdateFailOpenPolicy(ctx context.Context, service *zscaler.Service, openPolicy *WebFailOpenPolicy) (*WebFailOpenPolicy, error) {
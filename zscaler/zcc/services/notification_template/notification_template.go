@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zcc/services/common"
 )
 
@@ -80,7 +82,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, templateName strin
 			return &template, nil
 		}
 	}
-	return nil, fmt.Errorf("no notification template found with name: %s", templateName)
+	return nil, errorx.NotFoundf("no notification template found with name: %s", templateName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, template *NotificationTemplate) (*NotificationTemplate, *http.Response, error) {
@@ -158,3 +160,12 @@ func GetAll(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOpt
 	}
 	return common.ReadAllPagesV2[NotificationTemplate](ctx, service.Client, notificationTemplateEndpointV2, params, common.DefaultPageSize)
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOptions, pageOpts ...zscaler.PageOption) iter.Seq2[NotificationTemplate, error] {
+	params := common.QueryParamsV2{}
+	if opts != nil {
+		params.Keyword = opts.Keyword
+	}
+	return common.AllPagesV2[NotificationTemplate](ctx, service.Client, notificationTemplateEndpointV2, params, pageOpts...)
+}
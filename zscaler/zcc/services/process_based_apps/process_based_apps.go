@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zcc/services/common"
 )
 
@@ -84,5 +85,5 @@ func GetByName(ctx context.Context, service *zscaler.Service, name string) (*Pro
 		}
 		page++
 	}
-	return nil, nil, fmt.Errorf("no process-based app found with name: %s", name)
+	return nil, nil, errorx.NotFoundf("no process-based app found with name: %s", name)
 }
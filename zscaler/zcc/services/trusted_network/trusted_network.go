@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zcc/services/common"
 )
 
@@ -106,7 +107,7 @@ func GetTrustedNetworkByName(ctx context.Context, service *zscaler.Service, name
 		page++
 	}
 
-	return nil, nil, fmt.Errorf("trusted network with name '%s' not found", name)
+	return nil, nil, errorx.NotFoundf("trusted network with name '%s' not found", name)
 }
 
 func GetTrustedNetworkByID(ctx context.Context, service *zscaler.Service, id string) (*TrustedNetwork, *http.Response, error) {
@@ -132,7 +133,7 @@ func GetTrustedNetworkByID(ctx context.Context, service *zscaler.Service, id str
 		page++
 	}
 
-	return nil, nil, fmt.Errorf("trusted network with ID %s not found", id)
+	return nil, nil, errorx.NotFoundf("trusted network with ID %s not found", id)
 }
 
 func CreateTrustedNetwork(ctx context.Context, service *zscaler.Service, network *TrustedNetwork) (*TrustedNetwork, *http.Response, error) {
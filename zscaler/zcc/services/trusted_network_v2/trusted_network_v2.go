@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zcc/services/common"
 )
 
@@ -90,7 +92,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, networkName string
 			return &network, nil
 		}
 	}
-	return nil, fmt.Errorf("no trusted network found with name: %s", networkName)
+	return nil, errorx.NotFoundf("no trusted network found with name: %s", networkName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, network *TrustedNetworkV2) (*TrustedNetworkV2, *http.Response, error) {
@@ -157,3 +159,13 @@ func GetAll(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOpt
 	}
 	return common.ReadAllPagesV2[TrustedNetworkV2](ctx, service.Client, trustedNetworkEndpointV2, params, common.DefaultPageSize)
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOptions, pageOpts ...zscaler.PageOption) iter.Seq2[TrustedNetworkV2, error] {
+	params := common.QueryParamsV2{}
+	if opts != nil {
+		params.Keyword = opts.Keyword
+		params.Type = opts.Type
+	}
+	return common.AllPagesV2[TrustedNetworkV2](ctx, service.Client, trustedNetworkEndpointV2, params, pageOpts...)
+}
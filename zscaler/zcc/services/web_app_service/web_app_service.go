@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zcc/services/common"
 )
 
@@ -88,7 +89,7 @@ func GetByAppID(ctx context.Context, service *zscaler.Service, appID string) (*W
 		}
 		page++
 	}
-	return nil, fmt.Errorf("web app service with ID %s not found", appID)
+	return nil, errorx.NotFoundf("web app service with ID %s not found", appID)
 }
 
 func GetByName(ctx context.Context, service *zscaler.Service, name string) (*WebAppService, error) {
@@ -110,7 +111,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, name string) (*Web
 		}
 		page++
 	}
-	return nil, fmt.Errorf("web app service with name %q not found", name)
+	return nil, errorx.NotFoundf("web app service with name %q not found", name)
 }
 
 func UpdateWebAppService(ctx context.Context, service *zscaler.Service, app *WebAppService) (*WebAppService, error) {
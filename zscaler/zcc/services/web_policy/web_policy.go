@@ -10,6 +10,7 @@ import (
 	"strconv"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zcc/services/common"
 )
 
@@ -740,7 +741,7 @@ func GetWebPolicyByID(ctx context.Context, service *zscaler.Service, id string,
 		}
 		return &policy, nil
 	}
-	return nil, fmt.Errorf("web policy with id %q (deviceType=%d) not found", id, deviceType)
+	return nil, errorx.NotFoundf("web policy with id %q (deviceType=%d) not found", id, deviceType)
 }
 
 // normalizeWebPolicyID renders the raw `id` value into the canonical
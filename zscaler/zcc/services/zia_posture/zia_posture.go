@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zcc/services/common"
 )
 
@@ -81,7 +83,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, postureName string
 			return &posture, nil
 		}
 	}
-	return nil, fmt.Errorf("no zia posture found with name: %s", postureName)
+	return nil, errorx.NotFoundf("no zia posture found with name: %s", postureName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, posture *ZIAPosture) (*ZIAPosture, *http.Response, error) {
@@ -155,3 +157,13 @@ func GetAll(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOpt
 	}
 	return common.ReadAllPagesV2[ZIAPosture](ctx, service.Client, ziaPostureEndpointV2, params, common.DefaultPageSize)
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOptions, pageOpts ...zscaler.PageOption) iter.Seq2[ZIAPosture, error] {
+	params := common.QueryParamsV2{}
+	if opts != nil {
+		params.Keyword = opts.Keyword
+		params.PlatformType = opts.PlatformType
+	}
+	return common.AllPagesV2[ZIAPosture](ctx, service.Client, ziaPostureEndpointV2, params, pageOpts...)
+}
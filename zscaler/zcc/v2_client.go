@@ -402,8 +402,8 @@ func (client *Client) do(req *http.Request, v interface{}, start time.Time, reqI
 		return resp, err
 	}
 
-	// Log the raw response body for debugging
-	client.Config.Logger.Printf("[DEBUG] Raw response body: %s", string(bodyBytes))
+	// Log the response body for debugging, without OTPs and passwords
+	logger.LogResponseBody(client.Config.Logger, bodyBytes)
 
 	if v != nil {
 		// Decode JSON from the raw response body
@@ -442,8 +442,11 @@ func (client *Client) newRequest(method, urlPath string, options, body interface
 }
 
 func (client *Client) do(req *http.Request, v interface{}, start time.Time, reqID string) (*http.Response, error) {
-	// Initialize the HTTP client using the configuration's method
-	httpClient := getHTTPClient(client.Config.Logger, nil, client.Config)
+	// Use the configured HTTP client, initializing it if needed
+	httpClient := client.Config.HTTPClient
+	if httpClient == nil {
+		httpClient = getHTTPClient(client.Config.Logger, nil, client.Config)
+	}
 
 	resp, err := httpClient.Do(req)
 	if err != nil {
@@ -457,7 +460,7 @@ func (client *Client) do(req *http.Request, v interface{}, start time.Time, reqI
 	resp.Body = io.NopCloser(bytes.NewBuffer(respBody)) // Reset the response body
 
 	logger.LogResponse(client.Config.Logger, resp, start, reqID)
-	logger.WriteLog(client.Config.Logger, "Response Body: %s", string(respBody)) // Log the response body separately
+	logger.LogResponseBody(client.Config.Logger, respBody) // Log the response body separately
 
 	if err := errorx.CheckErrorInResponse(resp, err); err != nil {
 		return resp, err
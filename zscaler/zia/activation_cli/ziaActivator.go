@@ -44,14 +44,13 @@ func main() {
 		log.Fatalf("[ERROR] Failed Initializing ZIA client: %v\n", err)
 	}
 
-	// Call the activation API using the instantiated client
-	resp, err := activation.CreateActivation(context.Background(), cli, activation.Activation{
-		Status: "ACTIVE",
-	})
-	if err != nil {
+	// Activate and wait until the tenant reports ACTIVE
+	session := activation.NewSession(cli, activation.WithQuietPeriod(0))
+	session.Track()
+	if err := session.Commit(context.Background()); err != nil {
 		log.Printf("[ERROR] Activation Failed: %v\n", err)
 	} else {
-		log.Printf("[INFO] Activation succeeded: %#v\n", resp)
+		log.Printf("[INFO] Activation succeeded\n")
 	}
 
 	os.Exit(0)
@@ -0,0 +1,304 @@
+package activation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
+)
+
+// Activation states reported by GetActivationStatus.
+const (
+	StatusActive     = "ACTIVE"
+	StatusPending    = "PENDING"
+	StatusInProgress = "INPROGRESS"
+)
+
+// ErrSessionClosed is returned by Session.Do after Session.Close.
+var ErrSessionClosed = errors.New("activation session closed")
+
+// EditLockError reports a change or activation that still found the ZIA edit
+// lock taken after every attempt. errors.Is(err, errorx.ErrEditLock) holds.
+type EditLockError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *EditLockError) Error() string {
+	return fmt.Sprintf("edit lock not available after %d attempts: %v", e.Attempts, e.Err)
+}
+
+func (e *EditLockError) Unwrap() error {
+	return e.Err
+}
+
+// SessionOption configures a Session.
+type SessionOption func(*Session)
+
+// WithQuietPeriod sets how long a session waits after the last change before
+// activating, 5s by default. Zero disables automatic activation: changes are
+// activated by Commit and Close only.
+func WithQuietPeriod(d time.Duration) SessionOption {
+	return func(s *Session) {
+		s.quietPeriod = d
+	}
+}
+
+// WithPollInterval sets how often GetActivationStatus is polled until the
+// tenant reports ACTIVE, 2s by default.
+func WithPollInterval(d time.Duration) SessionOption {
+	return func(s *Session) {
+		s.pollInterval = d
+	}
+}
+
+// WithActivationTimeout bounds how long an activation waits for ACTIVE, 5
+// minutes by default.
+func WithActivationTimeout(d time.Duration) SessionOption {
+	return func(s *Session) {
+		s.activationTimeout = d
+	}
+}
+
+// WithEditLockAttempts sets how many times Do and activations are attempted
+// while the edit lock is taken, 5 by default. Attempts are spaced by the poll
+// interval, doubling up to 30s.
+func WithEditLockAttempts(n int) SessionOption {
+	return func(s *Session) {
+		s.editLockAttempts = n
+	}
+}
+
+// WithCloseTimeout bounds the final activation of Close when its context is
+// already done, 2 minutes by default.
+func WithCloseTimeout(d time.Duration) SessionOption {
+	return func(s *Session) {
+		s.closeTimeout = d
+	}
+}
+
+// Session tracks ZIA changes and activates them, so callers no longer have to
+// remember to call CreateActivation. Changes made through Do, or reported with
+// Track, are coalesced: one activation runs once no change was made for the
+// quiet period, or on Commit. Activations wait until the tenant reports
+// ACTIVE. Close activates whatever is still pending, even when the program is
+// being interrupted:
+//
+//	session := activation.NewSession(service)
+//	defer session.Close(ctx)
+//	err := session.Do(ctx, func(ctx context.Context) error {
+//		_, _, err := rule_labels.Create(ctx, service, &rule_labels.RuleLabels{Name: "prod"})
+//		return err
+//	})
+//
+// A Session is safe for concurrent use.
+type Session struct {
+	service           *zscaler.Service
+	quietPeriod       time.Duration
+	pollInterval      time.Duration
+	activationTimeout time.Duration
+	closeTimeout      time.Duration
+	editLockAttempts  int
+
+	mu         sync.Mutex
+	pending    int
+	timer      *time.Timer
+	activating chan struct{} // closed when the running activation ends
+	err        error
+	closed     bool
+}
+
+// NewSession returns a session activating the changes made through service.
+func NewSession(service *zscaler.Service, opts ...SessionOption) *Session {
+	s := &Session{
+		service:           service,
+		quietPeriod:       5 * time.Second,
+		pollInterval:      2 * time.Second,
+		activationTimeout: 5 * time.Minute,
+		closeTimeout:      2 * time.Minute,
+		editLockAttempts:  5,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Do runs change, retrying it while the edit lock is taken, and tracks it
+// for activation when it succeeds. change must be safe to run again after an
+// edit lock error, which the API returns before applying anything.
+func (s *Session) Do(ctx context.Context, change func(ctx context.Context) error) error {
+	s.mu.Lock()
+	closed := s.closed
+	s.mu.Unlock()
+	if closed {
+		return ErrSessionClosed
+	}
+	if err := s.retryEditLock(ctx, change); err != nil {
+		return err
+	}
+	s.Track()
+	return nil
+}
+
+// Track records a change made outside Do and schedules its activation.
+func (s *Session) Track() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pending++
+	if s.closed || s.quietPeriod <= 0 {
+		return
+	}
+	if s.timer == nil {
+		s.timer = time.AfterFunc(s.quietPeriod, s.activateInBackground)
+		return
+	}
+	s.timer.Reset(s.quietPeriod)
+}
+
+// Pending returns the number of changes not activated yet.
+func (s *Session) Pending() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pending
+}
+
+// Err returns the error of the last automatic activation, or nil once an
+// activation succeeded.
+func (s *Session) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Commit activates the pending changes now and waits until the tenant reports
+// ACTIVE. It waits for an activation already under way first.
+func (s *Session) Commit(ctx context.Context) error {
+	s.mu.Lock()
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.mu.Unlock()
+	return s.activate(ctx)
+}
+
+// Close stops automatic activation and commits the pending changes. If ctx is
+// already done, as when the program is shutting down on a signal, the final
+// activation runs anyway, bounded by the close timeout. Do fails after Close.
+func (s *Session) Close(ctx context.Context) error {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	if ctx.Err() != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(context.WithoutCancel(ctx), s.closeTimeout)
+		defer cancel()
+	}
+	return s.Commit(ctx)
+}
+
+func (s *Session) activateInBackground() {
+	if err := s.activate(context.Background()); err != nil {
+		s.service.Client.GetLogger().Printf("[ERROR] ZIA activation failed: %v", err)
+	}
+}
+
+// activate activates the pending changes. Changes tracked while it runs are
+// left pending for the next activation, and the changes of a failed
+// activation are pending again.
+func (s *Session) activate(ctx context.Context) error {
+	s.mu.Lock()
+	for s.activating != nil {
+		running := s.activating
+		s.mu.Unlock()
+		select {
+		case <-running:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		s.mu.Lock()
+	}
+	n := s.pending
+	if n == 0 {
+		s.mu.Unlock()
+		return nil
+	}
+	s.pending = 0
+	done := make(chan struct{})
+	s.activating = done
+	s.mu.Unlock()
+
+	err := s.activateAndWait(ctx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.activating = nil
+	close(done)
+	s.err = err
+	if err != nil {
+		s.pending += n
+		return err
+	}
+	s.service.Client.GetLogger().Printf("[INFO] activated %d ZIA changes", n)
+	return nil
+}
+
+func (s *Session) activateAndWait(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.activationTimeout)
+	defer cancel()
+	var status *Activation
+	err := s.retryEditLock(ctx, func(ctx context.Context) error {
+		var err error
+		status, err = CreateActivation(ctx, s.service, Activation{Status: StatusActive})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("activating ZIA changes: %w", err)
+	}
+	for status.Status != StatusActive {
+		if err := sleep(ctx, s.pollInterval); err != nil {
+			return fmt.Errorf("waiting for ZIA activation (status %s): %w", status.Status, err)
+		}
+		if status, err = GetActivationStatus(ctx, s.service); err != nil {
+			return fmt.Errorf("polling ZIA activation status: %w", err)
+		}
+	}
+	return nil
+}
+
+// retryEditLock runs fn until it does not fail with an edit lock error, up to
+// the configured number of attempts.
+func (s *Session) retryEditLock(ctx context.Context, fn func(ctx context.Context) error) error {
+	wait := s.pollInterval
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil || !errors.Is(err, errorx.ErrEditLock) {
+			return err
+		}
+		if attempt >= s.editLockAttempts {
+			return &EditLockError{Attempts: attempt, Err: err}
+		}
+		s.service.Client.GetLogger().Printf("[WARN] ZIA edit lock not available (attempt %d/%d), retrying in %v", attempt, s.editLockAttempts, wait)
+		if err := sleep(ctx, wait); err != nil {
+			return err
+		}
+		if wait *= 2; wait > 30*time.Second {
+			wait = 30 * time.Second
+		}
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
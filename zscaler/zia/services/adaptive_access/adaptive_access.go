@@ -2,12 +2,13 @@ package adaptive_access
 
 import (
 	"context"
-	"fmt"
+	"iter"
 	"net/url"
 	"strconv"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -54,7 +55,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, profileName string
 			return &adaptiveAccess, nil
 		}
 	}
-	return nil, fmt.Errorf("no adaptive access profile found with name: %s", profileName)
+	return nil, errorx.NotFoundf("no adaptive access profile found with name: %s", profileName)
 }
 
 // GetProfileRules retrieves the Adaptive Access profile information based on
@@ -87,3 +88,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]AdaptiveAccess, er
 	err := common.ReadAllPages(ctx, service.Client, adaptiveAccessEndpoint, &adaptiveAccessProfiles)
 	return adaptiveAccessProfiles, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[AdaptiveAccess, error] {
+	return common.AllPages[AdaptiveAccess](ctx, service.Client, adaptiveAccessEndpoint, opts...)
+}
@@ -3,10 +3,12 @@ package admins
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -126,7 +128,7 @@ func GetAdminUsersByLoginName(ctx context.Context, service *zscaler.Service, adm
 			return &adminUser, nil
 		}
 	}
-	return nil, fmt.Errorf("no admin login found with name: %s", adminUsersLoginName)
+	return nil, errorx.NotFoundf("no admin login found with name: %s", adminUsersLoginName)
 }
 
 func GetAdminByUsername(ctx context.Context, service *zscaler.Service, adminUsername string) (*AdminUsers, error) {
@@ -139,7 +141,7 @@ func GetAdminByUsername(ctx context.Context, service *zscaler.Service, adminUser
 			return &adminUser, nil
 		}
 	}
-	return nil, fmt.Errorf("no admin found with username: %s", adminUsername)
+	return nil, errorx.NotFoundf("no admin found with username: %s", adminUsername)
 }
 
 func CreateAdminUser(ctx context.Context, service *zscaler.Service, adminUser AdminUsers) (*AdminUsers, error) {
@@ -179,12 +181,22 @@ func GetAllAdminUsers(ctx context.Context, service *zscaler.Service) ([]AdminUse
 	return adminUsers, err
 }
 
+// AllAdminUsers is like GetAllAdminUsers, but returns an iterator that fetches one page at a time.
+func AllAdminUsers(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[AdminUsers, error] {
+	return common.AllPages[AdminUsers](ctx, service.Client, adminUsersEndpoint+"?includeAuditorUsers=true&includeAdminUsers=true", opts...)
+}
+
 func GetPasswordExpirySettings(ctx context.Context, service *zscaler.Service) ([]PasswordExpiry, error) {
 	var expiry []PasswordExpiry
 	err := common.ReadAllPages(ctx, service.Client, passwordExpiryEndpoint, &expiry)
 	return expiry, err
 }
 
+// AllPasswordExpirySettings is like GetPasswordExpirySettings, but returns an iterator that fetches one page at a time.
+func AllPasswordExpirySettings(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[PasswordExpiry, error] {
+	return common.AllPages[PasswordExpiry](ctx, service.Client, passwordExpiryEndpoint, opts...)
+}
+
 func UpdatePasswordExpirySettings(ctx context.Context, service *zscaler.Service, advancedSettings *PasswordExpiry) (*PasswordExpiry, *http.Response, error) {
 	resp, err := service.Client.UpdateWithPut(ctx, (passwordExpiryEndpoint), *advancedSettings)
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 )
 
 const (
@@ -96,7 +97,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, adminRoleName stri
 			return &adminRole, nil
 		}
 	}
-	return nil, fmt.Errorf("no admin role found with name: %s", adminRoleName)
+	return nil, errorx.NotFoundf("no admin role found with name: %s", adminRoleName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, roleID *AdminRoles) (*AdminRoles, *http.Response, error) {
@@ -145,7 +146,7 @@ func GetAPIRole(ctx context.Context, service *zscaler.Service, apiRole, includeA
 			return &apiRoleEnabled, nil
 		}
 	}
-	return nil, fmt.Errorf("no api role found with name: %s", apiRole)
+	return nil, errorx.NotFoundf("no api role found with name: %s", apiRole)
 }
 
 func GetAuditorRole(ctx context.Context, service *zscaler.Service, auditorRole, includeAuditorRole string) (*AdminRoles, error) {
@@ -159,7 +160,7 @@ func GetAuditorRole(ctx context.Context, service *zscaler.Service, auditorRole,
 			return &auditorRoleEnabled, nil
 		}
 	}
-	return nil, fmt.Errorf("no auditor role found with name: %s", auditorRole)
+	return nil, errorx.NotFoundf("no auditor role found with name: %s", auditorRole)
 }
 
 func GetPartnerRole(ctx context.Context, service *zscaler.Service, partnerRole, includePartnerRole string) (*AdminRoles, error) {
@@ -173,7 +174,7 @@ func GetPartnerRole(ctx context.Context, service *zscaler.Service, partnerRole,
 			return &partnerRoleEnabled, nil
 		}
 	}
-	return nil, fmt.Errorf("no partner role found with name: %s", partnerRole)
+	return nil, errorx.NotFoundf("no partner role found with name: %s", partnerRole)
 }
 
 func GetAllAdminRoles(ctx context.Context, service *zscaler.Service) ([]AdminRoles, error) {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
@@ -77,3 +78,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]AlertSubscriptions
 	err := common.ReadAllPages(ctx, service.Client, alertsEndpoint, &alerts)
 	return alerts, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[AlertSubscriptions, error] {
+	return common.AllPages[AlertSubscriptions](ctx, service.Client, alertsEndpoint, opts...)
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -53,7 +55,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, className string)
 			return &bdwClass, nil
 		}
 	}
-	return nil, fmt.Errorf("no bandwidth classes found with name: %s", className)
+	return nil, errorx.NotFoundf("no bandwidth classes found with name: %s", className)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, classID *BandwidthClasses) (*BandwidthClasses, *http.Response, error) {
@@ -97,8 +99,18 @@ func GetAllLite(ctx context.Context, service *zscaler.Service) ([]BandwidthClass
 	return classes, err
 }
 
+// AllLite is like GetAllLite, but returns an iterator that fetches one page at a time.
+func AllLite(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[BandwidthClasses, error] {
+	return common.AllPages[BandwidthClasses](ctx, service.Client, bandwidthClassEndpoint+"/lite", opts...)
+}
+
 func GetAll(ctx context.Context, service *zscaler.Service) ([]BandwidthClasses, error) {
 	var classes []BandwidthClasses
 	err := common.ReadAllPages(ctx, service.Client, bandwidthClassEndpoint, &classes)
 	return classes, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[BandwidthClasses, error] {
+	return common.AllPages[BandwidthClasses](ctx, service.Client, bandwidthClassEndpoint, opts...)
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -61,7 +63,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, ruleName string) (
 			return &rule, nil
 		}
 	}
-	return nil, fmt.Errorf("no Bandwidth Control rule rule found with name: %s", ruleName)
+	return nil, errorx.NotFoundf("no Bandwidth Control rule rule found with name: %s", ruleName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, rule *BandwidthControlRules) (*BandwidthControlRules, error) {
@@ -104,8 +106,18 @@ func GetAllLite(ctx context.Context, service *zscaler.Service) ([]BandwidthContr
 	return profiles, err
 }
 
+// AllLite is like GetAllLite, but returns an iterator that fetches one page at a time.
+func AllLite(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[BandwidthControlRules, error] {
+	return common.AllPages[BandwidthControlRules](ctx, service.Client, bandwidthControlEndpoint+"/lite", opts...)
+}
+
 func GetAll(ctx context.Context, service *zscaler.Service) ([]BandwidthControlRules, error) {
 	var rules []BandwidthControlRules
 	err := common.ReadAllPages(ctx, service.Client, bandwidthControlEndpoint, &rules)
 	return rules, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[BandwidthControlRules, error] {
+	return common.AllPages[BandwidthControlRules](ctx, service.Client, bandwidthControlEndpoint, opts...)
+}
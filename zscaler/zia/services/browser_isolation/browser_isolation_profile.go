@@ -2,10 +2,11 @@ package browser_isolation
 
 import (
 	"context"
-	"fmt"
+	"iter"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -38,7 +39,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, profileName string
 			return &cbi, nil
 		}
 	}
-	return nil, fmt.Errorf("no cloud browser isolation profile found with name: %s", profileName)
+	return nil, errorx.NotFoundf("no cloud browser isolation profile found with name: %s", profileName)
 }
 
 // Updated GetAll function
@@ -48,6 +49,11 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]CBIProfile, error)
 	return cbiProfiles, checkNotSubscribedError(err)
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[CBIProfile, error] {
+	return common.AllPages[CBIProfile](ctx, service.Client, cbiProfileEndpoint, opts...)
+}
+
 type NotSubscribedError struct {
 	message string
 }
@@ -3,10 +3,12 @@ package c2c_incident_receiver
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/url"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -109,7 +111,7 @@ func GetC2CIRName(ctx context.Context, service *zscaler.Service, recieverName st
 			return &c2cIRReceiver, nil
 		}
 	}
-	return nil, fmt.Errorf("no incident receiver found with name: %s", recieverName)
+	return nil, errorx.NotFoundf("no incident receiver found with name: %s", recieverName)
 }
 
 func ValidateDelete(ctx context.Context, service *zscaler.Service, receiverID int) (*C2CIncidentReceiver, error) {
@@ -139,8 +141,18 @@ func GetAllLite(ctx context.Context, service *zscaler.Service) ([]C2CIncidentRec
 	return c2cIRReceivers, err
 }
 
+// AllLite is like GetAllLite, but returns an iterator that fetches one page at a time.
+func AllLite(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[C2CIncidentReceiver, error] {
+	return common.AllPages[C2CIncidentReceiver](ctx, service.Client, c2cIRReceiverEndpoint+"/lite", opts...)
+}
+
 func GetAll(ctx context.Context, service *zscaler.Service) ([]C2CIncidentReceiver, error) {
 	var c2cIRReceivers []C2CIncidentReceiver
 	err := common.ReadAllPages(ctx, service.Client, c2cIRReceiverEndpoint, &c2cIRReceivers)
 	return c2cIRReceivers, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[C2CIncidentReceiver, error] {
+	return common.AllPages[C2CIncidentReceiver](ctx, service.Client, c2cIRReceiverEndpoint, opts...)
+}
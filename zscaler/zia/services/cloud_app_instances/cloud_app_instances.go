@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -55,7 +57,7 @@ func GetInstanceByName(ctx context.Context, service *zscaler.Service, instanceNa
 			return &cloudInstance, nil
 		}
 	}
-	return nil, fmt.Errorf("no cloud instance found with name: %s", instanceName)
+	return nil, errorx.NotFoundf("no cloud instance found with name: %s", instanceName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, instanceID *CloudApplicationInstances) (*CloudApplicationInstances, *http.Response, error) {
@@ -98,3 +100,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]CloudApplicationIn
 	err := common.ReadAllPages(ctx, service.Client, cloudInstancesEndpoint, &cloudInstances)
 	return cloudInstances, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[CloudApplicationInstances, error] {
+	return common.AllPages[CloudApplicationInstances](ctx, service.Client, cloudInstancesEndpoint, opts...)
+}
@@ -3,6 +3,7 @@ package cloudapplications
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/url"
 	"strconv"
 
@@ -30,33 +31,37 @@ type CloudApplications struct {
 }
 
 func GetCloudApplicationPolicy(ctx context.Context, service *zscaler.Service, params map[string]interface{}) ([]CloudApplications, error) {
-	queryParams := url.Values{}
-	// log.Printf("[DEBUG] Received params: %v", params)
-
-	if appClasses, ok := params["appClass"].([]interface{}); ok {
-		for _, appClass := range appClasses {
-			queryParams.Add("appClass", appClass.(string))
-		}
-	}
-	if groupResults, ok := params["groupResults"].(bool); ok {
-		queryParams.Set("groupResults", strconv.FormatBool(groupResults))
+	var results []CloudApplications
+	err := common.ReadAllPages(ctx, service.Client, policyEndpoint(cloudAppPolicyEndpoint, params), &results)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching cloud application policies: %w", err)
 	}
+	return results, nil
+}
 
-	endpoint := fmt.Sprintf("%s?%s", cloudAppPolicyEndpoint, queryParams.Encode())
-	// log.Printf("[DEBUG] Constructed endpoint: %s", endpoint)
+// AllCloudApplicationPolicy is like GetCloudApplicationPolicy, but returns an iterator that fetches one page at a time.
+func AllCloudApplicationPolicy(ctx context.Context, service *zscaler.Service, params map[string]interface{}, opts ...zscaler.PageOption) iter.Seq2[CloudApplications, error] {
+	return common.AllPages[CloudApplications](ctx, service.Client, policyEndpoint(cloudAppPolicyEndpoint, params), opts...)
+}
 
+func GetCloudApplicationSSLPolicy(ctx context.Context, service *zscaler.Service, params map[string]interface{}) ([]CloudApplications, error) {
 	var results []CloudApplications
-	err := common.ReadAllPages(ctx, service.Client, endpoint, &results)
+	err := common.ReadAllPages(ctx, service.Client, policyEndpoint(cloudAppSSLPolicyEndpoint, params), &results)
 	if err != nil {
-		return nil, fmt.Errorf("error fetching cloud application policies: %w", err)
+		return nil, fmt.Errorf("error fetching cloud application SSL policies: %w", err)
 	}
 	return results, nil
 }
 
-func GetCloudApplicationSSLPolicy(ctx context.Context, service *zscaler.Service, params map[string]interface{}) ([]CloudApplications, error) {
-	queryParams := url.Values{}
-	// log.Printf("[DEBUG] Received params: %v", params)
+// AllCloudApplicationSSLPolicy is like GetCloudApplicationSSLPolicy, but returns an iterator that fetches one page at a time.
+func AllCloudApplicationSSLPolicy(ctx context.Context, service *zscaler.Service, params map[string]interface{}, opts ...zscaler.PageOption) iter.Seq2[CloudApplications, error] {
+	return common.AllPages[CloudApplications](ctx, service.Client, policyEndpoint(cloudAppSSLPolicyEndpoint, params), opts...)
+}
 
+// policyEndpoint returns endpoint filtered by the appClass and groupResults
+// params.
+func policyEndpoint(endpoint string, params map[string]interface{}) string {
+	queryParams := url.Values{}
 	if appClasses, ok := params["appClass"].([]interface{}); ok {
 		for _, appClass := range appClasses {
 			queryParams.Add("appClass", appClass.(string))
@@ -65,14 +70,5 @@ func GetCloudApplicationSSLPolicy(ctx context.Context, service *zscaler.Service,
 	if groupResults, ok := params["groupResults"].(bool); ok {
 		queryParams.Set("groupResults", strconv.FormatBool(groupResults))
 	}
-
-	endpoint := fmt.Sprintf("%s?%s", cloudAppSSLPolicyEndpoint, queryParams.Encode())
-	//log.Printf("[DEBUG] Constructed endpoint: %s", endpoint)
-
-	var results []CloudApplications
-	err := common.ReadAllPages(ctx, service.Client, endpoint, &results)
-	if err != nil {
-		return nil, fmt.Errorf("error fetching cloud application SSL policies: %w", err)
-	}
-	return results, nil
+	return fmt.Sprintf("%s?%s", endpoint, queryParams.Encode())
 }
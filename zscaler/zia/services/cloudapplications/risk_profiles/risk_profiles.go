@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -77,7 +79,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, profileName string
 			return &riskProfile, nil
 		}
 	}
-	return nil, fmt.Errorf("no risk profiles found with name: %s", profileName)
+	return nil, errorx.NotFoundf("no risk profiles found with name: %s", profileName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, profileID *RiskProfiles) (*RiskProfiles, *http.Response, error) {
@@ -121,8 +123,18 @@ func GetAllLite(ctx context.Context, service *zscaler.Service) ([]RiskProfiles,
 	return profiles, err
 }
 
+// AllLite is like GetAllLite, but returns an iterator that fetches one page at a time.
+func AllLite(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[RiskProfiles, error] {
+	return common.AllPages[RiskProfiles](ctx, service.Client, riskProfilesEndpoint+"/lite", opts...)
+}
+
 func GetAll(ctx context.Context, service *zscaler.Service) ([]RiskProfiles, error) {
 	var profiles []RiskProfiles
 	err := common.ReadAllPages(ctx, service.Client, riskProfilesEndpoint, &profiles)
 	return profiles, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[RiskProfiles, error] {
+	return common.AllPages[RiskProfiles](ctx, service.Client, riskProfilesEndpoint, opts...)
+}
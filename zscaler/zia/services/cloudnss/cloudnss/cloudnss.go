@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -263,7 +265,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, feedName string) (
 			return &feed, nil
 		}
 	}
-	return nil, fmt.Errorf("no nss feed found with name: %s", feedName)
+	return nil, errorx.NotFoundf("no nss feed found with name: %s", feedName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, feed *NSSFeed) (*NSSFeed, error) {
@@ -315,6 +317,11 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]NSSFeed, error) {
 	return rules, err
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[NSSFeed, error] {
+	return common.AllPages[NSSFeed](ctx, service.Client, nssFeedsEndpoint, opts...)
+}
+
 func validateFeedOutputParams(params map[string]string) error {
 	if t, ok := params["type"]; ok {
 		if !supportedTypes[t] {
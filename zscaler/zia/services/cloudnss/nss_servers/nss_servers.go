@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 )
 
 const (
@@ -54,7 +55,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, serverName string)
 			return &nss, nil
 		}
 	}
-	return nil, fmt.Errorf("no nss server found with name: %s", serverName)
+	return nil, errorx.NotFoundf("no nss server found with name: %s", serverName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, nssServer *NSSServers) (*NSSServers, error) {
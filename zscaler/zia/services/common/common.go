@@ -3,6 +3,7 @@ package common
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"strings"
@@ -142,22 +143,18 @@ func ReadAllPages[T any](ctx context.Context, client *zscaler.Client, endpoint s
 		pageSize = customPageSize[0]
 	}
 
-	page := 1
-	if !strings.Contains(endpoint, "?") {
-		endpoint += "?"
-	}
-
+	fetch := pageFetcher[T](client, endpoint, pageSize)
+	var token zscaler.PageToken
 	for {
-		pageItems := []T{}
-		err := client.Read(ctx, fmt.Sprintf("%s&pageSize=%d&page=%d", endpoint, pageSize, page), &pageItems)
+		page, err := fetch(ctx, token)
 		if err != nil {
 			return err
 		}
-		*list = append(*list, pageItems...)
-		if len(pageItems) < pageSize {
+		*list = append(*list, page.Items...)
+		if page.Next == "" {
 			break
 		}
-		page++
+		token = page.Next
 	}
 
 	filtered, err := zscaler.ApplyJMESPathFromContext(ctx, *list)
@@ -169,6 +166,47 @@ func ReadAllPages[T any](ctx context.Context, client *zscaler.Client, endpoint s
 	return nil
 }
 
+// AllPages returns an iterator over every item of a paginated ZIA endpoint,
+// fetching one page at a time instead of loading the whole list like
+// ReadAllPages. Page tokens are page numbers.
+//
+//	for user, err := range common.AllPages[users.Users](ctx, service.Client, "/zia/api/v1/users") {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func AllPages[T any](ctx context.Context, client *zscaler.Client, endpoint string, opts ...zscaler.PageOption) iter.Seq2[T, error] {
+	pageSize := zscaler.NewPageOptions(opts...).PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	return zscaler.Paginate(ctx, pageFetcher[T](client, endpoint, pageSize), opts...)
+}
+
+// pageFetcher reads page-numbered pages of endpoint. A page shorter than
+// pageSize is the last one.
+func pageFetcher[T any](client *zscaler.Client, endpoint string, pageSize int) zscaler.PageFetcher[T] {
+	if !strings.Contains(endpoint, "?") {
+		endpoint += "?"
+	}
+	return func(ctx context.Context, token zscaler.PageToken) (zscaler.Page[T], error) {
+		page, err := token.Int(1)
+		if err != nil {
+			return zscaler.Page[T]{}, err
+		}
+		pageItems := []T{}
+		if err := client.Read(ctx, fmt.Sprintf("%s&pageSize=%d&page=%d", endpoint, pageSize, page), &pageItems); err != nil {
+			return zscaler.Page[T]{}, err
+		}
+		result := zscaler.Page[T]{Items: pageItems}
+		if len(pageItems) >= pageSize {
+			result.Next = zscaler.PageNumberToken(page + 1)
+		}
+		return result, nil
+	}
+}
+
 func ReadPage[T any](ctx context.Context, client *zscaler.Client, endpoint string, page int, list *[]T, customPageSize ...int) error {
 	if list == nil {
 		return nil
@@ -3,10 +3,12 @@ package devicegroups
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/url"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -82,7 +84,7 @@ func GetDeviceGroupByName(ctx context.Context, service *zscaler.Service, deviceG
 			return &deviceGroup, nil
 		}
 	}
-	return nil, fmt.Errorf("no device group found with name: %s", deviceGroupName)
+	return nil, errorx.NotFoundf("no device group found with name: %s", deviceGroupName)
 }
 
 func GetIncludeDeviceInfo(ctx context.Context, service *zscaler.Service, includeDeviceInfo, includePseudoGroups bool) ([]DeviceGroups, error) {
@@ -109,6 +111,11 @@ func GetAllDevicesGroups(ctx context.Context, service *zscaler.Service) ([]Devic
 	return owners, err
 }
 
+// AllDevicesGroups is like GetAllDevicesGroups, but returns an iterator that fetches one page at a time.
+func AllDevicesGroups(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[DeviceGroups, error] {
+	return common.AllPages[DeviceGroups](ctx, service.Client, deviceGroupEndpoint, opts...)
+}
+
 func GetDevicesByID(ctx context.Context, service *zscaler.Service, deviceID int) (*Devices, error) {
 	devices, err := GetAllDevices(ctx, service)
 	if err != nil {
@@ -121,7 +128,7 @@ func GetDevicesByID(ctx context.Context, service *zscaler.Service, deviceID int)
 		}
 	}
 
-	return nil, fmt.Errorf("no device found with ID: %d", deviceID)
+	return nil, errorx.NotFoundf("no device found with ID: %d", deviceID)
 }
 
 // Get Devices by Name.
@@ -137,7 +144,7 @@ func GetDevicesByName(ctx context.Context, service *zscaler.Service, deviceName
 			return &device, nil
 		}
 	}
-	return nil, fmt.Errorf("no device found with name: %s", deviceName)
+	return nil, errorx.NotFoundf("no device found with name: %s", deviceName)
 }
 
 func GetDevicesByModel(ctx context.Context, service *zscaler.Service, deviceModel string) (*Devices, error) {
@@ -151,7 +158,7 @@ func GetDevicesByModel(ctx context.Context, service *zscaler.Service, deviceMode
 			return &model, nil
 		}
 	}
-	return nil, fmt.Errorf("no device found with model: %s", deviceModel)
+	return nil, errorx.NotFoundf("no device found with model: %s", deviceModel)
 }
 
 func GetDevicesByOwner(ctx context.Context, service *zscaler.Service, ownerName string) (*Devices, error) {
@@ -165,7 +172,7 @@ func GetDevicesByOwner(ctx context.Context, service *zscaler.Service, ownerName
 			return &owner, nil
 		}
 	}
-	return nil, fmt.Errorf("no device found for owner: %s", ownerName)
+	return nil, errorx.NotFoundf("no device found for owner: %s", ownerName)
 }
 
 func GetDevicesByOSType(ctx context.Context, service *zscaler.Service, osTypeName string) (*Devices, error) {
@@ -179,7 +186,7 @@ func GetDevicesByOSType(ctx context.Context, service *zscaler.Service, osTypeNam
 			return &osType, nil
 		}
 	}
-	return nil, fmt.Errorf("no device found for type: %s", osTypeName)
+	return nil, errorx.NotFoundf("no device found for type: %s", osTypeName)
 }
 
 func GetDevicesByOSVersion(ctx context.Context, service *zscaler.Service, osVersionName string) (*Devices, error) {
@@ -193,7 +200,7 @@ func GetDevicesByOSVersion(ctx context.Context, service *zscaler.Service, osVers
 			return &osVersion, nil
 		}
 	}
-	return nil, fmt.Errorf("no device found for version: %s", osVersionName)
+	return nil, errorx.NotFoundf("no device found for version: %s", osVersionName)
 }
 
 func GetAllDevices(ctx context.Context, service *zscaler.Service) ([]Devices, error) {
@@ -201,3 +208,8 @@ func GetAllDevices(ctx context.Context, service *zscaler.Service) ([]Devices, er
 	err := common.ReadAllPages(ctx, service.Client, devicesEndpoint, &owners)
 	return owners, err
 }
+
+// AllDevices is like GetAllDevices, but returns an iterator that fetches one page at a time.
+func AllDevices(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[Devices, error] {
+	return common.AllPages[Devices](ctx, service.Client, devicesEndpoint, opts...)
+}
@@ -3,11 +3,13 @@ package devices
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/url"
 	"strconv"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -73,7 +75,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, deviceName string)
 			return &device, nil
 		}
 	}
-	return nil, fmt.Errorf("no device found with name: %s", deviceName)
+	return nil, errorx.NotFoundf("no device found with name: %s", deviceName)
 }
 
 // GetAll retrieves a list of all the devices registered with Zscaler.
@@ -84,6 +86,16 @@ func GetByName(ctx context.Context, service *zscaler.Service, deviceName string)
 // results, so they should not be set here.
 func GetAll(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOptions) ([]Devices, error) {
 	var devices []Devices
+	err := common.ReadAllPages(ctx, service.Client, getAllEndpoint(opts), &devices)
+	return devices, err
+}
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOptions, pageOpts ...zscaler.PageOption) iter.Seq2[Devices, error] {
+	return common.AllPages[Devices](ctx, service.Client, getAllEndpoint(opts), pageOpts...)
+}
+
+func getAllEndpoint(opts *GetAllFilterOptions) string {
 	endpoint := devicesEndpoint
 
 	queryParams := url.Values{}
@@ -104,7 +116,5 @@ func GetAll(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOpt
 	if len(queryParams) > 0 {
 		endpoint += "?" + queryParams.Encode()
 	}
-
-	err := common.ReadAllPages(ctx, service.Client, endpoint, &devices)
-	return devices, err
+	return endpoint
 }
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -57,7 +59,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, engineName string)
 			return &engine, nil
 		}
 	}
-	return nil, fmt.Errorf("no dlp engine found with name: %s", engineName)
+	return nil, errorx.NotFoundf("no dlp engine found with name: %s", engineName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, engineID *DLPEngines) (*DLPEngines, *http.Response, error) {
@@ -101,6 +103,11 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]DLPEngines, error)
 	return dlpEngines, err
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[DLPEngines, error] {
+	return common.AllPages[DLPEngines](ctx, service.Client, dlpEnginesEndpoint, opts...)
+}
+
 // Functions to for DLP Engine Lite query
 func GetEngineLiteID(ctx context.Context, service *zscaler.Service, engineID int) (*DLPEngines, error) {
 	dlpEngines, err := GetAllEngineLite(ctx, service)
@@ -112,7 +119,7 @@ func GetEngineLiteID(ctx context.Context, service *zscaler.Service, engineID int
 			return &engine, nil
 		}
 	}
-	return nil, fmt.Errorf("no dlp engine found with ID: %d", engineID)
+	return nil, errorx.NotFoundf("no dlp engine found with ID: %d", engineID)
 }
 
 func GetByPredefinedEngine(ctx context.Context, service *zscaler.Service, engineName string) (*DLPEngines, error) {
@@ -125,7 +132,7 @@ func GetByPredefinedEngine(ctx context.Context, service *zscaler.Service, engine
 			return &engine, nil
 		}
 	}
-	return nil, fmt.Errorf("no predefined dlp engine found with name: %s", engineName)
+	return nil, errorx.NotFoundf("no predefined dlp engine found with name: %s", engineName)
 }
 
 func GetAllEngineLite(ctx context.Context, service *zscaler.Service) ([]DLPEngines, error) {
@@ -133,3 +140,8 @@ func GetAllEngineLite(ctx context.Context, service *zscaler.Service) ([]DLPEngin
 	err := common.ReadAllPages(ctx, service.Client, dlpEngineLiteEndpoint, &engines)
 	return engines, err
 }
+
+// AllEngineLite is like GetAllEngineLite, but returns an iterator that fetches one page at a time.
+func AllEngineLite(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[DLPEngines, error] {
+	return common.AllPages[DLPEngines](ctx, service.Client, dlpEngineLiteEndpoint, opts...)
+}
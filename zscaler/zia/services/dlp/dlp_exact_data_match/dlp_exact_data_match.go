@@ -3,10 +3,12 @@ package dlp_exact_data_match
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/url"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -126,7 +128,7 @@ func GetDLPEDMByName(ctx context.Context, service *zscaler.Service, edmSchemaNam
 			return &edmSchema, nil
 		}
 	}
-	return nil, fmt.Errorf("no edm schema found with name: %s", edmSchemaName)
+	return nil, errorx.NotFoundf("no edm schema found with name: %s", edmSchemaName)
 }
 
 func GetAll(ctx context.Context, service *zscaler.Service) ([]DLPEDMSchema, error) {
@@ -134,3 +136,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]DLPEDMSchema, erro
 	err := common.ReadAllPages(ctx, service.Client, dlpEDMSchemaEndpoint, &edmData)
 	return edmData, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[DLPEDMSchema, error] {
+	return common.AllPages[DLPEDMSchema](ctx, service.Client, dlpEDMSchemaEndpoint, opts...)
+}
@@ -3,6 +3,7 @@ package dlp_exact_data_match_lite
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/url"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
@@ -58,6 +59,20 @@ type TokenList struct {
 }
 
 func GetBySchemaName(ctx context.Context, service *zscaler.Service, schemaName string, activeOnly, fetchTokens bool) ([]DLPEDMLite, error) {
+	var edmData []DLPEDMLite
+	err := common.ReadAllPages(ctx, service.Client, schemaNameEndpoint(schemaName, activeOnly, fetchTokens), &edmData)
+	if err != nil {
+		return nil, err
+	}
+	return edmData, nil
+}
+
+// AllBySchemaName is like GetBySchemaName, but returns an iterator that fetches one page at a time.
+func AllBySchemaName(ctx context.Context, service *zscaler.Service, schemaName string, activeOnly, fetchTokens bool, opts ...zscaler.PageOption) iter.Seq2[DLPEDMLite, error] {
+	return common.AllPages[DLPEDMLite](ctx, service.Client, schemaNameEndpoint(schemaName, activeOnly, fetchTokens), opts...)
+}
+
+func schemaNameEndpoint(schemaName string, activeOnly, fetchTokens bool) string {
 	queryParameters := url.Values{}
 	queryParameters.Set("schemaName", schemaName)
 	if activeOnly {
@@ -66,17 +81,21 @@ func GetBySchemaName(ctx context.Context, service *zscaler.Service, schemaName s
 	if fetchTokens {
 		queryParameters.Set("fetchTokens", "true")
 	}
+	return fmt.Sprintf("%s?%s", dlpEDMELiteEndpoint, queryParameters.Encode())
+}
 
-	endpoint := fmt.Sprintf("%s?%s", dlpEDMELiteEndpoint, queryParameters.Encode())
+func GetAllEDMSchema(ctx context.Context, service *zscaler.Service, activeOnly, fetchTokens bool) ([]DLPEDMLite, error) {
 	var edmData []DLPEDMLite
-	err := common.ReadAllPages(ctx, service.Client, endpoint, &edmData)
-	if err != nil {
-		return nil, err
-	}
-	return edmData, nil
+	err := common.ReadAllPages(ctx, service.Client, allEDMSchemaEndpoint(activeOnly, fetchTokens), &edmData)
+	return edmData, err
 }
 
-func GetAllEDMSchema(ctx context.Context, service *zscaler.Service, activeOnly, fetchTokens bool) ([]DLPEDMLite, error) {
+// AllEDMSchema is like GetAllEDMSchema, but returns an iterator that fetches one page at a time.
+func AllEDMSchema(ctx context.Context, service *zscaler.Service, activeOnly, fetchTokens bool, opts ...zscaler.PageOption) iter.Seq2[DLPEDMLite, error] {
+	return common.AllPages[DLPEDMLite](ctx, service.Client, allEDMSchemaEndpoint(activeOnly, fetchTokens), opts...)
+}
+
+func allEDMSchemaEndpoint(activeOnly, fetchTokens bool) string {
 	queryParameters := url.Values{}
 	if activeOnly {
 		queryParameters.Set("activeOnly", "true")
@@ -89,8 +108,5 @@ func GetAllEDMSchema(ctx context.Context, service *zscaler.Service, activeOnly,
 	if len(queryParameters) > 0 {
 		endpoint += "?" + queryParameters.Encode()
 	}
-
-	var edmData []DLPEDMLite
-	err := common.ReadAllPages(ctx, service.Client, endpoint, &edmData)
-	return edmData, err
+	return endpoint
 }
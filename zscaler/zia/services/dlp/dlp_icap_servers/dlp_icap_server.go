@@ -3,9 +3,11 @@ package dlp_icap_servers
 import (
 	"context"
 	"fmt"
+	"iter"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -49,7 +51,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, icapServerName str
 			return &icap, nil
 		}
 	}
-	return nil, fmt.Errorf("no dlp icap server found with name: %s", icapServerName)
+	return nil, errorx.NotFoundf("no dlp icap server found with name: %s", icapServerName)
 }
 
 func GetAll(ctx context.Context, service *zscaler.Service) ([]DLPICAPServers, error) {
@@ -57,3 +59,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]DLPICAPServers, er
 	err := common.ReadAllPages(ctx, service.Client, dlpIcapServersEndpoint, &icapServers)
 	return icapServers, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[DLPICAPServers, error] {
+	return common.AllPages[DLPICAPServers](ctx, service.Client, dlpIcapServersEndpoint, opts...)
+}
@@ -3,10 +3,12 @@ package dlp_idm_profile_lite
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/url"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -56,7 +58,7 @@ func GetDLPProfileLiteID(ctx context.Context, service *zscaler.Service, ProfileL
 		}
 	}
 
-	return nil, fmt.Errorf("no DLP profile found with ProfileLiteID: %d", ProfileLiteID)
+	return nil, errorx.NotFoundf("no DLP profile found with ProfileLiteID: %d", ProfileLiteID)
 }
 
 func GetDLPProfileLiteByName(ctx context.Context, service *zscaler.Service, profileLiteName string, activeOnly bool) (*DLPIDMProfileLite, error) {
@@ -77,7 +79,7 @@ func GetDLPProfileLiteByName(ctx context.Context, service *zscaler.Service, prof
 			return &profile, nil
 		}
 	}
-	return nil, fmt.Errorf("no idm profile template found with name: %s", profileLiteName)
+	return nil, errorx.NotFoundf("no idm profile template found with name: %s", profileLiteName)
 }
 
 func GetAll(ctx context.Context, service *zscaler.Service, activeOnly bool) ([]DLPIDMProfileLite, error) {
@@ -90,3 +92,12 @@ func GetAll(ctx context.Context, service *zscaler.Service, activeOnly bool) ([]D
 	err := common.ReadAllPages(ctx, service.Client, endpoint, &idmpProfile)
 	return idmpProfile, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, activeOnly bool, opts ...zscaler.PageOption) iter.Seq2[DLPIDMProfileLite, error] {
+	endpoint := dlpIDMProfileLiteEndpoint
+	if activeOnly {
+		endpoint += "?activeOnly=true"
+	}
+	return common.AllPages[DLPIDMProfileLite](ctx, service.Client, endpoint, opts...)
+}
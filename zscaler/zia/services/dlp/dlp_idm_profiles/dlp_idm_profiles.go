@@ -3,9 +3,11 @@ package dlp_idm_profiles
 import (
 	"context"
 	"fmt"
+	"iter"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -100,7 +102,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, idmProfileName str
 			return &icap, nil
 		}
 	}
-	return nil, fmt.Errorf("no dlp icap server found with name: %s", idmProfileName)
+	return nil, errorx.NotFoundf("no dlp icap server found with name: %s", idmProfileName)
 }
 
 func GetAll(ctx context.Context, service *zscaler.Service) ([]DLPIDMProfile, error) {
@@ -108,3 +110,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]DLPIDMProfile, err
 	err := common.ReadAllPages(ctx, service.Client, dlpIDMProfileEndpoint, &idmpProfile)
 	return idmpProfile, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[DLPIDMProfile, error] {
+	return common.AllPages[DLPIDMProfile](ctx, service.Client, dlpIDMProfileEndpoint, opts...)
+}
@@ -3,9 +3,11 @@ package dlp_incident_receiver_servers
 import (
 	"context"
 	"fmt"
+	"iter"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -52,7 +54,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, receiverName strin
 			return &receiver, nil
 		}
 	}
-	return nil, fmt.Errorf("no dlp incident receiver found with name: %s", receiverName)
+	return nil, errorx.NotFoundf("no dlp incident receiver found with name: %s", receiverName)
 }
 
 func GetAll(ctx context.Context, service *zscaler.Service) ([]IncidentReceiverServers, error) {
@@ -60,3 +62,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]IncidentReceiverSe
 	err := common.ReadAllPages(ctx, service.Client, dlpIncidentReceiverEndpoint, &incidentReceiver)
 	return incidentReceiver, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[IncidentReceiverServers, error] {
+	return common.AllPages[IncidentReceiverServers](ctx, service.Client, dlpIncidentReceiverEndpoint, opts...)
+}
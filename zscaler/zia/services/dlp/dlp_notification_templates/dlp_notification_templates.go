@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -60,7 +62,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, templateName strin
 			return &template, nil
 		}
 	}
-	return nil, fmt.Errorf("no dictionary found with name: %s", templateName)
+	return nil, errorx.NotFoundf("no dictionary found with name: %s", templateName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, dlpTemplateID *DlpNotificationTemplates) (*DlpNotificationTemplates, *http.Response, error) {
@@ -103,3 +105,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]DlpNotificationTem
 	err := common.ReadAllPages(ctx, service.Client, dlpNotificationTemplatesEndpoint, &dlpTemplates)
 	return dlpTemplates, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[DlpNotificationTemplates, error] {
+	return common.AllPages[DlpNotificationTemplates](ctx, service.Client, dlpNotificationTemplatesEndpoint, opts...)
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -200,7 +202,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, ruleName string) (
 			}
 		}
 	}
-	return nil, fmt.Errorf("no web dlp rule found with name: %s", ruleName)
+	return nil, errorx.NotFoundf("no web dlp rule found with name: %s", ruleName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, ruleID *WebDLPRules) (*WebDLPRules, error) {
@@ -243,3 +245,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]WebDLPRules, error
 	err := common.ReadAllPages(ctx, service.Client, webDlpRulesEndpoint, &webDlpRules)
 	return webDlpRules, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[WebDLPRules, error] {
+	return common.AllPages[WebDLPRules](ctx, service.Client, webDlpRulesEndpoint, opts...)
+}
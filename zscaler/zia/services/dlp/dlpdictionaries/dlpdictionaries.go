@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -166,7 +168,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, dictionaryName str
 			return &dictionary, nil
 		}
 	}
-	return nil, fmt.Errorf("no dictionary found with name: %s", dictionaryName)
+	return nil, errorx.NotFoundf("no dictionary found with name: %s", dictionaryName)
 }
 
 func GetPredefinedIdentifiers(ctx context.Context, service *zscaler.Service, dictionaryName string) ([]string, int, error) {
@@ -225,3 +227,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]DlpDictionary, err
 	err := common.ReadAllPages(ctx, service.Client, dlpDictionariesEndpoint, &dictionaries)
 	return dictionaries, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[DlpDictionary, error] {
+	return common.AllPages[DlpDictionary](ctx, service.Client, dlpDictionariesEndpoint, opts...)
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -73,7 +75,7 @@ func GetEmailProfileByName(ctx context.Context, service *zscaler.Service, profil
 			return &emailProfile, nil
 		}
 	}
-	return nil, fmt.Errorf("no email profile found with name: %s", profileName)
+	return nil, errorx.NotFoundf("no email profile found with name: %s", profileName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, profiles *EmailProfiles) (*EmailProfiles, *http.Response, error) {
@@ -115,6 +117,16 @@ func Delete(ctx context.Context, service *zscaler.Service, profileID int) (*http
 // Page and pageSize are handled by common.ReadAllPages.
 func GetAllLite(ctx context.Context, service *zscaler.Service, opts *GetAllLiteFilterOptions) ([]EmailProfiles, error) {
 	var emailProfiles []EmailProfiles
+	err := common.ReadAllPages(ctx, service.Client, allLiteEndpoint(opts), &emailProfiles)
+	return emailProfiles, err
+}
+
+// AllLite is like GetAllLite, but returns an iterator that fetches one page at a time.
+func AllLite(ctx context.Context, service *zscaler.Service, opts *GetAllLiteFilterOptions, pageOpts ...zscaler.PageOption) iter.Seq2[EmailProfiles, error] {
+	return common.AllPages[EmailProfiles](ctx, service.Client, allLiteEndpoint(opts), pageOpts...)
+}
+
+func allLiteEndpoint(opts *GetAllLiteFilterOptions) string {
 	endpoint := emailProfilesEndpoint + "/lite"
 
 	queryParams := url.Values{}
@@ -135,15 +147,23 @@ func GetAllLite(ctx context.Context, service *zscaler.Service, opts *GetAllLiteF
 	if len(queryParams) > 0 {
 		endpoint += "?" + queryParams.Encode()
 	}
-
-	err := common.ReadAllPages(ctx, service.Client, endpoint, &emailProfiles)
-	return emailProfiles, err
+	return endpoint
 }
 
 // GetAll retrieves all email recipient profiles with optional filters.
 // Page and pageSize are handled by common.ReadAllPages.
 func GetAll(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOptions) ([]EmailProfiles, error) {
 	var emailProfiles []EmailProfiles
+	err := common.ReadAllPages(ctx, service.Client, getAllEndpoint(opts), &emailProfiles)
+	return emailProfiles, err
+}
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOptions, pageOpts ...zscaler.PageOption) iter.Seq2[EmailProfiles, error] {
+	return common.AllPages[EmailProfiles](ctx, service.Client, getAllEndpoint(opts), pageOpts...)
+}
+
+func getAllEndpoint(opts *GetAllFilterOptions) string {
 	endpoint := emailProfilesEndpoint
 
 	queryParams := url.Values{}
@@ -161,9 +181,7 @@ func GetAll(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOpt
 	if len(queryParams) > 0 {
 		endpoint += "?" + queryParams.Encode()
 	}
-
-	err := common.ReadAllPages(ctx, service.Client, endpoint, &emailProfiles)
-	return emailProfiles, err
+	return endpoint
 }
 
 // GetCount retrieves the count of recipient email profiles with optional filters.
@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -56,7 +58,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, ruleName string) (
 			return &fileTypeControlRule, nil
 		}
 	}
-	return nil, fmt.Errorf("no custom file types found with name: %s", ruleName)
+	return nil, errorx.NotFoundf("no custom file types found with name: %s", ruleName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, ruleID *CustomFileTypes) (*CustomFileTypes, error) {
@@ -100,6 +102,11 @@ func GetCustomFileTypes(ctx context.Context, service *zscaler.Service) ([]Custom
 	return customFileTypes, err
 }
 
+// AllCustomFileTypes is like GetCustomFileTypes, but returns an iterator that fetches one page at a time.
+func AllCustomFileTypes(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[CustomFileTypes, error] {
+	return common.AllPages[CustomFileTypes](ctx, service.Client, customFilefileType, opts...)
+}
+
 // GetCustomFileTypeCountFilterOptions represents optional filter parameters for GetCustomFileTypeCount
 type GetCustomFileTypeCountFilterOptions struct {
 	// Query string to filter custom file types by name or other attributes
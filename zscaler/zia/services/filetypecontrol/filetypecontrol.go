@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -176,7 +178,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, ruleName string) (
 			return &fileTypeControlRule, nil
 		}
 	}
-	return nil, fmt.Errorf("no file type control rule found with name: %s", ruleName)
+	return nil, errorx.NotFoundf("no file type control rule found with name: %s", ruleName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, ruleID *FileTypeRules) (*FileTypeRules, error) {
@@ -220,6 +222,11 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]FileTypeRules, err
 	return fileTypeRules, err
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[FileTypeRules, error] {
+	return common.AllPages[FileTypeRules](ctx, service.Client, fileTypeControlEndpoint, opts...)
+}
+
 // GetFileTypeCategoriesFilterOptions represents optional filter parameters for GetFileTypeCategories
 type GetFileTypeCategoriesFilterOptions struct {
 	// Enum values to filter file types for specific policy categories.
@@ -233,6 +240,16 @@ type GetFileTypeCategoriesFilterOptions struct {
 // available for configuring rule conditions in different ZIA policies.
 func GetFileTypeCategories(ctx context.Context, service *zscaler.Service, opts *GetFileTypeCategoriesFilterOptions) ([]FileTypeCategory, error) {
 	var fileTypeCategory []FileTypeCategory
+	err := common.ReadAllPages(ctx, service.Client, allFileTypeCategoriesEndpoint(opts), &fileTypeCategory)
+	return fileTypeCategory, err
+}
+
+// AllFileTypeCategories is like GetFileTypeCategories, but returns an iterator that fetches one page at a time.
+func AllFileTypeCategories(ctx context.Context, service *zscaler.Service, opts *GetFileTypeCategoriesFilterOptions, pageOpts ...zscaler.PageOption) iter.Seq2[FileTypeCategory, error] {
+	return common.AllPages[FileTypeCategory](ctx, service.Client, allFileTypeCategoriesEndpoint(opts), pageOpts...)
+}
+
+func allFileTypeCategoriesEndpoint(opts *GetFileTypeCategoriesFilterOptions) string {
 	endpoint := fileTypeCategoriesEndPoint
 
 	// Build query parameters
@@ -253,9 +270,7 @@ func GetFileTypeCategories(ctx context.Context, service *zscaler.Service, opts *
 	if baseQuery != "" {
 		endpoint += "?" + baseQuery
 	}
-
-	err := common.ReadAllPages(ctx, service.Client, endpoint, &fileTypeCategory)
-	return fileTypeCategory, err
+	return endpoint
 }
 
 func GetCustomFileTypes(ctx context.Context, service *zscaler.Service) ([]CustomFileTypes, error) {
@@ -263,3 +278,8 @@ func GetCustomFileTypes(ctx context.Context, service *zscaler.Service) ([]Custom
 	err := common.ReadAllPages(ctx, service.Client, customFilefileType, &fileTypeRules)
 	return fileTypeRules, err
 }
+
+// AllCustomFileTypes is like GetCustomFileTypes, but returns an iterator that fetches one page at a time.
+func AllCustomFileTypes(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[CustomFileTypes, error] {
+	return common.AllPages[CustomFileTypes](ctx, service.Client, customFilefileType, opts...)
+}
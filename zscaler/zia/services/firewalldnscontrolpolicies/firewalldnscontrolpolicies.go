@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -172,7 +174,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, ruleName string) (
 			return &rule, nil
 		}
 	}
-	return nil, fmt.Errorf("no firewall dns rule found with name: %s", ruleName)
+	return nil, errorx.NotFoundf("no firewall dns rule found with name: %s", ruleName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, rule *FirewallDNSRules) (*FirewallDNSRules, error) {
@@ -261,6 +263,11 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]FirewallDNSRules,
 	return rules, err
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[FirewallDNSRules, error] {
+	return common.AllPages[FirewallDNSRules](ctx, service.Client, firewallDnsRulesEndpoint, opts...)
+}
+
 /*
 func validateFirewallDNSRules(rule *FirewallDNSRules) error {
 	switch rule.Action {
@@ -2,10 +2,11 @@ package applicationservices
 
 import (
 	"context"
-	"fmt"
+	"iter"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -30,7 +31,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, serviceName string
 			return &appServicesLite, nil
 		}
 	}
-	return nil, fmt.Errorf("no application services found with name: %s", serviceName)
+	return nil, errorx.NotFoundf("no application services found with name: %s", serviceName)
 }
 
 func GetAll(ctx context.Context, service *zscaler.Service) ([]ApplicationServicesLite, error) {
@@ -38,3 +39,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]ApplicationService
 	err := common.ReadAllPages(ctx, service.Client, appServicesLiteEndpoint, &appServices)
 	return appServices, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[ApplicationServicesLite, error] {
+	return common.AllPages[ApplicationServicesLite](ctx, service.Client, appServicesLiteEndpoint, opts...)
+}
@@ -2,10 +2,11 @@ package appservicegroups
 
 import (
 	"context"
-	"fmt"
+	"iter"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -30,7 +31,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, serviceGroupName s
 			return &appServicesGroupLite, nil
 		}
 	}
-	return nil, fmt.Errorf("no app services group found with name: %s", serviceGroupName)
+	return nil, errorx.NotFoundf("no app services group found with name: %s", serviceGroupName)
 }
 
 func GetAll(ctx context.Context, service *zscaler.Service) ([]ApplicationServicesGroupLite, error) {
@@ -38,3 +39,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]ApplicationService
 	err := common.ReadAllPages(ctx, service.Client, appServicesGroupLiteEndpoint, &appServiceGroups)
 	return appServiceGroups, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[ApplicationServicesGroupLite, error] {
+	return common.AllPages[ApplicationServicesGroupLite](ctx, service.Client, appServicesGroupLiteEndpoint, opts...)
+}
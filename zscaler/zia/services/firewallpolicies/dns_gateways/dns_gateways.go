@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -54,7 +56,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, gwName string) (*D
 			return &dnsGateway, nil
 		}
 	}
-	return nil, fmt.Errorf("no dns gateway found with name: %s", gwName)
+	return nil, errorx.NotFoundf("no dns gateway found with name: %s", gwName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, gwID *DNSGateways) (*DNSGateways, *http.Response, error) {
@@ -98,8 +100,18 @@ func GetAllLite(ctx context.Context, service *zscaler.Service) ([]DNSGateways, e
 	return dnsGateways, err
 }
 
+// AllLite is like GetAllLite, but returns an iterator that fetches one page at a time.
+func AllLite(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[DNSGateways, error] {
+	return common.AllPages[DNSGateways](ctx, service.Client, dnsGatewaysEndpoint+"/lite", opts...)
+}
+
 func GetAll(ctx context.Context, service *zscaler.Service) ([]DNSGateways, error) {
 	var dnsGateways []DNSGateways
 	err := common.ReadAllPages(ctx, service.Client, dnsGatewaysEndpoint, &dnsGateways)
 	return dnsGateways, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[DNSGateways, error] {
+	return common.AllPages[DNSGateways](ctx, service.Client, dnsGatewaysEndpoint, opts...)
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -188,7 +190,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, ruleName string) (
 			return &rule, nil
 		}
 	}
-	return nil, fmt.Errorf("no firewall rule found with name: %s", ruleName)
+	return nil, errorx.NotFoundf("no firewall rule found with name: %s", ruleName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, rule *FirewallFilteringRules) (*FirewallFilteringRules, error) {
@@ -229,6 +231,18 @@ func Delete(ctx context.Context, service *zscaler.Service, ruleID int) (*http.Re
 // This endpoint supports a maximum page size of 5000.
 func GetAll(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOptions) ([]FirewallFilteringRules, error) {
 	var rules []FirewallFilteringRules
+	// Use pageSize=5000 as this endpoint supports it
+	err := common.ReadAllPages(ctx, service.Client, getAllEndpoint(opts), &rules, 5000)
+	return rules, err
+}
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOptions, pageOpts ...zscaler.PageOption) iter.Seq2[FirewallFilteringRules, error] {
+	pageOpts = append([]zscaler.PageOption{zscaler.WithPageSize(5000)}, pageOpts...)
+	return common.AllPages[FirewallFilteringRules](ctx, service.Client, getAllEndpoint(opts), pageOpts...)
+}
+
+func getAllEndpoint(opts *GetAllFilterOptions) string {
 	endpoint := firewallRulesEndpoint
 
 	// Build query parameters from filter options
@@ -301,10 +315,7 @@ func GetAll(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOpt
 	if baseQuery != "" {
 		endpoint += "?" + baseQuery
 	}
-
-	// Use pageSize=5000 as this endpoint supports it
-	err := common.ReadAllPages(ctx, service.Client, endpoint, &rules, 5000)
-	return rules, err
+	return endpoint
 }
 
 // GetFirewallFilteringRuleCount retrieves the count of firewall filtering rules using optional filters.
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 )
 
 const (
@@ -63,7 +64,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, ipDestinationGroup
 			return &ipDestinationGroup, nil
 		}
 	}
-	return nil, fmt.Errorf("no ip destination group found with name: %s", ipDestinationGroupsName)
+	return nil, errorx.NotFoundf("no ip destination group found with name: %s", ipDestinationGroupsName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, ipGroupID *IPDestinationGroups) (*IPDestinationGroups, error) {
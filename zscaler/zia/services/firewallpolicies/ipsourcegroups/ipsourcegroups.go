@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 )
 
 const (
@@ -53,7 +54,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, ipSourceGroupsName
 			return &ipSourceGroup, nil
 		}
 	}
-	return nil, fmt.Errorf("no ip source group found with name: %s", ipSourceGroupsName)
+	return nil, errorx.NotFoundf("no ip source group found with name: %s", ipSourceGroupsName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, ipGroupID *IPSourceGroups) (*IPSourceGroups, error) {
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -44,7 +46,7 @@ func GetNetworkApplicationGroupsByName(ctx context.Context, service *zscaler.Ser
 			return &networkAppGroup, nil
 		}
 	}
-	return nil, fmt.Errorf("no network application groups found with name: %s", appGroupsName)
+	return nil, errorx.NotFoundf("no network application groups found with name: %s", appGroupsName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, applicationGroup *NetworkApplicationGroups) (*NetworkApplicationGroups, error) {
@@ -87,3 +89,8 @@ func GetAllNetworkApplicationGroups(ctx context.Context, service *zscaler.Servic
 	err := common.ReadAllPages(ctx, service.Client, networkAppGroupsEndpoint, &networkApplicationGroups)
 	return networkApplicationGroups, err
 }
+
+// AllNetworkApplicationGroups is like GetAllNetworkApplicationGroups, but returns an iterator that fetches one page at a time.
+func AllNetworkApplicationGroups(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[NetworkApplicationGroups, error] {
+	return common.AllPages[NetworkApplicationGroups](ctx, service.Client, networkAppGroupsEndpoint, opts...)
+}
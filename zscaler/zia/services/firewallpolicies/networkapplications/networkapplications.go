@@ -3,9 +3,11 @@ package networkapplications
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/url"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -50,7 +52,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, nwApplicationName,
 		return &networkApplications[0], nil
 	}
 
-	return nil, fmt.Errorf("no network application found with name: %s", nwApplicationName)
+	return nil, errorx.NotFoundf("no network application found with name: %s", nwApplicationName)
 }
 
 func GetAll(ctx context.Context, service *zscaler.Service, locale string) ([]NetworkApplications, error) {
@@ -63,3 +65,13 @@ func GetAll(ctx context.Context, service *zscaler.Service, locale string) ([]Net
 	err := common.ReadAllPages(ctx, service.Client, endpoint, &networkApplications)
 	return networkApplications, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, locale string, opts ...zscaler.PageOption) iter.Seq2[NetworkApplications, error] {
+	endpoint := networkApplicationsEndpoint
+	if locale != "" {
+		// Properly escape the locale string and append it as a query parameter
+		endpoint = fmt.Sprintf("%s?locale=%s", networkApplicationsEndpoint, url.QueryEscape(locale))
+	}
+	return common.AllPages[NetworkApplications](ctx, service.Client, endpoint, opts...)
+}
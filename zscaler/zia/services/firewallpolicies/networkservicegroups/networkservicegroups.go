@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/firewallpolicies/networkservices"
 )
@@ -58,7 +60,7 @@ func GetNetworkServiceGroupsByName(ctx context.Context, service *zscaler.Service
 			return &networkServiceGroup, nil
 		}
 	}
-	return nil, fmt.Errorf("no network service groups found with name: %s", serviceGroupsName)
+	return nil, errorx.NotFoundf("no network service groups found with name: %s", serviceGroupsName)
 }
 
 func CreateNetworkServiceGroups(ctx context.Context, service *zscaler.Service, networkServiceGroups *NetworkServiceGroups) (*NetworkServiceGroups, error) {
@@ -101,3 +103,8 @@ func GetAllNetworkServiceGroups(ctx context.Context, service *zscaler.Service) (
 	err := common.ReadAllPages(ctx, service.Client, networkServiceGroupsEndpoint, &networkServiceGroups)
 	return networkServiceGroups, err
 }
+
+// AllNetworkServiceGroups is like GetAllNetworkServiceGroups, but returns an iterator that fetches one page at a time.
+func AllNetworkServiceGroups(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[NetworkServiceGroups, error] {
+	return common.AllPages[NetworkServiceGroups](ctx, service.Client, networkServiceGroupsEndpoint, opts...)
+}
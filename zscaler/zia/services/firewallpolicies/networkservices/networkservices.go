@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -76,7 +78,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, networkServiceName
 			if len(networkServices) > 0 {
 				return &networkServices[0], nil
 			}
-			return nil, fmt.Errorf("no network services found with the provided filters (protocol: %v, locale: %v)", protocol, locale)
+			return nil, errorx.NotFoundf("no network services found with the provided filters (protocol: %v, locale: %v)", protocol, locale)
 		}
 		return nil, fmt.Errorf("name parameter is required when protocol and locale are not provided")
 	}
@@ -87,7 +89,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, networkServiceName
 			return &networkService, nil
 		}
 	}
-	return nil, fmt.Errorf("no network services found with name: %s", networkServiceName)
+	return nil, errorx.NotFoundf("no network services found with name: %s", networkServiceName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, networkService *NetworkServices) (*NetworkServices, error) {
@@ -127,7 +129,16 @@ func Delete(ctx context.Context, service *zscaler.Service, serviceID int) (*http
 
 func GetAllNetworkServices(ctx context.Context, service *zscaler.Service, protocol, locale *string) ([]NetworkServices, error) {
 	var networkServices []NetworkServices
+	err := common.ReadAllPages(ctx, service.Client, allNetworkServicesEndpoint(protocol, locale), &networkServices)
+	return networkServices, err
+}
+
+// AllNetworkServices is like GetAllNetworkServices, but returns an iterator that fetches one page at a time.
+func AllNetworkServices(ctx context.Context, service *zscaler.Service, protocol, locale *string, opts ...zscaler.PageOption) iter.Seq2[NetworkServices, error] {
+	return common.AllPages[NetworkServices](ctx, service.Client, allNetworkServicesEndpoint(protocol, locale), opts...)
+}
 
+func allNetworkServicesEndpoint(protocol, locale *string) string {
 	// Build the endpoint with optional query parameters
 	endpoint := networkServicesEndpoint
 	queryParams := url.Values{}
@@ -143,7 +154,5 @@ func GetAllNetworkServices(ctx context.Context, service *zscaler.Service, protoc
 	if len(queryParams) > 0 {
 		endpoint = fmt.Sprintf("%s?%s", endpoint, queryParams.Encode())
 	}
-
-	err := common.ReadAllPages(ctx, service.Client, endpoint, &networkServices)
-	return networkServices, err
+	return endpoint
 }
@@ -0,0 +1,517 @@
+package ruleanalyzer
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/firewallpolicies/filteringrules"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/firewallpolicies/networkservices"
+)
+
+// matcher is the traffic a rule matches. A nil field matches anything.
+type matcher struct {
+	src      *addresses
+	dst      *addresses
+	services *serviceSet
+	criteria map[string]tokens
+}
+
+// Criteria compared by ID or name.
+const (
+	criterionApplications      = "applications"
+	criterionLocations         = "locations"
+	criterionUsers             = "users"
+	criterionTimeWindows       = "timeWindows"
+	criterionSourceCountries   = "sourceCountries"
+	criterionDeviceTrustLevels = "deviceTrustLevels"
+	criterionDeviceGroups      = "deviceGroups"
+	criterionDevices           = "devices"
+	criterionWorkloadGroups    = "workloadGroups"
+	criterionZPAAppSegments    = "zpaAppSegments"
+)
+
+func (m *matcher) covers(o *matcher) bool {
+	if !m.src.covers(o.src) || !m.dst.covers(o.dst) || !m.services.covers(o.services) {
+		return false
+	}
+	for name, t := range m.criteria {
+		if !t.covers(o.criteria[name]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *matcher) intersects(o *matcher) bool {
+	if !m.src.intersects(o.src) || !m.dst.intersects(o.dst) || !m.services.intersects(o.services) {
+		return false
+	}
+	for name, t := range m.criteria {
+		if !t.intersects(o.criteria[name]) {
+			return false
+		}
+	}
+	return true
+}
+
+// broad reports whether the rule matches any source, destination, network
+// service, application and ZPA app segment.
+func (m *matcher) broad() bool {
+	return m.src == nil && m.dst == nil && m.services == nil &&
+		m.criteria[criterionApplications] == nil && m.criteria[criterionZPAAppSegments] == nil
+}
+
+// resolver resolves the references of rules to the objects of a snapshot.
+type resolver struct {
+	srcGroups     map[int]*addresses
+	dstGroups     map[int]*addresses
+	services      map[int]*serviceSet
+	serviceGroups map[int]*serviceSet
+}
+
+func newResolver(s *Snapshot) *resolver {
+	r := &resolver{
+		srcGroups:     make(map[int]*addresses),
+		dstGroups:     make(map[int]*addresses),
+		services:      make(map[int]*serviceSet),
+		serviceGroups: make(map[int]*serviceSet),
+	}
+	for _, g := range s.SourceIPGroups {
+		a := &addresses{}
+		a.addAll(g.IPAddresses)
+		r.srcGroups[g.ID] = a
+	}
+	for _, g := range s.DestinationIPGroups {
+		a := &addresses{}
+		a.addAll(g.Addresses)
+		a.categories = a.categories.add(g.IPCategories...)
+		a.countries = a.countries.add(g.Countries...)
+		r.dstGroups[g.ID] = a
+	}
+	for _, svc := range s.NetworkServices {
+		r.services[svc.ID] = newServiceSet(svc.ID, svc.DestTCPPorts, svc.DestUDPPorts)
+	}
+	for _, g := range s.NetworkServiceGroups {
+		set := &serviceSet{}
+		for _, svc := range g.Services {
+			member := newServiceSet(svc.ID, svc.DestTCPPorts, svc.DestUDPPorts)
+			// Groups may list their services without ports.
+			if known, ok := r.services[svc.ID]; ok && len(member.other) > 0 {
+				member = known
+			}
+			set.merge(member)
+		}
+		r.serviceGroups[g.ID] = set
+	}
+	return r
+}
+
+// matcher builds the matcher of rule, and returns the references it could not
+// resolve.
+func (r *resolver) matcher(rule *filteringrules.FirewallFilteringRules) (*matcher, []string) {
+	var unresolved []string
+	resolve := func(kind string, refs []common.IDNameExtensions, known map[int]*addresses, into *addresses) {
+		for _, ref := range refs {
+			if a, ok := known[ref.ID]; ok {
+				into.merge(a)
+				continue
+			}
+			unresolved = append(unresolved, fmt.Sprintf("%s %d", kind, ref.ID))
+			into.names = append(into.names, kind+":"+strconv.Itoa(ref.ID))
+		}
+	}
+
+	m := &matcher{criteria: make(map[string]tokens)}
+	if len(rule.SrcIps) > 0 || len(rule.SrcIpGroups) > 0 {
+		m.src = &addresses{}
+		m.src.addAll(rule.SrcIps)
+		resolve("source IP group", rule.SrcIpGroups, r.srcGroups, m.src)
+	}
+	if len(rule.DestAddresses) > 0 || len(rule.DestIpGroups) > 0 || len(rule.DestIpCategories) > 0 || len(rule.DestCountries) > 0 {
+		m.dst = &addresses{}
+		m.dst.addAll(rule.DestAddresses)
+		m.dst.categories = m.dst.categories.add(rule.DestIpCategories...)
+		m.dst.countries = m.dst.countries.add(rule.DestCountries...)
+		resolve("destination IP group", rule.DestIpGroups, r.dstGroups, m.dst)
+	}
+	if len(rule.NwServices) > 0 || len(rule.NwServiceGroups) > 0 {
+		m.services = &serviceSet{}
+		for _, ref := range rule.NwServices {
+			if s, ok := r.services[ref.ID]; ok {
+				m.services.merge(s)
+				continue
+			}
+			unresolved = append(unresolved, fmt.Sprintf("network service %d", ref.ID))
+			m.services.other = m.services.other.add("service:" + strconv.Itoa(ref.ID))
+		}
+		for _, ref := range rule.NwServiceGroups {
+			if s, ok := r.serviceGroups[ref.ID]; ok {
+				m.services.merge(s)
+				continue
+			}
+			unresolved = append(unresolved, fmt.Sprintf("network service group %d", ref.ID))
+			m.services.other = m.services.other.add("serviceGroup:" + strconv.Itoa(ref.ID))
+		}
+	}
+
+	m.criteria[criterionApplications] = tokens(nil).
+		add(rule.NwApplications...).
+		addIDs("applicationGroup", rule.NwApplicationGroups).
+		addIDs("appService", rule.AppServices).
+		addIDs("appServiceGroup", rule.AppServiceGroups)
+	m.criteria[criterionLocations] = tokens(nil).
+		addIDs("location", rule.Locations).
+		addIDs("locationGroup", rule.LocationsGroups)
+	m.criteria[criterionUsers] = tokens(nil).
+		addIDs("user", rule.Users).
+		addIDs("group", rule.Groups).
+		addIDs("department", rule.Departments)
+	m.criteria[criterionTimeWindows] = tokens(nil).addIDs("timeWindow", rule.TimeWindows)
+	m.criteria[criterionDeviceTrustLevels] = tokens(nil).add(rule.DeviceTrustLevels...)
+	m.criteria[criterionDeviceGroups] = tokens(nil).addIDs("deviceGroup", rule.DeviceGroups)
+	m.criteria[criterionDevices] = tokens(nil).addIDs("device", rule.Devices)
+	for _, w := range rule.WorkloadGroups {
+		m.criteria[criterionWorkloadGroups] = m.criteria[criterionWorkloadGroups].add("workloadGroup:" + strconv.Itoa(w.ID))
+	}
+	for _, segment := range rule.ZPAAppSegments {
+		id := segment.ExternalID
+		if id == "" {
+			id = strconv.Itoa(segment.ID)
+		}
+		m.criteria[criterionZPAAppSegments] = m.criteria[criterionZPAAppSegments].add("zpaAppSegment:" + id)
+	}
+	if len(rule.SourceCountries) > 0 {
+		if rule.ExcludeSrcCountries {
+			// An exclusion list only equals itself.
+			countries := append([]string(nil), rule.SourceCountries...)
+			sort.Strings(countries)
+			m.criteria[criterionSourceCountries] = tokens(nil).add("not:" + strings.Join(countries, ","))
+		} else {
+			m.criteria[criterionSourceCountries] = tokens(nil).add(rule.SourceCountries...)
+		}
+	}
+	return m, unresolved
+}
+
+// tokens is a set of IDs or names. A nil set matches anything.
+type tokens map[string]bool
+
+func (t tokens) add(values ...string) tokens {
+	for _, v := range values {
+		if t == nil {
+			t = make(tokens)
+		}
+		t[v] = true
+	}
+	return t
+}
+
+func (t tokens) addIDs(kind string, refs []common.IDNameExtensions) tokens {
+	for _, ref := range refs {
+		t = t.add(kind + ":" + strconv.Itoa(ref.ID))
+	}
+	return t
+}
+
+func (t tokens) covers(o tokens) bool {
+	if t == nil {
+		return true
+	}
+	if o == nil {
+		return false
+	}
+	return t.contains(o)
+}
+
+// contains reports whether every token of o is in t, treating both as plain
+// sets.
+func (t tokens) contains(o tokens) bool {
+	for v := range o {
+		if !t[v] {
+			return false
+		}
+	}
+	return true
+}
+
+func (t tokens) intersects(o tokens) bool {
+	if t == nil || o == nil {
+		return true
+	}
+	return t.shares(o)
+}
+
+func (t tokens) shares(o tokens) bool {
+	for v := range o {
+		if t[v] {
+			return true
+		}
+	}
+	return false
+}
+
+// addresses is a set of IP ranges, FQDNs, IP categories and countries. A nil
+// set matches any address.
+type addresses struct {
+	ranges     []interval[netip.Addr]
+	names      []string
+	categories tokens
+	countries  tokens
+}
+
+// addAll adds IP addresses, CIDRs, IP ranges and FQDNs. Wildcard FQDNs are
+// kept with a leading dot, as in ".example.com".
+func (a *addresses) addAll(values []string) {
+	for _, v := range values {
+		v = strings.ToLower(strings.TrimSpace(v))
+		if v == "" {
+			continue
+		}
+		if r, ok := parseIPRange(v); ok {
+			a.ranges = append(a.ranges, r)
+			continue
+		}
+		a.names = append(a.names, strings.TrimPrefix(v, "*"))
+	}
+}
+
+func (a *addresses) merge(o *addresses) {
+	a.ranges = append(a.ranges, o.ranges...)
+	a.names = append(a.names, o.names...)
+	a.categories = a.categories.add(keys(o.categories)...)
+	a.countries = a.countries.add(keys(o.countries)...)
+}
+
+func (a *addresses) covers(o *addresses) bool {
+	if a == nil {
+		return true
+	}
+	if o == nil {
+		return false
+	}
+	merged := mergeIntervals(a.ranges)
+	for _, r := range o.ranges {
+		if !containsInterval(merged, r) {
+			return false
+		}
+	}
+	for _, n := range o.names {
+		covered := false
+		for _, an := range a.names {
+			if fqdnCovers(an, n) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return a.categories.contains(o.categories) && a.countries.contains(o.countries)
+}
+
+// intersects reports whether a and o share an address. An IP address and an
+// FQDN or a category are not compared, so the result may miss overlaps but
+// does not report false ones.
+func (a *addresses) intersects(o *addresses) bool {
+	if a == nil || o == nil {
+		return true
+	}
+	if overlapIntervals(a.ranges, o.ranges) {
+		return true
+	}
+	for _, an := range a.names {
+		for _, on := range o.names {
+			if fqdnCovers(an, on) || fqdnCovers(on, an) {
+				return true
+			}
+		}
+	}
+	return a.categories.shares(o.categories) || a.countries.shares(o.countries)
+}
+
+// fqdnCovers reports whether the FQDN pattern a matches every name b does.
+// A pattern with a leading dot matches the domain and its subdomains.
+func fqdnCovers(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if !strings.HasPrefix(a, ".") {
+		return false
+	}
+	return strings.HasSuffix(b, a) || strings.TrimPrefix(b, ".") == a[1:]
+}
+
+func parseIPRange(s string) (interval[netip.Addr], bool) {
+	if strings.Contains(s, "/") {
+		p, err := netip.ParsePrefix(s)
+		if err != nil {
+			return interval[netip.Addr]{}, false
+		}
+		p = p.Masked()
+		lo := p.Addr().Unmap()
+		b := lo.AsSlice()
+		for i := p.Bits(); i < len(b)*8; i++ {
+			b[i/8] |= 1 << (7 - i%8)
+		}
+		hi, _ := netip.AddrFromSlice(b)
+		return interval[netip.Addr]{lo, hi}, true
+	}
+	if from, to, ok := strings.Cut(s, "-"); ok {
+		lo, err1 := netip.ParseAddr(strings.TrimSpace(from))
+		hi, err2 := netip.ParseAddr(strings.TrimSpace(to))
+		if err1 != nil || err2 != nil || lo.BitLen() != hi.BitLen() || hi.Less(lo) {
+			return interval[netip.Addr]{}, false
+		}
+		return interval[netip.Addr]{lo, hi}, true
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return interval[netip.Addr]{}, false
+	}
+	addr = addr.Unmap()
+	return interval[netip.Addr]{addr, addr}, true
+}
+
+// serviceSet is a set of TCP and UDP destination ports. Services without
+// ports, such as ICMP, are compared by ID. A nil set matches any service.
+type serviceSet struct {
+	tcp   []interval[port]
+	udp   []interval[port]
+	other tokens
+}
+
+func newServiceSet(id int, tcp, udp []networkservices.NetworkPorts) *serviceSet {
+	s := &serviceSet{tcp: portIntervals(tcp), udp: portIntervals(udp)}
+	if len(s.tcp) == 0 && len(s.udp) == 0 {
+		s.other = s.other.add("service:" + strconv.Itoa(id))
+	}
+	return s
+}
+
+func portIntervals(ports []networkservices.NetworkPorts) []interval[port] {
+	var out []interval[port]
+	for _, p := range ports {
+		end := p.End
+		if end == 0 {
+			end = p.Start
+		}
+		out = append(out, interval[port]{port(p.Start), port(end)})
+	}
+	return out
+}
+
+func (s *serviceSet) merge(o *serviceSet) {
+	s.tcp = append(s.tcp, o.tcp...)
+	s.udp = append(s.udp, o.udp...)
+	s.other = s.other.add(keys(o.other)...)
+}
+
+func (s *serviceSet) covers(o *serviceSet) bool {
+	if s == nil {
+		return true
+	}
+	if o == nil {
+		return false
+	}
+	tcp, udp := mergeIntervals(s.tcp), mergeIntervals(s.udp)
+	for _, r := range o.tcp {
+		if !containsInterval(tcp, r) {
+			return false
+		}
+	}
+	for _, r := range o.udp {
+		if !containsInterval(udp, r) {
+			return false
+		}
+	}
+	return s.other.contains(o.other)
+}
+
+func (s *serviceSet) intersects(o *serviceSet) bool {
+	if s == nil || o == nil {
+		return true
+	}
+	return overlapIntervals(s.tcp, o.tcp) || overlapIntervals(s.udp, o.udp) || s.other.shares(o.other)
+}
+
+// port is a TCP or UDP port, ordered like netip.Addr so both share the
+// interval code.
+type port int
+
+func (p port) Compare(o port) int {
+	switch {
+	case p < o:
+		return -1
+	case p > o:
+		return 1
+	}
+	return 0
+}
+
+func (p port) Next() port {
+	return p + 1
+}
+
+type bound[T any] interface {
+	Compare(T) int
+	Next() T
+}
+
+// interval is the closed range [lo, hi].
+type interval[T bound[T]] struct {
+	lo, hi T
+}
+
+// mergeIntervals sorts in and joins overlapping and adjacent intervals.
+func mergeIntervals[T bound[T]](in []interval[T]) []interval[T] {
+	sorted := append([]interval[T](nil), in...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].lo.Compare(sorted[j].lo) < 0 })
+	var out []interval[T]
+	for _, r := range sorted {
+		if n := len(out); n > 0 {
+			last := &out[n-1]
+			if r.lo.Compare(last.hi) <= 0 || r.lo.Compare(last.hi.Next()) == 0 {
+				if r.hi.Compare(last.hi) > 0 {
+					last.hi = r.hi
+				}
+				continue
+			}
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// containsInterval reports whether r lies within one of the merged intervals.
+func containsInterval[T bound[T]](merged []interval[T], r interval[T]) bool {
+	for _, m := range merged {
+		if m.lo.Compare(r.lo) <= 0 && r.hi.Compare(m.hi) <= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func overlapIntervals[T bound[T]](a, b []interval[T]) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x.lo.Compare(y.hi) <= 0 && y.lo.Compare(x.hi) <= 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func keys(t tokens) []string {
+	out := make([]string, 0, len(t))
+	for k := range t {
+		out = append(out, k)
+	}
+	return out
+}
@@ -0,0 +1,313 @@
+// Package ruleanalyzer reports ZIA firewall filtering rules that never match,
+// duplicate an earlier rule, allow any traffic or overlap a rule with the
+// opposite action. It works on a Snapshot, so the same analysis runs against a
+// tenant or against a saved policy in CI.
+package ruleanalyzer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/firewallpolicies/filteringrules"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/firewallpolicies/ipdestinationgroups"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/firewallpolicies/ipsourcegroups"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/firewallpolicies/networkservicegroups"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/firewallpolicies/networkservices"
+)
+
+// Finding types.
+const (
+	// FindingShadowed is reported for a rule that never matches, because an
+	// earlier rule that allows what it blocks, or blocks what it allows,
+	// matches all of its traffic.
+	FindingShadowed = "SHADOWED"
+	// FindingRedundant is reported for a rule that an earlier rule with the
+	// same decision makes useless. The BLOCK_* actions are one decision.
+	FindingRedundant = "REDUNDANT"
+	// FindingOverlyBroad is reported for an allow rule with any source,
+	// destination, network service, application and ZPA app segment.
+	FindingOverlyBroad = "OVERLY_BROAD"
+	// FindingConflict is reported for two rules that match some of the same
+	// traffic with opposite actions, so their order decides the outcome.
+	FindingConflict = "CONFLICT"
+	// FindingUnresolved is reported for a reference to a group or service
+	// missing from the snapshot. The rule is then compared by ID only.
+	FindingUnresolved = "UNRESOLVED_REFERENCE"
+)
+
+// Severities, from lowest to highest.
+const (
+	SeverityInfo   = "INFO"
+	SeverityLow    = "LOW"
+	SeverityMedium = "MEDIUM"
+	SeverityHigh   = "HIGH"
+)
+
+var severityRank = map[string]int{
+	SeverityInfo:   1,
+	SeverityLow:    2,
+	SeverityMedium: 3,
+	SeverityHigh:   4,
+}
+
+// evalNwApp is the action of rules that hand the traffic over to network
+// application evaluation rather than deciding it.
+const evalNwApp = "EVAL_NWAPP"
+
+// Snapshot is the firewall filtering policy of a tenant with the objects its
+// rules reference. It can be saved as JSON and analyzed without a tenant.
+type Snapshot struct {
+	Rules                []filteringrules.FirewallFilteringRules     `json:"rules"`
+	SourceIPGroups       []ipsourcegroups.IPSourceGroups             `json:"sourceIpGroups,omitempty"`
+	DestinationIPGroups  []ipdestinationgroups.IPDestinationGroups   `json:"destinationIpGroups,omitempty"`
+	NetworkServices      []networkservices.NetworkServices           `json:"networkServices,omitempty"`
+	NetworkServiceGroups []networkservicegroups.NetworkServiceGroups `json:"networkServiceGroups,omitempty"`
+}
+
+// GetSnapshot reads the firewall filtering rules of the tenant, and the
+// source IP groups, destination IP groups, network services and network
+// service groups they reference.
+func GetSnapshot(ctx context.Context, service *zscaler.Service) (*Snapshot, error) {
+	rules, err := filteringrules.GetAll(ctx, service, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read firewall filtering rules: %w", err)
+	}
+	srcGroups, err := ipsourcegroups.GetAll(ctx, service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source IP groups: %w", err)
+	}
+	dstGroups, err := ipdestinationgroups.GetAll(ctx, service, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read destination IP groups: %w", err)
+	}
+	services, err := networkservices.GetAllNetworkServices(ctx, service, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read network services: %w", err)
+	}
+	serviceGroups, err := networkservicegroups.GetAllNetworkServiceGroups(ctx, service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read network service groups: %w", err)
+	}
+	return &Snapshot{
+		Rules:                rules,
+		SourceIPGroups:       srcGroups,
+		DestinationIPGroups:  dstGroups,
+		NetworkServices:      services,
+		NetworkServiceGroups: serviceGroups,
+	}, nil
+}
+
+// Finding is one issue of the policy. Related* identify the other rule
+// involved, if any.
+type Finding struct {
+	Type             string `json:"type"`
+	Severity         string `json:"severity"`
+	RuleID           int    `json:"ruleId"`
+	RuleName         string `json:"ruleName"`
+	RuleOrder        int    `json:"ruleOrder"`
+	RelatedRuleID    int    `json:"relatedRuleId,omitempty"`
+	RelatedRuleName  string `json:"relatedRuleName,omitempty"`
+	RelatedRuleOrder int    `json:"relatedRuleOrder,omitempty"`
+	Message          string `json:"message"`
+}
+
+// Report holds the findings of Analyze in rule order.
+type Report struct {
+	RulesAnalyzed int       `json:"rulesAnalyzed"`
+	Findings      []Finding `json:"findings"`
+}
+
+// Count returns the number of findings of type findingType, or of all
+// findings if findingType is empty.
+func (r *Report) Count(findingType string) int {
+	n := 0
+	for _, f := range r.Findings {
+		if findingType == "" || f.Type == findingType {
+			n++
+		}
+	}
+	return n
+}
+
+// AtLeast returns the findings of the given severity or higher. A CI gate
+// fails when it is not empty.
+func (r *Report) AtLeast(severity string) []Finding {
+	var findings []Finding
+	for _, f := range r.Findings {
+		if severityRank[f.Severity] >= severityRank[severity] {
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+type analyzer struct {
+	types   map[string]bool
+	ignored map[int]bool
+}
+
+// Option configures Analyze.
+type Option func(*analyzer)
+
+// WithFindingTypes reports only findings of the given types.
+func WithFindingTypes(types ...string) Option {
+	return func(a *analyzer) {
+		a.types = make(map[string]bool, len(types))
+		for _, t := range types {
+			a.types[t] = true
+		}
+	}
+}
+
+// WithIgnoredRules drops the findings about the rules with the given IDs,
+// for exceptions that were reviewed and accepted.
+func WithIgnoredRules(ids ...int) Option {
+	return func(a *analyzer) {
+		if a.ignored == nil {
+			a.ignored = make(map[int]bool, len(ids))
+		}
+		for _, id := range ids {
+			a.ignored[id] = true
+		}
+	}
+}
+
+// analyzedRule is an enabled rule with its references resolved.
+type analyzedRule struct {
+	rule  *filteringrules.FirewallFilteringRules
+	match *matcher
+}
+
+// Analyze checks the enabled rules of snapshot in evaluation order.
+//
+// A rule is shadowed or redundant if a single earlier rule matches all of its
+// traffic; the analysis does not combine several earlier rules. Rules with
+// the EVAL_NWAPP action do not decide the traffic, so they shadow nothing and
+// conflict with nothing. Users, groups and departments are compared by ID, so
+// a rule for a group does not cover a rule for one of its users.
+func Analyze(snapshot *Snapshot, opts ...Option) *Report {
+	a := &analyzer{}
+	for _, opt := range opts {
+		opt(a)
+	}
+	res := newResolver(snapshot)
+
+	var rules []analyzedRule
+	var findings []Finding
+	for i := range snapshot.Rules {
+		rule := &snapshot.Rules[i]
+		if rule.State == "DISABLED" {
+			continue
+		}
+		m, unresolved := res.matcher(rule)
+		for _, ref := range unresolved {
+			findings = append(findings, Finding{
+				Type:     FindingUnresolved,
+				Severity: SeverityInfo,
+				Message:  fmt.Sprintf("rule %q references %s, which is not in the snapshot; it is compared by ID only", rule.Name, ref),
+			}.about(rule))
+		}
+		rules = append(rules, analyzedRule{rule: rule, match: m})
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		ri, rj := rules[i].rule, rules[j].rule
+		if ri.DefaultRule != rj.DefaultRule {
+			return rj.DefaultRule
+		}
+		return ri.Order < rj.Order
+	})
+
+	for i, r := range rules {
+		findings = append(findings, checkRule(rules[:i], r)...)
+	}
+
+	report := &Report{RulesAnalyzed: len(rules), Findings: []Finding{}}
+	for _, f := range findings {
+		if (a.types == nil || a.types[f.Type]) && !a.ignored[f.RuleID] {
+			report.Findings = append(report.Findings, f)
+		}
+	}
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		return report.Findings[i].RuleOrder < report.Findings[j].RuleOrder
+	})
+	return report
+}
+
+// checkRule compares r with the rules evaluated before it.
+func checkRule(earlier []analyzedRule, r analyzedRule) []Finding {
+	rule := r.rule
+	if rule.DefaultRule {
+		return nil
+	}
+	var findings []Finding
+	if isAllow(rule.Action) && !rule.Predefined && r.match.broad() {
+		findings = append(findings, Finding{
+			Type:     FindingOverlyBroad,
+			Severity: SeverityMedium,
+			Message:  fmt.Sprintf("rule %q allows any source, destination, network service and application", rule.Name),
+		}.about(rule))
+	}
+	for _, e := range earlier {
+		if e.rule.Action == evalNwApp || !e.match.covers(r.match) {
+			continue
+		}
+		if decision(e.rule.Action) == decision(rule.Action) {
+			return append(findings, Finding{
+				Type:     FindingRedundant,
+				Severity: SeverityLow,
+				Message:  fmt.Sprintf("rule %q is redundant: rule %q (order %d) matches all of its traffic first with action %s", rule.Name, e.rule.Name, e.rule.Order, e.rule.Action),
+			}.about(rule).relatedTo(e.rule))
+		}
+		return append(findings, Finding{
+			Type:     FindingShadowed,
+			Severity: SeverityHigh,
+			Message:  fmt.Sprintf("rule %q never matches: rule %q (order %d) matches all of its traffic first with action %s", rule.Name, e.rule.Name, e.rule.Order, e.rule.Action),
+		}.about(rule).relatedTo(e.rule))
+	}
+	for _, e := range earlier {
+		if e.rule.DefaultRule || !opposite(e.rule.Action, rule.Action) || !e.match.intersects(r.match) {
+			continue
+		}
+		findings = append(findings, Finding{
+			Type:     FindingConflict,
+			Severity: SeverityLow,
+			Message:  fmt.Sprintf("rule %q (%s) overlaps rule %q (order %d, %s): the traffic both match gets the action of the earlier rule", rule.Name, rule.Action, e.rule.Name, e.rule.Order, e.rule.Action),
+		}.about(rule).relatedTo(e.rule))
+	}
+	return findings
+}
+
+func (f Finding) about(rule *filteringrules.FirewallFilteringRules) Finding {
+	f.RuleID, f.RuleName, f.RuleOrder = rule.ID, rule.Name, rule.Order
+	return f
+}
+
+func (f Finding) relatedTo(rule *filteringrules.FirewallFilteringRules) Finding {
+	f.RelatedRuleID, f.RelatedRuleName, f.RelatedRuleOrder = rule.ID, rule.Name, rule.Order
+	return f
+}
+
+func isAllow(action string) bool {
+	return action == "ALLOW"
+}
+
+func isBlock(action string) bool {
+	return strings.HasPrefix(action, "BLOCK")
+}
+
+// decision returns the outcome of action: the BLOCK_* actions only differ in
+// how the traffic is dropped.
+func decision(action string) string {
+	if isBlock(action) {
+		return "BLOCK"
+	}
+	return action
+}
+
+// opposite reports whether one action allows and the other blocks.
+func opposite(a, b string) bool {
+	return (isAllow(a) && isBlock(b)) || (isBlock(a) && isAllow(b))
+}
@@ -2,10 +2,10 @@ package timewindow
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 )
 
 const (
@@ -32,7 +32,7 @@ func GetTimeWindowByName(ctx context.Context, service *zscaler.Service, timeWind
 			return &timeWindow, nil
 		}
 	}
-	return nil, fmt.Errorf("no time window found with name: %s", timeWindowName)
+	return nil, errorx.NotFoundf("no time window found with name: %s", timeWindowName)
 }
 
 func GetAll(ctx context.Context, service *zscaler.Service) ([]TimeWindow, error) {
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -201,7 +203,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, ruleName string) (
 			return &rule, nil
 		}
 	}
-	return nil, fmt.Errorf("no forwarding rule found with name: %s", ruleName)
+	return nil, errorx.NotFoundf("no forwarding rule found with name: %s", ruleName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, rule *ForwardingRules) (*ForwardingRules, error) {
@@ -243,3 +245,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]ForwardingRules, e
 	err := common.ReadAllPages(ctx, service.Client, forwardingRulesEndpoint, &rules)
 	return rules, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[ForwardingRules, error] {
+	return common.AllPages[ForwardingRules](ctx, service.Client, forwardingRulesEndpoint, opts...)
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -88,7 +90,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, proxyName string)
 			return &proxy, nil
 		}
 	}
-	return nil, fmt.Errorf("no proxy found with name: %s", proxyName)
+	return nil, errorx.NotFoundf("no proxy found with name: %s", proxyName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, proxyID *Proxies) (*Proxies, *http.Response, error) {
@@ -132,14 +134,29 @@ func GetAllLite(ctx context.Context, service *zscaler.Service) ([]Proxies, error
 	return proxies, err
 }
 
+// AllLite is like GetAllLite, but returns an iterator that fetches one page at a time.
+func AllLite(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[Proxies, error] {
+	return common.AllPages[Proxies](ctx, service.Client, proxiesEndpoint+"/lite", opts...)
+}
+
 func GetAll(ctx context.Context, service *zscaler.Service) ([]Proxies, error) {
 	var proxies []Proxies
 	err := common.ReadAllPages(ctx, service.Client, proxiesEndpoint, &proxies)
 	return proxies, err
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[Proxies, error] {
+	return common.AllPages[Proxies](ctx, service.Client, proxiesEndpoint, opts...)
+}
+
 func GetDedicatedIPGWLite(ctx context.Context, service *zscaler.Service) ([]DedicatedIPGateways, error) {
 	var gws []DedicatedIPGateways
 	err := common.ReadAllPages(ctx, service.Client, ipGatewayEndpoint, &gws)
 	return gws, err
 }
+
+// AllDedicatedIPGWLite is like GetDedicatedIPGWLite, but returns an iterator that fetches one page at a time.
+func AllDedicatedIPGWLite(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[DedicatedIPGateways, error] {
+	return common.AllPages[DedicatedIPGateways](ctx, service.Client, ipGatewayEndpoint, opts...)
+}
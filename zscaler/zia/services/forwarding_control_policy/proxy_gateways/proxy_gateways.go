@@ -2,10 +2,11 @@ package proxy_gateways
 
 import (
 	"context"
-	"fmt"
+	"iter"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -65,7 +66,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, gwName string) (*P
 			return &proxyGW, nil
 		}
 	}
-	return nil, fmt.Errorf("no proxy gateway found with name: %s", gwName)
+	return nil, errorx.NotFoundf("no proxy gateway found with name: %s", gwName)
 }
 
 func GetLite(ctx context.Context, service *zscaler.Service) ([]ProxyGateways, error) {
@@ -74,8 +75,18 @@ func GetLite(ctx context.Context, service *zscaler.Service) ([]ProxyGateways, er
 	return proxyGWs, err
 }
 
+// AllLite is like GetLite, but returns an iterator that fetches one page at a time.
+func AllLite(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[ProxyGateways, error] {
+	return common.AllPages[ProxyGateways](ctx, service.Client, proxyGatewayLiteEndpoint, opts...)
+}
+
 func GetAll(ctx context.Context, service *zscaler.Service) ([]ProxyGateways, error) {
 	var proxyGWs []ProxyGateways
 	err := common.ReadAllPages(ctx, service.Client, proxyGatewaysEndpoint, &proxyGWs)
 	return proxyGWs, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[ProxyGateways, error] {
+	return common.AllPages[ProxyGateways](ctx, service.Client, proxyGatewaysEndpoint, opts...)
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -99,7 +101,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, gwName string) (*Z
 			return &zpaGateway, nil
 		}
 	}
-	return nil, fmt.Errorf("no zpa gateway found with name: %s", gwName)
+	return nil, errorx.NotFoundf("no zpa gateway found with name: %s", gwName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, rule *ZPAGateways) (*ZPAGateways, error) {
@@ -141,3 +143,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]ZPAGateways, error
 	err := common.ReadAllPages(ctx, service.Client, zpaGatewaysEndpoint, &rules)
 	return rules, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[ZPAGateways, error] {
+	return common.AllPages[ZPAGateways](ctx, service.Client, zpaGatewaysEndpoint, opts...)
+}
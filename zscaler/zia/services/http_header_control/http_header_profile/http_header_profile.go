@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 )
 
 const (
@@ -103,7 +104,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, profileName string
 			return &headerProfile, nil
 		}
 	}
-	return nil, fmt.Errorf("no header profile found with name: %s", profileName)
+	return nil, errorx.NotFoundf("no header profile found with name: %s", profileName)
 }
 
 // GetAll retrieves all HTTP header profiles.
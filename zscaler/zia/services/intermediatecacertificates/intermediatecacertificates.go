@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -132,7 +134,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, certName string) (
 			return &certificate, nil
 		}
 	}
-	return nil, fmt.Errorf("no intermediate ca certificate found with name: %s", certName)
+	return nil, errorx.NotFoundf("no intermediate ca certificate found with name: %s", certName)
 }
 
 func GetDownloadAttestation(ctx context.Context, service *zscaler.Service, certID int) (*IntermediateCACertificate, error) {
@@ -210,6 +212,11 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]IntermediateCACert
 	return intermediateCACertificates, nil
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[IntermediateCACertificate, error] {
+	return common.AllPages[IntermediateCACertificate](ctx, service.Client, intermediateCaCertificatesEndpoint, opts...)
+}
+
 func CreateIntCACertificate(ctx context.Context, service *zscaler.Service, cert *IntermediateCACertificate) (*IntermediateCACertificate, error) {
 	resp, err := service.Client.Create(ctx, intermediateCaCertificatesEndpoint, *cert)
 	if err != nil {
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -153,7 +155,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, ruleName string) (
 			return &rule, nil
 		}
 	}
-	return nil, fmt.Errorf("no firewall ips rule found with name: %s", ruleName)
+	return nil, errorx.NotFoundf("no firewall ips rule found with name: %s", ruleName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, rule *FirewallIPSRules) (*FirewallIPSRules, error) {
@@ -195,3 +197,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]FirewallIPSRules,
 	err := common.ReadAllPages(ctx, service.Client, firewallIpsRulesEndpoint, &rules)
 	return rules, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[FirewallIPSRules, error] {
+	return common.AllPages[FirewallIPSRules](ctx, service.Client, firewallIpsRulesEndpoint, opts...)
+}
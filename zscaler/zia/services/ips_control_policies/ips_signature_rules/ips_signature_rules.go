@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -205,7 +207,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, signatureName stri
 			return &ipsSignature, nil
 		}
 	}
-	return nil, fmt.Errorf("no IPS signature found with name: %s", signatureName)
+	return nil, errorx.NotFoundf("no IPS signature found with name: %s", signatureName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, ipsSignature *IPSSignatureRules) (*IPSSignatureRules, *http.Response, error) {
@@ -249,6 +251,11 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]IPSSignatureRules,
 	return ipsSignatures, err
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[IPSSignatureRules, error] {
+	return common.AllPages[IPSSignatureRules](ctx, service.Client, ipsSignaturesEndpoint, opts...)
+}
+
 // ExportIPSSignatureRules exports custom IPS signature rules to a CSV file
 // (GET /ipsSignatureRules/export). The endpoint streams the file body with
 // Content-Type: application/octet-stream and Content-Disposition: attachment;
@@ -3,10 +3,12 @@ package locationgroups
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/url"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -136,7 +138,7 @@ func GetLocationGroupByName(ctx context.Context, service *zscaler.Service, locat
 			return &locationGroup, nil
 		}
 	}
-	return nil, fmt.Errorf("no location group found with name: %s", locationGroupName)
+	return nil, errorx.NotFoundf("no location group found with name: %s", locationGroupName)
 }
 
 // GetGroupType queries the location group by its type
@@ -151,7 +153,7 @@ func GetGroupType(ctx context.Context, service *zscaler.Service, gType string) (
 			return &locationGroup, nil
 		}
 	}
-	return nil, fmt.Errorf("no group type found with name: %s", gType)
+	return nil, errorx.NotFoundf("no group type found with name: %s", gType)
 }
 
 // GetAllFilterOptions represents optional filter parameters for GetAll
@@ -176,6 +178,17 @@ type GetAllFilterOptions struct {
 // The API supports a maximum page size of 1000.
 func GetAll(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOptions) ([]LocationGroup, error) {
 	var locationGroups []LocationGroup
+	// Use common.ReadAllPages with default page size 1000 (API maximum)
+	err := common.ReadAllPages(ctx, service.Client, getAllEndpoint(opts), &locationGroups)
+	return locationGroups, err
+}
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOptions, pageOpts ...zscaler.PageOption) iter.Seq2[LocationGroup, error] {
+	return common.AllPages[LocationGroup](ctx, service.Client, getAllEndpoint(opts), pageOpts...)
+}
+
+func getAllEndpoint(opts *GetAllFilterOptions) string {
 	endpoint := locationGroupEndpoint
 
 	// Build query parameters
@@ -209,10 +222,7 @@ func GetAll(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOpt
 	if baseQuery != "" {
 		endpoint += "?" + baseQuery
 	}
-
-	// Use common.ReadAllPages with default page size 1000 (API maximum)
-	err := common.ReadAllPages(ctx, service.Client, endpoint, &locationGroups)
-	return locationGroups, err
+	return endpoint
 }
 
 // GetLocationGroupCount retrieves the count of location groups using optional filters.
@@ -3,10 +3,12 @@ package locationlite
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/url"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -110,6 +112,17 @@ type GetAllFilterOptions struct {
 // The API supports a maximum page size of 1000.
 func GetAll(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOptions) ([]LocationLite, error) {
 	var locations []LocationLite
+	// Use common.ReadAllPages with default page size 1000 (API maximum)
+	err := common.ReadAllPages(ctx, service.Client, getAllEndpoint(opts), &locations)
+	return locations, err
+}
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOptions, pageOpts ...zscaler.PageOption) iter.Seq2[LocationLite, error] {
+	return common.AllPages[LocationLite](ctx, service.Client, getAllEndpoint(opts), pageOpts...)
+}
+
+func getAllEndpoint(opts *GetAllFilterOptions) string {
 	endpoint := locationLiteEndpoint
 
 	// Build query parameters
@@ -137,10 +150,7 @@ func GetAll(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOpt
 	if baseQuery != "" {
 		endpoint += "?" + baseQuery
 	}
-
-	// Use common.ReadAllPages with default page size 1000 (API maximum)
-	err := common.ReadAllPages(ctx, service.Client, endpoint, &locations)
-	return locations, err
+	return endpoint
 }
 
 func GetLocationLiteByName(ctx context.Context, service *zscaler.Service, locationLiteName string) (*LocationLite, error) {
@@ -158,5 +168,5 @@ func GetLocationLiteByName(ctx context.Context, service *zscaler.Service, locati
 			return &locationLite, nil
 		}
 	}
-	return nil, fmt.Errorf("no location found with name: %s", locationLiteName)
+	return nil, errorx.NotFoundf("no location found with name: %s", locationLiteName)
 }
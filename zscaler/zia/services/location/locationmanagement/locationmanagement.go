@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -276,7 +278,7 @@ func GetSubLocationBySubID(ctx context.Context, service *zscaler.Service, subLoc
 			return subLoc, nil
 		}
 	}
-	return nil, fmt.Errorf("sublocation not found: %d", subLocationID)
+	return nil, errorx.NotFoundf("sublocation not found: %d", subLocationID)
 }
 
 // GetSublocations gets all sub-locations for a given location ID.
@@ -297,7 +299,7 @@ func GetSubLocation(ctx context.Context, service *zscaler.Service, locationID, s
 			return &location, nil
 		}
 	}
-	return nil, fmt.Errorf("sublocation not found: %d", subLocationID)
+	return nil, errorx.NotFoundf("sublocation not found: %d", subLocationID)
 }
 
 // GetLocationByName gets a location by its name.
@@ -313,7 +315,7 @@ func GetLocationByName(ctx context.Context, service *zscaler.Service, locationNa
 			return &location, nil
 		}
 	}
-	return nil, fmt.Errorf("no location found with name: %s", locationName)
+	return nil, errorx.NotFoundf("no location found with name: %s", locationName)
 }
 
 // GetSubLocationByNames gets a sub-location by its name and parent location name
@@ -331,7 +333,7 @@ func GetSubLocationByNames(ctx context.Context, service *zscaler.Service, locati
 			return &subLocation, nil
 		}
 	}
-	return nil, fmt.Errorf("no sublocation found with name: %s in location:%s", locationName, locationName)
+	return nil, errorx.NotFoundf("no sublocation found with name: %s in location:%s", locationName, locationName)
 }
 
 // GetSubLocationByName gets a sub-location by its name (fetches all locations's sub-location to find a match).
@@ -348,7 +350,7 @@ func GetSubLocationByName(ctx context.Context, service *zscaler.Service, subLoca
 			}
 		}
 	}
-	return nil, fmt.Errorf("no sublocation found with name: %s", subLocatioName)
+	return nil, errorx.NotFoundf("no sublocation found with name: %s", subLocatioName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, locations *Locations) (*Locations, error) {
@@ -427,6 +429,12 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]Locations, error)
 	return locations, nil
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[Locations, error] {
+	opts = append([]zscaler.PageOption{zscaler.WithPageSize(1000)}, opts...)
+	return common.AllPages[Locations](ctx, service.Client, locationsEndpoint, opts...)
+}
+
 func GetAllSublocations(ctx context.Context, service *zscaler.Service) ([]Locations, error) {
 	// Step 1: Fetch all parent locations.
 	parentLocations, err := GetAll(ctx, service)
@@ -452,6 +460,27 @@ func GetAllSublocations(ctx context.Context, service *zscaler.Service) ([]Locati
 	return allSublocations, nil
 }
 
+// AllSublocations is like GetAllSublocations, but returns an iterator that
+// fetches the parent locations and the sub-locations of each one page at a
+// time. opts apply to the listing of each parent's sub-locations, so
+// WithStartToken is not supported.
+func AllSublocations(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[Locations, error] {
+	return func(yield func(Locations, error) bool) {
+		for parent, err := range All(ctx, service) {
+			if err != nil {
+				yield(Locations{}, err)
+				return
+			}
+			subEndpoint := fmt.Sprintf("%s/%d%s", locationsEndpoint, parent.ID, subLocationEndpoint)
+			for sublocation, err := range common.AllPages[Locations](ctx, service.Client, subEndpoint, opts...) {
+				if !yield(sublocation, err) || err != nil {
+					return
+				}
+			}
+		}
+	}
+}
+
 // GetLocationOrSublocationByID gets a location or sub-location by its ID.
 func GetLocationOrSublocationByID(ctx context.Context, service *zscaler.Service, id int) (*Locations, error) {
 	location, err := GetLocation(context.Background(), service, id)
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -76,7 +78,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, ruleName string) (
 			return &rule, nil
 		}
 	}
-	return nil, fmt.Errorf("no NAT Control rule rule found with name: %s", ruleName)
+	return nil, errorx.NotFoundf("no NAT Control rule rule found with name: %s", ruleName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, rule *NatControlPolicies) (*NatControlPolicies, error) {
@@ -118,3 +120,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]NatControlPolicies
 	err := common.ReadAllPages(ctx, service.Client, dnatRulesEndpoint, &rules)
 	return rules, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[NatControlPolicies, error] {
+	return common.AllPages[NatControlPolicies](ctx, service.Client, dnatRulesEndpoint, opts...)
+}
@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -118,6 +120,15 @@ func GetPacFiles(ctx context.Context, service *zscaler.Service, filter string) (
 	return pacFiles, err
 }
 
+// AllPacFiles is like GetPacFiles, but returns an iterator that fetches one page at a time.
+func AllPacFiles(ctx context.Context, service *zscaler.Service, filter string, opts ...zscaler.PageOption) iter.Seq2[PACFileConfig, error] {
+	endpoint := pacfileEndpoint
+	if filter != "" {
+		endpoint += fmt.Sprintf("?filter=%s", url.QueryEscape(filter))
+	}
+	return common.AllPages[PACFileConfig](ctx, service.Client, endpoint, opts...)
+}
+
 func GetPacFileByName(ctx context.Context, service *zscaler.Service, pacFileName string) (*PACFileConfig, error) {
 	var pacFiles []PACFileConfig
 	err := common.ReadAllPages(ctx, service.Client, pacfileEndpoint, &pacFiles)
@@ -129,7 +140,7 @@ func GetPacFileByName(ctx context.Context, service *zscaler.Service, pacFileName
 			return &pacFile, nil
 		}
 	}
-	return nil, fmt.Errorf("no pac file found with name: %s", pacFileName)
+	return nil, errorx.NotFoundf("no pac file found with name: %s", pacFileName)
 }
 
 func GetPacFileVersion(ctx context.Context, service *zscaler.Service, pacID int, filter string) ([]PACFileConfig, error) {
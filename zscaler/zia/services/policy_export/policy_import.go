@@ -0,0 +1,620 @@
+package policy_export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
+)
+
+// Statuses of an imported rule.
+const (
+	ImportCreated = "CREATED"
+	ImportUpdated = "UPDATED"
+	ImportSkipped = "SKIPPED"
+	ImportFailed  = "FAILED"
+)
+
+// policyType is a rule type of the export: the rules of a policy type are
+// read from the export file named after its endpoint or the policy type, e.g.
+// firewallFilteringRules.json or FIREWALL.json.
+type policyType struct {
+	name     string
+	endpoint string
+}
+
+// policyTypes are the importable policy types, in import order.
+var policyTypes = []policyType{
+	{"FIREWALL", "/zia/api/v1/firewallFilteringRules"},
+	{"DNS", "/zia/api/v1/firewallDnsRules"},
+	{"IPS", "/zia/api/v1/firewallIpsRules"},
+	{"URL_FILTERING", "/zia/api/v1/urlFilteringRules"},
+	{"SSL_INSPECTION", "/zia/api/v1/sslInspectionRules"},
+	{"FILE_TYPE", "/zia/api/v1/fileTypeRules"},
+	{"SANDBOX", "/zia/api/v1/sandboxRules"},
+	{"DLP", "/zia/api/v1/webDlpRules"},
+	{"FORWARDING", "/zia/api/v1/forwardingRules"},
+	{"NAT", "/zia/api/v1/dnatRules"},
+	{"BANDWIDTH", "/zia/api/v1/bandwidthControlRules"},
+}
+
+// fileAliases maps export file names that differ from the endpoint to their
+// policy type.
+var fileAliases = map[string]string{
+	"firewallrules": "FIREWALL",
+	"dlprules":      "DLP",
+}
+
+// urlCategoriesFile is the export file listing URL categories. When present,
+// custom categories referenced by rules are matched by name in the target
+// tenant; otherwise category IDs are kept as exported.
+const urlCategoriesFile = "urlcategories"
+
+// referenceEndpoints lists the objects of each rule field that references
+// objects by ID and name. They are resolved by name in the target tenant; a
+// reference in any other field fails the rule, see unmappedReferences.
+var referenceEndpoints = map[string]string{
+	"locations":           "/zia/api/v1/locations/lite",
+	"locationGroups":      "/zia/api/v1/locations/groups/lite",
+	"users":               "/zia/api/v1/users",
+	"groups":              "/zia/api/v1/groups",
+	"departments":         "/zia/api/v1/departments",
+	"overrideUsers":       "/zia/api/v1/users",
+	"overrideGroups":      "/zia/api/v1/groups",
+	"labels":              "/zia/api/v1/ruleLabels",
+	"timeWindows":         "/zia/api/v1/timeWindows",
+	"srcIpGroups":         "/zia/api/v1/ipSourceGroups",
+	"destIpGroups":        "/zia/api/v1/ipDestinationGroups",
+	"nwServices":          "/zia/api/v1/networkServices",
+	"nwServiceGroups":     "/zia/api/v1/networkServiceGroups",
+	"nwApplicationGroups": "/zia/api/v1/networkApplicationGroups",
+	"appServices":         "/zia/api/v1/appServices/lite",
+	"appServiceGroups":    "/zia/api/v1/appServiceGroups/lite",
+	"devices":             "/zia/api/v1/deviceGroups/devices",
+	"deviceGroups":        "/zia/api/v1/deviceGroups",
+	"workloadGroups":      "/zia/api/v1/workloadGroups",
+}
+
+const urlCategoriesLiteEndpoint = "/zia/api/v1/urlCategories/lite"
+
+// readOnlyRuleFields are dropped from exported rules before they are sent.
+var readOnlyRuleFields = []string{"id", "lastModifiedTime", "lastModifiedBy", "accessControl"}
+
+// RuleResult reports what ImportPolicies did with one exported rule.
+type RuleResult struct {
+	PolicyType string
+	Name       string
+	// Status is ImportCreated, ImportUpdated, ImportSkipped or ImportFailed.
+	Status string
+	// ID is the rule ID in the target tenant, if the rule was written.
+	ID int
+	// Reason explains why the rule was skipped.
+	Reason string
+	// Unresolved lists the references not found in the target tenant, or
+	// held in a field the importer cannot resolve, as field/name.
+	Unresolved []string
+	Err        error
+}
+
+// ImportReport is the per-rule result of ImportPolicies.
+type ImportReport struct {
+	Rules []RuleResult
+	// IgnoredFiles are the export files holding no importable policy type.
+	IgnoredFiles []string
+}
+
+// Count returns the number of rules with status.
+func (r *ImportReport) Count(status string) int {
+	n := 0
+	for _, rule := range r.Rules {
+		if rule.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Err joins the errors of the failed rules, or returns nil if none failed.
+func (r *ImportReport) Err() error {
+	var errs []error
+	for _, rule := range r.Rules {
+		if rule.Status == ImportFailed {
+			errs = append(errs, fmt.Errorf("%s rule %q: %w", rule.PolicyType, rule.Name, rule.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ImportOption configures ImportPolicies.
+type ImportOption func(*importer)
+
+// WithImportPolicyTypes restricts the import to policyTypes, e.g. "FIREWALL"
+// and "URL_FILTERING". All policy types found in the export are imported by
+// default.
+func WithImportPolicyTypes(policyTypes ...string) ImportOption {
+	return func(im *importer) {
+		im.policyTypes = map[string]bool{}
+		for _, t := range policyTypes {
+			im.policyTypes[strings.ToUpper(t)] = true
+		}
+	}
+}
+
+// WithUpdateExisting sets whether rules that already exist in the target
+// tenant, matched by name, are updated (the default) or skipped.
+func WithUpdateExisting(update bool) ImportOption {
+	return func(im *importer) {
+		im.updateExisting = update
+	}
+}
+
+// WithStopOnError stops the import at the first rule that fails. By default
+// the remaining rules are still imported.
+func WithStopOnError() ImportOption {
+	return func(im *importer) {
+		im.stopOnError = true
+	}
+}
+
+type importer struct {
+	service        *zscaler.Service
+	policyTypes    map[string]bool
+	updateExisting bool
+	stopOnError    bool
+
+	// names holds the object IDs of the target tenant by lower-case name,
+	// per endpoint, loaded on first use.
+	names map[string]map[string]json.RawMessage
+	// categoryNames maps the exported custom URL category IDs to their names.
+	categoryNames map[string]string
+}
+
+// ImportPolicies restores the rules of a policy export, as written by
+// ExportPolicies, into the tenant of service. source is the export directory
+// or the ZIP archive returned by the export API.
+//
+// Rules are imported policy type by policy type, in rule order. A rule whose
+// name exists in the tenant is updated, others are created. The objects a
+// rule references (locations, users, groups, departments, labels, time
+// windows, IP groups, network services, devices, custom URL categories and
+// the like, see referenceEndpoints) are looked up by name in the tenant, so
+// policies can be promoted between tenants. A rule with a reference that
+// cannot be found, or that sits in a field the importer cannot resolve, fails
+// rather than being created with a wider scope or bound to unrelated objects.
+// Predefined and default rules are only updated.
+//
+// The returned error reports an unreadable export or failed lookups; the
+// result of each rule is in the report, see ImportReport.Err. As for any ZIA
+// change, the imported rules take effect once activated.
+func ImportPolicies(ctx context.Context, service *zscaler.Service, source string, opts ...ImportOption) (*ImportReport, error) {
+	im := &importer{
+		service:        service,
+		updateExisting: true,
+		names:          map[string]map[string]json.RawMessage{},
+	}
+	for _, opt := range opts {
+		opt(im)
+	}
+
+	files, err := readExport(source)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ImportReport{}
+	rulesByType := map[string][]map[string]interface{}{}
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		stem := strings.ToLower(strings.TrimSuffix(path.Base(name), path.Ext(name)))
+		if stem == urlCategoriesFile {
+			if err := im.loadCategoryNames(files[name]); err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", name, err)
+			}
+			continue
+		}
+		pt, ok := policyTypeOf(stem)
+		if !ok {
+			report.IgnoredFiles = append(report.IgnoredFiles, name)
+			continue
+		}
+		rules, err := decodeRules(files[name])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		rulesByType[pt.name] = append(rulesByType[pt.name], rules...)
+	}
+
+	for _, pt := range policyTypes {
+		rules := rulesByType[pt.name]
+		if len(rules) == 0 || (im.policyTypes != nil && !im.policyTypes[pt.name]) {
+			continue
+		}
+		sortByOrder(rules)
+		existing, err := im.existingRules(ctx, pt)
+		if err != nil {
+			return report, err
+		}
+		for _, rule := range rules {
+			result := im.importRule(ctx, pt, rule, existing)
+			report.Rules = append(report.Rules, result)
+			if result.Status == ImportFailed && im.stopOnError {
+				return report, nil
+			}
+		}
+	}
+	return report, nil
+}
+
+func (im *importer) importRule(ctx context.Context, pt policyType, rule map[string]interface{}, existing map[string]existingRule) RuleResult {
+	name, _ := rule["name"].(string)
+	result := RuleResult{PolicyType: pt.name, Name: name}
+	if name == "" {
+		result.Status = ImportFailed
+		result.Err = errors.New("rule has no name")
+		return result
+	}
+	for _, field := range readOnlyRuleFields {
+		delete(rule, field)
+	}
+
+	target, exists := existing[strings.ToLower(name)]
+	switch {
+	case exists && !im.updateExisting:
+		result.Status, result.ID, result.Reason = ImportSkipped, target.ID, "rule exists"
+		return result
+	case !exists && (rule["predefined"] == true || rule["defaultRule"] == true):
+		result.Status, result.Reason = ImportSkipped, "predefined rule not found in the tenant"
+		return result
+	}
+
+	unresolved, err := im.resolveReferences(ctx, rule)
+	if err != nil {
+		result.Status, result.Err = ImportFailed, err
+		return result
+	}
+	if len(unresolved) > 0 {
+		result.Status, result.Unresolved = ImportFailed, unresolved
+		result.Err = fmt.Errorf("unresolved references: %s", strings.Join(unresolved, ", "))
+		return result
+	}
+
+	if exists {
+		rule["id"] = target.ID
+		if _, err := im.service.Client.UpdateWithPut(ctx, fmt.Sprintf("%s/%d", pt.endpoint, target.ID), rulePayload{rule}); err != nil {
+			result.Status, result.Err = ImportFailed, err
+			return result
+		}
+		result.Status, result.ID = ImportUpdated, target.ID
+		im.service.Client.GetLogger().Printf("[INFO] updated %s rule %q (%d)", pt.name, name, target.ID)
+		return result
+	}
+
+	data, err := json.Marshal(rule)
+	if err != nil {
+		result.Status, result.Err = ImportFailed, err
+		return result
+	}
+	resp, err := im.service.Client.CreateWithRawPayload(ctx, pt.endpoint, string(data))
+	if err != nil {
+		result.Status, result.Err = ImportFailed, err
+		return result
+	}
+	var created existingRule
+	if len(resp) > 0 {
+		_ = json.Unmarshal(resp, &created)
+	}
+	existing[strings.ToLower(name)] = existingRule{ID: created.ID, Name: name}
+	result.Status, result.ID = ImportCreated, created.ID
+	im.service.Client.GetLogger().Printf("[INFO] created %s rule %q (%d)", pt.name, name, created.ID)
+	return result
+}
+
+type existingRule struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func (im *importer) existingRules(ctx context.Context, pt policyType) (map[string]existingRule, error) {
+	var rules []existingRule
+	if err := common.ReadAllPages(ctx, im.service.Client, pt.endpoint, &rules); err != nil {
+		return nil, fmt.Errorf("failed to list %s rules: %w", pt.name, err)
+	}
+	existing := make(map[string]existingRule, len(rules))
+	for _, r := range rules {
+		existing[strings.ToLower(r.Name)] = r
+	}
+	return existing, nil
+}
+
+// resolveReferences replaces the IDs of the objects referenced by rule with
+// the IDs of the objects of the same name in the target tenant, and returns
+// the references that were not found.
+func (im *importer) resolveReferences(ctx context.Context, rule map[string]interface{}) ([]string, error) {
+	unresolved := unmappedReferences(rule)
+	fields := make([]string, 0, len(referenceEndpoints))
+	for field := range referenceEndpoints {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		refs, ok := rule[field].([]interface{})
+		if !ok || len(refs) == 0 {
+			continue
+		}
+		names, err := im.lookup(ctx, field, referenceEndpoints[field])
+		if err != nil {
+			return nil, err
+		}
+		for i, ref := range refs {
+			obj, ok := ref.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _ := obj["name"].(string)
+			if name == "" {
+				unresolved = append(unresolved, fmt.Sprintf("%s/%v", field, obj["id"]))
+				continue
+			}
+			id, ok := names[strings.ToLower(name)]
+			if !ok {
+				unresolved = append(unresolved, field+"/"+name)
+				continue
+			}
+			refs[i] = map[string]interface{}{"id": id, "name": name}
+		}
+	}
+
+	categories, ok := rule["urlCategories"].([]interface{})
+	if !ok || len(im.categoryNames) == 0 {
+		return unresolved, nil
+	}
+	for i, c := range categories {
+		id, ok := c.(string)
+		if !ok {
+			continue
+		}
+		name, custom := im.categoryNames[id]
+		if !custom {
+			continue
+		}
+		names, err := im.lookup(ctx, "urlCategories", urlCategoriesLiteEndpoint)
+		if err != nil {
+			return nil, err
+		}
+		target, ok := names[strings.ToLower(name)]
+		if !ok {
+			unresolved = append(unresolved, "urlCategories/"+name)
+			continue
+		}
+		var targetID string
+		if err := json.Unmarshal(target, &targetID); err != nil {
+			return nil, fmt.Errorf("URL category %q: %w", name, err)
+		}
+		categories[i] = targetID
+	}
+	return unresolved, nil
+}
+
+type namedObject struct {
+	ID             json.RawMessage `json:"id"`
+	Name           string          `json:"name"`
+	ConfiguredName string          `json:"configuredName"`
+	CustomCategory bool            `json:"customCategory"`
+}
+
+// lookup returns the IDs of the objects listed by endpoint, by lower-case
+// name. Custom URL categories are named by their configuredName.
+func (im *importer) lookup(ctx context.Context, field, endpoint string) (map[string]json.RawMessage, error) {
+	if names, ok := im.names[endpoint]; ok {
+		return names, nil
+	}
+	var objects []namedObject
+	if err := common.ReadAllPages(ctx, im.service.Client, endpoint, &objects); err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", field, err)
+	}
+	names := make(map[string]json.RawMessage, len(objects))
+	for _, o := range objects {
+		name := o.Name
+		if o.ConfiguredName != "" {
+			name = o.ConfiguredName
+		}
+		names[strings.ToLower(name)] = o.ID
+	}
+	im.names[endpoint] = names
+	return names, nil
+}
+
+// unmappedReferences returns the references of rule held in fields missing
+// from referenceEndpoints, as field/name. Their IDs are those of the source
+// tenant, so they cannot be sent as is.
+func unmappedReferences(rule map[string]interface{}) []string {
+	var refs []string
+	for field, value := range rule {
+		if _, ok := referenceEndpoints[field]; ok {
+			continue
+		}
+		values, ok := value.([]interface{})
+		if !ok {
+			values = []interface{}{value}
+		}
+		for _, v := range values {
+			obj, ok := v.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			_, hasID := obj["id"]
+			_, hasExternalID := obj["externalId"]
+			if !hasID && !hasExternalID {
+				continue
+			}
+			name, _ := obj["name"].(string)
+			if name == "" {
+				name = fmt.Sprint(obj["id"])
+			}
+			refs = append(refs, field+"/"+name)
+		}
+	}
+	sort.Strings(refs)
+	return refs
+}
+
+func (im *importer) loadCategoryNames(data []byte) error {
+	var categories []namedObject
+	if err := json.Unmarshal(data, &categories); err != nil {
+		var wrapped struct {
+			Categories []namedObject `json:"urlCategories"`
+		}
+		if json.Unmarshal(data, &wrapped) != nil {
+			return err
+		}
+		categories = wrapped.Categories
+	}
+	im.categoryNames = map[string]string{}
+	for _, c := range categories {
+		var id string
+		if !c.CustomCategory || json.Unmarshal(c.ID, &id) != nil || c.ConfiguredName == "" {
+			continue
+		}
+		im.categoryNames[id] = c.ConfiguredName
+	}
+	return nil
+}
+
+func policyTypeOf(stem string) (policyType, bool) {
+	if alias, ok := fileAliases[stem]; ok {
+		stem = strings.ToLower(alias)
+	}
+	for _, pt := range policyTypes {
+		if stem == strings.ToLower(pt.name) || stem == strings.ToLower(path.Base(pt.endpoint)) {
+			return pt, true
+		}
+	}
+	return policyType{}, false
+}
+
+// decodeRules decodes the rules of an export file: a JSON array of rules or an
+// object with a rules array. Numbers are kept as json.Number so IDs and
+// values are sent back unchanged.
+func decodeRules(data []byte) ([]map[string]interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if obj, ok := v.(map[string]interface{}); ok {
+		v = obj["rules"]
+	}
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil, errors.New("expected a JSON array of rules or an object with a rules array")
+	}
+	rules := make([]map[string]interface{}, 0, len(list))
+	for _, item := range list {
+		rule, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("rules must be JSON objects")
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// sortByOrder sorts rules by their order field, keeping rules without one
+// last, in file order.
+func sortByOrder(rules []map[string]interface{}) {
+	order := func(rule map[string]interface{}) (int64, bool) {
+		n, ok := rule["order"].(json.Number)
+		if !ok {
+			return 0, false
+		}
+		i, err := n.Int64()
+		return i, err == nil
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		oi, iok := order(rules[i])
+		oj, jok := order(rules[j])
+		if iok != jok {
+			return iok
+		}
+		return oi < oj
+	})
+}
+
+// readExport returns the JSON files of an export directory or ZIP archive by
+// slash-separated path.
+func readExport(source string) (map[string][]byte, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy export: %w", err)
+	}
+	files := map[string][]byte{}
+	if !info.IsDir() {
+		r, err := zip.OpenReader(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open policy export %q: %w", source, err)
+		}
+		defer r.Close()
+		for _, f := range r.File {
+			if f.FileInfo().IsDir() || !strings.EqualFold(path.Ext(f.Name), ".json") {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return nil, fmt.Errorf("failed to open zipped file %q: %w", f.Name, err)
+			}
+			data, err := io.ReadAll(rc)
+			rc.Close()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read zipped file %q: %w", f.Name, err)
+			}
+			files[path.Clean(f.Name)] = data
+		}
+		return files, nil
+	}
+	err = filepath.WalkDir(source, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.EqualFold(filepath.Ext(p), ".json") {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(source, p)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy export: %w", err)
+	}
+	return files, nil
+}
+
+// rulePayload sends a decoded rule through the client methods, which only
+// accept structs.
+type rulePayload struct {
+	rule map[string]interface{}
+}
+
+func (p rulePayload) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.rule)
+}
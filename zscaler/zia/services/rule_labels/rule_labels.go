@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -61,7 +63,7 @@ func GetRuleLabelByName(ctx context.Context, service *zscaler.Service, labelName
 			return &ruleLabel, nil
 		}
 	}
-	return nil, fmt.Errorf("no rule label found with name: %s", labelName)
+	return nil, errorx.NotFoundf("no rule label found with name: %s", labelName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, Labels *RuleLabels) (*RuleLabels, *http.Response, error) {
@@ -104,3 +106,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]RuleLabels, error)
 	err := common.ReadAllPages(ctx, service.Client, ruleLabelsEndpoint, &ruleLabels)
 	return ruleLabels, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[RuleLabels, error] {
+	return common.AllPages[RuleLabels](ctx, service.Client, ruleLabelsEndpoint, opts...)
+}
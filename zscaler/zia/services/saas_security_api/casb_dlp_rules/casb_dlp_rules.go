@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 
@@ -140,3 +141,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]CasbDLPRules, erro
 	err := common.ReadAllPages(ctx, service.Client, casbDlpRulesEndpoint+"/all", &rules)
 	return rules, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[CasbDLPRules, error] {
+	return common.AllPages[CasbDLPRules](ctx, service.Client, casbDlpRulesEndpoint+"/all", opts...)
+}
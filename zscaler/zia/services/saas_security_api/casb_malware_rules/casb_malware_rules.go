@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 
@@ -99,3 +100,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]CasbMalwareRules,
 	err := common.ReadAllPages(ctx, service.Client, casbMalwareRulesEndpoint+"/all", &rules)
 	return rules, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[CasbMalwareRules, error] {
+	return common.AllPages[CasbMalwareRules](ctx, service.Client, casbMalwareRulesEndpoint+"/all", opts...)
+}
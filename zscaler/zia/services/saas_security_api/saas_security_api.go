@@ -3,6 +3,7 @@ package saas_security_api
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/url"
 	"strconv"
 
@@ -125,18 +126,33 @@ func GetDomainProfiles(ctx context.Context, service *zscaler.Service) ([]DomainP
 	return profiles, err
 }
 
+// AllDomainProfiles is like GetDomainProfiles, but returns an iterator that fetches one page at a time.
+func AllDomainProfiles(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[DomainProfiles, error] {
+	return common.AllPages[DomainProfiles](ctx, service.Client, domainProfilesEndpoint, opts...)
+}
+
 func GetQuarantineTombstoneLite(ctx context.Context, service *zscaler.Service) ([]QuarantineTombstoneLite, error) {
 	var templates []QuarantineTombstoneLite
 	err := common.ReadAllPages(ctx, service.Client, quarantineTombstoneTemplateEndpoint, &templates)
 	return templates, err
 }
 
+// AllQuarantineTombstoneLite is like GetQuarantineTombstoneLite, but returns an iterator that fetches one page at a time.
+func AllQuarantineTombstoneLite(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[QuarantineTombstoneLite, error] {
+	return common.AllPages[QuarantineTombstoneLite](ctx, service.Client, quarantineTombstoneTemplateEndpoint, opts...)
+}
+
 func GetCasbEmailLabelLite(ctx context.Context, service *zscaler.Service) ([]CasbEmailLabel, error) {
 	var labels []CasbEmailLabel
 	err := common.ReadAllPages(ctx, service.Client, casbEmailLabelEndpoint, &labels)
 	return labels, err
 }
 
+// AllCasbEmailLabelLite is like GetCasbEmailLabelLite, but returns an iterator that fetches one page at a time.
+func AllCasbEmailLabelLite(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[CasbEmailLabel, error] {
+	return common.AllPages[CasbEmailLabel](ctx, service.Client, casbEmailLabelEndpoint, opts...)
+}
+
 func GetCasbTenantTagPolicy(ctx context.Context, service *zscaler.Service, tenantID int) ([]CasbTenantTags, error) {
 	var tags []CasbTenantTags
 	endpoint := fmt.Sprintf("%s/%d/tags/policy", casbTenantEndpoint, tenantID)
@@ -144,8 +160,24 @@ func GetCasbTenantTagPolicy(ctx context.Context, service *zscaler.Service, tenan
 	return tags, err
 }
 
+// AllCasbTenantTagPolicy is like GetCasbTenantTagPolicy, but returns an iterator that fetches one page at a time.
+func AllCasbTenantTagPolicy(ctx context.Context, service *zscaler.Service, tenantID int, opts ...zscaler.PageOption) iter.Seq2[CasbTenantTags, error] {
+	endpoint := fmt.Sprintf("%s/%d/tags/policy", casbTenantEndpoint, tenantID)
+	return common.AllPages[CasbTenantTags](ctx, service.Client, endpoint, opts...)
+}
+
 func GetCasbTenantLite(ctx context.Context, service *zscaler.Service, queryParams map[string]interface{}) ([]CasbTenants, error) {
 	var tenants []CasbTenants
+	err := common.ReadAllPages(ctx, service.Client, allCasbTenantLiteEndpoint(queryParams), &tenants)
+	return tenants, err
+}
+
+// AllCasbTenantLite is like GetCasbTenantLite, but returns an iterator that fetches one page at a time.
+func AllCasbTenantLite(ctx context.Context, service *zscaler.Service, queryParams map[string]interface{}, opts ...zscaler.PageOption) iter.Seq2[CasbTenants, error] {
+	return common.AllPages[CasbTenants](ctx, service.Client, allCasbTenantLiteEndpoint(queryParams), opts...)
+}
+
+func allCasbTenantLiteEndpoint(queryParams map[string]interface{}) string {
 	baseEndpoint := fmt.Sprintf("%s/lite", casbTenantEndpoint)
 	queryString := ""
 	if len(queryParams) > 0 {
@@ -166,9 +198,7 @@ func GetCasbTenantLite(ctx context.Context, service *zscaler.Service, queryParam
 	}
 
 	endpoint := baseEndpoint + queryString
-
-	err := common.ReadAllPages(ctx, service.Client, endpoint, &tenants)
-	return tenants, err
+	return endpoint
 }
 
 func GetAll(ctx context.Context, service *zscaler.Service) ([]CasbTenantScanInfo, error) {
@@ -176,3 +206,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]CasbTenantScanInfo
 	err := common.ReadAllPages(ctx, service.Client, casbTenantEndpoint+"/scanInfo", &scanInfos)
 	return scanInfos, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[CasbTenantScanInfo, error] {
+	return common.AllPages[CasbTenantScanInfo](ctx, service.Client, casbTenantEndpoint+"/scanInfo", opts...)
+}
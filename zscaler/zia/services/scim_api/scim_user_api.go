@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -65,7 +66,7 @@ func GetUserByName(ctx context.Context, service *zscaler.ScimZIAService, display
 		return nil, resp, err
 	}
 	if len(list) == 0 {
-		return nil, resp, fmt.Errorf("no SCIM user found with display name '%s'", displayName)
+		return nil, resp, errorx.NotFoundf("no SCIM user found with display name '%s'", displayName)
 	}
 	return &list[0], resp, nil
 }
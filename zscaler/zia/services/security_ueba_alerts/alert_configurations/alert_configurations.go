@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -72,7 +74,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, ruleName string) (
 			return &alertDefinition, nil
 		}
 	}
-	return nil, fmt.Errorf("no alert configuration rule found with name: %s", ruleName)
+	return nil, errorx.NotFoundf("no alert configuration rule found with name: %s", ruleName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, alertDefinitions *AlertConfigurationRule) (*AlertConfigurationRule, *http.Response, error) {
@@ -115,3 +117,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]AlertConfiguration
 	err := common.ReadAllPages(ctx, service.Client, alertConfigurationEndpoint, &alertConfiguration)
 	return alertConfiguration, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[AlertConfigurationRule, error] {
+	return common.AllPages[AlertConfigurationRule](ctx, service.Client, alertConfigurationEndpoint, opts...)
+}
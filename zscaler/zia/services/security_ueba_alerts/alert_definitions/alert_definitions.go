@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -51,7 +53,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, alertName string)
 			return &alertDefinition, nil
 		}
 	}
-	return nil, fmt.Errorf("no alert definition found with name: %s", alertName)
+	return nil, errorx.NotFoundf("no alert definition found with name: %s", alertName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, alertDefinitions *AlertDefinitions) (*AlertDefinitions, *http.Response, error) {
@@ -94,3 +96,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]AlertDefinitions,
 	err := common.ReadAllPages(ctx, service.Client, alertDefinitionsEndpoint, &alertDefinitions)
 	return alertDefinitions, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[AlertDefinitions, error] {
+	return common.AllPages[AlertDefinitions](ctx, service.Client, alertDefinitionsEndpoint, opts...)
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -72,7 +74,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, ruleName string) (
 			return &uebaRules, nil
 		}
 	}
-	return nil, fmt.Errorf("no ueba rules found with name: %s", ruleName)
+	return nil, errorx.NotFoundf("no ueba rules found with name: %s", ruleName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, uebaRules *UebaRules) (*UebaRules, *http.Response, error) {
@@ -115,3 +117,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]UebaRules, error)
 	err := common.ReadAllPages(ctx, service.Client, uebaRulesEndpoint, &uebaRules)
 	return uebaRules, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[UebaRules, error] {
+	return common.AllPages[UebaRules](ctx, service.Client, uebaRulesEndpoint, opts...)
+}
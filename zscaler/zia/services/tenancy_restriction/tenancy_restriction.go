@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -57,7 +59,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, profileName string
 			return &profile, nil
 		}
 	}
-	return nil, fmt.Errorf("no tenant restriction profile found with name: %s", profileName)
+	return nil, errorx.NotFoundf("no tenant restriction profile found with name: %s", profileName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, instanceID *TenancyRestrictionProfile) (*TenancyRestrictionProfile, *http.Response, error) {
@@ -101,6 +103,11 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]TenancyRestriction
 	return profiles, err
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[TenancyRestrictionProfile, error] {
+	return common.AllPages[TenancyRestrictionProfile](ctx, service.Client, tenantRestrictionEndpoint, opts...)
+}
+
 func GetAppItemCount(ctx context.Context, service *zscaler.Service, appType, itemType string, excludeProfile ...int) (map[string]int, error) {
 	endpoint := fmt.Sprintf("%s/app-item-count/%s/%s", tenantRestrictionEndpoint, url.PathEscape(appType), url.PathEscape(itemType))
 
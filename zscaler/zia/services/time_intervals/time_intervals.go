@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -53,7 +55,7 @@ func GetTimeIntervalByName(ctx context.Context, service *zscaler.Service, timeIn
 			return &timeInterval, nil
 		}
 	}
-	return nil, fmt.Errorf("no time interval found with name: %s", timeIntervalName)
+	return nil, errorx.NotFoundf("no time interval found with name: %s", timeIntervalName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, intervalID *TimeInterval) (*TimeInterval, *http.Response, error) {
@@ -96,3 +98,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]TimeInterval, erro
 	err := common.ReadAllPages(ctx, service.Client, timeIntervalEndpoint, &timeInterval)
 	return timeInterval, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[TimeInterval, error] {
+	return common.AllPages[TimeInterval](ctx, service.Client, timeIntervalEndpoint, opts...)
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -229,7 +231,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, ruleName string) (
 			return &rule, nil
 		}
 	}
-	return nil, fmt.Errorf("no traffic capture rule found with name: %s", ruleName)
+	return nil, errorx.NotFoundf("no traffic capture rule found with name: %s", ruleName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, rule *TrafficCaptureRules) (*TrafficCaptureRules, error) {
@@ -269,6 +271,16 @@ func Delete(ctx context.Context, service *zscaler.Service, ruleID int) (*http.Re
 // GetAll retrieves all traffic capture rules with optional filters.
 func GetAll(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOptions) ([]TrafficCaptureRules, error) {
 	var rules []TrafficCaptureRules
+	err := common.ReadAllPages(ctx, service.Client, getAllEndpoint(opts), &rules)
+	return rules, err
+}
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOptions, pageOpts ...zscaler.PageOption) iter.Seq2[TrafficCaptureRules, error] {
+	return common.AllPages[TrafficCaptureRules](ctx, service.Client, getAllEndpoint(opts), pageOpts...)
+}
+
+func getAllEndpoint(opts *GetAllFilterOptions) string {
 	endpoint := trafficCaptureRulesEndpoint
 
 	// Build query parameters from filter options
@@ -317,9 +329,7 @@ func GetAll(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOpt
 	if baseQuery != "" {
 		endpoint += "?" + baseQuery
 	}
-
-	err := common.ReadAllPages(ctx, service.Client, endpoint, &rules)
-	return rules, err
+	return endpoint
 }
 
 // GetTrafficCaptureRuleCount retrieves the count of traffic capture rules using optional filters.
@@ -400,6 +410,23 @@ func GetTrafficCaptureRuleOrder(ctx context.Context, service *zscaler.Service) (
 // GetTrafficCaptureRuleLabels retrieves the list of rule labels associated with the Traffic Capture policy rules.
 func GetTrafficCaptureRuleLabels(ctx context.Context, service *zscaler.Service, opts *GetTrafficCaptureRuleLabelsFilterOptions) ([]RuleLabelInfo, error) {
 	var ruleLabels []RuleLabelInfo
+
+	// Use common.ReadAllPages to handle pagination (page and pageSize added automatically)
+	err := common.ReadAllPages(ctx, service.Client, ruleLabelsEndpoint(opts), &ruleLabels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve traffic capture rule labels: %w", err)
+	}
+
+	service.Client.GetLogger().Printf("[DEBUG] Returning %d traffic capture rule labels", len(ruleLabels))
+	return ruleLabels, nil
+}
+
+// AllTrafficCaptureRuleLabels is like GetTrafficCaptureRuleLabels, but returns an iterator that fetches one page at a time.
+func AllTrafficCaptureRuleLabels(ctx context.Context, service *zscaler.Service, opts *GetTrafficCaptureRuleLabelsFilterOptions, pageOpts ...zscaler.PageOption) iter.Seq2[RuleLabelInfo, error] {
+	return common.AllPages[RuleLabelInfo](ctx, service.Client, ruleLabelsEndpoint(opts), pageOpts...)
+}
+
+func ruleLabelsEndpoint(opts *GetTrafficCaptureRuleLabelsFilterOptions) string {
 	endpoint := trafficCaptureRulesEndpoint + "/ruleLabels"
 
 	// Build query parameters from filter options
@@ -418,13 +445,5 @@ func GetTrafficCaptureRuleLabels(ctx context.Context, service *zscaler.Service,
 	if baseQuery != "" {
 		endpoint += "?" + baseQuery
 	}
-
-	// Use common.ReadAllPages to handle pagination (page and pageSize added automatically)
-	err := common.ReadAllPages(ctx, service.Client, endpoint, &ruleLabels)
-	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve traffic capture rule labels: %w", err)
-	}
-
-	service.Client.GetLogger().Printf("[DEBUG] Returning %d traffic capture rule labels", len(ruleLabels))
-	return ruleLabels, nil
+	return endpoint
 }
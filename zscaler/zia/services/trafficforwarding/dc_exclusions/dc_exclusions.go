@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -71,7 +72,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, dcName string) (*D
 			return &dcExclusions[i], nil
 		}
 	}
-	return nil, fmt.Errorf("no dc exclusion found with name: %s", dcName)
+	return nil, errorx.NotFoundf("no dc exclusion found with name: %s", dcName)
 }
 
 // Create sends the DC exclusion as an array payload. The API expects a JSON array
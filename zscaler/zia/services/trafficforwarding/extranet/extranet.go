@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -102,7 +103,7 @@ func GetExtranetByName(ctx context.Context, service *zscaler.Service, extranetNa
 			return &extranets[i], nil
 		}
 	}
-	return nil, fmt.Errorf("no extranet found with name: %s", extranetName)
+	return nil, errorx.NotFoundf("no extranet found with name: %s", extranetName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, extranet *Extranet) (*Extranet, *http.Response, error) {
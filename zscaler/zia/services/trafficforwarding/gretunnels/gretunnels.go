@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -154,7 +156,7 @@ func GetByIPAddress(ctx context.Context, service *zscaler.Service, sourceIP stri
 			return &source, nil
 		}
 	}
-	return nil, fmt.Errorf("no device group found with name: %s", sourceIP)
+	return nil, errorx.NotFoundf("no device group found with name: %s", sourceIP)
 }
 
 // Adds a GRE tunnel configuration.
@@ -198,3 +200,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]GreTunnels, error)
 	err := common.ReadAllPages(ctx, service.Client, greTunnelsEndpoint, &greTunnels)
 	return greTunnels, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[GreTunnels, error] {
+	return common.AllPages[GreTunnels](ctx, service.Client, greTunnelsEndpoint, opts...)
+}
@@ -2,6 +2,7 @@ package ipv6_config
 
 import (
 	"context"
+	"iter"
 	"net/url"
 	"strings"
 
@@ -36,24 +37,32 @@ func GetIPv6Config(ctx context.Context, service *zscaler.Service) (*IPv6Config,
 
 func GetDns64Prefix(ctx context.Context, service *zscaler.Service, search ...string) ([]IPv6ConfigPrefix, error) {
 	var prefix []IPv6ConfigPrefix
-	endpoint := ipv6configEndpoint + "/dns64prefix"
-
-	if len(search) > 0 && strings.TrimSpace(search[0]) != "" {
-		endpoint += "?search=" + url.QueryEscape(search[0])
-	}
-
-	err := common.ReadAllPages(ctx, service.Client, endpoint, &prefix)
+	err := common.ReadAllPages(ctx, service.Client, prefixEndpoint("/dns64prefix", search...), &prefix)
 	return prefix, err
 }
 
+// AllDns64Prefix is like GetDns64Prefix, but returns an iterator that fetches one page at a time.
+func AllDns64Prefix(ctx context.Context, service *zscaler.Service, search string, opts ...zscaler.PageOption) iter.Seq2[IPv6ConfigPrefix, error] {
+	return common.AllPages[IPv6ConfigPrefix](ctx, service.Client, prefixEndpoint("/dns64prefix", search), opts...)
+}
+
 func GetNat64Prefix(ctx context.Context, service *zscaler.Service, search ...string) ([]IPv6ConfigPrefix, error) {
 	var prefix []IPv6ConfigPrefix
-	endpoint := ipv6configEndpoint + "/nat64prefix"
+	err := common.ReadAllPages(ctx, service.Client, prefixEndpoint("/nat64prefix", search...), &prefix)
+	return prefix, err
+}
+
+// AllNat64Prefix is like GetNat64Prefix, but returns an iterator that fetches one page at a time.
+func AllNat64Prefix(ctx context.Context, service *zscaler.Service, search string, opts ...zscaler.PageOption) iter.Seq2[IPv6ConfigPrefix, error] {
+	return common.AllPages[IPv6ConfigPrefix](ctx, service.Client, prefixEndpoint("/nat64prefix", search), opts...)
+}
 
+// prefixEndpoint returns the endpoint of the prefixes under path, filtered by
+// the first search term if it is not blank.
+func prefixEndpoint(path string, search ...string) string {
+	endpoint := ipv6configEndpoint + path
 	if len(search) > 0 && strings.TrimSpace(search[0]) != "" {
 		endpoint += "?search=" + url.QueryEscape(search[0])
 	}
-
-	err := common.ReadAllPages(ctx, service.Client, endpoint, &prefix)
-	return prefix, err
+	return endpoint
 }
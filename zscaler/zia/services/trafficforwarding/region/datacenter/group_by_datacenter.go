@@ -2,6 +2,7 @@ package datacenter
 
 import (
 	"context"
+	"iter"
 	"net/url"
 	"strconv"
 	"strings"
@@ -35,6 +36,19 @@ type GreVIP struct {
 
 func SearchByDatacenters(ctx context.Context, service *zscaler.Service, params common.DatacenterSearchParameters) ([]DatacenterVIPS, error) {
 	var zscalerVips []DatacenterVIPS
+	err := common.ReadAllPages(ctx, service.Client, datacentersEndpoint(params), &zscalerVips)
+	if err != nil {
+		return nil, err
+	}
+	return zscalerVips, nil
+}
+
+// AllByDatacenters is like SearchByDatacenters, but returns an iterator that fetches one page at a time.
+func AllByDatacenters(ctx context.Context, service *zscaler.Service, params common.DatacenterSearchParameters, opts ...zscaler.PageOption) iter.Seq2[DatacenterVIPS, error] {
+	return common.AllPages[DatacenterVIPS](ctx, service.Client, datacentersEndpoint(params), opts...)
+}
+
+func datacentersEndpoint(params common.DatacenterSearchParameters) string {
 	var queryParams []string
 
 	if params.RoutableIP {
@@ -68,10 +82,5 @@ func SearchByDatacenters(ctx context.Context, service *zscaler.Service, params c
 	if len(queryParams) > 0 {
 		endpoint += "?" + strings.Join(queryParams, "&")
 	}
-
-	err := common.ReadAllPages(ctx, service.Client, endpoint, &zscalerVips)
-	if err != nil {
-		return nil, err
-	}
-	return zscalerVips, nil
+	return endpoint
 }
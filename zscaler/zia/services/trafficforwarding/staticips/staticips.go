@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -98,7 +100,7 @@ func GetByIPAddress(ctx context.Context, service *zscaler.Service, address strin
 			return &static, nil
 		}
 	}
-	return nil, fmt.Errorf("no device group found with name: %s", address)
+	return nil, errorx.NotFoundf("no device group found with name: %s", address)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, staticIpID *StaticIP) (*StaticIP, *http.Response, error) {
@@ -141,3 +143,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]StaticIP, error) {
 	err := common.ReadAllPages(ctx, service.Client, staticIPEndpoint, &staticIPs)
 	return staticIPs, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[StaticIP, error] {
+	return common.AllPages[StaticIP](ctx, service.Client, staticIPEndpoint, opts...)
+}
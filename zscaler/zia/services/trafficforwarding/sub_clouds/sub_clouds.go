@@ -3,10 +3,12 @@ package sub_clouds
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -52,6 +54,11 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]SubClouds, error)
 	return subClouds, err
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[SubClouds, error] {
+	return common.AllPages[SubClouds](ctx, service.Client, subCloudsEndpoint, opts...)
+}
+
 func GetByName(ctx context.Context, service *zscaler.Service, subCloudName string) (*SubClouds, error) {
 	subClouds, err := GetAll(ctx, service)
 	if err != nil {
@@ -62,7 +69,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, subCloudName strin
 			return &subClouds[i], nil
 		}
 	}
-	return nil, fmt.Errorf("no subcloud found with name: %s", subCloudName)
+	return nil, errorx.NotFoundf("no subcloud found with name: %s", subCloudName)
 }
 
 func Update(ctx context.Context, service *zscaler.Service, cloudID int, subClouds *SubClouds) (*SubClouds, *http.Response, error) {
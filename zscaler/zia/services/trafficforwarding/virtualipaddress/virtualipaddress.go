@@ -3,11 +3,13 @@ package virtualipaddress
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/url"
 	"strconv"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/trafficforwarding/staticips"
 )
@@ -67,7 +69,7 @@ func GetZscalerVIPs(ctx context.Context, service *zscaler.Service, datacenter st
 			return &vips, nil
 		}
 	}
-	return nil, fmt.Errorf("no datacenter found with name: %s", datacenter)
+	return nil, errorx.NotFoundf("no datacenter found with name: %s", datacenter)
 }
 
 // Gets a paginated list of the virtual IP addresses (VIPs) available in the Zscaler cloud by sourceIP.
@@ -220,6 +222,11 @@ func GetAll(ctx context.Context, service *zscaler.Service, sourceIP string) ([]G
 	return zscalerVips, err
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, sourceIP string, opts ...zscaler.PageOption) iter.Seq2[GREVirtualIPList, error] {
+	return common.AllPages[GREVirtualIPList](ctx, service.Client, vipRecommendedListEndpoint+"?sourceIp="+sourceIP, opts...)
+}
+
 func getAllStaticIPs(ctx context.Context, service *zscaler.Service) ([]staticips.StaticIP, error) {
 	var staticIPs []staticips.StaticIP
 	err := common.ReadAllPages(ctx, service.Client, staticIPEndpoint, &staticIPs)
@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -70,6 +72,20 @@ func Get(ctx context.Context, service *zscaler.Service, vpnCredentialID int) (*V
 }
 
 func GetVPNByType(ctx context.Context, service *zscaler.Service, vpnType string, includeOnlyWithoutLocation *bool, locationId *int, managedBy *int) ([]VPNCredentials, error) {
+	var vpnTypes []VPNCredentials
+	err := common.ReadAllPages(ctx, service.Client, vpnByTypeEndpoint(vpnType, includeOnlyWithoutLocation, locationId, managedBy), &vpnTypes)
+	if err != nil {
+		return nil, err
+	}
+	return vpnTypes, nil
+}
+
+// AllVPNByType is like GetVPNByType, but returns an iterator that fetches one page at a time.
+func AllVPNByType(ctx context.Context, service *zscaler.Service, vpnType string, includeOnlyWithoutLocation *bool, locationId *int, managedBy *int, opts ...zscaler.PageOption) iter.Seq2[VPNCredentials, error] {
+	return common.AllPages[VPNCredentials](ctx, service.Client, vpnByTypeEndpoint(vpnType, includeOnlyWithoutLocation, locationId, managedBy), opts...)
+}
+
+func vpnByTypeEndpoint(vpnType string, includeOnlyWithoutLocation *bool, locationId *int, managedBy *int) string {
 	queryParams := url.Values{}
 	queryParams.Set("type", vpnType)
 
@@ -82,13 +98,7 @@ func GetVPNByType(ctx context.Context, service *zscaler.Service, vpnType string,
 	if managedBy != nil {
 		queryParams.Set("managedBy", strconv.Itoa(*managedBy))
 	}
-
-	var vpnTypes []VPNCredentials
-	err := common.ReadAllPages(ctx, service.Client, fmt.Sprintf("%s?%s", vpnCredentialsEndpoint, queryParams.Encode()), &vpnTypes)
-	if err != nil {
-		return nil, err
-	}
-	return vpnTypes, nil
+	return fmt.Sprintf("%s?%s", vpnCredentialsEndpoint, queryParams.Encode())
 }
 
 func GetByFQDN(ctx context.Context, service *zscaler.Service, vpnCredentialName string) (*VPNCredentials, error) {
@@ -103,7 +113,7 @@ func GetByFQDN(ctx context.Context, service *zscaler.Service, vpnCredentialName
 			return &vpnCredential, nil
 		}
 	}
-	return nil, fmt.Errorf("no vpn credentials found with fqdn: %s", vpnCredentialName)
+	return nil, errorx.NotFoundf("no vpn credentials found with fqdn: %s", vpnCredentialName)
 }
 
 func GetByIP(ctx context.Context, service *zscaler.Service, vpnCredentialIP string) (*VPNCredentials, error) {
@@ -118,7 +128,7 @@ func GetByIP(ctx context.Context, service *zscaler.Service, vpnCredentialIP stri
 			return &vpnCredential, nil
 		}
 	}
-	return nil, fmt.Errorf("no vpn credentials found with ip: %s", vpnCredentialIP)
+	return nil, errorx.NotFoundf("no vpn credentials found with ip: %s", vpnCredentialIP)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, vpnCredentials *VPNCredentials) (*VPNCredentials, *http.Response, error) {
@@ -199,3 +209,9 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]VPNCredentials, er
 
 	return vpnTypes, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[VPNCredentials, error] {
+	opts = append([]zscaler.PageOption{zscaler.WithPageSize(1000)}, opts...)
+	return common.AllPages[VPNCredentials](ctx, service.Client, vpnCredentialsEndpoint, opts...)
+}
@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -180,7 +182,7 @@ func GetCustomURLCategories(ctx context.Context, service *zscaler.Service, custo
 			return &custom, nil
 		}
 	}
-	return nil, fmt.Errorf("no custom url category found with name: %s", customName)
+	return nil, errorx.NotFoundf("no custom url category found with name: %s", customName)
 }
 
 func GetAllCustomURLCategories(ctx context.Context, service *zscaler.Service) ([]URLCategory, error) {
@@ -272,6 +274,11 @@ func GetAllLite(ctx context.Context, service *zscaler.Service) ([]URLCategory, e
 	return urlCategories, nil
 }
 
+// AllLite is like GetAllLite, but returns an iterator that fetches one page at a time.
+func AllLite(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[URLCategory, error] {
+	return common.AllPages[URLCategory](ctx, service.Client, urlCategoriesEndpoint+"/lite", opts...)
+}
+
 func CreateURLReview(ctx context.Context, service *zscaler.Service, domains []string) ([]URLReview, error) {
 	resp, err := service.Client.CreateWithSlicePayload(ctx, urlCategoriesEndpoint+"/review/domains", domains)
 	if err != nil {
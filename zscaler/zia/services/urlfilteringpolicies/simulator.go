@@ -0,0 +1,448 @@
+package urlfilteringpolicies
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/firewallpolicies/timewindow"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/urlcategories"
+)
+
+// DefaultAction is the action of requests no enabled rule matches.
+const DefaultAction = "ALLOW"
+
+// Snapshot is the URL filtering policy of a tenant, as evaluated by a
+// Simulator. It can be saved as JSON and loaded in tests, so policy changes
+// are checked without a tenant.
+type Snapshot struct {
+	Rules []URLFilteringRule `json:"rules"`
+
+	// CustomCategories are matched against the request URL: a URL listed in
+	// urls belongs to the custom category only, one listed in
+	// dbCategorizedUrls keeps its predefined categories too.
+	CustomCategories []urlcategories.URLCategory `json:"customCategories,omitempty"`
+
+	TimeWindows []timewindow.TimeWindow `json:"timeWindows,omitempty"`
+
+	// Classifications holds the predefined categories of URLs, as returned by
+	// urlcategories.GetURLLookup, by URL without scheme.
+	Classifications map[string][]string `json:"classifications,omitempty"`
+}
+
+// GetSnapshot reads the URL filtering rules, custom URL categories and time
+// windows of the tenant, and classifies urls with urlcategories.GetURLLookup.
+func GetSnapshot(ctx context.Context, service *zscaler.Service, urls ...string) (*Snapshot, error) {
+	rules, err := GetAll(ctx, service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read URL filtering rules: %w", err)
+	}
+	categories, err := urlcategories.GetAllCustomURLCategories(ctx, service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read custom URL categories: %w", err)
+	}
+	windows, err := timewindow.GetAll(ctx, service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read time windows: %w", err)
+	}
+	snapshot := &Snapshot{Rules: rules, CustomCategories: categories, TimeWindows: windows}
+	if len(urls) > 0 {
+		lookups, err := urlcategories.GetURLLookup(ctx, service, urls)
+		if err != nil {
+			return nil, fmt.Errorf("failed to classify URLs: %w", err)
+		}
+		snapshot.Classifications = make(map[string][]string, len(lookups))
+		for _, l := range lookups {
+			snapshot.Classifications[classificationKey(l.URL)] = append(append([]string{}, l.URLClassifications...), l.URLClassificationsWithSecurityAlert...)
+		}
+	}
+	return snapshot, nil
+}
+
+// SimulationRequest describes the request a Simulator evaluates. Users,
+// groups, departments, locations and location groups are given by name or ID.
+type SimulationRequest struct {
+	URL string
+
+	// Categories are the predefined categories of the URL. When empty, they
+	// are taken from the classifications of the snapshot.
+	Categories []string
+
+	User           string
+	Groups         []string
+	Department     string
+	Location       string
+	LocationGroups []string
+
+	// Time is when the request is made, in the time zone of the location.
+	// The zero value means now.
+	Time time.Time
+
+	// Protocol is a rule protocol such as HTTPS_RULE, or HTTPS. It defaults
+	// to the scheme of the URL.
+	Protocol string
+
+	// RequestMethod is the HTTP method, e.g. POST or CONNECT. It defaults to
+	// GET.
+	RequestMethod string
+}
+
+// SimulationResult is the outcome of Simulator.Evaluate.
+type SimulationResult struct {
+	// Rule is the rule that fired, or nil if none did.
+	Rule *URLFilteringRule
+
+	// Action is the action of Rule, or DefaultAction.
+	Action string
+
+	// Categories are the categories the URL was evaluated with.
+	Categories []string
+
+	// Trace lists the rules evaluated, in order, up to the one that fired.
+	Trace []TraceStep
+}
+
+// TraceStep records the evaluation of one rule.
+type TraceStep struct {
+	RuleID  int
+	Name    string
+	Order   int
+	Matched bool
+
+	// Reason tells why the rule did not match.
+	Reason string
+
+	// Unevaluated lists the criteria of the rule the simulator cannot
+	// evaluate, such as devices or source IP groups. They are assumed to
+	// match.
+	Unevaluated []string
+}
+
+// Simulator evaluates URL filtering rules offline, the way ZIA does: enabled
+// rules are evaluated by order and the first rule whose criteria all match
+// fires. Within a rule, users, groups and departments match if any of them
+// does, as do locations and location groups.
+type Simulator struct {
+	snapshot Snapshot
+	rules    []URLFilteringRule
+	windows  map[int]timewindow.TimeWindow
+}
+
+// NewSimulator returns a simulator of the policy in snapshot.
+func NewSimulator(snapshot Snapshot) *Simulator {
+	s := &Simulator{
+		snapshot: snapshot,
+		rules:    append([]URLFilteringRule{}, snapshot.Rules...),
+		windows:  make(map[int]timewindow.TimeWindow, len(snapshot.TimeWindows)),
+	}
+	sort.SliceStable(s.rules, func(i, j int) bool {
+		if s.rules[i].Order != s.rules[j].Order {
+			return s.rules[i].Order < s.rules[j].Order
+		}
+		return s.rules[i].Rank < s.rules[j].Rank
+	})
+	for _, w := range snapshot.TimeWindows {
+		s.windows[w.ID] = w
+	}
+	return s
+}
+
+// Evaluate returns the rule that fires for req.
+func (s *Simulator) Evaluate(req SimulationRequest) (*SimulationResult, error) {
+	u, err := parseRequestURL(req.URL)
+	if err != nil {
+		return nil, err
+	}
+	if req.Time.IsZero() {
+		req.Time = time.Now()
+	}
+	if req.Protocol == "" {
+		req.Protocol = u.Scheme
+	}
+	if req.RequestMethod == "" {
+		req.RequestMethod = "GET"
+	}
+
+	result := &SimulationResult{Action: DefaultAction, Categories: s.categorize(u, req.Categories)}
+	for i := range s.rules {
+		rule := &s.rules[i]
+		step := TraceStep{RuleID: rule.ID, Name: rule.Name, Order: rule.Order}
+		step.Reason = s.mismatch(rule, req, result.Categories)
+		step.Matched = step.Reason == ""
+		if step.Matched {
+			step.Unevaluated = unevaluated(rule)
+		}
+		result.Trace = append(result.Trace, step)
+		if step.Matched {
+			result.Rule = rule
+			result.Action = rule.Action
+			break
+		}
+	}
+	return result, nil
+}
+
+// mismatch returns why rule does not match req, or "" if it does.
+func (s *Simulator) mismatch(rule *URLFilteringRule, req SimulationRequest, categories []string) string {
+	switch {
+	case strings.EqualFold(rule.State, "DISABLED"):
+		return "rule is disabled"
+	case !matchCategories(rule.URLCategories, categories):
+		return fmt.Sprintf("URL categories %v not in %v", categories, rule.URLCategories)
+	case !matchCategories(rule.URLCategories2, categories):
+		return fmt.Sprintf("URL categories %v not in %v", categories, rule.URLCategories2)
+	case !matchProtocol(rule.Protocols, req.Protocol):
+		return fmt.Sprintf("protocol %s not in %v", req.Protocol, rule.Protocols)
+	case len(rule.RequestMethods) > 0 && !containsFold(rule.RequestMethods, req.RequestMethod):
+		return fmt.Sprintf("request method %s not in %v", req.RequestMethod, rule.RequestMethods)
+	case !matchWho(rule, req):
+		return "user, groups and department not in the rule"
+	case !matchWhere(rule, req):
+		return "location and location groups not in the rule"
+	}
+	return s.matchTime(rule, req.Time)
+}
+
+func matchCategories(ruleCategories, categories []string) bool {
+	if len(ruleCategories) == 0 || containsFold(ruleCategories, "ANY") {
+		return true
+	}
+	for _, c := range categories {
+		if containsFold(ruleCategories, c) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchProtocol(protocols []string, protocol string) bool {
+	if len(protocols) == 0 {
+		return true
+	}
+	protocol = normalizeProtocol(protocol)
+	for _, p := range protocols {
+		if p = normalizeProtocol(p); p == "ANY" || p == protocol {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeProtocol(p string) string {
+	return strings.TrimSuffix(strings.ToUpper(p), "_RULE")
+}
+
+func matchWho(rule *URLFilteringRule, req SimulationRequest) bool {
+	if len(rule.Users) == 0 && len(rule.Groups) == 0 && len(rule.Departments) == 0 {
+		return true
+	}
+	return matchRefs(rule.Users, req.User) ||
+		matchRefs(rule.Groups, req.Groups...) ||
+		matchRefs(rule.Departments, req.Department)
+}
+
+func matchWhere(rule *URLFilteringRule, req SimulationRequest) bool {
+	if len(rule.Locations) == 0 && len(rule.LocationGroups) == 0 {
+		return true
+	}
+	return matchRefs(rule.Locations, req.Location) || matchRefs(rule.LocationGroups, req.LocationGroups...)
+}
+
+// matchRefs reports whether any value names, or is the ID of, one of refs.
+func matchRefs(refs []common.IDNameExtensions, values ...string) bool {
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		for _, r := range refs {
+			if strings.EqualFold(r.Name, v) || strconv.Itoa(r.ID) == v {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchTime checks the validity period and the time windows of rule.
+func (s *Simulator) matchTime(rule *URLFilteringRule, t time.Time) string {
+	if rule.EnforceTimeValidity {
+		if rule.ValidityStartTime != 0 && t.Unix() < int64(rule.ValidityStartTime) {
+			return "rule is not valid yet"
+		}
+		if rule.ValidityEndTime != 0 && t.Unix() >= int64(rule.ValidityEndTime) {
+			return "rule is no longer valid"
+		}
+	}
+	if len(rule.TimeWindows) == 0 {
+		return ""
+	}
+	for _, ref := range rule.TimeWindows {
+		w, ok := s.windows[ref.ID]
+		if !ok {
+			for _, candidate := range s.snapshot.TimeWindows {
+				if strings.EqualFold(candidate.Name, ref.Name) {
+					w, ok = candidate, true
+					break
+				}
+			}
+		}
+		if ok && inTimeWindow(w, t) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("%s is outside the rule time windows", t.Format("Mon 15:04"))
+}
+
+// inTimeWindow reports whether t falls in w. Times are minutes after
+// midnight; a window ending before it starts runs past midnight.
+func inTimeWindow(w timewindow.TimeWindow, t time.Time) bool {
+	minute := int32(t.Hour()*60 + t.Minute())
+	today := onDay(w, t.Weekday())
+	if w.StartTime <= w.EndTime {
+		return today && minute >= w.StartTime && minute < w.EndTime
+	}
+	yesterday := onDay(w, (t.Weekday()+6)%7)
+	return (today && minute >= w.StartTime) || (yesterday && minute < w.EndTime)
+}
+
+func onDay(w timewindow.TimeWindow, day time.Weekday) bool {
+	if len(w.DayOfWeek) == 0 {
+		return true
+	}
+	name := strings.ToUpper(day.String()[:3])
+	for _, d := range w.DayOfWeek {
+		if d = strings.ToUpper(d); d == "EVERYDAY" || d == name {
+			return true
+		}
+	}
+	return false
+}
+
+// unevaluated lists the criteria of rule the simulator cannot evaluate.
+func unevaluated(rule *URLFilteringRule) []string {
+	var criteria []string
+	add := func(set bool, name string) {
+		if set {
+			criteria = append(criteria, name)
+		}
+	}
+	add(len(rule.Devices) > 0, "devices")
+	add(len(rule.DeviceGroups) > 0, "deviceGroups")
+	add(len(rule.DeviceTrustLevels) > 0, "deviceTrustLevels")
+	add(len(rule.SourceIPGroups) > 0, "sourceIpGroups")
+	add(len(rule.SourceCountries) > 0, "sourceCountries")
+	add(len(rule.UserAgentTypes) > 0, "userAgentTypes")
+	add(len(rule.UserRiskScoreLevels) > 0, "userRiskScoreLevels")
+	add(len(rule.WorkloadGroups) > 0, "workloadGroups")
+	add(len(rule.HTTPHeaderProfiles) > 0, "httpHeaderProfiles")
+	add(rule.TimeQuota > 0, "timeQuota")
+	add(rule.SizeQuota > 0, "sizeQuota")
+	return criteria
+}
+
+// categorize returns the categories of u: the given or snapshot
+// classification and the custom categories listing u.
+func (s *Simulator) categorize(u *url.URL, given []string) []string {
+	predefined := given
+	if len(predefined) == 0 {
+		key := classificationKey(u.Host + u.Path)
+		if c, ok := s.snapshot.Classifications[key]; ok {
+			predefined = c
+		} else {
+			predefined = s.snapshot.Classifications[classificationKey(u.Host)]
+		}
+	}
+	var custom []string
+	replaced := false
+	for _, c := range s.snapshot.CustomCategories {
+		switch {
+		case matchAnyURL(c.Urls, u) || matchAnyIP(c.IPRanges, u):
+			custom = append(custom, c.ID)
+			replaced = true
+		case matchAnyURL(c.DBCategorizedUrls, u) || matchAnyIP(c.IPRangesRetainingParentCategory, u):
+			custom = append(custom, c.ID)
+		}
+	}
+	if replaced {
+		return custom
+	}
+	return append(append([]string{}, predefined...), custom...)
+}
+
+// matchAnyURL reports whether one of the URL category entries covers u. An
+// entry starting with a dot covers the domain and its subdomains; an entry
+// with a path covers the paths below it.
+func matchAnyURL(entries []string, u *url.URL) bool {
+	host := strings.ToLower(u.Hostname())
+	for _, e := range entries {
+		e = strings.ToLower(strings.TrimSpace(e))
+		if i := strings.Index(e, "://"); i >= 0 {
+			e = e[i+3:]
+		}
+		domain, path, _ := strings.Cut(e, "/")
+		if strings.HasPrefix(domain, ".") {
+			if host != domain[1:] && !strings.HasSuffix(host, domain) {
+				continue
+			}
+		} else if host != domain {
+			continue
+		}
+		if path == "" || strings.HasPrefix(strings.TrimPrefix(strings.ToLower(u.Path), "/"), path) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchAnyIP(ranges []string, u *url.URL) bool {
+	ip := net.ParseIP(u.Hostname())
+	if ip == nil {
+		return false
+	}
+	for _, r := range ranges {
+		if _, n, err := net.ParseCIDR(r); err == nil && n.Contains(ip) {
+			return true
+		}
+		if other := net.ParseIP(r); other != nil && other.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseRequestURL(raw string) (*url.URL, error) {
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid request URL %q", raw)
+	}
+	return u, nil
+}
+
+// classificationKey normalizes a URL to the lower-case host and path, without
+// scheme or trailing slash.
+func classificationKey(raw string) string {
+	raw = strings.ToLower(raw)
+	if i := strings.Index(raw, "://"); i >= 0 {
+		raw = raw[i+3:]
+	}
+	return strings.TrimSuffix(raw, "/")
+}
+
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
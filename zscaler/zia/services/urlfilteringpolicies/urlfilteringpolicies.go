@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -274,7 +275,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, urlFilteringPolicy
 			return &urlFilteringPolicy, nil
 		}
 	}
-	return nil, fmt.Errorf("no url filtering rule found with name: %s", urlFilteringPolicyName)
+	return nil, errorx.NotFoundf("no url filtering rule found with name: %s", urlFilteringPolicyName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, ruleID *URLFilteringRule) (*URLFilteringRule, error) {
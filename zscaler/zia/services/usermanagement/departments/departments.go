@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -71,7 +73,7 @@ func GetDepartmentsByName(ctx context.Context, service *zscaler.Service, departm
 			return &department, nil
 		}
 	}
-	return nil, fmt.Errorf("no department found with name: %s", departmentName)
+	return nil, errorx.NotFoundf("no department found with name: %s", departmentName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, departmentID *Department) (*Department, *http.Response, error) {
@@ -119,6 +121,16 @@ type GetAllFilterOptions struct {
 
 func GetAll(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOptions) ([]Department, error) {
 	var departments []Department
+	err := common.ReadAllPages(ctx, service.Client, getAllEndpoint(service, opts), &departments)
+	return departments, err
+}
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOptions, pageOpts ...zscaler.PageOption) iter.Seq2[Department, error] {
+	return common.AllPages[Department](ctx, service.Client, getAllEndpoint(service, opts), pageOpts...)
+}
+
+func getAllEndpoint(service *zscaler.Service, opts *GetAllFilterOptions) string {
 	endpoint := departmentEndpoint
 
 	// Build query parameters from filter options
@@ -144,9 +156,7 @@ func GetAll(ctx context.Context, service *zscaler.Service, opts *GetAllFilterOpt
 	if len(queryParams) > 0 {
 		endpoint += "?" + queryParams.Encode()
 	}
-
-	err := common.ReadAllPages(ctx, service.Client, endpoint, &departments)
-	return departments, err
+	return endpoint
 }
 
 func GetAllLite(ctx context.Context, service *zscaler.Service) ([]Department, error) {
@@ -154,3 +164,8 @@ func GetAllLite(ctx context.Context, service *zscaler.Service) ([]Department, er
 	err := common.ReadAllPages(ctx, service.Client, departmentEndpoint+"/lite", &depts)
 	return depts, err
 }
+
+// AllLite is like GetAllLite, but returns an iterator that fetches one page at a time.
+func AllLite(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[Department, error] {
+	return common.AllPages[Department](ctx, service.Client, departmentEndpoint+"/lite", opts...)
+}
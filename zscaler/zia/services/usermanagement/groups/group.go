@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -62,7 +64,7 @@ func GetGroupByName(ctx context.Context, service *zscaler.Service, targetGroup s
 		}
 	}
 
-	return nil, fmt.Errorf("no group found with name: %s", targetGroup)
+	return nil, errorx.NotFoundf("no group found with name: %s", targetGroup)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, groupID *Groups) (*Groups, *http.Response, error) {
@@ -110,6 +112,16 @@ type GetAllGroupsFilterOptions struct {
 
 func GetAllGroups(ctx context.Context, service *zscaler.Service, opts *GetAllGroupsFilterOptions) ([]Groups, error) {
 	var groups []Groups
+	err := common.ReadAllPages(ctx, service.Client, allGroupsEndpoint(service, opts), &groups)
+	return groups, err
+}
+
+// AllGroups is like GetAllGroups, but returns an iterator that fetches one page at a time.
+func AllGroups(ctx context.Context, service *zscaler.Service, opts *GetAllGroupsFilterOptions, pageOpts ...zscaler.PageOption) iter.Seq2[Groups, error] {
+	return common.AllPages[Groups](ctx, service.Client, allGroupsEndpoint(service, opts), pageOpts...)
+}
+
+func allGroupsEndpoint(service *zscaler.Service, opts *GetAllGroupsFilterOptions) string {
 	endpoint := groupsEndpoint
 
 	// Build query parameters from filter options
@@ -135,9 +147,7 @@ func GetAllGroups(ctx context.Context, service *zscaler.Service, opts *GetAllGro
 	if len(queryParams) > 0 {
 		endpoint += "?" + queryParams.Encode()
 	}
-
-	err := common.ReadAllPages(ctx, service.Client, endpoint, &groups)
-	return groups, err
+	return endpoint
 }
 
 func GetAllLite(ctx context.Context, service *zscaler.Service) ([]Groups, error) {
@@ -145,3 +155,8 @@ func GetAllLite(ctx context.Context, service *zscaler.Service) ([]Groups, error)
 	err := common.ReadAllPages(ctx, service.Client, groupsEndpoint+"/lite", &groups)
 	return groups, err
 }
+
+// AllLite is like GetAllLite, but returns an iterator that fetches one page at a time.
+func AllLite(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[Groups, error] {
+	return common.AllPages[Groups](ctx, service.Client, groupsEndpoint+"/lite", opts...)
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -17,6 +19,8 @@ const (
 	usersEndpoint    = "/zia/api/v1/users"
 	enrollEndpoint   = "/zia/api/v1/enroll"
 	maxBulkDeleteIDs = 500
+	// allUsersPageSize is the largest page the users endpoint supports.
+	allUsersPageSize = 10000
 )
 
 type Users struct {
@@ -92,7 +96,7 @@ func GetUserByName(ctx context.Context, service *zscaler.Service, userName strin
 			return &user, nil
 		}
 	}
-	return nil, fmt.Errorf("no user found with name: %s", userName)
+	return nil, errorx.NotFoundf("no user found with name: %s", userName)
 }
 
 func EnrollUser(ctx context.Context, service *zscaler.Service, userID int, request EnrollUserRequest) (*EnrollResult, error) {
@@ -175,6 +179,20 @@ type GetAllUsersFilterOptions struct {
 
 func GetAllUsers(ctx context.Context, service *zscaler.Service, opts *GetAllUsersFilterOptions) ([]Users, error) {
 	var users []Users
+	// Use pageSize=10000 as this endpoint supports up to 10,000 records per page
+	err := common.ReadAllPages(ctx, service.Client, allUsersEndpoint(service, opts), &users, allUsersPageSize)
+	return users, err
+}
+
+// AllUsers iterates over the users matching opts one page at a time, so
+// tenants with hundreds of thousands of users can be processed without
+// holding them all in memory.
+func AllUsers(ctx context.Context, service *zscaler.Service, opts *GetAllUsersFilterOptions, pageOpts ...zscaler.PageOption) iter.Seq2[Users, error] {
+	pageOpts = append([]zscaler.PageOption{zscaler.WithPageSize(allUsersPageSize)}, pageOpts...)
+	return common.AllPages[Users](ctx, service.Client, allUsersEndpoint(service, opts), pageOpts...)
+}
+
+func allUsersEndpoint(service *zscaler.Service, opts *GetAllUsersFilterOptions) string {
 	endpoint := usersEndpoint
 
 	// Build query parameters from filter options
@@ -203,10 +221,7 @@ func GetAllUsers(ctx context.Context, service *zscaler.Service, opts *GetAllUser
 	if len(queryParams) > 0 {
 		endpoint += "?" + queryParams.Encode()
 	}
-
-	// Use pageSize=10000 as this endpoint supports up to 10,000 records per page
-	err := common.ReadAllPages(ctx, service.Client, endpoint, &users, 10000)
-	return users, err
+	return endpoint
 }
 
 func GetAllAuditors(ctx context.Context, service *zscaler.Service) ([]Users, error) {
@@ -218,7 +233,23 @@ func GetAllAuditors(ctx context.Context, service *zscaler.Service) ([]Users, err
 	return users, nil
 }
 
+// AllAuditors is like GetAllAuditors, but returns an iterator that fetches one page at a time.
+func AllAuditors(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[Users, error] {
+	return common.AllPages[Users](ctx, service.Client, usersEndpoint+"/auditors", opts...)
+}
+
 func GetUserReferences(ctx context.Context, service *zscaler.Service, name *string, includeAdminUsers *bool) ([]common.IDNameExternalID, error) {
+	var users []common.IDNameExternalID
+	err := common.ReadAllPages(ctx, service.Client, userReferencesEndpoint(name, includeAdminUsers), &users)
+	return users, err
+}
+
+// AllUserReferences is like GetUserReferences, but returns an iterator that fetches one page at a time.
+func AllUserReferences(ctx context.Context, service *zscaler.Service, name *string, includeAdminUsers *bool, opts ...zscaler.PageOption) iter.Seq2[common.IDNameExternalID, error] {
+	return common.AllPages[common.IDNameExternalID](ctx, service.Client, userReferencesEndpoint(name, includeAdminUsers), opts...)
+}
+
+func userReferencesEndpoint(name *string, includeAdminUsers *bool) string {
 	endpoint := usersEndpoint + "/references"
 	queryParams := url.Values{}
 
@@ -232,8 +263,5 @@ func GetUserReferences(ctx context.Context, service *zscaler.Service, name *stri
 	if len(queryParams) > 0 {
 		endpoint += "?" + queryParams.Encode()
 	}
-
-	var users []common.IDNameExternalID
-	err := common.ReadAllPages(ctx, service.Client, endpoint, &users)
-	return users, err
+	return endpoint
 }
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -72,7 +74,7 @@ func GetClusterByName(ctx context.Context, service *zscaler.Service, clusterName
 			return &vzenCluster, nil
 		}
 	}
-	return nil, fmt.Errorf("no vzen cluster found with name: %s", clusterName)
+	return nil, errorx.NotFoundf("no vzen cluster found with name: %s", clusterName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, vzenClusters *VZENClusters) (*VZENClusters, *http.Response, error) {
@@ -141,3 +143,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]VZENClusters, erro
 	err := common.ReadAllPages(ctx, service.Client, vzenClusterEndpoint, &vzenClusters)
 	return vzenClusters, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[VZENClusters, error] {
+	return common.AllPages[VZENClusters](ctx, service.Client, vzenClusterEndpoint, opts...)
+}
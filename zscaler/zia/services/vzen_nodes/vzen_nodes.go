@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -92,7 +94,7 @@ func GetNodeByName(ctx context.Context, service *zscaler.Service, nodeName strin
 			return &vzenNode, nil
 		}
 	}
-	return nil, fmt.Errorf("no vzen node found with name: %s", nodeName)
+	return nil, errorx.NotFoundf("no vzen node found with name: %s", nodeName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, vzenNodes *VZENNodes) (*VZENNodes, *http.Response, error) {
@@ -135,3 +137,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]VZENNodes, error)
 	err := common.ReadAllPages(ctx, service.Client, vzenNodeEndpoint, &vzenNodes)
 	return vzenNodes, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[VZENNodes, error] {
+	return common.AllPages[VZENNodes](ctx, service.Client, vzenNodeEndpoint, opts...)
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/common"
 )
 
@@ -93,7 +95,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, workloadName strin
 			return &workloadGroup, nil
 		}
 	}
-	return nil, fmt.Errorf("no workload group found with name: %s", workloadName)
+	return nil, errorx.NotFoundf("no workload group found with name: %s", workloadName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, groups *WorkloadGroup) (*WorkloadGroup, *http.Response, error) {
@@ -136,3 +138,8 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]WorkloadGroup, err
 	err := common.ReadAllPages(ctx, service.Client, workloadGroupsEndpoint, &workloadGroups)
 	return workloadGroups, err
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[WorkloadGroup, error] {
+	return common.AllPages[WorkloadGroup](ctx, service.Client, workloadGroupsEndpoint, opts...)
+}
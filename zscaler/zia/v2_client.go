@@ -23,6 +23,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/zscaler/zscaler-sdk-go/v3/cache"
+	"github.com/zscaler/zscaler-sdk-go/v3/internal/telemetry"
 	"github.com/zscaler/zscaler-sdk-go/v3/logger"
 	rl "github.com/zscaler/zscaler-sdk-go/v3/ratelimiter"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
@@ -86,6 +87,7 @@ func NewClient(config *Configuration) (*Client, error) {
 		cacheCleanwindow: config.ZIA.Client.Cache.DefaultTti,
 		cacheMaxSizeMB:   int(config.ZIA.Client.Cache.DefaultCacheMaxSizeMB),
 		rateLimiter:      rateLimiter,
+		telemetry:        config.getTelemetry(),
 		sessionTimeout:   JSessionIDTimeout * time.Minute,
 		sessionRefreshed: time.Time{},
 		ctx:              ctx,
@@ -380,6 +382,9 @@ func getHTTPClient(l logger.Logger, rateLimiter *rl.RateLimiter, cfg *Configurat
 			if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
 				retryAfter := getRetryAfter(resp, l)
 				if retryAfter > 0 {
+					if resp.Request != nil {
+						cfg.getTelemetry().RecordRateLimitWait(resp.Request.Context(), "zia", telemetry.WaitSourceRateLimited, retryAfter)
+					}
 					return retryAfter
 				}
 			}
@@ -436,7 +441,7 @@ func getHTTPClient(l logger.Logger, rateLimiter *rl.RateLimiter, cfg *Configurat
 		l.Printf("[INFO] HTTPS certificate validation is disabled (testing mode).")
 	}
 
-	retryableClient.HTTPClient.Transport = transport
+	retryableClient.HTTPClient.Transport = cfg.getTelemetry().Transport(transport, "zia")
 	return retryableClient.StandardClient()
 }
 
@@ -638,6 +643,9 @@ func (c *Client) do(req *http.Request, start time.Time, reqID string) (*http.Res
 			inCache = false
 			c.freshCache = false
 		}
+		if req.Method == http.MethodGet {
+			c.telemetry.RecordCacheLookup(req.Context(), telemetry.Request{Product: "zia", Method: req.Method, Endpoint: req.URL.Path}, inCache)
+		}
 		if inCache {
 			c.Logger.Printf("[INFO] served from cache, key:%s\n", key)
 			return resp, nil
@@ -690,14 +698,20 @@ func (c *Client) do(req *http.Request, start time.Time, reqID string) (*http.Res
 }
 
 // Request ... // Needs to review this function.
-func (c *Client) GenericRequest(ctx context.Context, baseUrl, endpoint, method string, body io.Reader, urlParams url.Values, contentType string) ([]byte, error) {
+func (c *Client) GenericRequest(ctx context.Context, baseUrl, endpoint, method string, body io.Reader, urlParams url.Values, contentType string) (_ []byte, err error) {
 	if contentType == "" {
 		contentType = contentTypeJSON
 	}
 
 	var req *http.Request
 	var resp *http.Response
-	var err error
+	call := telemetry.Request{Product: "zia", Method: method, Endpoint: endpoint, StartTime: time.Now()}
+	ctx, span := c.telemetry.StartRequest(ctx, call)
+	defer func() {
+		call.Attempt = telemetry.Attempts(ctx)
+		c.telemetry.EndRequest(ctx, span, call, resp, err)
+	}()
+
 	params := ""
 	if urlParams != nil {
 		params = urlParams.Encode()
@@ -756,6 +770,11 @@ func (c *Client) GenericRequest(ctx context.Context, baseUrl, endpoint, method s
 
 	}
 
+	// Still unauthorized after the session refresh retries
+	if resp.StatusCode > 299 {
+		return nil, errorx.CheckErrorInResponse(resp, fmt.Errorf("api responded with code: %d", resp.StatusCode))
+	}
+
 	// Read response body
 	bodyResp, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -13,8 +13,11 @@ import (
 
 	"github.com/kelseyhightower/envconfig"
 	"github.com/zscaler/zscaler-sdk-go/v3/cache"
+	"github.com/zscaler/zscaler-sdk-go/v3/internal/telemetry"
 	"github.com/zscaler/zscaler-sdk-go/v3/logger"
 	rl "github.com/zscaler/zscaler-sdk-go/v3/ratelimiter"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
 	"gopkg.in/yaml.v3"
 )
 
@@ -76,6 +79,7 @@ type Client struct {
 	cacheCleanwindow time.Duration
 	cacheMaxSizeMB   int
 	rateLimiter      *rl.RateLimiter
+	telemetry        *telemetry.Telemetry
 	sessionTicker    *time.Ticker
 	// stopTicker       chan bool
 	ctx        context.Context
@@ -144,6 +148,12 @@ type Configuration struct {
 		} `yaml:"testing"`
 	} `yaml:"zia"`
 	CacheManager cache.Cache
+	// TracerProvider and MeterProvider enable OpenTelemetry spans and metrics
+	// for the requests of the client. Set them with WithTracerProvider and
+	// WithMeterProvider.
+	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
+	telemetry      *telemetry.Telemetry
 }
 
 func NewConfiguration(conf ...ConfigSetter) (*Configuration, error) {
@@ -427,6 +437,35 @@ func WithUserAgent(userAgent string) ConfigSetter {
 	}
 }
 
+// WithTracerProvider enables OpenTelemetry tracing of ZIA requests: one client
+// span per request and one child span per wire attempt, retries included.
+func WithTracerProvider(tp trace.TracerProvider) ConfigSetter {
+	return func(c *Configuration) {
+		c.TracerProvider = tp
+		c.telemetry = telemetry.New(c.TracerProvider, c.MeterProvider, VERSION)
+		setHttpClients(c)
+	}
+}
+
+// WithMeterProvider enables OpenTelemetry metrics for ZIA requests: request
+// latency, rate-limit wait time and cache lookups.
+func WithMeterProvider(mp metric.MeterProvider) ConfigSetter {
+	return func(c *Configuration) {
+		c.MeterProvider = mp
+		c.telemetry = telemetry.New(c.TracerProvider, c.MeterProvider, VERSION)
+		setHttpClients(c)
+	}
+}
+
+// getTelemetry returns the configured telemetry, or no-op telemetry when
+// neither provider is set.
+func (c *Configuration) getTelemetry() *telemetry.Telemetry {
+	if c == nil || c.telemetry == nil {
+		return telemetry.Noop
+	}
+	return c.telemetry
+}
+
 func WithDebug(debug bool) ConfigSetter {
 	return func(c *Configuration) {
 		c.Debug = debug
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/url"
 	"strconv"
 
@@ -322,3 +323,60 @@ func (p *PaginationQueryParams) WithIDPName(idpNames []string) *PaginationQueryP
 	p.IDPName = idpNames
 	return p
 }
+
+// AllPagesWithPagination returns an iterator over every record of an
+// offset-paginated zidentity endpoint, fetching one page at a time instead of
+// aggregating like ReadAllPagesWithPagination. Page tokens are offsets.
+func AllPagesWithPagination[T any](ctx context.Context, client *zscaler.Client, endpoint string, queryParams *PaginationQueryParams, opts ...zscaler.PageOption) iter.Seq2[T, error] {
+	params := PaginationQueryParams{Limit: DefaultPaginationOptions.DefaultPageSize}
+	if queryParams != nil {
+		params = *queryParams
+	}
+	if size := zscaler.NewPageOptions(opts...).PageSize; size > 0 {
+		params.WithLimit(size)
+	}
+	fetch := func(ctx context.Context, token zscaler.PageToken) (zscaler.Page[T], error) {
+		offset, err := token.Int(0)
+		if err != nil {
+			return zscaler.Page[T]{}, err
+		}
+		p := params
+		p.Offset = offset
+		var response PaginationResponse[T]
+		if err := client.Read(ctx, BuildEndpointWithParams(endpoint, &p), &response); err != nil {
+			return zscaler.Page[T]{}, fmt.Errorf("failed to fetch page at offset %d: %w", offset, err)
+		}
+		result := zscaler.Page[T]{Items: response.Records, Total: response.ResultsTotal}
+		if len(response.Records) >= p.Limit && response.NextLink != "" {
+			result.Next = zscaler.PageNumberToken(offset + len(response.Records))
+		}
+		return result, nil
+	}
+	return zscaler.Paginate(ctx, fetch, opts...)
+}
+
+// AllPagesWithCursor returns an iterator over every record of a
+// cursor-paginated zidentity endpoint. Page tokens are the next_link URLs
+// returned by the API.
+func AllPagesWithCursor[T any](ctx context.Context, client *zscaler.Client, endpoint string, queryParams *PaginationQueryParams, opts ...zscaler.PageOption) iter.Seq2[T, error] {
+	params := PaginationQueryParams{Limit: DefaultPaginationOptions.DefaultPageSize}
+	if queryParams != nil {
+		params = *queryParams
+	}
+	if size := zscaler.NewPageOptions(opts...).PageSize; size > 0 {
+		params.WithLimit(size)
+	}
+	firstURL := BuildEndpointWithParams(endpoint, &params)
+	fetch := func(ctx context.Context, token zscaler.PageToken) (zscaler.Page[T], error) {
+		currentURL := string(token)
+		if currentURL == "" {
+			currentURL = firstURL
+		}
+		var response PaginationResponse[T]
+		if err := client.Read(ctx, currentURL, &response); err != nil {
+			return zscaler.Page[T]{}, fmt.Errorf("failed to fetch page: %w", err)
+		}
+		return zscaler.Page[T]{Items: response.Records, Next: zscaler.PageToken(response.NextLink), Total: response.ResultsTotal}, nil
+	}
+	return zscaler.Paginate(ctx, fetch, opts...)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
@@ -52,6 +53,12 @@ func GetAll(ctx context.Context, service *zscaler.Service, queryParams *common.P
 	return common.ReadAllPagesWithPagination[Groups](ctx, service.Client, groupsEndpoint, queryParams)
 }
 
+// All iterates over the groups matching queryParams one page at a time.
+// queryParams.Offset is ignored; resume with WithStartToken instead.
+func All(ctx context.Context, service *zscaler.Service, queryParams *common.PaginationQueryParams, opts ...zscaler.PageOption) iter.Seq2[Groups, error] {
+	return common.AllPagesWithPagination[Groups](ctx, service.Client, groupsEndpoint, queryParams, opts...)
+}
+
 // GetByName retrieves groups by searching through paginated data for the specified name
 func GetByName(ctx context.Context, service *zscaler.Service, name string) ([]Groups, error) {
 	var allGroups []Groups
@@ -94,6 +101,12 @@ func GetUsers(ctx context.Context, service *zscaler.Service, groupID string, que
 	return common.ReadAllPagesWithPagination[interface{}](ctx, service.Client, usersEndpoint, queryParams)
 }
 
+// AllUsers iterates over the users within a specific group one page at a time.
+func AllUsers(ctx context.Context, service *zscaler.Service, groupID string, queryParams *common.PaginationQueryParams, opts ...zscaler.PageOption) iter.Seq2[interface{}, error] {
+	usersEndpoint := fmt.Sprintf("%s/%s/users", groupsEndpoint, groupID)
+	return common.AllPagesWithPagination[interface{}](ctx, service.Client, usersEndpoint, queryParams, opts...)
+}
+
 func Create(ctx context.Context, service *zscaler.Service, groups *Groups) (*Groups, *http.Response, error) {
 	resp, err := service.Client.Create(ctx, groupsEndpoint, *groups)
 	if err != nil {
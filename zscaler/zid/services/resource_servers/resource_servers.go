@@ -3,6 +3,7 @@ package resourceservers
 import (
 	"context"
 	"fmt"
+	"iter"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
@@ -59,6 +60,12 @@ func GetAll(ctx context.Context, service *zscaler.Service, queryParams *common.P
 	return common.ReadAllPagesWithPagination[ResourceServers](ctx, service.Client, resourceServerEndpoint, queryParams)
 }
 
+// All iterates over the resource servers matching queryParams one page at a time.
+// queryParams.Offset is ignored; resume with WithStartToken instead.
+func All(ctx context.Context, service *zscaler.Service, queryParams *common.PaginationQueryParams, opts ...zscaler.PageOption) iter.Seq2[ResourceServers, error] {
+	return common.AllPagesWithPagination[ResourceServers](ctx, service.Client, resourceServerEndpoint, queryParams, opts...)
+}
+
 // GetByName retrieves resource servers by searching through paginated data for the specified name
 func GetByName(ctx context.Context, service *zscaler.Service, name string) ([]ResourceServers, error) {
 	var allResources []ResourceServers
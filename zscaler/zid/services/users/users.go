@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
@@ -49,6 +50,12 @@ func GetAll(ctx context.Context, service *zscaler.Service, queryParams *common.P
 	return common.ReadAllPagesWithPagination[Users](ctx, service.Client, usersEndpoint, queryParams)
 }
 
+// All iterates over the users matching queryParams one page at a time.
+// queryParams.Offset is ignored; resume with WithStartToken instead.
+func All(ctx context.Context, service *zscaler.Service, queryParams *common.PaginationQueryParams, opts ...zscaler.PageOption) iter.Seq2[Users, error] {
+	return common.AllPagesWithPagination[Users](ctx, service.Client, usersEndpoint, queryParams, opts...)
+}
+
 // GetByName retrieves users by searching through paginated data for the specified name
 func GetByName(ctx context.Context, service *zscaler.Service, name string) ([]Users, error) {
 	var allUsers []Users
@@ -91,6 +98,12 @@ func GetUsers(ctx context.Context, service *zscaler.Service, userID string, quer
 	return common.ReadAllPagesWithPagination[interface{}](ctx, service.Client, usersEndpoint, queryParams)
 }
 
+// AllUsers is like GetUsers, but returns an iterator that fetches one page at a time.
+func AllUsers(ctx context.Context, service *zscaler.Service, userID string, queryParams *common.PaginationQueryParams, opts ...zscaler.PageOption) iter.Seq2[interface{}, error] {
+	usersEndpoint := fmt.Sprintf("%s/%s/users", usersEndpoint, userID)
+	return common.AllPagesWithPagination[interface{}](ctx, service.Client, usersEndpoint, queryParams, opts...)
+}
+
 func Create(ctx context.Context, service *zscaler.Service, user *Users) (*Users, *http.Response, error) {
 	resp, err := service.Client.Create(ctx, usersEndpoint, *user)
 	if err != nil {
@@ -3,10 +3,12 @@ package administrator_controller
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -70,7 +72,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, adminName string)
 			return &admin, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no administrator username named '%s' was found", adminName)
+	return nil, resp, errorx.NotFoundf("no administrator username named '%s' was found", adminName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, admin *AdministratorController) (*AdministratorController, *http.Response, error) {
@@ -109,3 +111,9 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]AdministratorContr
 	}
 	return list, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[AdministratorController, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + administratorEndpoint
+	return common.AllPages[AdministratorController](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
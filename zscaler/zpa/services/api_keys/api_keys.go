@@ -3,10 +3,12 @@ package api_keys
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -59,7 +61,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, keyName string) (*
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no api key named '%s' was found", keyName)
+	return nil, resp, errorx.NotFoundf("no api key named '%s' was found", keyName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, apiKey APIKeys) (*APIKeys, *http.Response, error) {
@@ -100,3 +102,9 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]APIKeys, *http.Res
 	}
 	return list, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[APIKeys, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + apiKeysEndpoint
+	return common.AllPages[APIKeys](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
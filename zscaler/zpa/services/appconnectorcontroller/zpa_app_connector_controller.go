@@ -3,10 +3,12 @@ package appconnectorcontroller
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -124,7 +126,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, appConnectorName s
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no app connector named '%s' was found", appConnectorName)
+	return nil, resp, errorx.NotFoundf("no app connector named '%s' was found", appConnectorName)
 }
 
 func GetAll(ctx context.Context, service *zscaler.Service) ([]AppConnector, *http.Response, error) {
@@ -136,6 +138,12 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]AppConnector, *htt
 	return list, resp, nil
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[AppConnector, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + appConnectorEndpoint
+	return common.AllPages[AppConnector](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
+
 // Update Updates the App Connector details for the specified ID.
 func Update(ctx context.Context, service *zscaler.Service, appConnectorID string, appConnector AppConnector) (*AppConnector, *http.Response, error) {
 	path := fmt.Sprintf("%v/%v", mgmtConfig+service.Client.GetCustomerID()+appConnectorEndpoint, appConnectorID)
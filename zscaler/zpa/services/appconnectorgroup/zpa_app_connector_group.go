@@ -3,10 +3,12 @@ package appconnectorgroup
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/appconnectorcontroller"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
@@ -129,7 +131,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, appConnectorGroupN
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no app connector group named '%s' was found", appConnectorGroupName)
+	return nil, resp, errorx.NotFoundf("no app connector group named '%s' was found", appConnectorGroupName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, appConnectorGroup AppConnectorGroup) (*AppConnectorGroup, *http.Response, error) {
@@ -171,6 +173,12 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]AppConnectorGroup,
 	return list, resp, nil
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[AppConnectorGroup, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + appConnectorGroupEndpoint
+	return common.AllPages[AppConnectorGroup](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
+
 func GetAppconnectorGroupSummary(ctx context.Context, service *zscaler.Service) ([]common.CommonSummary, *http.Response, error) {
 	relativeURL := mgmtConfig + service.Client.GetCustomerID() + appConnectorGroupEndpoint + "/summary"
 	list, resp, err := common.GetAllPagesGenericWithCustomFilters[common.CommonSummary](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()})
@@ -180,6 +188,12 @@ func GetAppconnectorGroupSummary(ctx context.Context, service *zscaler.Service)
 	return list, resp, nil
 }
 
+// AllAppconnectorGroupSummary is like GetAppconnectorGroupSummary, but returns an iterator that fetches one page at a time.
+func AllAppconnectorGroupSummary(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[common.CommonSummary, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + appConnectorGroupEndpoint + "/summary"
+	return common.AllPages[common.CommonSummary](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
+
 func GetAppConnectorGroupSG(ctx context.Context, service *zscaler.Service, appConnectorGroupID string) (*AppConnectorGroup, *http.Response, error) {
 	v := new(AppConnectorGroup)
 	relativeURL := fmt.Sprintf("%s/%s/sg", mgmtConfig+service.Client.GetCustomerID()+appConnectorGroupEndpoint, appConnectorGroupID)
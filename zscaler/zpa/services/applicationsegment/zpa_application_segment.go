@@ -4,12 +4,14 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"iter"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/applicationsegmentbrowseraccess"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/servergroup"
@@ -170,7 +172,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, appName string) (*
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no application segment named '%s' was found", appName)
+	return nil, resp, errorx.NotFoundf("no application segment named '%s' was found", appName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, appSegment ApplicationSegmentResource) (*ApplicationSegmentResource, *http.Response, error) {
@@ -212,6 +214,12 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]ApplicationSegment
 	return list, resp, nil
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[ApplicationSegmentResource, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + appSegmentEndpoint
+	return common.AllPages[ApplicationSegmentResource](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
+
 func GetMultiMatchUnsupportedReferences(ctx context.Context, service *zscaler.Service, domainNames MultiMatchUnsupportedReferencesPayload) ([]MultiMatchUnsupportedReferencesResponse, *http.Response, error) {
 	// Validate that at least one domain name is provided
 	if len(domainNames) == 0 {
@@ -247,6 +255,12 @@ func GetApplicationSummary(ctx context.Context, service *zscaler.Service) ([]com
 	return list, resp, nil
 }
 
+// AllApplicationSummary is like GetApplicationSummary, but returns an iterator that fetches one page at a time.
+func AllApplicationSummary(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[common.CommonSummary, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + appSegmentEndpoint + "/summary"
+	return common.AllPages[common.CommonSummary](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
+
 // ApplicationCountResponse represents the response from GetApplicationCount
 func GetApplicationCount(ctx context.Context, service *zscaler.Service) ([]ApplicationCountResponse, *http.Response, error) {
 	relativeURL := mgmtConfig + service.Client.GetCustomerID() + appSegmentEndpoint + "/configured/count"
@@ -3,10 +3,12 @@ package applicationsegmentbrowseraccess
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/servergroup"
 )
@@ -129,7 +131,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, BaName string) (*B
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no browser access application named '%s' was found", BaName)
+	return nil, resp, errorx.NotFoundf("no browser access application named '%s' was found", BaName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, browserAccess BrowserAccess) (*BrowserAccess, *http.Response, error) {
@@ -190,3 +192,18 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]BrowserAccess, *ht
 	}
 	return result, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[BrowserAccess, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + browserAccessEndpoint
+	return func(yield func(BrowserAccess, error) bool) {
+		for item, err := range common.AllPages[BrowserAccess](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...) {
+			if err == nil && len(item.ClientlessApps) == 0 {
+				continue
+			}
+			if !yield(item, err) {
+				return
+			}
+		}
+	}
+}
@@ -3,6 +3,7 @@ package applicationsegmentbytype
 import (
 	"context"
 	"fmt"
+	"iter"
 	"log"
 	"net/http"
 	"net/url"
@@ -31,6 +32,34 @@ type AppSegmentBaseAppDto struct {
 }
 
 func GetByApplicationType(ctx context.Context, service *zscaler.Service, appName, applicationType string, expandAll bool) ([]AppSegmentBaseAppDto, *http.Response, error) {
+	constructedURL, filter, err := byApplicationType(service, appName, applicationType, expandAll)
+	if err != nil {
+		return nil, nil, err
+	}
+	log.Printf("Constructed URL: %s\n", constructedURL)
+
+	list, resp, err := common.GetAllPagesGenericWithCustomFilters[AppSegmentBaseAppDto](ctx, service.Client, constructedURL, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return list, resp, nil
+}
+
+// AllByApplicationType is like GetByApplicationType, but returns an iterator that fetches one page at a time.
+func AllByApplicationType(ctx context.Context, service *zscaler.Service, appName, applicationType string, expandAll bool, opts ...zscaler.PageOption) iter.Seq2[AppSegmentBaseAppDto, error] {
+	constructedURL, filter, err := byApplicationType(service, appName, applicationType, expandAll)
+	if err != nil {
+		return func(yield func(AppSegmentBaseAppDto, error) bool) {
+			yield(AppSegmentBaseAppDto{}, err)
+		}
+	}
+	return common.AllPages[AppSegmentBaseAppDto](ctx, service.Client, constructedURL, filter, opts...)
+}
+
+// byApplicationType returns the URL and filter that list the segments of
+// applicationType, or an error if the type is not valid.
+func byApplicationType(service *zscaler.Service, appName, applicationType string, expandAll bool) (string, common.Filter, error) {
 	validApplicationTypes := map[string]bool{
 		"BROWSER_ACCESS":       true,
 		"INSPECT":              true,
@@ -38,7 +67,7 @@ func GetByApplicationType(ctx context.Context, service *zscaler.Service, appName
 	}
 
 	if !validApplicationTypes[applicationType] {
-		return nil, nil, fmt.Errorf("invalid applicationType '%s'. Valid types are 'BROWSER_ACCESS', 'INSPECT', 'SECURE_REMOTE_ACCESS'", applicationType)
+		return "", common.Filter{}, fmt.Errorf("invalid applicationType '%s'. Valid types are 'BROWSER_ACCESS', 'INSPECT', 'SECURE_REMOTE_ACCESS'", applicationType)
 	}
 
 	relativeURL := mgmtConfig + service.Client.GetCustomerID() + applicationEndpoint + "/getAppsByType"
@@ -51,9 +80,6 @@ func GetByApplicationType(ctx context.Context, service *zscaler.Service, appName
 		query.Set("search", appName)
 	}
 
-	constructedURL := relativeURL + "?" + query.Encode()
-	log.Printf("Constructed URL: %s\n", constructedURL)
-
 	// Construct the filter
 	filter := common.Filter{
 		MicroTenantID: service.MicroTenantID(),
@@ -62,12 +88,7 @@ func GetByApplicationType(ctx context.Context, service *zscaler.Service, appName
 		filter.Search = appName
 	}
 
-	list, resp, err := common.GetAllPagesGenericWithCustomFilters[AppSegmentBaseAppDto](ctx, service.Client, constructedURL, filter)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	return list, resp, nil
+	return relativeURL + "?" + query.Encode(), filter, nil
 }
 
 func DeleteByApplicationType(ctx context.Context, service *zscaler.Service, applicationID, applicationType string) (*http.Response, error) {
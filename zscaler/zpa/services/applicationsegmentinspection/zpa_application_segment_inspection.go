@@ -3,10 +3,12 @@ package applicationsegmentinspection
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/servergroup"
 )
@@ -153,7 +155,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, appSegmentName str
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no inspection application segment named '%s' was found", appSegmentName)
+	return nil, resp, errorx.NotFoundf("no inspection application segment named '%s' was found", appSegmentName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, appSegmentInspection AppSegmentInspection) (*AppSegmentInspection, *http.Response, error) {
@@ -226,3 +228,18 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]AppSegmentInspecti
 	}
 	return result, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[AppSegmentInspection, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + appSegmentInspectionEndpoint
+	return func(yield func(AppSegmentInspection, error) bool) {
+		for item, err := range common.AllPages[AppSegmentInspection](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...) {
+			if err == nil && len(item.InspectionAppDto) == 0 {
+				continue
+			}
+			if !yield(item, err) {
+				return
+			}
+		}
+	}
+}
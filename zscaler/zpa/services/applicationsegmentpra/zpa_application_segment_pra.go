@@ -3,10 +3,12 @@ package applicationsegmentpra
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/servergroup"
 )
@@ -148,7 +150,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, praName string) (*
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no pra application named '%s' was found", praName)
+	return nil, resp, errorx.NotFoundf("no pra application named '%s' was found", praName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, appSegmentPra AppSegmentPRA) (*AppSegmentPRA, *http.Response, error) {
@@ -270,3 +272,18 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]AppSegmentPRA, *ht
 	}
 	return result, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[AppSegmentPRA, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + appSegmentPraEndpoint
+	return func(yield func(AppSegmentPRA, error) bool) {
+		for item, err := range common.AllPages[AppSegmentPRA](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...) {
+			if err == nil && len(item.PRAApps) == 0 {
+				continue
+			}
+			if !yield(item, err) {
+				return
+			}
+		}
+	}
+}
@@ -3,10 +3,12 @@ package appservercontroller
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -51,7 +53,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, appServerName stri
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no application server named '%s' was found", appServerName)
+	return nil, resp, errorx.NotFoundf("no application server named '%s' was found", appServerName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, server ApplicationServer) (*ApplicationServer, *http.Response, error) {
@@ -90,6 +92,12 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]ApplicationServer,
 	return list, resp, nil
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[ApplicationServer, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + appServerControllerEndpoint
+	return common.AllPages[ApplicationServer](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
+
 func GetServerSummary(ctx context.Context, service *zscaler.Service) ([]common.CommonSummary, *http.Response, error) {
 	relativeURL := mgmtConfig + service.Client.GetCustomerID() + appServerControllerEndpoint + "/summary"
 	list, resp, err := common.GetAllPagesGenericWithCustomFilters[common.CommonSummary](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()})
@@ -98,3 +106,9 @@ func GetServerSummary(ctx context.Context, service *zscaler.Service) ([]common.C
 	}
 	return list, resp, nil
 }
+
+// AllServerSummary is like GetServerSummary, but returns an iterator that fetches one page at a time.
+func AllServerSummary(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[common.CommonSummary, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + appServerControllerEndpoint + "/summary"
+	return common.AllPages[common.CommonSummary](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
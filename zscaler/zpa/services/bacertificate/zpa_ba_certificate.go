@@ -3,9 +3,11 @@ package bacertificate
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -61,7 +63,7 @@ func GetIssuedByName(ctx context.Context, service *zscaler.Service, CertName str
 			return &baCertificate, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no issued certificate named '%s' was found", CertName)
+	return nil, resp, errorx.NotFoundf("no issued certificate named '%s' was found", CertName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, baCertificate BaCertificate) (*BaCertificate, *http.Response, error) {
@@ -91,3 +93,9 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]BaCertificate, *ht
 	}
 	return list, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[BaCertificate, error] {
+	relativeURL := mgmtConfigV2 + service.Client.GetCustomerID() + baCertificateIssuedEndpoint
+	return common.AllPages[BaCertificate](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
@@ -2,11 +2,12 @@ package branch_connector
 
 import (
 	"context"
-	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -42,6 +43,12 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]BranchConnector, *
 	return list, resp, nil
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[BranchConnector, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + branchConnectorEndpoint
+	return common.AllPages[BranchConnector](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
+
 func GetByName(ctx context.Context, service *zscaler.Service, branchConnectorName string) (*BranchConnector, *http.Response, error) {
 	relativeURL := mgmtConfig + service.Client.GetCustomerID() + branchConnectorEndpoint
 	list, resp, err := common.GetAllPagesGenericWithCustomFilters[BranchConnector](ctx, service.Client, relativeURL, common.Filter{Search: branchConnectorName, MicroTenantID: service.MicroTenantID()})
@@ -53,5 +60,5 @@ func GetByName(ctx context.Context, service *zscaler.Service, branchConnectorNam
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no branch connector group named '%s' was found", branchConnectorName)
+	return nil, resp, errorx.NotFoundf("no branch connector group named '%s' was found", branchConnectorName)
 }
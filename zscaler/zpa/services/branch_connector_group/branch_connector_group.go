@@ -2,6 +2,7 @@ package branch_connector_group
 
 import (
 	"context"
+	"iter"
 	"net/http"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
@@ -21,3 +22,9 @@ func GetBranchConnectorGroupSummary(ctx context.Context, service *zscaler.Servic
 	}
 	return list, resp, nil
 }
+
+// AllBranchConnectorGroupSummary is like GetBranchConnectorGroupSummary, but returns an iterator that fetches one page at a time.
+func AllBranchConnectorGroupSummary(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[common.CommonSummary, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + branchConnectorGroupEndpoint + "/summary"
+	return common.AllPages[common.CommonSummary](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
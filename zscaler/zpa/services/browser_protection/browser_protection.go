@@ -3,10 +3,12 @@ package browser_protection
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -85,6 +87,12 @@ func GetActiveBrowserProtectionProfile(ctx context.Context, service *zscaler.Ser
 	return list, resp, nil
 }
 
+// AllActiveBrowserProtectionProfile is like GetActiveBrowserProtectionProfile, but returns an iterator that fetches one page at a time.
+func AllActiveBrowserProtectionProfile(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[BrowserProtection, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + "/activeBrowserProtectionProfile"
+	return common.AllPages[BrowserProtection](ctx, service.Client, relativeURL, common.Filter{}, opts...)
+}
+
 func GetBrowserProtectionProfile(ctx context.Context, service *zscaler.Service) ([]BrowserProtection, *http.Response, error) {
 	relativeURL := mgmtConfig + service.Client.GetCustomerID() + "/browserProtectionProfile"
 	list, resp, err := common.GetAllPagesGenericWithCustomFilters[BrowserProtection](ctx, service.Client, relativeURL, common.Filter{})
@@ -94,6 +102,12 @@ func GetBrowserProtectionProfile(ctx context.Context, service *zscaler.Service)
 	return list, resp, nil
 }
 
+// AllBrowserProtectionProfile is like GetBrowserProtectionProfile, but returns an iterator that fetches one page at a time.
+func AllBrowserProtectionProfile(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[BrowserProtection, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + "/browserProtectionProfile"
+	return common.AllPages[BrowserProtection](ctx, service.Client, relativeURL, common.Filter{}, opts...)
+}
+
 func GetBrowserProtectionProfileByName(ctx context.Context, service *zscaler.Service, profileName string) (*BrowserProtection, *http.Response, error) {
 	relativeURL := mgmtConfig + service.Client.GetCustomerID() + "/browserProtectionProfile"
 	list, resp, err := common.GetAllPagesGenericWithCustomFilters[BrowserProtection](ctx, service.Client, relativeURL, common.Filter{Search: profileName, MicroTenantID: service.MicroTenantID()})
@@ -105,7 +119,7 @@ func GetBrowserProtectionProfileByName(ctx context.Context, service *zscaler.Ser
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no browser protection profile named '%s' was found", profileName)
+	return nil, resp, errorx.NotFoundf("no browser protection profile named '%s' was found", profileName)
 }
 
 func UpdateBrowserProtectionProfile(ctx context.Context, service *zscaler.Service, profileID string) (*http.Response, error) {
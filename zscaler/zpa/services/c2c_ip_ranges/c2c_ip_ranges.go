@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -60,7 +61,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, portalName string)
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no user portal named '%s' was found", portalName)
+	return nil, resp, errorx.NotFoundf("no user portal named '%s' was found", portalName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, ipRange *IPRanges) (*IPRanges, *http.Response, error) {
@@ -2,11 +2,12 @@ package cloud_connector
 
 import (
 	"context"
-	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -40,6 +41,12 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]CloudConnector, *h
 	return list, resp, nil
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[CloudConnector, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + cloudConnectorEndpoint
+	return common.AllPages[CloudConnector](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
+
 func GetByName(ctx context.Context, service *zscaler.Service, cloudConnectorName string) (*CloudConnector, *http.Response, error) {
 	relativeURL := mgmtConfig + service.Client.GetCustomerID() + cloudConnectorEndpoint
 	list, resp, err := common.GetAllPagesGenericWithCustomFilters[CloudConnector](ctx, service.Client, relativeURL, common.Filter{Search: cloudConnectorName, MicroTenantID: service.MicroTenantID()})
@@ -51,5 +58,5 @@ func GetByName(ctx context.Context, service *zscaler.Service, cloudConnectorName
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no cloud connector group named '%s' was found", cloudConnectorName)
+	return nil, resp, errorx.NotFoundf("no cloud connector group named '%s' was found", cloudConnectorName)
 }
@@ -2,11 +2,12 @@ package cloud_connector_group
 
 import (
 	"context"
-	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -71,7 +72,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, cloudConnectorGrou
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no application named '%s' was found", cloudConnectorGroupName)
+	return nil, resp, errorx.NotFoundf("no application named '%s' was found", cloudConnectorGroupName)
 }
 
 func GetAll(ctx context.Context, service *zscaler.Service) ([]CloudConnectorGroup, *http.Response, error) {
@@ -83,6 +84,12 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]CloudConnectorGrou
 	return list, resp, nil
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[CloudConnectorGroup, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + cloudConnectorGroupEndpoint
+	return common.AllPages[CloudConnectorGroup](ctx, service.Client, relativeURL, common.Filter{}, opts...)
+}
+
 func GetCloudConnectorGroupSummary(ctx context.Context, service *zscaler.Service) ([]CloudConnectorGroup, *http.Response, error) {
 	relativeURL := mgmtConfig + service.Client.GetCustomerID() + cloudConnectorGroupEndpoint + "/summary"
 	list, resp, err := common.GetAllPagesGenericWithCustomFilters[CloudConnectorGroup](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()})
@@ -91,3 +98,9 @@ func GetCloudConnectorGroupSummary(ctx context.Context, service *zscaler.Service
 	}
 	return list, resp, nil
 }
+
+// AllCloudConnectorGroupSummary is like GetCloudConnectorGroupSummary, but returns an iterator that fetches one page at a time.
+func AllCloudConnectorGroupSummary(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[CloudConnectorGroup, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + cloudConnectorGroupEndpoint + "/summary"
+	return common.AllPages[CloudConnectorGroup](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
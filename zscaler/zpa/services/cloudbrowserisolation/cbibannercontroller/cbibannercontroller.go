@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 )
 
 const (
@@ -60,7 +61,7 @@ func GetByNameOrID(ctx context.Context, service *zscaler.Service, identifier str
 		}
 	}
 
-	return nil, resp, fmt.Errorf("no isolation banner named or with ID '%s' was found", identifier)
+	return nil, resp, errorx.NotFoundf("no isolation banner named or with ID '%s' was found", identifier)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, cbiBanner *CBIBannerController) (*CBIBannerController, *http.Response, error) {
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 )
 
 const (
@@ -43,7 +44,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, certificateName st
 			return &cert, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no certificate named '%s' was found", certificateName)
+	return nil, resp, errorx.NotFoundf("no certificate named '%s' was found", certificateName)
 }
 
 func GetByNameOrID(ctx context.Context, service *zscaler.Service, identifier string) (*CBICertificate, *http.Response, error) {
@@ -64,7 +65,7 @@ func GetByNameOrID(ctx context.Context, service *zscaler.Service, identifier str
 			return Get(ctx, service, certificate.ID)
 		}
 	}
-	return nil, resp, fmt.Errorf("no isolation certificate named or with ID '%s' was found", identifier)
+	return nil, resp, errorx.NotFoundf("no isolation certificate named or with ID '%s' was found", identifier)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, cbiProfile *CBICertificate) (*CBICertificate, *http.Response, error) {
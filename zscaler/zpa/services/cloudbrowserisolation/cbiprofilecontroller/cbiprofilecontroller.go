@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 )
 
 const (
@@ -131,7 +132,7 @@ func GetByNameOrID(ctx context.Context, service *zscaler.Service, identifier str
 		}
 	}
 
-	return nil, resp, fmt.Errorf("no isolation profile named or with ID '%s' was found", identifier)
+	return nil, resp, errorx.NotFoundf("no isolation profile named or with ID '%s' was found", identifier)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, cbiProfile *IsolationProfile) (*IsolationProfile, *http.Response, error) {
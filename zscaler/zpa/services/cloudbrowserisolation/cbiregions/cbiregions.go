@@ -2,11 +2,11 @@ package cbiregions
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 )
 
 const (
@@ -30,7 +30,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, cbiRegionName stri
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no region named '%s' was found", cbiRegionName)
+	return nil, resp, errorx.NotFoundf("no region named '%s' was found", cbiRegionName)
 }
 
 func GetAll(ctx context.Context, service *zscaler.Service) ([]CBIRegions, *http.Response, error) {
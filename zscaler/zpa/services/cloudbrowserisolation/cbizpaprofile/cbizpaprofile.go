@@ -2,11 +2,11 @@ package cbizpaprofile
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 )
 
 const (
@@ -42,7 +42,7 @@ func Get(ctx context.Context, service *zscaler.Service, profileID string) (*ZPAP
 		}
 	}
 
-	return nil, resp, fmt.Errorf("no isolation profile with ID '%s' was found", profileID)
+	return nil, resp, errorx.NotFoundf("no isolation profile with ID '%s' was found", profileID)
 }
 
 // The current API does not seem to support search by Name
@@ -56,7 +56,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, profileName string
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no zpa profile named '%s' was found", profileName)
+	return nil, resp, errorx.NotFoundf("no zpa profile named '%s' was found", profileName)
 }
 
 func GetAll(ctx context.Context, service *zscaler.Service) ([]ZPAProfiles, *http.Response, error) {
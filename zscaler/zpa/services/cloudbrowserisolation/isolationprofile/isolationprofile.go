@@ -2,11 +2,12 @@ package isolationprofile
 
 import (
 	"context"
-	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -46,7 +47,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, profileName string
 			return &profile, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no isolation profile named '%s' was found", profileName)
+	return nil, resp, errorx.NotFoundf("no isolation profile named '%s' was found", profileName)
 }
 
 func GetAll(ctx context.Context, service *zscaler.Service) ([]IsolationProfile, *http.Response, error) {
@@ -57,3 +58,9 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]IsolationProfile,
 	}
 	return list, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[IsolationProfile, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + isolationProfileEndpoint
+	return common.AllPages[IsolationProfile](ctx, service.Client, relativeURL, common.Filter{}, opts...)
+}
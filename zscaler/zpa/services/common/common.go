@@ -3,14 +3,17 @@ package common
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa"
 )
 
@@ -258,6 +261,38 @@ func getAllPagesGeneric[T any](ctx context.Context, client *zscaler.Client, rela
 	)
 }
 
+// getRemainingPages fetches pages 2 through totalPages, in parallel when the
+// context opts in with zscaler.ContextWithPageConcurrency. It returns the
+// response of the last page fetched.
+func getRemainingPages[T any](ctx context.Context, client *zscaler.Client, relativeURL string, totalPages, pageSize int, filters Filter) ([]T, *http.Response, error) {
+	var result []T
+	var resp *http.Response
+	if n := zscaler.PageConcurrencyFromContext(ctx); n > 1 && totalPages > 2 {
+		var mu sync.Mutex
+		result, err := zscaler.FetchPages(ctx, 2, totalPages, n, func(ctx context.Context, page int) ([]T, error) {
+			_, l, r, err := getAllPagesGenericWithCustomFilters[T](ctx, client, relativeURL, page, pageSize, filters)
+			if r != nil && (page == totalPages || err != nil) {
+				mu.Lock()
+				resp = r
+				mu.Unlock()
+			}
+			return l, err
+		})
+		return result, resp, err
+	}
+
+	var l []T
+	var err error
+	for page := 2; page <= totalPages; page++ {
+		totalPages, l, resp, err = getAllPagesGenericWithCustomFilters[T](ctx, client, relativeURL, page, pageSize, filters)
+		if err != nil {
+			return nil, resp, err
+		}
+		result = append(result, l...)
+	}
+	return result, resp, nil
+}
+
 // GetAllPagesGeneric fetches all resources instead of just one single page
 func GetAllPagesGeneric[T any](ctx context.Context, client *zscaler.Client, relativeURL, searchQuery string) ([]T, *http.Response, error) {
 	// Convert search query to filter format for ZPA endpoints (except SCIM endpoints)
@@ -273,14 +308,14 @@ func GetAllPagesGeneric[T any](ctx context.Context, client *zscaler.Client, rela
 	if err != nil {
 		return nil, resp, err
 	}
-	var l []T
-	for page := 2; page <= totalPages; page++ {
-		totalPages, l, resp, err = getAllPagesGeneric[T](ctx, client, relativeURL, page, DefaultPageSize, Filter{Search: searchQuery})
-		if err != nil {
-			return nil, resp, err
-		}
-		result = append(result, l...)
+	rest, lastResp, err := getRemainingPages[T](ctx, client, relativeURL, totalPages, DefaultPageSize, Filter{Search: searchQuery})
+	if lastResp != nil {
+		resp = lastResp
+	}
+	if err != nil {
+		return nil, resp, err
 	}
+	result = append(result, rest...)
 
 	result, err = zscaler.ApplyJMESPathFromContext(ctx, result)
 	if err != nil {
@@ -290,6 +325,92 @@ func GetAllPagesGeneric[T any](ctx context.Context, client *zscaler.Client, rela
 	return result, resp, nil
 }
 
+// AllPages returns an iterator over every resource of a paginated ZPA
+// endpoint matching filters, fetching one page at a time instead of loading the
+// whole list like GetAllPagesGenericWithCustomFilters. Page tokens are page
+// numbers.
+func AllPages[T any](ctx context.Context, client *zscaler.Client, relativeURL string, filters Filter, opts ...zscaler.PageOption) iter.Seq2[T, error] {
+	pageSize := zscaler.NewPageOptions(opts...).PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	prepared := false
+	fetch := func(ctx context.Context, token zscaler.PageToken) (zscaler.Page[T], error) {
+		if !prepared {
+			var err error
+			if filters, err = resolveFilters(ctx, client, relativeURL, filters); err != nil {
+				return zscaler.Page[T]{}, err
+			}
+			prepared = true
+		}
+		page, err := token.Int(1)
+		if err != nil {
+			return zscaler.Page[T]{}, err
+		}
+		totalPages, list, _, err := getAllPagesGenericWithCustomFilters[T](ctx, client, relativeURL, page, pageSize, filters)
+		if err != nil {
+			return zscaler.Page[T]{}, err
+		}
+		result := zscaler.Page[T]{Items: list}
+		if page < totalPages {
+			result.Next = zscaler.PageNumberToken(page + 1)
+		}
+		return result, nil
+	}
+	return zscaler.Paginate(ctx, fetch, opts...)
+}
+
+// resolveFilters looks up a microtenant given by name and converts the search
+// term to the format the endpoint expects.
+func resolveFilters(ctx context.Context, client *zscaler.Client, relativeURL string, filters Filter) (Filter, error) {
+	if (filters.MicroTenantID == nil || *filters.MicroTenantID == "") && filters.MicroTenantName != nil && *filters.MicroTenantName != "" {
+		mt, _, err := getMicroTenantByName(ctx, client, *filters.MicroTenantName)
+		if err != nil {
+			return filters, err
+		}
+		filters.MicroTenantID = &mt.ID
+	}
+	if filters.Search != "" {
+		if isZPAEndpoint(relativeURL) && !isSCIMEndpoint(relativeURL) {
+			filters.Search = convertZPASearchToFilter(filters.Search)
+		} else {
+			filters.Search = sanitizeSearchQuery(filters.Search)
+		}
+	}
+	return filters, nil
+}
+
+// AllScimPages returns an iterator over every resource of a paginated ZPA SCIM
+// endpoint, fetching itemsPerPage resources (at most 100) at a time. Page
+// tokens are SCIM start indexes.
+func AllScimPages[T any](ctx context.Context, client *zpa.ScimZpaClient, baseURL string, itemsPerPage int, opts ...zscaler.PageOption) iter.Seq2[T, error] {
+	if itemsPerPage <= 0 {
+		itemsPerPage = 10
+	} else if itemsPerPage > 100 {
+		itemsPerPage = 100
+	}
+	fetch := func(ctx context.Context, token zscaler.PageToken) (zscaler.Page[T], error) {
+		startIndex, err := token.Int(1)
+		if err != nil {
+			return zscaler.Page[T]{}, err
+		}
+		var paginatedResponse struct {
+			Resources    []T `json:"Resources"`
+			TotalResults int `json:"totalResults"`
+		}
+		paginatedURL := fmt.Sprintf("%s?startIndex=%d&count=%d", baseURL, startIndex, itemsPerPage)
+		if _, err := client.DoRequest(ctx, http.MethodGet, paginatedURL, nil, &paginatedResponse); err != nil {
+			return zscaler.Page[T]{}, fmt.Errorf("error fetching paginated data: %w", err)
+		}
+		result := zscaler.Page[T]{Items: paginatedResponse.Resources, Total: paginatedResponse.TotalResults}
+		if startIndex+itemsPerPage <= paginatedResponse.TotalResults && len(paginatedResponse.Resources) > 0 {
+			result.Next = zscaler.PageNumberToken(startIndex + itemsPerPage)
+		}
+		return result, nil
+	}
+	return zscaler.Paginate(ctx, fetch, opts...)
+}
+
 type microTenantSample struct {
 	ID   string `json:"id,omitempty"`
 	Name string `json:"name,omitempty"`
@@ -306,7 +427,7 @@ func getMicroTenantByName(ctx context.Context, client *zscaler.Client, microTena
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no microtenant named '%s' was found", microTenantName)
+	return nil, resp, errorx.NotFoundf("no microtenant named '%s' was found", microTenantName)
 }
 
 // GetAllPagesGenericWithCustomFilters fetches all resources instead of just one single page
@@ -381,14 +502,14 @@ func GetAllPagesGenericWithCustomFilters[T any](ctx context.Context, client *zsc
 		return nil, resp, err
 	}
 
-	var l []T
-	for page := 2; page <= totalPages; page++ {
-		totalPages, l, resp, err = getAllPagesGenericWithCustomFilters[T](ctx, client, relativeURL, page, DefaultPageSize, filters)
-		if err != nil {
-			return nil, resp, err
-		}
-		result = append(result, l...)
+	rest, lastResp, err := getRemainingPages[T](ctx, client, relativeURL, totalPages, DefaultPageSize, filters)
+	if lastResp != nil {
+		resp = lastResp
 	}
+	if err != nil {
+		return nil, resp, err
+	}
+	result = append(result, rest...)
 
 	result, err = zscaler.ApplyJMESPathFromContext(ctx, result)
 	if err != nil {
@@ -613,3 +734,35 @@ func GetAllPagesGenericWithPostSearch[T any](ctx context.Context, client *zscale
 
 	return result, lastResp, nil
 }
+
+// AllPostSearchPages returns an iterator over every resource of a ZPA endpoint
+// that paginates through a POST search body, fetching one page at a time
+// instead of loading the whole list like GetAllPagesGenericWithPostSearch.
+// Page tokens are page numbers.
+func AllPostSearchPages[T any](ctx context.Context, client *zscaler.Client, relativeURL string, searchRequest SearchRequest, filter Filter, opts ...zscaler.PageOption) iter.Seq2[T, error] {
+	pageSize := zscaler.NewPageOptions(opts...).PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	fetch := func(ctx context.Context, token zscaler.PageToken) (zscaler.Page[T], error) {
+		page, err := token.Int(1)
+		if err != nil {
+			return zscaler.Page[T]{}, err
+		}
+		req := searchRequest
+		req.PageBy = &SearchPageBy{Page: page, PageSize: pageSize}
+		var paged struct {
+			TotalPages interface{} `json:"totalPages"`
+			List       []T         `json:"list"`
+		}
+		if _, err := client.NewRequestDo(ctx, "POST", relativeURL, filter, req, &paged); err != nil {
+			return zscaler.Page[T]{}, err
+		}
+		result := zscaler.Page[T]{Items: paged.List}
+		if totalPages, _ := strconv.Atoi(fmt.Sprintf("%v", paged.TotalPages)); page < totalPages {
+			result.Next = zscaler.PageNumberToken(page + 1)
+		}
+		return result, nil
+	}
+	return zscaler.Paginate(ctx, fetch, opts...)
+}
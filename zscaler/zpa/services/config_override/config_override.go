@@ -3,6 +3,7 @@ package config_override
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
@@ -64,3 +65,9 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]ConfigOverrides, *
 	}
 	return list, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[ConfigOverrides, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + configOverridesEndpoint
+	return common.AllPages[ConfigOverrides](ctx, service.Client, relativeURL, common.Filter{}, opts...)
+}
@@ -2,6 +2,7 @@ package customer_dr_tool
 
 import (
 	"context"
+	"iter"
 	"net/http"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
@@ -33,3 +34,9 @@ func GetCustomerDRTool(ctx context.Context, service *zscaler.Service) ([]Custome
 	}
 	return list, resp, nil
 }
+
+// AllCustomerDRTool is like GetCustomerDRTool, but returns an iterator that fetches one page at a time.
+func AllCustomerDRTool(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[CustomerDrTool, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + customerDRToolEndpoint
+	return common.AllPages[CustomerDrTool](ctx, service.Client, relativeURL, common.Filter{}, opts...)
+}
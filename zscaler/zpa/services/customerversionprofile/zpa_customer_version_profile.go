@@ -3,10 +3,12 @@ package customerversionprofile
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -73,7 +75,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, versionProfileName
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no version profile named '%s' was found", versionProfileName)
+	return nil, resp, errorx.NotFoundf("no version profile named '%s' was found", versionProfileName)
 }
 
 func GetAll(ctx context.Context, service *zscaler.Service) ([]CustomerVersionProfile, *http.Response, error) {
@@ -85,6 +87,12 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]CustomerVersionPro
 	return list, resp, nil
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[CustomerVersionProfile, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + customerVersionProfileEndpoint
+	return common.AllPages[CustomerVersionProfile](ctx, service.Client, relativeURL, common.Filter{}, opts...)
+}
+
 func Update(ctx context.Context, service *zscaler.Service, profileID string, versionProfile *CustomerVersionProfile) (*http.Response, error) {
 	path := fmt.Sprintf("%v/%v", mgmtConfig+service.Client.GetCustomerID()+customerVersionProfileEndpoint, profileID)
 	resp, err := service.Client.NewRequestDo(ctx, "PUT", path, common.Filter{}, versionProfile, nil)
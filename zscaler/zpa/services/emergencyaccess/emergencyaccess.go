@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -55,7 +56,7 @@ func GetByEmailID(ctx context.Context, service *zscaler.Service, emailID string)
 			return &emgAccess, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no emergency access record found with email ID '%s'", emailID)
+	return nil, resp, errorx.NotFoundf("no emergency access record found with email ID '%s'", emailID)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, emergencyAccess *EmergencyAccess) (*EmergencyAccess, *http.Response, error) {
@@ -3,10 +3,12 @@ package enrollmentcert
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -82,7 +84,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, certName string) (
 			return &cert, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no signing certificate named '%s' was found", certName)
+	return nil, resp, errorx.NotFoundf("no signing certificate named '%s' was found", certName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, cert *EnrollmentCert) (*EnrollmentCert, *http.Response, error) {
@@ -121,6 +123,12 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]EnrollmentCert, *h
 	return list, resp, nil
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[EnrollmentCert, error] {
+	relativeURL := mgmtConfigV2 + service.Client.GetCustomerID() + enrollmentCertEndpoint
+	return common.AllPages[EnrollmentCert](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
+
 func GenerateCSR(ctx context.Context, service *zscaler.Service, cert *GenerateEnrollmentCSR) (*GenerateEnrollmentCSR, *http.Response, error) {
 	v := new(GenerateEnrollmentCSR)
 	resp, err := service.Client.NewRequestDo(ctx, "POST", mgmtConfigV1+service.Client.GetCustomerID()+enrollmentCertEndpoint+"/csr/generate", nil, cert, v)
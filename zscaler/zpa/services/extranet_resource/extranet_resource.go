@@ -2,11 +2,12 @@ package extranet_resource
 
 import (
 	"context"
-	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -24,6 +25,12 @@ func GetExtranetResourcePartner(ctx context.Context, service *zscaler.Service) (
 	return list, resp, nil
 }
 
+// AllExtranetResourcePartner is like GetExtranetResourcePartner, but returns an iterator that fetches one page at a time.
+func AllExtranetResourcePartner(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[common.CommonSummary, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + extranetResourceEndpoint
+	return common.AllPages[common.CommonSummary](ctx, service.Client, relativeURL, common.Filter{}, opts...)
+}
+
 func GetExtranetResourcePartnerByName(ctx context.Context, service *zscaler.Service, extranetName string) (*common.CommonSummary, *http.Response, error) {
 	relativeURL := mgmtConfig + service.Client.GetCustomerID() + extranetResourceEndpoint
 	list, resp, err := common.GetAllPagesGenericWithCustomFilters[common.CommonSummary](ctx, service.Client, relativeURL, common.Filter{})
@@ -35,5 +42,5 @@ func GetExtranetResourcePartnerByName(ctx context.Context, service *zscaler.Serv
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no extranet resource named '%s' was found", extranetName)
+	return nil, resp, errorx.NotFoundf("no extranet resource named '%s' was found", extranetName)
 }
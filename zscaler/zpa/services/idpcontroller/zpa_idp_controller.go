@@ -3,9 +3,11 @@ package idpcontroller
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -85,7 +87,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, idpName string) (*
 			return &idpController, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no Idp-Controller named '%s' was found", idpName)
+	return nil, resp, errorx.NotFoundf("no Idp-Controller named '%s' was found", idpName)
 }
 
 func GetAll(ctx context.Context, service *zscaler.Service) ([]IdpController, *http.Response, error) {
@@ -96,3 +98,9 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]IdpController, *ht
 	}
 	return list, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[IdpController, error] {
+	relativeURL := fmt.Sprintf("%s%s%s", mgmtConfigV2, service.Client.GetCustomerID(), idpControllerEndpoint)
+	return common.AllPages[IdpController](ctx, service.Client, relativeURL, common.Filter{}, opts...)
+}
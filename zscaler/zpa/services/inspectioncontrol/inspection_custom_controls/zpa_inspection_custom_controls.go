@@ -4,10 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -82,7 +84,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, controlName string
 			return &control, resp, err
 		}
 	}
-	return nil, resp, fmt.Errorf("no custom inspection control named '%s' was found", controlName)
+	return nil, resp, errorx.NotFoundf("no custom inspection control named '%s' was found", controlName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, customControls InspectionCustomControl) (*InspectionCustomControl, *http.Response, error) {
@@ -123,3 +125,9 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]InspectionCustomCo
 	}
 	return list, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[InspectionCustomControl, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + customControlsEndpoint
+	return common.AllPages[InspectionCustomControl](ctx, service.Client, relativeURL, common.Filter{}, opts...)
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -106,7 +107,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, name, version stri
 		}
 	}
 	service.Client.GetLogger().Printf("[ERROR] no predefined control named '%s' found", name)
-	return nil, resp, fmt.Errorf("no predefined control named '%s' found", name)
+	return nil, resp, errorx.NotFoundf("no predefined control named '%s' found", name)
 }
 
 func GetAllByGroup(ctx context.Context, service *zscaler.Service, version, groupName string) ([]PredefinedControls, error) {
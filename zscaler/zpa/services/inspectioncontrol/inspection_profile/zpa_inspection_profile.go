@@ -3,10 +3,12 @@ package inspection_profile
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -169,7 +171,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, profileName string
 			return &inspection, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no inspection profile named '%s' was found", profileName)
+	return nil, resp, errorx.NotFoundf("no inspection profile named '%s' was found", profileName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, inspectionProfile InspectionProfile) (*InspectionProfile, *http.Response, error) {
@@ -244,3 +246,9 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]InspectionProfile,
 	}
 	return list, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[InspectionProfile, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + inspectionProfileEndpoint
+	return common.AllPages[InspectionProfile](ctx, service.Client, relativeURL, common.Filter{}, opts...)
+}
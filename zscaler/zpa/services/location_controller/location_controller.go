@@ -3,10 +3,12 @@ package location_controller
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -25,6 +27,12 @@ func GetLocationExtranetResource(ctx context.Context, service *zscaler.Service,
 	return list, resp, nil
 }
 
+// AllLocationExtranetResource is like GetLocationExtranetResource, but returns an iterator that fetches one page at a time.
+func AllLocationExtranetResource(ctx context.Context, service *zscaler.Service, zpnErID string, opts ...zscaler.PageOption) iter.Seq2[common.CommonSummary, error] {
+	relativeURL := fmt.Sprintf("%s/%s", mgmtConfig+service.Client.GetCustomerID()+locationEndpoint+"/extranetResource", zpnErID)
+	return common.AllPages[common.CommonSummary](ctx, service.Client, relativeURL, common.Filter{}, opts...)
+}
+
 func GetLocationSummary(ctx context.Context, service *zscaler.Service) ([]common.CommonSummary, *http.Response, error) {
 	relativeURL := mgmtConfig + service.Client.GetCustomerID() + locationEndpoint + "/summary"
 	list, resp, err := common.GetAllPagesGenericWithCustomFilters[common.CommonSummary](ctx, service.Client, relativeURL, common.Filter{})
@@ -34,6 +42,12 @@ func GetLocationSummary(ctx context.Context, service *zscaler.Service) ([]common
 	return list, resp, nil
 }
 
+// AllLocationSummary is like GetLocationSummary, but returns an iterator that fetches one page at a time.
+func AllLocationSummary(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[common.CommonSummary, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + locationEndpoint + "/summary"
+	return common.AllPages[common.CommonSummary](ctx, service.Client, relativeURL, common.Filter{}, opts...)
+}
+
 func GetLocationSummaryByName(ctx context.Context, service *zscaler.Service, locationName string) (*common.CommonSummary, *http.Response, error) {
 	relativeURL := mgmtConfig + service.Client.GetCustomerID() + locationEndpoint + "/summary"
 	list, resp, err := common.GetAllPagesGenericWithCustomFilters[common.CommonSummary](ctx, service.Client, relativeURL, common.Filter{})
@@ -45,7 +59,7 @@ func GetLocationSummaryByName(ctx context.Context, service *zscaler.Service, loc
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no location named '%s' was found", locationName)
+	return nil, resp, errorx.NotFoundf("no location named '%s' was found", locationName)
 }
 
 func GetLocationGroupExtranetResource(ctx context.Context, service *zscaler.Service, zpnErID string) ([]common.LocationGroupDTO, *http.Response, error) {
@@ -56,3 +70,9 @@ func GetLocationGroupExtranetResource(ctx context.Context, service *zscaler.Serv
 	}
 	return list, resp, nil
 }
+
+// AllLocationGroupExtranetResource is like GetLocationGroupExtranetResource, but returns an iterator that fetches one page at a time.
+func AllLocationGroupExtranetResource(ctx context.Context, service *zscaler.Service, zpnErID string, opts ...zscaler.PageOption) iter.Seq2[common.LocationGroupDTO, error] {
+	relativeURL := fmt.Sprintf("%s/%s", mgmtConfig+service.Client.GetCustomerID()+locationGroupEndpoint+"/extranetResource", zpnErID)
+	return common.AllPages[common.LocationGroupDTO](ctx, service.Client, relativeURL, common.Filter{}, opts...)
+}
@@ -3,10 +3,12 @@ package lssconfigcontroller
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -180,7 +182,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, lssName string) (*
 			return &lss, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no lss controller named '%s' was found", lssName)
+	return nil, resp, errorx.NotFoundf("no lss controller named '%s' was found", lssName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, lssConfig *LSSResource) (*LSSResource, *http.Response, error) {
@@ -218,3 +220,9 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]LSSResource, *http
 	}
 	return list, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[LSSResource, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + lssConfigEndpoint
+	return common.AllPages[LSSResource](ctx, service.Client, relativeURL, common.Filter{}, opts...)
+}
@@ -3,10 +3,12 @@ package machinegroup
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -66,7 +68,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, machineGroupName s
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no machine group named '%s' was found", machineGroupName)
+	return nil, resp, errorx.NotFoundf("no machine group named '%s' was found", machineGroupName)
 }
 
 func GetAll(ctx context.Context, service *zscaler.Service) ([]MachineGroup, *http.Response, error) {
@@ -78,6 +80,12 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]MachineGroup, *htt
 	return list, resp, nil
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[MachineGroup, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + machineGroupEndpoint
+	return common.AllPages[MachineGroup](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
+
 func GetMachineGroupSummary(ctx context.Context, service *zscaler.Service) ([]MachineGroup, *http.Response, error) {
 	relativeURL := mgmtConfig + service.Client.GetCustomerID() + machineGroupEndpoint + "/summary"
 	list, resp, err := common.GetAllPagesGenericWithCustomFilters[MachineGroup](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()})
@@ -86,3 +94,9 @@ func GetMachineGroupSummary(ctx context.Context, service *zscaler.Service) ([]Ma
 	}
 	return list, resp, nil
 }
+
+// AllMachineGroupSummary is like GetMachineGroupSummary, but returns an iterator that fetches one page at a time.
+func AllMachineGroupSummary(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[MachineGroup, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + machineGroupEndpoint + "/summary"
+	return common.AllPages[MachineGroup](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
@@ -2,11 +2,12 @@ package managed_browser
 
 import (
 	"context"
-	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -47,6 +48,12 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]ManagedBrowserProf
 	return list, resp, nil
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[ManagedBrowserProfile, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + managedBrowserEndpoint
+	return common.AllPages[ManagedBrowserProfile](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
+
 func GetByName(ctx context.Context, service *zscaler.Service, managedBrowserName string) (*ManagedBrowserProfile, *http.Response, error) {
 	relativeURL := mgmtConfig + service.Client.GetCustomerID() + managedBrowserEndpoint
 	list, resp, err := common.GetAllPagesGenericWithCustomFilters[ManagedBrowserProfile](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()})
@@ -58,5 +65,5 @@ func GetByName(ctx context.Context, service *zscaler.Service, managedBrowserName
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no managed browser profile named '%s' was found", managedBrowserName)
+	return nil, resp, errorx.NotFoundf("no managed browser profile named '%s' was found", managedBrowserName)
 }
@@ -3,10 +3,12 @@ package microtenants
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -115,7 +117,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, microTenantName st
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no microtenant named '%s' was found", microTenantName)
+	return nil, resp, errorx.NotFoundf("no microtenant named '%s' was found", microTenantName)
 }
 
 func GetMicrotenantByName(ctx context.Context, service *zscaler.Service, microtenantName string) (*MicroTenant, *http.Response, error) {
@@ -146,7 +148,7 @@ func GetMicrotenantByName(ctx context.Context, service *zscaler.Service, microte
 			return &ns, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no microtenant named '%s' was found", microtenantName)
+	return nil, resp, errorx.NotFoundf("no microtenant named '%s' was found", microtenantName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, microTenant MicroTenant) (*MicroTenant, *http.Response, error) {
@@ -187,3 +189,9 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]MicroTenant, *http
 	}
 	return list, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[MicroTenant, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + microtenantsEndpoint
+	return common.AllPages[MicroTenant](ctx, service.Client, relativeURL, common.Filter{}, opts...)
+}
@@ -2,11 +2,12 @@ package np_client
 
 import (
 	"context"
-	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -40,6 +41,13 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]NPClient, *http.Re
 	return list, resp, nil
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[NPClient, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + vpnConnectedUsersEndpoint
+	filter := common.Filter{}
+	return common.AllPages[NPClient](ctx, service.Client, relativeURL, filter, opts...)
+}
+
 func GetByName(ctx context.Context, service *zscaler.Service, userName string) (*NPClient, *http.Response, error) {
 	relativeURL := mgmtConfig + service.Client.GetCustomerID() + vpnConnectedUsersEndpoint
 
@@ -54,5 +62,5 @@ func GetByName(ctx context.Context, service *zscaler.Service, userName string) (
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no username named '%s' was found", userName)
+	return nil, resp, errorx.NotFoundf("no username named '%s' was found", userName)
 }
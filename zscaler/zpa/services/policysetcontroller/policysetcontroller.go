@@ -4,13 +4,16 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"iter"
 	"log"
 	"net/http"
 	"sort"
 	"strings"
 	"sync"
 
+	"github.com/zscaler/zscaler-sdk-go/v3/logger"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/appconnectorgroup"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/applicationsegment"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
@@ -237,7 +240,7 @@ func GetByNameAndType(ctx context.Context, service *zscaler.Service, policyType,
 			return &p, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no policy rule named :%s found", ruleName)
+	return nil, resp, errorx.NotFoundf("no policy rule named :%s found", ruleName)
 }
 
 func GetByNameAndTypes(ctx context.Context, service *zscaler.Service, policyTypes []string, ruleName string) (p *PolicyRule, resp *http.Response, err error) {
@@ -335,8 +338,9 @@ func BulkReorder(ctx context.Context, service *zscaler.Service, policySetType st
 		if err != nil {
 			log.Printf("Error reading response body: %s\n", err.Error())
 		}
-		log.Printf("Error response from API: %s\n", string(bodyBytes))
-		return resp, fmt.Errorf("API request failed with status code %d: %s", resp.StatusCode, string(bodyBytes))
+		body := logger.GetRedactor().RedactBody(bodyBytes)
+		log.Printf("Error response from API: %s\n", body)
+		return resp, fmt.Errorf("API request failed with status code %d: %s", resp.StatusCode, body)
 	}
 
 	return resp, nil
@@ -350,3 +354,9 @@ func GetAllByType(ctx context.Context, service *zscaler.Service, policyType stri
 	}
 	return list, resp, nil
 }
+
+// AllByType is like GetAllByType, but returns an iterator that fetches one page at a time.
+func AllByType(ctx context.Context, service *zscaler.Service, policyType string, opts ...zscaler.PageOption) iter.Seq2[PolicyRule, error] {
+	relativeURL := fmt.Sprintf(mgmtConfig+service.Client.GetCustomerID()+"/policySet/rules/policyType/%s", policyType)
+	return common.AllPages[PolicyRule](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
@@ -4,13 +4,16 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"iter"
 	"log"
 	"net/http"
 	"sort"
 	"strings"
 	"sync"
 
+	"github.com/zscaler/zscaler-sdk-go/v3/logger"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/appconnectorgroup"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/applicationsegment"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
@@ -311,7 +314,7 @@ func GetByNameAndType(ctx context.Context, service *zscaler.Service, policyType,
 			return &p, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no policy rule named '%s' found", ruleName)
+	return nil, resp, errorx.NotFoundf("no policy rule named '%s' found", ruleName)
 }
 
 func GetByNameAndTypes(ctx context.Context, service *zscaler.Service, policyTypes []string, ruleName string) (*PolicyRuleResource, *http.Response, error) {
@@ -321,7 +324,7 @@ func GetByNameAndTypes(ctx context.Context, service *zscaler.Service, policyType
 			return p, resp, nil
 		}
 	}
-	return nil, nil, fmt.Errorf("no policy rule named '%s' found in any policy type", ruleName)
+	return nil, nil, errorx.NotFoundf("no policy rule named '%s' found in any policy type", ruleName)
 }
 
 // PUT --> /mgmtconfig/v1/admin/customers/{customerId}/policySet/{policySetId}/rule/{ruleId}/reorder/{newOrder}
@@ -412,8 +415,9 @@ func BulkReorder(ctx context.Context, service *zscaler.Service, policySetType st
 		if err != nil {
 			log.Printf("Error reading response body: %s\n", err.Error())
 		}
-		log.Printf("Error response from API: %s\n", string(bodyBytes))
-		return resp, fmt.Errorf("API request failed with status code %d: %s", resp.StatusCode, string(bodyBytes))
+		body := logger.GetRedactor().RedactBody(bodyBytes)
+		log.Printf("Error response from API: %s\n", body)
+		return resp, fmt.Errorf("API request failed with status code %d: %s", resp.StatusCode, body)
 	}
 
 	return resp, nil
@@ -428,6 +432,12 @@ func GetAllByType(ctx context.Context, service *zscaler.Service, policyType stri
 	return list, resp, nil
 }
 
+// AllByType is like GetAllByType, but returns an iterator that fetches one page at a time.
+func AllByType(ctx context.Context, service *zscaler.Service, policyType string, opts ...zscaler.PageOption) iter.Seq2[PolicyRuleResource, error] {
+	relativeURL := fmt.Sprintf(mgmtConfigV1+service.Client.GetCustomerID()+"/policySet/rules/policyType/%s", policyType)
+	return common.AllPages[PolicyRuleResource](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
+
 func GetPolicyCount(ctx context.Context, service *zscaler.Service, policyType string) ([]PolicyRuleResource, *http.Response, error) {
 	relativeURL := fmt.Sprintf(mgmtConfigV1+service.Client.GetCustomerID()+"/policySet/rules/policyType/%s/count", policyType)
 	list, resp, err := common.GetAllPagesGenericWithCustomFilters[PolicyRuleResource](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()})
@@ -437,6 +447,12 @@ func GetPolicyCount(ctx context.Context, service *zscaler.Service, policyType st
 	return list, resp, nil
 }
 
+// AllPolicyCount is like GetPolicyCount, but returns an iterator that fetches one page at a time.
+func AllPolicyCount(ctx context.Context, service *zscaler.Service, policyType string, opts ...zscaler.PageOption) iter.Seq2[PolicyRuleResource, error] {
+	relativeURL := fmt.Sprintf(mgmtConfigV1+service.Client.GetCustomerID()+"/policySet/rules/policyType/%s/count", policyType)
+	return common.AllPages[PolicyRuleResource](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
+
 func GetPolicyByApplication(ctx context.Context, service *zscaler.Service, policyType string, applicationID string) ([]PolicyRuleResource, *http.Response, error) {
 	relativeURL := fmt.Sprintf(mgmtConfigV1+service.Client.GetCustomerID()+"/policySet/rules/policyType/%s/application/%s", policyType, applicationID)
 	list, resp, err := common.GetAllPagesGenericWithCustomFilters[PolicyRuleResource](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()})
@@ -446,6 +462,12 @@ func GetPolicyByApplication(ctx context.Context, service *zscaler.Service, polic
 	return list, resp, nil
 }
 
+// AllPolicyByApplication is like GetPolicyByApplication, but returns an iterator that fetches one page at a time.
+func AllPolicyByApplication(ctx context.Context, service *zscaler.Service, policyType string, applicationID string, opts ...zscaler.PageOption) iter.Seq2[PolicyRuleResource, error] {
+	relativeURL := fmt.Sprintf(mgmtConfigV1+service.Client.GetCustomerID()+"/policySet/rules/policyType/%s/application/%s", policyType, applicationID)
+	return common.AllPages[PolicyRuleResource](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
+
 func GetRiskScoreValues(ctx context.Context, service *zscaler.Service, excludeUnknown *bool) ([]string, *http.Response, error) {
 	relativeURL := mgmtConfigV2 + service.Client.GetCustomerID() + "/riskScoreValues"
 
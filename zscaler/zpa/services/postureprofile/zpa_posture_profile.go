@@ -3,10 +3,12 @@ package postureprofile
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -58,7 +60,7 @@ func GetByPostureUDID(ctx context.Context, service *zscaler.Service, postureUDID
 			return &postureProfile, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no posture profile with postureUDID '%s' was found", postureUDID)
+	return nil, resp, errorx.NotFoundf("no posture profile with postureUDID '%s' was found", postureUDID)
 }
 
 func GetByName(ctx context.Context, service *zscaler.Service, postureName string) (*PostureProfile, *http.Response, error) {
@@ -80,7 +82,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, postureName string
 			return &postureProfile, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no posture profile named '%s' was found", postureName)
+	return nil, resp, errorx.NotFoundf("no posture profile named '%s' was found", postureName)
 }
 
 func GetAll(ctx context.Context, service *zscaler.Service) ([]PostureProfile, *http.Response, error) {
@@ -92,3 +94,9 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]PostureProfile, *h
 
 	return list, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[PostureProfile, error] {
+	relativeURL := mgmtConfigV2 + service.Client.GetCustomerID() + postureProfileEndpoint
+	return common.AllPages[PostureProfile](ctx, service.Client, relativeURL, common.Filter{}, opts...)
+}
@@ -3,10 +3,12 @@ package private_cloud
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -71,7 +73,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, privateCloudName s
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no app connector group named '%s' was found", privateCloudName)
+	return nil, resp, errorx.NotFoundf("no app connector group named '%s' was found", privateCloudName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, privateCloud PrivateCloudController) (*PrivateCloudController, *http.Response, error) {
@@ -112,3 +114,9 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]PrivateCloudContro
 	}
 	return list, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[PrivateCloudController, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + privateCloudEndpoint
+	return common.AllPages[PrivateCloudController](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
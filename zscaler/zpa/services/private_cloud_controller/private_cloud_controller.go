@@ -3,10 +3,12 @@ package private_cloud_controller
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -99,7 +101,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, controllerName str
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no private cloud controller named '%s' was found", controllerName)
+	return nil, resp, errorx.NotFoundf("no private cloud controller named '%s' was found", controllerName)
 }
 
 func GetAll(ctx context.Context, service *zscaler.Service) ([]PrivateCloudController, *http.Response, error) {
@@ -111,6 +113,12 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]PrivateCloudContro
 	return list, resp, nil
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[PrivateCloudController, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + privateCloudControllerEndpoint
+	return common.AllPages[PrivateCloudController](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
+
 // Update Updates the private cloud controller details for the specified ID.
 func Update(ctx context.Context, service *zscaler.Service, controllerID string, pcController PrivateCloudController) (*PrivateCloudController, *http.Response, error) {
 	path := fmt.Sprintf("%v/%v", mgmtConfig+service.Client.GetCustomerID()+privateCloudControllerEndpoint, controllerID)
@@ -3,10 +3,12 @@ package private_cloud_group
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -68,7 +70,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, groupName string)
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no private cloud group named '%s' was found", groupName)
+	return nil, resp, errorx.NotFoundf("no private cloud group named '%s' was found", groupName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, controllerGroup PrivateCloudGroup) (*PrivateCloudGroup, *http.Response, error) {
@@ -110,6 +112,12 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]PrivateCloudGroup,
 	return list, resp, nil
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[PrivateCloudGroup, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + privateCloudControllerGroupEndpoint
+	return common.AllPages[PrivateCloudGroup](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
+
 func GetGroupSummary(ctx context.Context, service *zscaler.Service) ([]PrivateCloudGroup, *http.Response, error) {
 	relativeURL := mgmtConfig + service.Client.GetCustomerID() + privateCloudControllerGroupEndpoint + "/summary"
 	list, resp, err := common.GetAllPagesGenericWithCustomFilters[PrivateCloudGroup](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()})
@@ -118,3 +126,9 @@ func GetGroupSummary(ctx context.Context, service *zscaler.Service) ([]PrivateCl
 	}
 	return list, resp, nil
 }
+
+// AllGroupSummary is like GetGroupSummary, but returns an iterator that fetches one page at a time.
+func AllGroupSummary(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[PrivateCloudGroup, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + privateCloudControllerGroupEndpoint + "/summary"
+	return common.AllPages[PrivateCloudGroup](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
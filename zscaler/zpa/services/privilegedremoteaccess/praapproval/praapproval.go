@@ -3,10 +3,12 @@ package praapproval
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -115,7 +117,7 @@ func GetByEmailID(ctx context.Context, service *zscaler.Service, emailID string)
 			}
 		}
 	}
-	return nil, resp, fmt.Errorf("no privileged approval with emailID '%s' was found", emailID)
+	return nil, resp, errorx.NotFoundf("no privileged approval with emailID '%s' was found", emailID)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, privilegedApproval *PrivilegedApproval) (*PrivilegedApproval, *http.Response, error) {
@@ -162,3 +164,9 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]PrivilegedApproval
 	}
 	return list, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[PrivilegedApproval, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + privilegedApprovalEndpoint
+	return common.AllPages[PrivilegedApproval](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
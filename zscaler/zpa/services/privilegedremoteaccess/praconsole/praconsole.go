@@ -3,10 +3,12 @@ package praconsole
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -93,7 +95,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, consoleName string
 			return &cred, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no pra  console named '%s' was found", consoleName)
+	return nil, resp, errorx.NotFoundf("no pra  console named '%s' was found", consoleName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, praConsole *PRAConsole) (*PRAConsole, *http.Response, error) {
@@ -142,3 +144,9 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]PRAConsole, *http.
 	}
 	return list, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[PRAConsole, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + praConsoleEndpoint
+	return common.AllPages[PRAConsole](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
@@ -3,10 +3,12 @@ package pracredential
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -92,7 +94,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, credentialName str
 			return &cred, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no credential controller named '%s' was found", credentialName)
+	return nil, resp, errorx.NotFoundf("no credential controller named '%s' was found", credentialName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, credential *Credential) (*Credential, *http.Response, error) {
@@ -150,3 +152,9 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]Credential, *http.
 	}
 	return list, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[Credential, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + credentialEndpoint
+	return common.AllPages[Credential](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
@@ -3,10 +3,12 @@ package pracredentialpool
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -71,7 +73,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, credentialName str
 			return &cred, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no credential controller named '%s' was found", credentialName)
+	return nil, resp, errorx.NotFoundf("no credential controller named '%s' was found", credentialName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, credential *CredentialPool) (*CredentialPool, *http.Response, error) {
@@ -122,3 +124,9 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]CredentialPool, *h
 	}
 	return list, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[CredentialPool, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + credentialEndpoint
+	return common.AllPages[CredentialPool](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
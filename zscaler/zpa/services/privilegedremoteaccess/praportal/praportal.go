@@ -3,10 +3,12 @@ package praportal
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -115,7 +117,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, portalName string)
 			return &sra, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no pra portal '%s' was found", portalName)
+	return nil, resp, errorx.NotFoundf("no pra portal '%s' was found", portalName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, sraPortal *PRAPortal) (*PRAPortal, *http.Response, error) {
@@ -153,3 +155,9 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]PRAPortal, *http.R
 	}
 	return list, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[PRAPortal, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + praPortalEndpoint
+	return common.AllPages[PRAPortal](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
@@ -3,10 +3,12 @@ package provisioningkey
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -73,7 +75,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, associationType, n
 			return &provisioningKey, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no Provisioning Key named '%s' was found", name)
+	return nil, resp, errorx.NotFoundf("no Provisioning Key named '%s' was found", name)
 }
 
 // POST --> /mgmtconfig/v1/admin/customers/{customerId}/associationType/{associationType}/provisioningKey
@@ -147,6 +149,22 @@ func GetAllByAssociationType(ctx context.Context, service *zscaler.Service, asso
 	return list, nil
 }
 
+// AllByAssociationType is like GetAllByAssociationType, but returns an
+// iterator that fetches one page at a time.
+func AllByAssociationType(ctx context.Context, service *zscaler.Service, associationType string, opts ...zscaler.PageOption) iter.Seq2[ProvisioningKey, error] {
+	relativeURL := fmt.Sprintf(mgmtConfig+service.Client.GetCustomerID()+"/associationType/%s/provisioningKey", associationType)
+	return func(yield func(ProvisioningKey, error) bool) {
+		for key, err := range common.AllPages[ProvisioningKey](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...) {
+			if err == nil {
+				key.AssociationType = associationType
+			}
+			if !yield(key, err) {
+				return
+			}
+		}
+	}
+}
+
 func GetAllByZComponentID(ctx context.Context, service *zscaler.Service, associationType, zcomponentID string) ([]ProvisioningKey, error) {
 	relativeURL := fmt.Sprintf(mgmtConfig+service.Client.GetCustomerID()+"/associationType/%s/zcomponent/%s/provisioningKey", associationType, zcomponentID)
 
@@ -3,10 +3,12 @@ package role_controller
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -108,7 +110,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, roleName string) (
 			return &role, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no role named '%s' was found", roleName)
+	return nil, resp, errorx.NotFoundf("no role named '%s' was found", roleName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, role *RoleController) (*RoleController, *http.Response, error) {
@@ -147,6 +149,12 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]RoleController, *h
 	return list, resp, nil
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[RoleController, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + rolesEndpoint
+	return common.AllPages[RoleController](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
+
 func GetPermissionGroups(ctx context.Context, service *zscaler.Service) ([]ClassPermissionGroup, *http.Response, error) {
 	var groups []ClassPermissionGroup
 	url := mgmtConfig + service.Client.GetCustomerID() + permissionGroupsEndpoint
@@ -3,9 +3,11 @@ package samlattribute
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -50,7 +52,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, samlAttrName strin
 			return &samlAttribute, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no saml attribute named '%s' was found", samlAttrName)
+	return nil, resp, errorx.NotFoundf("no saml attribute named '%s' was found", samlAttrName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, samlAttribute *SamlAttribute) (*SamlAttribute, *http.Response, error) {
@@ -90,6 +92,12 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]SamlAttribute, *ht
 	return list, resp, nil
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[SamlAttribute, error] {
+	relativeURL := fmt.Sprintf("%s%s%s", mgmtConfigV2, service.Client.GetCustomerID(), samlAttributeEndpoint)
+	return common.AllPages[SamlAttribute](ctx, service.Client, relativeURL, common.Filter{}, opts...)
+}
+
 // GetAllByIdp gets all SAML attributes for a specified IDP ID
 func GetAllByIdp(ctx context.Context, service *zscaler.Service, idpID string) ([]SamlAttribute, *http.Response, error) {
 	relativeURL := fmt.Sprintf("%s%s%s/idp/%s", mgmtConfigV2, service.Client.GetCustomerID(), samlAttributeEndpoint, idpID)
@@ -100,6 +108,12 @@ func GetAllByIdp(ctx context.Context, service *zscaler.Service, idpID string) ([
 	return list, resp, nil
 }
 
+// AllByIdp is like GetAllByIdp, but returns an iterator that fetches one page at a time.
+func AllByIdp(ctx context.Context, service *zscaler.Service, idpID string, opts ...zscaler.PageOption) iter.Seq2[SamlAttribute, error] {
+	relativeURL := fmt.Sprintf("%s%s%s/idp/%s", mgmtConfigV2, service.Client.GetCustomerID(), samlAttributeEndpoint, idpID)
+	return common.AllPages[SamlAttribute](ctx, service.Client, relativeURL, common.Filter{}, opts...)
+}
+
 // GetByIdpAndAttributeID gets a specific SAML attribute by its ID within a specific IDP
 func GetByIdpAndAttributeID(ctx context.Context, service *zscaler.Service, idpID, attributeID string) (*SamlAttribute, *http.Response, error) {
 	list, resp, err := GetAllByIdp(ctx, service, idpID)
@@ -111,5 +125,5 @@ func GetByIdpAndAttributeID(ctx context.Context, service *zscaler.Service, idpID
 			return &samlAttribute, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no saml attribute with ID '%s' was found in IDP '%s'", attributeID, idpID)
+	return nil, resp, errorx.NotFoundf("no saml attribute with ID '%s' was found in IDP '%s'", attributeID, idpID)
 }
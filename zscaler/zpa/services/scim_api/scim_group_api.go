@@ -3,10 +3,12 @@ package scim_api
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -62,7 +64,7 @@ func GetGroupByName(ctx context.Context, service *zscaler.ScimZPAService, groupN
 
 	// If no items were returned, the group was not found
 	if len(list) == 0 {
-		return nil, resp, fmt.Errorf("no SCIM group named '%s' was found", groupName)
+		return nil, resp, errorx.NotFoundf("no SCIM group named '%s' was found", groupName)
 	}
 
 	return &list[0], resp, nil
@@ -120,3 +122,10 @@ func GetAllGroups(ctx context.Context, service *zscaler.ScimZPAService, count ..
 	// Call the pagination function with nil as the searchFunc
 	return common.GetAllPagesScimGenericWithSearch[ScimGroup](ctx, service.Client, relativeURL, itemsPerPage, nil)
 }
+
+// AllGroups is like GetAllGroups, but returns an iterator that fetches one page at a
+// time. WithPageSize sets the count of items per page.
+func AllGroups(ctx context.Context, service *zscaler.ScimZPAService, opts ...zscaler.PageOption) iter.Seq2[ScimGroup, error] {
+	relativeURL := fmt.Sprintf("%s%s", service.Client.ScimConfig.IDPId, groupScimConfigEndpoint)
+	return common.AllScimPages[ScimGroup](ctx, service.Client, relativeURL, zscaler.NewPageOptions(opts...).PageSize, opts...)
+}
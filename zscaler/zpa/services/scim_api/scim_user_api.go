@@ -3,10 +3,12 @@ package scim_api
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -84,7 +86,7 @@ func GetUserByName(ctx context.Context, service *zscaler.ScimZPAService, userNam
 
 	// If no items were returned, the user was not found
 	if len(list) == 0 {
-		return nil, resp, fmt.Errorf("no SCIM user named '%s' was found", userName)
+		return nil, resp, errorx.NotFoundf("no SCIM user named '%s' was found", userName)
 	}
 
 	return &list[0], resp, nil
@@ -142,3 +144,10 @@ func GetAllUsers(ctx context.Context, service *zscaler.ScimZPAService, count ...
 	// Call the pagination function with nil as the searchFunc
 	return common.GetAllPagesScimGenericWithSearch[ScimUser](ctx, service.Client, relativeURL, itemsPerPage, nil)
 }
+
+// AllUsers is like GetAllUsers, but returns an iterator that fetches one page at a
+// time. WithPageSize sets the count of items per page.
+func AllUsers(ctx context.Context, service *zscaler.ScimZPAService, opts ...zscaler.PageOption) iter.Seq2[ScimUser, error] {
+	relativeURL := fmt.Sprintf("%s%s", service.Client.ScimConfig.IDPId, userScimConfigEndpoint)
+	return common.AllScimPages[ScimUser](ctx, service.Client, relativeURL, zscaler.NewPageOptions(opts...).PageSize, opts...)
+}
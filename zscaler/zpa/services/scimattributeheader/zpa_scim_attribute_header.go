@@ -3,10 +3,12 @@ package scimattributeheader
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -55,12 +57,25 @@ func SearchValues(ctx context.Context, service *zscaler.Service, idpId, ScimAttr
 	return l, err
 }
 
+// AllSearchValues is like SearchValues, but returns an iterator that fetches one page at a time.
+func AllSearchValues(ctx context.Context, service *zscaler.Service, idpId, ScimAttrHeaderID, searchQuery string, opts ...zscaler.PageOption) iter.Seq2[string, error] {
+	searchQuery = strings.Split(searchQuery, "@")[0]
+	relativeURL := fmt.Sprintf("%s/%s/scimattribute/idpId/%s/attributeId/%s", userConfig, service.Client.GetCustomerID(), idpId, ScimAttrHeaderID)
+	return common.AllPages[string](ctx, service.Client, relativeURL, common.Filter{Search: searchQuery}, opts...)
+}
+
 func GetValues(ctx context.Context, service *zscaler.Service, idpId, ScimAttrHeaderID string) ([]string, error) {
 	relativeURL := fmt.Sprintf("%s/%s/scimattribute/idpId/%s/attributeId/%s", userConfig, service.Client.GetCustomerID(), idpId, ScimAttrHeaderID)
 	l, _, err := common.GetAllPagesGeneric[string](ctx, service.Client, relativeURL, "")
 	return l, err
 }
 
+// AllValues is like GetValues, but returns an iterator that fetches one page at a time.
+func AllValues(ctx context.Context, service *zscaler.Service, idpId, ScimAttrHeaderID string, opts ...zscaler.PageOption) iter.Seq2[string, error] {
+	relativeURL := fmt.Sprintf("%s/%s/scimattribute/idpId/%s/attributeId/%s", userConfig, service.Client.GetCustomerID(), idpId, ScimAttrHeaderID)
+	return common.AllPages[string](ctx, service.Client, relativeURL, common.Filter{}, opts...)
+}
+
 func GetByName(ctx context.Context, service *zscaler.Service, scimAttributeName, IdpId string) (*ScimAttributeHeader, *http.Response, error) {
 	relativeURL := fmt.Sprintf("%s/%s%s", mgmtConfig+service.Client.GetCustomerID()+idpId, IdpId, scimAttrEndpoint)
 	list, resp, err := common.GetAllPagesGeneric[ScimAttributeHeader](ctx, service.Client, relativeURL, "")
@@ -72,7 +87,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, scimAttributeName,
 			return &scimAttribute, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no scim named '%s' was found", scimAttributeName)
+	return nil, resp, errorx.NotFoundf("no scim named '%s' was found", scimAttributeName)
 }
 
 // mgmtconfig/v1/admin/customers/{customerId}/idp/{idpId}/scimattribute
@@ -84,3 +99,9 @@ func GetAllByIdpId(ctx context.Context, service *zscaler.Service, IdpId string)
 	}
 	return list, resp, nil
 }
+
+// AllByIdpId is like GetAllByIdpId, but returns an iterator that fetches one page at a time.
+func AllByIdpId(ctx context.Context, service *zscaler.Service, IdpId string, opts ...zscaler.PageOption) iter.Seq2[ScimAttributeHeader, error] {
+	relativeURL := fmt.Sprintf("%s/%s%s", mgmtConfig+service.Client.GetCustomerID()+idpId, IdpId, scimAttrEndpoint)
+	return common.AllPages[ScimAttributeHeader](ctx, service.Client, relativeURL, common.Filter{}, opts...)
+}
@@ -3,10 +3,12 @@ package scimgroup
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -58,7 +60,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, scimName, idpId st
 		}
 	}
 
-	return nil, resp, fmt.Errorf("no SCIM group named '%s' was found", scimName)
+	return nil, resp, errorx.NotFoundf("no SCIM group named '%s' was found", scimName)
 }
 
 func GetAllByIdpId(ctx context.Context, service *zscaler.Service, idpId string) ([]ScimGroup, *http.Response, error) {
@@ -72,3 +74,13 @@ func GetAllByIdpId(ctx context.Context, service *zscaler.Service, idpId string)
 	}
 	return list, resp, nil
 }
+
+// AllByIdpId iterates over the SCIM groups of an IdP one page at a time, so
+// IdPs with very many groups can be processed without loading them all.
+func AllByIdpId(ctx context.Context, service *zscaler.Service, idpId string, opts ...zscaler.PageOption) iter.Seq2[ScimGroup, error] {
+	relativeURL := fmt.Sprintf("%s/%s", userConfig+service.Client.GetCustomerID()+scimGroupEndpoint+idpIdPath, idpId)
+	return common.AllPages[ScimGroup](ctx, service.Client, relativeURL, common.Filter{
+		SortBy:    string(service.SortBy),
+		SortOrder: string(service.SortOrder),
+	}, opts...)
+}
@@ -3,10 +3,12 @@ package segmentgroup
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -99,7 +101,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, segmentName string
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no application named '%s' was found", segmentName)
+	return nil, resp, errorx.NotFoundf("no application named '%s' was found", segmentName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, segmentGroup *SegmentGroup) (*SegmentGroup, *http.Response, error) {
@@ -146,3 +148,9 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]SegmentGroup, *htt
 	}
 	return list, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[SegmentGroup, error] {
+	relativeURL := mgmtConfigV1 + service.Client.GetCustomerID() + segmentGroupEndpoint
+	return common.AllPages[SegmentGroup](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
@@ -3,10 +3,12 @@ package servergroup
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/appconnectorgroup"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/appservercontroller"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
@@ -148,7 +150,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, serverGroupName st
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no server group named '%s' was found", serverGroupName)
+	return nil, resp, errorx.NotFoundf("no server group named '%s' was found", serverGroupName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, serverGroup *ServerGroup) (*ServerGroup, *http.Response, error) {
@@ -186,3 +188,9 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]ServerGroup, *http
 	}
 	return list, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[ServerGroup, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + serverGroupEndpoint
+	return common.AllPages[ServerGroup](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
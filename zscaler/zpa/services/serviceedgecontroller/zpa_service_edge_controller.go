@@ -3,10 +3,12 @@ package serviceedgecontroller
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -136,7 +138,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, serviceEdgeName st
 			return &service, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no service edge named '%s' was found", serviceEdgeName)
+	return nil, resp, errorx.NotFoundf("no service edge named '%s' was found", serviceEdgeName)
 }
 
 func GetAll(ctx context.Context, service *zscaler.Service) ([]ServiceEdgeController, *http.Response, error) {
@@ -148,6 +150,12 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]ServiceEdgeControl
 	return list, resp, nil
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[ServiceEdgeController, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + serviceEdgeControllerEndpoint
+	return common.AllPages[ServiceEdgeController](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
+
 type BulkDeleteRequest struct {
 	IDs []string `json:"ids"`
 }
@@ -3,10 +3,12 @@ package serviceedgegroup
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/serviceedgecontroller"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/trustednetwork"
@@ -82,7 +84,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, serviceEdgeGroupNa
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no service edge group named '%s' was found", serviceEdgeGroupName)
+	return nil, resp, errorx.NotFoundf("no service edge group named '%s' was found", serviceEdgeGroupName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, serviceEdge ServiceEdgeGroup) (*ServiceEdgeGroup, *http.Response, error) {
@@ -123,3 +125,9 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]ServiceEdgeGroup,
 	}
 	return list, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[ServiceEdgeGroup, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + serviceEdgeGroupEndpoint
+	return common.AllPages[ServiceEdgeGroup](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
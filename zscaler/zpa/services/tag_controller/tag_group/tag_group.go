@@ -3,10 +3,12 @@ package tag_group
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -93,7 +95,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, tagGroupName strin
 			return &tagGroup, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no tag group named '%s' was found", tagGroupName)
+	return nil, resp, errorx.NotFoundf("no tag group named '%s' was found", tagGroupName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, tagGroup TagGroup) (*TagGroup, *http.Response, error) {
@@ -147,3 +149,15 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]TagGroup, *http.Re
 	}
 	return list, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[TagGroup, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + tagGroupSearchEndpoint
+	searchRequest := common.SearchRequest{
+		SortBy: &common.SearchSortBy{
+			SortName:  "name",
+			SortOrder: "ASC",
+		},
+	}
+	return common.AllPostSearchPages[TagGroup](ctx, service.Client, relativeURL, searchRequest, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
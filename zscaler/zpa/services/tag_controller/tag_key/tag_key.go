@@ -3,10 +3,12 @@ package tag_key_controller
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -90,7 +92,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, namespaceID, tagKe
 			return &tagKey, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no tag key named '%s' was found", tagKeyName)
+	return nil, resp, errorx.NotFoundf("no tag key named '%s' was found", tagKeyName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, namespaceID string, tagKey TagKey) (*TagKey, *http.Response, error) {
@@ -145,6 +147,18 @@ func GetAll(ctx context.Context, service *zscaler.Service, namespaceID string) (
 	return list, resp, nil
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, namespaceID string, opts ...zscaler.PageOption) iter.Seq2[TagKey, error] {
+	relativeURL := namespacePath(service.Client.GetCustomerID(), namespaceID) + tagKeySearchPath
+	searchRequest := common.SearchRequest{
+		SortBy: &common.SearchSortBy{
+			SortName:  "name",
+			SortOrder: "ASC",
+		},
+	}
+	return common.AllPostSearchPages[TagKey](ctx, service.Client, relativeURL, searchRequest, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
+
 func BulkUpdateStatus(ctx context.Context, service *zscaler.Service, namespaceID string, bulkUpdate BulkUpdateStatusRequest) (*http.Response, error) {
 	path := namespacePath(service.Client.GetCustomerID(), namespaceID) + bulkUpdateStatusPath
 	resp, err := service.Client.NewRequestDo(ctx, "PUT", path, common.Filter{MicroTenantID: service.MicroTenantID()}, bulkUpdate, nil)
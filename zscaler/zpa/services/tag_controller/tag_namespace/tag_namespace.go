@@ -3,10 +3,12 @@ package tag_namespace
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -78,7 +80,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, namespaceName stri
 			return &ns, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no namespace named '%s' was found", namespaceName)
+	return nil, resp, errorx.NotFoundf("no namespace named '%s' was found", namespaceName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, namespace Namespace) (*Namespace, *http.Response, error) {
@@ -123,6 +125,18 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]Namespace, *http.R
 	return list, resp, nil
 }
 
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[Namespace, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + namespaceSearchEndpoint
+	searchRequest := common.SearchRequest{
+		SortBy: &common.SearchSortBy{
+			SortName:  "name",
+			SortOrder: "ASC",
+		},
+	}
+	return common.AllPostSearchPages[Namespace](ctx, service.Client, relativeURL, searchRequest, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
+
 func UpdateStatus(ctx context.Context, service *zscaler.Service, namespaceID string, statusUpdate UpdateStatusRequest) (*http.Response, error) {
 	path := fmt.Sprintf("%v/%v/status", mgmtConfig+service.Client.GetCustomerID()+namespaceEndpoint, namespaceID)
 	resp, err := service.Client.NewRequestDo(ctx, "PUT", path, common.Filter{MicroTenantID: service.MicroTenantID()}, statusUpdate, nil)
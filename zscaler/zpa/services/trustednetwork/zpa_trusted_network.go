@@ -3,10 +3,12 @@ package trustednetwork
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -50,7 +52,7 @@ func GetByNetID(ctx context.Context, service *zscaler.Service, netID string) (*T
 			return &trustedNetwork, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no trusted network with NetworkID '%s' was found", netID)
+	return nil, resp, errorx.NotFoundf("no trusted network with NetworkID '%s' was found", netID)
 }
 
 func GetByName(ctx context.Context, service *zscaler.Service, trustedNetworkName string) (*TrustedNetwork, *http.Response, error) {
@@ -72,7 +74,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, trustedNetworkName
 			return &trustedNetwork, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no trusted network named '%s' was found", trustedNetworkName)
+	return nil, resp, errorx.NotFoundf("no trusted network named '%s' was found", trustedNetworkName)
 }
 
 func GetAll(ctx context.Context, service *zscaler.Service) ([]TrustedNetwork, *http.Response, error) {
@@ -83,3 +85,9 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]TrustedNetwork, *h
 	}
 	return list, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[TrustedNetwork, error] {
+	relativeURL := mgmtConfigV2 + service.Client.GetCustomerID() + trustedNetworkEndpoint
+	return common.AllPages[TrustedNetwork](ctx, service.Client, relativeURL, common.Filter{}, opts...)
+}
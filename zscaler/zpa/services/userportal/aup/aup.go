@@ -3,10 +3,12 @@ package aup
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -51,7 +53,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, userPortalName str
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no application named '%s' was found", userPortalName)
+	return nil, resp, errorx.NotFoundf("no application named '%s' was found", userPortalName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, userPortalAup *UserPortalAup) (*UserPortalAup, *http.Response, error) {
@@ -89,3 +91,9 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]UserPortalAup, *ht
 	}
 	return list, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[UserPortalAup, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + userPortalAUPEndpoint
+	return common.AllPages[UserPortalAup](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
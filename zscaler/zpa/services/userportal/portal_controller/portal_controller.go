@@ -3,10 +3,12 @@ package portal_controller
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -61,7 +63,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, portalName string)
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no user portal named '%s' was found", portalName)
+	return nil, resp, errorx.NotFoundf("no user portal named '%s' was found", portalName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, controllerGroup UserPortalController) (*UserPortalController, *http.Response, error) {
@@ -102,3 +104,9 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]UserPortalControll
 	}
 	return list, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[UserPortalController, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + userPortalEndpoint
+	return common.AllPages[UserPortalController](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
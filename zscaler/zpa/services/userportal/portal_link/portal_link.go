@@ -3,10 +3,12 @@ package portal_link
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/userportal/portal_controller"
 )
@@ -64,7 +66,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, portalName string)
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no user portal link named '%s' was found", portalName)
+	return nil, resp, errorx.NotFoundf("no user portal link named '%s' was found", portalName)
 }
 
 func GetUserPortalLinks(ctx context.Context, service *zscaler.Service, portalID string) (*UserPortalLink, *http.Response, error) {
@@ -150,3 +152,9 @@ func GetAll(ctx context.Context, service *zscaler.Service) ([]UserPortalLink, *h
 	}
 	return list, resp, nil
 }
+
+// All is like GetAll, but returns an iterator that fetches one page at a time.
+func All(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[UserPortalLink, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + userPortalLinkEndpoint
+	return common.AllPages[UserPortalLink](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
@@ -2,11 +2,12 @@ package workload_tag_group
 
 import (
 	"context"
-	"fmt"
+	"iter"
 	"net/http"
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/common"
 )
 
@@ -24,6 +25,12 @@ func GetWorkloadTagGroup(ctx context.Context, service *zscaler.Service) ([]commo
 	return list, resp, nil
 }
 
+// AllWorkloadTagGroup is like GetWorkloadTagGroup, but returns an iterator that fetches one page at a time.
+func AllWorkloadTagGroup(ctx context.Context, service *zscaler.Service, opts ...zscaler.PageOption) iter.Seq2[common.CommonSummary, error] {
+	relativeURL := mgmtConfig + service.Client.GetCustomerID() + workloadTagGroupEndpoint
+	return common.AllPages[common.CommonSummary](ctx, service.Client, relativeURL, common.Filter{MicroTenantID: service.MicroTenantID()}, opts...)
+}
+
 func GetByName(ctx context.Context, service *zscaler.Service, workloadTagGroupName string) (*common.CommonSummary, *http.Response, error) {
 	relativeURL := mgmtConfig + service.Client.GetCustomerID() + workloadTagGroupEndpoint
 	list, resp, err := common.GetAllPagesGeneric[common.CommonSummary](ctx, service.Client, relativeURL, "")
@@ -35,5 +42,5 @@ func GetByName(ctx context.Context, service *zscaler.Service, workloadTagGroupNa
 			return &app, resp, nil
 		}
 	}
-	return nil, resp, fmt.Errorf("no workload tag group named '%s' was found", workloadTagGroupName)
+	return nil, resp, errorx.NotFoundf("no workload tag group named '%s' was found", workloadTagGroupName)
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/hashicorp/go-retryablehttp"
 	"github.com/zscaler/zscaler-sdk-go/v3/cache"
+	"github.com/zscaler/zscaler-sdk-go/v3/internal/telemetry"
 	"github.com/zscaler/zscaler-sdk-go/v3/logger"
 	rl "github.com/zscaler/zscaler-sdk-go/v3/ratelimiter"
 	"github.com/zscaler/zscaler-sdk-go/v3/utils"
@@ -106,6 +107,9 @@ func getHTTPClient(l logger.Logger, rateLimiter *rl.RateLimiter, cfg *Configurat
 			if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
 				retryAfter := getRetryAfter(resp, l)
 				if retryAfter > 0 {
+					if resp.Request != nil {
+						cfg.getTelemetry().RecordRateLimitWait(resp.Request.Context(), "zpa", telemetry.WaitSourceRateLimited, retryAfter)
+					}
 					return retryAfter
 				}
 			}
@@ -164,7 +168,7 @@ func getHTTPClient(l logger.Logger, rateLimiter *rl.RateLimiter, cfg *Configurat
 		l.Printf("[INFO] HTTPS certificate validation is disabled (testing mode).")
 	}
 
-	retryableClient.HTTPClient.Transport = transport
+	retryableClient.HTTPClient.Transport = cfg.getTelemetry().Transport(transport, "zpa")
 	return retryableClient.StandardClient()
 }
 
@@ -260,6 +264,12 @@ func containsInt(codes []int, code int) bool {
 	return false
 }
 
+// redactBody removes secrets from a response body before it is logged. It
+// exists because the logger parameter of Authenticate shadows the package.
+func redactBody(body []byte) string {
+	return logger.GetRedactor().RedactBody(body)
+}
+
 func Authenticate(ctx context.Context, cfg *Configuration, logger logger.Logger) (*AuthToken, error) {
 	cfg.Lock()
 	defer cfg.Unlock()
@@ -289,8 +299,11 @@ func Authenticate(ctx context.Context, cfg *Configuration, logger logger.Logger)
 	data.Set("client_id", clientID)
 	data.Set("client_secret", clientSecret)
 
-	// Use getHTTPClient to handle retries, rate-limiting, etc.
-	httpClient := getHTTPClient(logger, cfg.RateLimiter, cfg)
+	// Use the configured client, or getHTTPClient to handle retries, rate-limiting, etc.
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = getHTTPClient(logger, cfg.RateLimiter, cfg)
+	}
 
 	req, err := http.NewRequestWithContext(ctx, "POST", authURL, strings.NewReader(data.Encode()))
 	if err != nil {
@@ -311,8 +324,9 @@ func Authenticate(ctx context.Context, cfg *Configuration, logger logger.Logger)
 
 	if resp.StatusCode >= 300 {
 		respBody, _ := io.ReadAll(resp.Body)
-		logger.Printf("[ERROR] Authentication failed with status: %d, response: %s", resp.StatusCode, string(respBody))
-		return nil, fmt.Errorf("authentication failed with status: %d, response: %s", resp.StatusCode, string(respBody))
+		body := redactBody(respBody)
+		logger.Printf("[ERROR] Authentication failed with status: %d, response: %s", resp.StatusCode, body)
+		return nil, fmt.Errorf("authentication failed with status: %d, response: %s", resp.StatusCode, body)
 	}
 
 	var token AuthToken
@@ -329,6 +343,20 @@ func Authenticate(ctx context.Context, cfg *Configuration, logger logger.Logger)
 }
 
 func (client *Client) NewRequestDo(method, url string, options, body, v interface{}) (*http.Response, error) {
+	return client.NewRequestDoWithContext(client.Config.Context, method, url, options, body, v)
+}
+
+// NewRequestDoWithContext is NewRequestDo with the context the request is
+// traced under when a TracerProvider is configured.
+func (client *Client) NewRequestDoWithContext(ctx context.Context, method, url string, options, body, v interface{}) (resp *http.Response, err error) {
+	tel := client.Config.getTelemetry()
+	call := telemetry.Request{Product: "zpa", Method: method, Endpoint: url, StartTime: time.Now()}
+	ctx, span := tel.StartRequest(ctx, call)
+	defer func() {
+		call.Attempt = telemetry.Attempts(ctx)
+		tel.EndRequest(ctx, span, call, resp, err)
+	}()
+
 	req, err := client.getRequest(method, url, options, body)
 	if err != nil {
 		return nil, err
@@ -356,6 +384,9 @@ func (client *Client) NewRequestDo(method, url string, options, body, v interfac
 			client.cache.Delete(key) // Delete stale cache entries
 			inCache = false
 		}
+		if req.Method == http.MethodGet {
+			tel.RecordCacheLookup(ctx, call, inCache)
+		}
 
 		if inCache {
 			if v != nil {
@@ -374,7 +405,7 @@ func (client *Client) NewRequestDo(method, url string, options, body, v interfac
 	}
 
 	// Make the actual request if not in cache
-	resp, err := client.newRequestDoCustom(method, url, options, body, v)
+	resp, err = client.newRequestDoCustom(ctx, method, url, options, body, v)
 	if err != nil {
 		return resp, err
 	}
@@ -474,7 +505,7 @@ func (client *Client) getRequest(method, urlPath string, options, body interface
 	return req, nil
 }
 
-func (client *Client) newRequestDoCustom(method, urlStr string, options, body, v interface{}) (*http.Response, error) {
+func (client *Client) newRequestDoCustom(ctx context.Context, method, urlStr string, options, body, v interface{}) (*http.Response, error) {
 	// Authenticate the client using the global Authenticate function
 	_, err := Authenticate(client.Config.Context, client.Config, client.Config.Logger)
 	if err != nil {
@@ -486,6 +517,7 @@ func (client *Client) newRequestDoCustom(method, urlStr string, options, body, v
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
 	reqID := uuid.NewString()
 	start := time.Now()
@@ -18,7 +18,7 @@ func (client *Client) NewRequestDo(ctx context.Context, method, endpoint string,
 		if client.oauth2Credentials.LegacyClient == nil || client.oauth2Credentials.LegacyClient.ZpaClient == nil {
 			return nil, errLegacyClientNotSet
 		}
-		return client.oauth2Credentials.LegacyClient.ZpaClient.NewRequestDo(method, removeOneApiEndpointPrefix(endpoint), options, body, v)
+		return client.oauth2Credentials.LegacyClient.ZpaClient.NewRequestDoWithContext(ctx, method, removeOneApiEndpointPrefix(endpoint), options, body, v)
 	}
 	// Call the custom request handler
 	// Handle query parameters from options and any additional logic
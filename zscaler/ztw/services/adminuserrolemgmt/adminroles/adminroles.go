@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 )
 
 const (
@@ -88,7 +89,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, adminRoleName stri
 			return &adminRole, nil
 		}
 	}
-	return nil, fmt.Errorf("no admin role found with name: %s", adminRoleName)
+	return nil, errorx.NotFoundf("no admin role found with name: %s", adminRoleName)
 }
 
 func GetAPIRole(ctx context.Context, service *zscaler.Service, apiRole string) (*AdminRoles, error) {
@@ -102,7 +103,7 @@ func GetAPIRole(ctx context.Context, service *zscaler.Service, apiRole string) (
 			return &apiRoleEnabled, nil
 		}
 	}
-	return nil, fmt.Errorf("no api role found with name: %s", apiRole)
+	return nil, errorx.NotFoundf("no api role found with name: %s", apiRole)
 }
 
 func GetAuditorRole(ctx context.Context, service *zscaler.Service, auditorRole string) (*AdminRoles, error) {
@@ -116,7 +117,7 @@ func GetAuditorRole(ctx context.Context, service *zscaler.Service, auditorRole s
 			return &auditorRoleEnabled, nil
 		}
 	}
-	return nil, fmt.Errorf("no auditor role found with name: %s", auditorRole)
+	return nil, errorx.NotFoundf("no auditor role found with name: %s", auditorRole)
 }
 
 func GetPartnerRole(ctx context.Context, service *zscaler.Service, partnerRole string) (*AdminRoles, error) {
@@ -130,7 +131,7 @@ func GetPartnerRole(ctx context.Context, service *zscaler.Service, partnerRole s
 			return &partnerRoleEnabled, nil
 		}
 	}
-	return nil, fmt.Errorf("no auditor role found with name: %s", partnerRole)
+	return nil, errorx.NotFoundf("no auditor role found with name: %s", partnerRole)
 }
 
 func GetAllAdminRoles(ctx context.Context, service *zscaler.Service) ([]AdminRoles, error) {
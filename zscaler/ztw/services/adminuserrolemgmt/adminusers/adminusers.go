@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/ztw/services/common"
 )
 
@@ -120,7 +121,7 @@ func GetAdminUsersByLoginName(ctx context.Context, service *zscaler.Service, adm
 			return &adminUser, nil
 		}
 	}
-	return nil, fmt.Errorf("no admin login found with name: %s", adminUsersLoginName)
+	return nil, errorx.NotFoundf("no admin login found with name: %s", adminUsersLoginName)
 }
 
 func GetAdminByUsername(ctx context.Context, service *zscaler.Service, adminUsername string) (*AdminUsers, error) {
@@ -133,7 +134,7 @@ func GetAdminByUsername(ctx context.Context, service *zscaler.Service, adminUser
 			return &adminUser, nil
 		}
 	}
-	return nil, fmt.Errorf("no admin found with username: %s", adminUsername)
+	return nil, errorx.NotFoundf("no admin found with username: %s", adminUsername)
 }
 
 func CreateAdminUser(ctx context.Context, service *zscaler.Service, adminUser AdminUsers) (*AdminUsers, error) {
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/ztw/services/common"
 )
 
@@ -50,7 +51,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, gatewayName string
 			return &gateway, nil
 		}
 	}
-	return nil, fmt.Errorf("no DNS gateway found with name: %s", gatewayName)
+	return nil, errorx.NotFoundf("no DNS gateway found with name: %s", gatewayName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, gateway *DNSGateway) (*DNSGateway, error) {
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/ztw/services/common"
 )
 
@@ -56,7 +57,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, ecGroupName string
 			return &ec, nil
 		}
 	}
-	return nil, fmt.Errorf("no Cloud & Branch Connector Group found with name: %s", ecGroupName)
+	return nil, errorx.NotFoundf("no Cloud & Branch Connector Group found with name: %s", ecGroupName)
 }
 
 func Delete(ctx context.Context, service *zscaler.Service, ecGroupID int) (*http.Response, error) {
@@ -96,5 +97,5 @@ func GetEcGroupLiteByName(ctx context.Context, service *zscaler.Service, ecGroup
 			return &ecgroupLite, nil
 		}
 	}
-	return nil, fmt.Errorf("no Cloud & Branch Connector Group found with name: %s", ecGroupLiteName)
+	return nil, errorx.NotFoundf("no Cloud & Branch Connector Group found with name: %s", ecGroupLiteName)
 }
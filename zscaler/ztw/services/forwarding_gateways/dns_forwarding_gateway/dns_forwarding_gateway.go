@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/ztw/services/common"
 )
 
@@ -74,7 +75,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, dnsGWName string)
 			return &ec, nil
 		}
 	}
-	return nil, fmt.Errorf("no forwarding dns gateway found with name: %s", dnsGWName)
+	return nil, errorx.NotFoundf("no forwarding dns gateway found with name: %s", dnsGWName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, rules *DNSGateway) (*DNSGateway, *http.Response, error) {
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/ztw/services/common"
 )
 
@@ -102,7 +103,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, ecGWName string) (
 			return &ec, nil
 		}
 	}
-	return nil, fmt.Errorf("no forwarding gateway found with name: %s", ecGWName)
+	return nil, errorx.NotFoundf("no forwarding gateway found with name: %s", ecGWName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, rules *ECGateway) (*ECGateway, *http.Response, error) {
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/ztw/services/common"
 )
 
@@ -266,7 +267,7 @@ func GetLocationByName(ctx context.Context, service *zscaler.Service, locationNa
 			return &location, nil
 		}
 	}
-	return nil, fmt.Errorf("no location found with name: %s", locationName)
+	return nil, errorx.NotFoundf("no location found with name: %s", locationName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, locations *Locations) (*Locations, error) {
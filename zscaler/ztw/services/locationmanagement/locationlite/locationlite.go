@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/ztw/services/common"
 )
 
@@ -100,5 +101,5 @@ func GetLocationLiteByName(ctx context.Context, service *zscaler.Service, locati
 			return &locationLite, nil
 		}
 	}
-	return nil, fmt.Errorf("no location found with name: %s", locationLiteName)
+	return nil, errorx.NotFoundf("no location found with name: %s", locationLiteName)
 }
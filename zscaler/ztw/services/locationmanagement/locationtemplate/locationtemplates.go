@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/ztw/services/common"
 )
 
@@ -123,7 +124,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, templateName strin
 			return &location, nil
 		}
 	}
-	return nil, fmt.Errorf("no location template found with name: %s", templateName)
+	return nil, errorx.NotFoundf("no location template found with name: %s", templateName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, locations *LocationTemplate) (*LocationTemplate, error) {
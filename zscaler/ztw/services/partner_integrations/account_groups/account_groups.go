@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/ztw/services/common"
 )
 
@@ -44,7 +45,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, accountGroupsName
 			return &accountGroup, nil
 		}
 	}
-	return nil, fmt.Errorf("no account group found with name: %s", accountGroupsName)
+	return nil, errorx.NotFoundf("no account group found with name: %s", accountGroupsName)
 }
 
 func GetAccountGroupsLite(ctx context.Context, service *zscaler.Service) ([]AccountGroups, error) {
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/ztw/services/common"
 )
 
@@ -50,7 +51,7 @@ func GetSupportedRegionsByName(ctx context.Context, service *zscaler.Service, re
 			return &region, nil
 		}
 	}
-	return nil, fmt.Errorf("no supported region found with name: %s", regionName)
+	return nil, errorx.NotFoundf("no supported region found with name: %s", regionName)
 }
 
 func GetCloudFormationTemplateURL(ctx context.Context, service *zscaler.Service, awsAccountID *int) (string, error) {
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/ztw/services/common"
 )
 
@@ -121,7 +122,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, publicAccountName
 			return &accountName, nil
 		}
 	}
-	return nil, fmt.Errorf("no public account info found with name: %s", publicAccountName)
+	return nil, errorx.NotFoundf("no public account info found with name: %s", publicAccountName)
 }
 
 func GetPublicCloudInfoLite(ctx context.Context, service *zscaler.Service) ([]PublicCloudInfoLite, error) {
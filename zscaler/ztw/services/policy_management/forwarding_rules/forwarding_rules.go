@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/ztw/services/common"
 )
 
@@ -245,7 +246,7 @@ func GetRulesByName(ctx context.Context, service *zscaler.Service, ruleName stri
 			return &rule, nil
 		}
 	}
-	return nil, fmt.Errorf("no rule found with name: %s", ruleName)
+	return nil, errorx.NotFoundf("no rule found with name: %s", ruleName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, rules *ForwardingRules) (*ForwardingRules, error) {
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/ztw/services/common"
 )
 
@@ -61,7 +62,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, ipGroupsName strin
 			return &ipGroup, nil
 		}
 	}
-	return nil, fmt.Errorf("no ip group found with name: %s", ipGroupsName)
+	return nil, errorx.NotFoundf("no ip group found with name: %s", ipGroupsName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, ipGroupID *IPGroups) (*IPGroups, *http.Response, error) {
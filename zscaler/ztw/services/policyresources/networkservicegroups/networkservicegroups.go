@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/ztw/services/common"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/ztw/services/policyresources/networkservices"
 )
@@ -64,7 +65,7 @@ func GetNetworkServiceGroupsByName(ctx context.Context, service *zscaler.Service
 			return &networkServiceGroup, nil
 		}
 	}
-	return nil, fmt.Errorf("no network service groups found with name: %s", serviceGroupsName)
+	return nil, errorx.NotFoundf("no network service groups found with name: %s", serviceGroupsName)
 }
 
 func CreateNetworkServiceGroups(ctx context.Context, service *zscaler.Service, networkServiceGroups *NetworkServiceGroups) (*NetworkServiceGroups, error) {
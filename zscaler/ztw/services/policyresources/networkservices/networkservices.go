@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/ztw/services/common"
 )
 
@@ -77,7 +78,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, networkServiceName
 			return &networkService, nil
 		}
 	}
-	return nil, fmt.Errorf("no network services found with name: %s", networkServiceName)
+	return nil, errorx.NotFoundf("no network services found with name: %s", networkServiceName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, networkService *NetworkServices) (*NetworkServices, error) {
@@ -7,6 +7,7 @@ import (
 	"log"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/ztw/services/common"
 )
 
@@ -66,7 +67,7 @@ func GetPartnerAPIKey(ctx context.Context, service *zscaler.Service, apiKeyValue
 		}
 	}
 
-	return nil, fmt.Errorf("no partner api key found with key value: %s", apiKeyValue)
+	return nil, errorx.NotFoundf("no partner api key found with key value: %s", apiKeyValue)
 }
 
 func GetAll(ctx context.Context, service *zscaler.Service) ([]ProvisioningAPIKeys, error) {
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/ztw/services/common"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/ztw/services/locationmanagement/locationtemplate"
 )
@@ -137,7 +138,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, provUrlName string
 			return &provURL, nil
 		}
 	}
-	return nil, fmt.Errorf("no provisioning URL found with name: %s", provUrlName)
+	return nil, errorx.NotFoundf("no provisioning URL found with name: %s", provUrlName)
 }
 
 func Create(ctx context.Context, service *zscaler.Service, ProvURL *ProvisioningURL) (*ProvisioningURL, *http.Response, error) {
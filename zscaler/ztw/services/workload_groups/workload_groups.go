@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/ztw/services/common"
 )
 
@@ -91,7 +92,7 @@ func GetByName(ctx context.Context, service *zscaler.Service, workloadName strin
 			return &workloadGroup, nil
 		}
 	}
-	return nil, fmt.Errorf("no workload group found with name: %s", workloadName)
+	return nil, errorx.NotFoundf("no workload group found with name: %s", workloadName)
 }
 
 /*
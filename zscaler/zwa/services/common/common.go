@@ -3,6 +3,7 @@ package common
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 
@@ -288,3 +289,66 @@ func ReadPage[T any](ctx context.Context, client *zwa.Client, endpoint string, p
 
 	return pageResults.Items, &pageResults.Cursor, nil
 }
+
+// AllPages returns an iterator over every item of a paginated ZWA endpoint,
+// fetching one page at a time instead of aggregating like ReadAllPages. Page
+// tokens are page numbers; params.PageID, if set, is sent with every page.
+func AllPages[T any](ctx context.Context, client *zwa.Client, method, endpoint string, params *PaginationParams, requestBody interface{}, opts ...zscaler.PageOption) iter.Seq2[T, error] {
+	firstPage, size := 1, pageSize
+	var pageID *string
+	if params != nil {
+		if params.Page != nil {
+			firstPage = *params.Page
+		}
+		if params.PageSize != nil {
+			size = *params.PageSize
+		}
+		pageID = params.PageID
+	}
+	if s := zscaler.NewPageOptions(opts...).PageSize; s > 0 {
+		size = s
+	}
+	fetch := func(ctx context.Context, token zscaler.PageToken) (zscaler.Page[T], error) {
+		page, err := token.Int(firstPage)
+		if err != nil {
+			return zscaler.Page[T]{}, err
+		}
+		queryParams := url.Values{}
+		queryParams.Set("page", fmt.Sprintf("%d", page))
+		queryParams.Set("pageSize", fmt.Sprintf("%d", size))
+		if pageID != nil {
+			queryParams.Set("pageId", *pageID)
+		}
+		baseURL, err := url.Parse(endpoint)
+		if err != nil {
+			return zscaler.Page[T]{}, fmt.Errorf("invalid endpoint URL: %w", err)
+		}
+		baseURL.RawQuery = queryParams.Encode()
+
+		var pageResults struct {
+			Items  []T    `json:"logs"`
+			Cursor Cursor `json:"cursor"`
+		}
+		var body interface{}
+		switch method {
+		case http.MethodGet:
+		case http.MethodPost:
+			body = requestBody
+		default:
+			return zscaler.Page[T]{}, fmt.Errorf("unsupported HTTP method: %s", method)
+		}
+		resp, err := client.NewRequestDo(ctx, method, baseURL.String(), nil, body, &pageResults)
+		if err != nil {
+			return zscaler.Page[T]{}, fmt.Errorf("failed to fetch page %d: %w", page, err)
+		}
+		resp.Body.Close()
+
+		cursor := pageResults.Cursor
+		result := zscaler.Page[T]{Items: pageResults.Items, Total: cursor.TotalElements}
+		if cursor.CurrentPageSize >= size && page < cursor.TotalPages-1 {
+			result.Next = zscaler.PageNumberToken(page + 1)
+		}
+		return result, nil
+	}
+	return zscaler.Paginate(ctx, fetch, opts...)
+}
@@ -3,8 +3,10 @@ package customeraudit
 import (
 	"context"
 	"fmt"
+	"iter"
 	"net/http"
 
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zwa/services"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zwa/services/common"
 )
@@ -45,3 +47,9 @@ func GetCustomerAudit(ctx context.Context, service *services.Service, filters co
 
 	return allResults, cursor, nil
 }
+
+// AllCustomerAudit is like GetCustomerAudit, but returns an iterator that
+// fetches one page at a time.
+func AllCustomerAudit(ctx context.Context, service *services.Service, filters common.CommonDLPIncidentFiltering, paginationParams *common.PaginationParams, opts ...zscaler.PageOption) iter.Seq2[AuditLog, error] {
+	return common.AllPages[AuditLog](ctx, service.Client, http.MethodPost, customerAuditEndpoint, paginationParams, filters, opts...)
+}
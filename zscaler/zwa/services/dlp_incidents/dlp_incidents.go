@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"net/http"
 	"net/url"
 
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zwa/services"
 	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zwa/services/common"
 )
@@ -178,6 +180,13 @@ func FilterIncidentSearch(ctx context.Context, service *services.Service, filter
 	return allResults, cursor, nil
 }
 
+// AllIncidentSearch is like FilterIncidentSearch, but returns an iterator that
+// fetches one page at a time.
+func AllIncidentSearch(ctx context.Context, service *services.Service, filters common.CommonDLPIncidentFiltering, paginationParams *common.PaginationParams, opts ...zscaler.PageOption) iter.Seq2[common.IncidentDetails, error] {
+	path := fmt.Sprintf("%s/search", baseIncidentEndpoint)
+	return common.AllPages[common.IncidentDetails](ctx, service.Client, http.MethodPost, path, paginationParams, filters, opts...)
+}
+
 func AssignIncidentGroups(ctx context.Context, service *services.Service, dlpIncidentID int, groupIDs []int) (*IncidentGroupsResponse, *http.Response, error) {
 	if len(groupIDs) == 0 {
 		return nil, nil, errors.New("incident group IDs are required")
@@ -220,6 +229,18 @@ func GetIncidentTransactions(ctx context.Context, service *services.Service, tra
 	return allResults, cursor, nil
 }
 
+// AllIncidentTransactions is like GetIncidentTransactions, but returns an
+// iterator that fetches one page at a time.
+func AllIncidentTransactions(ctx context.Context, service *services.Service, transactionID string, paginationParams *common.PaginationParams, opts ...zscaler.PageOption) iter.Seq2[common.IncidentDetails, error] {
+	if transactionID == "" {
+		return func(yield func(common.IncidentDetails, error) bool) {
+			yield(common.IncidentDetails{}, errors.New("transaction ID is required"))
+		}
+	}
+	endpoint := fmt.Sprintf("%s/transactions/%s", baseIncidentEndpoint, transactionID)
+	return common.AllPages[common.IncidentDetails](ctx, service.Client, http.MethodGet, endpoint, paginationParams, nil, opts...)
+}
+
 // Gets the DLP incident details based on the incident ID.
 func GetDLPIncident(ctx context.Context, service *services.Service, dlpIncidentID string, fields []string) (*common.IncidentDetails, *http.Response, error) {
 	if dlpIncidentID == "" {
@@ -309,6 +330,18 @@ func GetDLPIncidentTickets(ctx context.Context, service *services.Service, dlpIn
 	return allResults, cursor, nil
 }
 
+// AllDLPIncidentTickets is like GetDLPIncidentTickets, but returns an iterator
+// that fetches one page at a time.
+func AllDLPIncidentTickets(ctx context.Context, service *services.Service, dlpIncidentID string, paginationParams *common.PaginationParams, opts ...zscaler.PageOption) iter.Seq2[Ticket, error] {
+	if dlpIncidentID == "" {
+		return func(yield func(Ticket, error) bool) {
+			yield(Ticket{}, errors.New("valid DLP incident ID is required"))
+		}
+	}
+	path := fmt.Sprintf("%s/tickets/%s", baseIncidentEndpoint, dlpIncidentID)
+	return common.AllPages[Ticket](ctx, service.Client, http.MethodGet, path, paginationParams, nil, opts...)
+}
+
 func GetDLPIncidentTriggers(ctx context.Context, service *services.Service, dlpIncidentID string) (DLPIncidentTriggerData, *http.Response, error) {
 	if dlpIncidentID == "" {
 		return nil, nil, errors.New("valid DLP incident ID is required")
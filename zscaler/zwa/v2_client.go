@@ -209,6 +209,12 @@ type ApiErr struct {
 	Message string `json:"message"`
 }
 
+// redactBody removes secrets from a response body before it is logged. It
+// exists because the logger parameter of Authenticate shadows the package.
+func redactBody(body []byte) string {
+	return logger.GetRedactor().RedactBody(body)
+}
+
 func Authenticate(ctx context.Context, cfg *Configuration, logger logger.Logger) (*AuthToken, error) {
 	cfg.Lock()
 	defer cfg.Unlock()
@@ -264,13 +270,14 @@ func Authenticate(ctx context.Context, cfg *Configuration, logger logger.Logger)
 		return nil, fmt.Errorf("failed to read authentication response: %w", err)
 	}
 
-	// Debug: Log the response body
-	logger.Printf("[DEBUG] Authentication response body: %s", string(respBody))
+	// Debug: Log the response body without the token
+	body := redactBody(respBody)
+	logger.Printf("[DEBUG] Authentication response body: %s", body)
 
 	// Check for valid status codes (200 or 201)
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		logger.Printf("[ERROR] Authentication failed: HTTP %d, response: %s", resp.StatusCode, string(respBody))
-		return nil, fmt.Errorf("authentication failed: HTTP %d, response: %s", resp.StatusCode, string(respBody))
+		logger.Printf("[ERROR] Authentication failed: HTTP %d, response: %s", resp.StatusCode, body)
+		return nil, fmt.Errorf("authentication failed: HTTP %d, response: %s", resp.StatusCode, body)
 	}
 
 	// Parse the response
@@ -475,7 +482,7 @@ func (client *Client) do(req *http.Request, v interface{}, start time.Time, reqI
 	resp.Body = io.NopCloser(bytes.NewBuffer(respBody)) // Reset the response body
 
 	logger.LogResponse(client.Config.Logger, resp, start, reqID)
-	logger.WriteLog(client.Config.Logger, "Response Body: %s", string(respBody)) // Log the response body separately
+	logger.LogResponseBody(client.Config.Logger, respBody) // Log the response body separately
 
 	if err := errorx.CheckErrorInResponse(resp, err); err != nil {
 		return resp, err
@@ -0,0 +1,378 @@
+// Package zscalertest provides an in-memory fake Zscaler tenant for testing
+// code built on the SDK without network access or credentials. The fake keeps
+// state between requests, so tests can create, list, update and delete objects
+// through the regular service functions and observe the results.
+//
+// The fake implements ZIA URL categories, firewall filtering rules, locations,
+// rule labels and activation status, and ZPA segment groups, server groups,
+// application segments and policy rules, including pagination, search, 404s,
+// edit locks, pending activation and, with WithETags, conditional GETs.
+// Requests to any other endpoint fail with 501 Not Implemented.
+//
+//	fake := zscalertest.NewServer()
+//	service, err := fake.NewService()
+//	if err != nil {
+//		return err
+//	}
+//	group, _, err := segmentgroup.Create(ctx, service, &segmentgroup.SegmentGroup{Name: "web"})
+package zscalertest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+)
+
+// DefaultCustomerID is the ZPA customer ID of a Server created without
+// WithCustomerID.
+const DefaultCustomerID = "216196257331281920"
+
+// Resource names a collection of objects held by the fake.
+type Resource string
+
+const (
+	ZIAURLCategories       Resource = "zia/urlCategories"
+	ZIAFirewallRules       Resource = "zia/firewallFilteringRules"
+	ZIALocations           Resource = "zia/locations"
+	ZIARuleLabels          Resource = "zia/ruleLabels"
+	ZPASegmentGroups       Resource = "zpa/segmentGroup"
+	ZPAServerGroups        Resource = "zpa/serverGroup"
+	ZPAApplicationSegments Resource = "zpa/application"
+)
+
+// PolicyRules returns the resource holding the ZPA policy rules of the policy
+// set of policyType, e.g. "ACCESS_POLICY".
+func PolicyRules(policyType string) Resource {
+	return Resource(policyRulesPrefix + policyType)
+}
+
+const policyRulesPrefix = "zpa/policySet/"
+
+// Activation states reported by the fake ZIA status endpoint.
+const (
+	StatusActive  = "ACTIVE"
+	StatusPending = "PENDING"
+)
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithCustomerID sets the ZPA customer ID the fake serves. Requests for other
+// customers fail with 404.
+func WithCustomerID(id string) Option {
+	return func(s *Server) {
+		s.customerID = id
+	}
+}
+
+// WithETags makes the fake send an ETag with every GET response and answer
+// 304 Not Modified to requests whose If-None-Match matches it. Without it the
+// fake, like most Zscaler APIs, sends no validators.
+func WithETags() Option {
+	return func(s *Server) {
+		s.etags = true
+	}
+}
+
+// Server is a fake Zscaler tenant. It implements http.Handler, so it can also
+// be served with httptest.NewServer, and http.RoundTripper, answering requests
+// in memory whatever their host. A Server is safe for concurrent use.
+type Server struct {
+	mu          sync.Mutex
+	customerID  string
+	collections map[Resource]*collection
+	policySets  map[string]string
+	activation  string
+	editLocks   int
+	seq         int64
+	etags       bool
+}
+
+// NewServer returns an empty fake tenant with activation status ACTIVE.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		customerID:  DefaultCustomerID,
+		collections: map[Resource]*collection{},
+		policySets:  map[string]string{},
+		activation:  StatusActive,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// CustomerID returns the ZPA customer ID the fake serves.
+func (s *Server) CustomerID() string {
+	return s.customerID
+}
+
+// Client returns an HTTP client that sends every request to s in memory.
+func (s *Server) Client() *http.Client {
+	return &http.Client{Transport: s}
+}
+
+// NewService returns a OneAPI service whose requests are all answered by s.
+// setters are applied after the fake's defaults, which shorten retry waits to
+// keep simulated edit locks cheap. The service talks to the fake directly,
+// without client side rate limiting. A cassette set with
+// zscaler.WithRecordMode records the fake's traffic.
+func (s *Server) NewService(setters ...zscaler.ConfigSetter) (*zscaler.Service, error) {
+	cfg, err := zscaler.NewConfiguration(append([]zscaler.ConfigSetter{
+		zscaler.WithClientID("zscalertest"),
+		zscaler.WithClientSecret("zscalertest"),
+		zscaler.WithVanityDomain("zscalertest"),
+		zscaler.WithZscalerCloud(""),
+		zscaler.WithZPACustomerID(s.customerID),
+		zscaler.WithCache(false),
+		zscaler.WithRateLimitMinWait(10 * time.Millisecond),
+		zscaler.WithRateLimitMaxWait(100 * time.Millisecond),
+	}, setters...)...)
+	if err != nil {
+		return nil, err
+	}
+	// The fake only speaks OneAPI; ignore ZSCALER_USE_LEGACY_CLIENT and
+	// similar settings picked up from the environment.
+	cfg.UseLegacyClient = false
+	cfg.LegacyClient = nil
+
+	httpClient := s.Client()
+	if cfg.Cassette != nil {
+		httpClient = cfg.Cassette.Client(httpClient)
+	}
+	if cfg.DryRunPlan != nil {
+		httpClient = cfg.DryRunPlan.Client(httpClient)
+	}
+	cfg.HTTPClient = httpClient
+	cfg.ZIAHTTPClient = httpClient
+	cfg.ZTWHTTPClient = httpClient
+	cfg.ZPAHTTPClient = httpClient
+	cfg.ZCCHTTPClient = httpClient
+	cfg.ZDXHTTPClient = httpClient
+	return zscaler.NewOneAPIClient(cfg)
+}
+
+// RoundTrip serves req in memory.
+func (s *Server) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := req.Context().Err(); err != nil {
+		return nil, err
+	}
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	resp := rec.Result()
+	resp.Request = req
+	return resp, nil
+}
+
+var zpaPath = regexp.MustCompile(`^/zpa/mgmtconfig/v[12]/admin/customers/([^/]+)(/.*)?$`)
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.etags && r.Method == http.MethodGet {
+		serveWithETag(w, r, s.serve)
+		return
+	}
+	s.serve(w, r)
+}
+
+// serveWithETag answers r with serve, adding an ETag derived from the body
+// and honouring If-None-Match.
+func serveWithETag(w http.ResponseWriter, r *http.Request, serve http.HandlerFunc) {
+	rec := httptest.NewRecorder()
+	serve(rec, r)
+	sum := sha256.Sum256(rec.Body.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+	for k, v := range rec.Header() {
+		w.Header()[k] = v
+	}
+	if rec.Code == http.StatusOK {
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+	}
+	w.WriteHeader(rec.Code)
+	_, _ = w.Write(rec.Body.Bytes())
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	switch {
+	case strings.HasSuffix(path, "/oauth2/v1/token") && r.Method == http.MethodPost:
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"access_token": "zscalertest-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	case strings.HasPrefix(path, ziaPrefix+"/"):
+		s.serveZIA(w, r, splitPath(strings.TrimPrefix(path, ziaPrefix)))
+	case zpaPath.MatchString(path):
+		m := zpaPath.FindStringSubmatch(path)
+		if m[1] != s.customerID {
+			writeZPAError(w, http.StatusNotFound, "resource.not.found", "customer "+m[1]+" not found")
+			return
+		}
+		s.serveZPA(w, r, splitPath(m[2]))
+	default:
+		notImplemented(w, r)
+	}
+}
+
+// Seed adds items, which may be SDK model structs or maps, to res without
+// marking ZIA activation pending. Items without an ID are assigned one. It
+// returns the IDs of the added items.
+func (s *Server) Seed(res Resource, items ...interface{}) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.collection(res)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		o, err := toObject(item)
+		if err != nil {
+			return nil, fmt.Errorf("zscalertest: seeding %s: %w", res, err)
+		}
+		if policyType, ok := strings.CutPrefix(string(res), policyRulesPrefix); ok {
+			o["policySetId"] = s.policySetID(policyType)
+		}
+		if c.conflict(o, "") != nil {
+			return nil, fmt.Errorf("zscalertest: seeding %s: duplicate name %q", res, stringField(o, c.nameKey))
+		}
+		o = c.insert(o, c.position(o))
+		ids = append(ids, idString(o["id"]))
+	}
+	return ids, nil
+}
+
+// Items returns a copy of the objects currently held in res, in list order.
+func (s *Server) Items(res Resource) []map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, err := s.collection(res)
+	if err != nil {
+		return nil
+	}
+	return cloneObjects(c.items)
+}
+
+// SimulateEditLock makes the next n ZIA write requests, including
+// activation, fail with 409 EDIT_LOCK_NOT_AVAILABLE as they do while another
+// admin session holds the lock. The SDK retries such requests until its
+// retry limit is reached.
+func (s *Server) SimulateEditLock(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.editLocks = n
+}
+
+// ActivationStatus returns the ZIA activation status: StatusPending after any
+// successful ZIA change and StatusActive once the changes are activated.
+func (s *Server) ActivationStatus() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.activation
+}
+
+// collection returns the collection of res, creating it on first use.
+func (s *Server) collection(res Resource) (*collection, error) {
+	if c, ok := s.collections[res]; ok {
+		return c, nil
+	}
+	c := &collection{nameKey: "name", newID: s.zpaID}
+	switch res {
+	case ZIAURLCategories:
+		c.nameKey = "configuredName"
+		c.newID = s.customCategoryID
+	case ZIAFirewallRules:
+		c.orderKey = "order"
+		c.newID = s.ziaID
+	case ZIALocations, ZIARuleLabels:
+		c.newID = s.ziaID
+	case ZPASegmentGroups, ZPAServerGroups, ZPAApplicationSegments:
+	default:
+		if !strings.HasPrefix(string(res), policyRulesPrefix) || string(res) == policyRulesPrefix {
+			return nil, fmt.Errorf("zscalertest: unknown resource %q", res)
+		}
+		c.orderKey = "ruleOrder"
+		c.orderString = true
+	}
+	s.collections[res] = c
+	return c, nil
+}
+
+func (s *Server) ziaID() interface{} {
+	s.seq++
+	return json.Number(strconv.FormatInt(1000+s.seq, 10))
+}
+
+func (s *Server) customCategoryID() interface{} {
+	s.seq++
+	return fmt.Sprintf("CUSTOM_%02d", s.seq)
+}
+
+// zpaID returns a ZPA style ID: a large number sent as a string.
+func (s *Server) zpaID() interface{} {
+	s.seq++
+	return strconv.FormatInt(72058304855000000+s.seq, 10)
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+// page returns the 1-based page of items of the given size.
+func page(items []object, number, size int) []object {
+	start := (number - 1) * size
+	if number < 1 || size < 1 || start >= len(items) {
+		return []object{}
+	}
+	end := start + size
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
+
+func queryInt(r *http.Request, key string, def int) int {
+	n, err := strconv.Atoi(r.URL.Query().Get(key))
+	if err != nil {
+		return def
+	}
+	return n
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func notImplemented(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusNotImplemented, map[string]string{
+		"code":    "NOT_IMPLEMENTED",
+		"message": fmt.Sprintf("zscalertest: %s %s is not implemented", r.Method, r.URL.Path),
+	})
+}
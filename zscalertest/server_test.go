@@ -0,0 +1,533 @@
+package zscalertest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zscaler/zscaler-sdk-go/v3/dryrun"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/errorx"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/activation"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/firewallpolicies/filteringrules"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/location/locationmanagement"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/rule_labels"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zia/services/urlcategories"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/applicationsegment"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/policysetcontroller"
+	"github.com/zscaler/zscaler-sdk-go/v3/zscaler/zpa/services/segmentgroup"
+)
+
+func newService(t *testing.T, fake *Server) *zscaler.Service {
+	t.Helper()
+	service, err := fake.NewService()
+	require.NoError(t, err)
+	return service
+}
+
+func TestZIA_CRUDAndActivation(t *testing.T) {
+	ctx := context.Background()
+	fake := NewServer()
+	service := newService(t, fake)
+
+	label, _, err := rule_labels.Create(ctx, service, &rule_labels.RuleLabels{Name: "prod"})
+	require.NoError(t, err)
+	require.NotZero(t, label.ID)
+	assert.Equal(t, StatusPending, fake.ActivationStatus())
+
+	got, err := rule_labels.GetRuleLabelByName(ctx, service, "PROD")
+	require.NoError(t, err)
+	assert.Equal(t, label.ID, got.ID)
+
+	_, _, err = rule_labels.Create(ctx, service, &rule_labels.RuleLabels{Name: "prod"})
+	assert.ErrorIs(t, err, errorx.ErrConflict)
+
+	label.Description = "production"
+	_, _, err = rule_labels.Update(ctx, service, label.ID, label)
+	require.NoError(t, err)
+	got, err = rule_labels.Get(ctx, service, label.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "production", got.Description)
+
+	status, err := activation.CreateActivation(ctx, service, activation.Activation{Status: "ACTIVE"})
+	require.NoError(t, err)
+	assert.Equal(t, StatusActive, status.Status)
+	assert.Equal(t, StatusActive, fake.ActivationStatus())
+
+	_, err = rule_labels.Delete(ctx, service, label.ID)
+	require.NoError(t, err)
+	_, err = rule_labels.Get(ctx, service, label.ID)
+	assert.ErrorIs(t, err, errorx.ErrNotFound)
+	current, err := activation.GetActivationStatus(ctx, service)
+	require.NoError(t, err)
+	assert.Equal(t, StatusPending, current.Status)
+}
+
+func TestZIA_EditLock(t *testing.T) {
+	ctx := context.Background()
+	fake := NewServer()
+	service := newService(t, fake)
+
+	fake.SimulateEditLock(2)
+	_, _, err := rule_labels.Create(ctx, service, &rule_labels.RuleLabels{Name: "retried"})
+	require.NoError(t, err, "the SDK retries until the lock is released")
+	assert.Len(t, fake.Items(ZIARuleLabels), 1)
+
+	limited, err := fake.NewService(zscaler.WithRateLimitMaxRetries(2))
+	require.NoError(t, err)
+	fake.SimulateEditLock(5)
+	_, _, err = rule_labels.Create(ctx, limited, &rule_labels.RuleLabels{Name: "locked"})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errorx.ErrEditLock)
+	assert.Len(t, fake.Items(ZIARuleLabels), 1, "a rejected write changes nothing")
+}
+
+func TestZIA_FirewallRuleOrder(t *testing.T) {
+	ctx := context.Background()
+	fake := NewServer()
+	service := newService(t, fake)
+
+	_, err := fake.Seed(ZIAFirewallRules,
+		filteringrules.FirewallFilteringRules{Name: "a", Order: 1},
+		filteringrules.FirewallFilteringRules{Name: "b", Order: 2},
+	)
+	require.NoError(t, err)
+
+	created, err := filteringrules.Create(ctx, service, &filteringrules.FirewallFilteringRules{Name: "first", Order: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 1, created.Order)
+
+	rules, err := filteringrules.GetAll(ctx, service, nil)
+	require.NoError(t, err)
+	var names []string
+	for _, r := range rules {
+		names = append(names, fmt.Sprintf("%s:%d", r.Name, r.Order))
+	}
+	assert.Equal(t, []string{"first:1", "a:2", "b:3"}, names)
+
+	byName, err := filteringrules.GetByName(ctx, service, "B")
+	require.NoError(t, err)
+	assert.Equal(t, "b", byName.Name)
+
+	_, err = filteringrules.Delete(ctx, service, created.ID)
+	require.NoError(t, err)
+	rules, err = filteringrules.GetAll(ctx, service, nil)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, 1, rules[0].Order)
+}
+
+func TestZIA_URLCategories(t *testing.T) {
+	ctx := context.Background()
+	fake := NewServer()
+	service := newService(t, fake)
+
+	_, err := fake.Seed(ZIAURLCategories, map[string]interface{}{"id": "NEWS_AND_MEDIA", "customCategory": false})
+	require.NoError(t, err)
+
+	category, err := urlcategories.CreateURLCategories(ctx, service, &urlcategories.URLCategory{
+		ConfiguredName: "Blocked",
+		SuperCategory:  "USER_DEFINED",
+		Urls:           []string{"a.example.com"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "CUSTOM_01", category.ID)
+
+	custom, err := urlcategories.GetAllCustomURLCategories(ctx, service)
+	require.NoError(t, err)
+	require.Len(t, custom, 1)
+
+	_, _, err = urlcategories.UpdateURLCategories(ctx, service, category.ID,
+		&urlcategories.URLCategory{Urls: []string{"b.example.com"}}, "ADD_TO_LIST")
+	require.NoError(t, err)
+	got, err := urlcategories.Get(ctx, service, category.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.example.com", "b.example.com"}, got.Urls)
+	assert.Equal(t, "Blocked", got.ConfiguredName)
+
+	_, err = urlcategories.Get(ctx, service, "CUSTOM_99")
+	assert.ErrorIs(t, err, errorx.ErrNotFound)
+}
+
+func TestZIA_Pagination(t *testing.T) {
+	ctx := context.Background()
+	fake := NewServer()
+	service := newService(t, fake)
+
+	for i := 0; i < 1001; i++ {
+		_, err := fake.Seed(ZIALocations, locationmanagement.Locations{Name: fmt.Sprintf("loc-%04d", i)})
+		require.NoError(t, err)
+	}
+	all, err := locationmanagement.GetAll(ctx, service)
+	require.NoError(t, err)
+	assert.Len(t, all, 1001)
+
+	resp, err := fake.Client().Get("https://api.example.com/zia/api/v1/locations?page=2&pageSize=3&search=LOC-00")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var page []locationmanagement.Locations
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&page))
+	require.Len(t, page, 3)
+	assert.Equal(t, "loc-0003", page[0].Name)
+}
+
+func TestZPA_CRUDSearchAndPagination(t *testing.T) {
+	ctx := context.Background()
+	fake := NewServer()
+	service := newService(t, fake)
+
+	for i := 0; i < 1001; i++ {
+		_, err := fake.Seed(ZPASegmentGroups, segmentgroup.SegmentGroup{Name: fmt.Sprintf("group %04d", i)})
+		require.NoError(t, err)
+	}
+	all, _, err := segmentgroup.GetAll(ctx, service)
+	require.NoError(t, err)
+	assert.Len(t, all, 1001)
+
+	group, _, err := segmentgroup.Create(ctx, service, &segmentgroup.SegmentGroup{Name: "web apps", Enabled: true})
+	require.NoError(t, err)
+	require.NotEmpty(t, group.ID)
+
+	found, _, err := segmentgroup.GetByName(ctx, service, "Web Apps")
+	require.NoError(t, err)
+	assert.Equal(t, group.ID, found.ID)
+
+	app, _, err := applicationsegment.Create(ctx, service, applicationsegment.ApplicationSegmentResource{
+		Name:           "intranet",
+		DomainNames:    []string{"intranet.example.com"},
+		SegmentGroupID: group.ID,
+	})
+	require.NoError(t, err)
+	app.Description = "updated"
+	_, err = applicationsegment.Update(ctx, service, app.ID, *app)
+	require.NoError(t, err)
+	gotApp, _, err := applicationsegment.Get(ctx, service, app.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "updated", gotApp.Description)
+	assert.Equal(t, group.ID, gotApp.SegmentGroupID)
+
+	_, err = applicationsegment.Delete(ctx, service, app.ID)
+	require.NoError(t, err)
+	_, _, err = applicationsegment.Get(ctx, service, app.ID)
+	assert.ErrorIs(t, err, errorx.ErrNotFound)
+
+	_, _, err = segmentgroup.Create(ctx, service, &segmentgroup.SegmentGroup{Name: "web apps"})
+	assert.ErrorIs(t, err, errorx.ErrConflict)
+}
+
+func TestZPA_PolicyRules(t *testing.T) {
+	ctx := context.Background()
+	fake := NewServer()
+	service := newService(t, fake)
+
+	set, _, err := policysetcontroller.GetByPolicyType(ctx, service, "ACCESS_POLICY")
+	require.NoError(t, err)
+	require.NotEmpty(t, set.ID)
+
+	var ids []string
+	for _, name := range []string{"r1", "r2", "r3"} {
+		rule, _, err := policysetcontroller.CreateRule(ctx, service, &policysetcontroller.PolicyRule{
+			Name: name, Action: "ALLOW", PolicySetID: set.ID,
+		})
+		require.NoError(t, err)
+		ids = append(ids, rule.ID)
+	}
+
+	_, err = policysetcontroller.Reorder(ctx, service, set.ID, ids[2], 1)
+	require.NoError(t, err)
+	order := func() []string {
+		rules, _, err := policysetcontroller.GetAllByType(ctx, service, "ACCESS_POLICY")
+		require.NoError(t, err)
+		var names []string
+		for _, r := range rules {
+			names = append(names, r.Name+":"+r.RuleOrder)
+		}
+		return names
+	}
+	assert.Equal(t, []string{"r3:1", "r1:2", "r2:3"}, order())
+
+	_, err = policysetcontroller.BulkReorder(ctx, service, "ACCESS_POLICY", map[string]int{ids[0]: 1, ids[1]: 2, ids[2]: 3})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"r1:1", "r2:2", "r3:3"}, order())
+
+	rule, _, err := policysetcontroller.GetByNameAndType(ctx, service, "ACCESS_POLICY", "r2")
+	require.NoError(t, err)
+	assert.Equal(t, ids[1], rule.ID)
+
+	_, err = policysetcontroller.Delete(ctx, service, set.ID, ids[0])
+	require.NoError(t, err)
+	assert.Equal(t, []string{"r2:1", "r3:2"}, order())
+	_, _, err = policysetcontroller.GetPolicyRule(ctx, service, set.ID, ids[0])
+	assert.ErrorIs(t, err, errorx.ErrNotFound)
+}
+
+func TestServer_HTTPHandler(t *testing.T) {
+	fake := NewServer(WithCustomerID("42"))
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	for path, status := range map[string]int{
+		"/zpa/mgmtconfig/v1/admin/customers/42/segmentGroup?page=1":                 http.StatusOK,
+		"/zpa/mgmtconfig/v1/admin/customers/43/segmentGroup":                        http.StatusNotFound,
+		"/zpa/mgmtconfig/v1/admin/customers/42/segmentGroup?search=name%2BLIKE%2Bx": http.StatusBadRequest,
+		"/zia/api/v1/ruleLabels/77":                                                 http.StatusNotFound,
+		"/zia/api/v1/users":                                                         http.StatusNotImplemented,
+	} {
+		resp, err := http.Get(srv.URL + path)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, status, resp.StatusCode, path)
+	}
+
+	_, err := fake.Seed("zia/unknown", map[string]interface{}{"name": "x"})
+	assert.Error(t, err)
+}
+
+func TestDryRun(t *testing.T) {
+	ctx := context.Background()
+	fake := NewServer()
+	ids, err := fake.Seed(ZIARuleLabels, map[string]interface{}{"name": "prod"})
+	require.NoError(t, err)
+	plan := dryrun.NewPlan()
+	service, err := fake.NewService(zscaler.WithDryRun(plan))
+	require.NoError(t, err)
+	assert.Same(t, plan, service.DryRunPlan())
+
+	label, err := rule_labels.GetRuleLabelByName(ctx, service, "prod")
+	require.NoError(t, err, "GETs reach the tenant")
+	label.Description = "production"
+	_, _, err = rule_labels.Update(ctx, service, label.ID, label)
+	require.NoError(t, err)
+	created, _, err := rule_labels.Create(ctx, service, &rule_labels.RuleLabels{Name: "staging"})
+	require.NoError(t, err)
+	assert.Equal(t, "staging", created.Name, "the synthetic response echoes the request")
+	_, err = rule_labels.Delete(ctx, service, label.ID)
+	require.NoError(t, err)
+
+	items := fake.Items(ZIARuleLabels)
+	require.Len(t, items, 1, "nothing was created or deleted")
+	assert.Nil(t, items[0]["description"])
+	assert.Equal(t, StatusActive, fake.ActivationStatus())
+
+	changes := plan.Changes()
+	require.Len(t, changes, 3)
+	assert.Equal(t, "update", changes[0].Action())
+	assert.Equal(t, "ruleLabels", changes[0].ResourceType)
+	assert.Equal(t, ids[0], changes[0].ResourceID)
+	assert.Contains(t, string(changes[0].Body), `"description":"production"`)
+	assert.Equal(t, "create", changes[1].Action())
+	assert.Equal(t, "delete", changes[2].Action())
+	assert.Equal(t, "zia", changes[2].Product)
+}
+
+func TestResponseCache(t *testing.T) {
+	ctx := context.Background()
+	fake := NewServer()
+	service, err := fake.NewService(zscaler.WithCache(true))
+	require.NoError(t, err)
+	group, _, err := segmentgroup.Create(ctx, service, &segmentgroup.SegmentGroup{Name: "web apps"})
+	require.NoError(t, err)
+
+	apps, _, err := applicationsegment.GetAll(ctx, service)
+	require.NoError(t, err)
+	assert.Empty(t, apps)
+	_, err = fake.Seed(ZPAApplicationSegments, applicationsegment.ApplicationSegmentResource{Name: "intranet", SegmentGroupID: group.ID})
+	require.NoError(t, err)
+	apps, _, err = applicationsegment.GetAll(ctx, service)
+	require.NoError(t, err)
+	assert.Empty(t, apps, "served from the cache")
+
+	group.Description = "updated"
+	_, err = segmentgroup.Update(ctx, service, group.ID, group)
+	require.NoError(t, err)
+	apps, _, err = applicationsegment.GetAll(ctx, service)
+	require.NoError(t, err)
+	assert.Len(t, apps, 1, "changing a segment group invalidates the cached application segments")
+
+	stats := service.Client.CacheStats()
+	assert.NotZero(t, stats.Hits)
+	assert.NotZero(t, stats.Invalidations)
+	assert.NotZero(t, service.Client.PurgeCacheResources("zpa:application"))
+	service.Client.PurgeCache()
+	assert.Zero(t, service.Client.CacheStats().Entries)
+}
+
+func TestCacheRevalidation(t *testing.T) {
+	for name, opts := range map[string][]Option{
+		"etag":      {WithETags()},
+		"body hash": nil,
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			fake := NewServer(opts...)
+			service, err := fake.NewService(
+				zscaler.WithCache(true),
+				zscaler.WithCacheTtl(time.Hour),
+				zscaler.WithCacheRule(zscaler.CacheRule{Pattern: "/zpa/mgmtconfig/*/admin/customers/*/segmentGroup", TTL: 20 * time.Millisecond}),
+				zscaler.WithCacheRevalidation(true),
+			)
+			require.NoError(t, err)
+			_, err = fake.Seed(ZPASegmentGroups, segmentgroup.SegmentGroup{Name: "web"})
+			require.NoError(t, err)
+
+			groups, _, err := segmentgroup.GetAll(ctx, service)
+			require.NoError(t, err)
+			require.Len(t, groups, 1)
+			time.Sleep(30 * time.Millisecond)
+			groups, _, err = segmentgroup.GetAll(ctx, service)
+			require.NoError(t, err)
+			assert.Len(t, groups, 1)
+			assert.Equal(t, int64(1), service.Client.CacheStats().Revalidations, "the expired entry is unchanged")
+
+			_, err = fake.Seed(ZPASegmentGroups, segmentgroup.SegmentGroup{Name: "changed out of band"})
+			require.NoError(t, err)
+			time.Sleep(30 * time.Millisecond)
+			groups, _, err = segmentgroup.GetAll(ctx, service)
+			require.NoError(t, err)
+			assert.Len(t, groups, 2)
+			assert.Equal(t, int64(1), service.Client.CacheStats().Revalidations)
+		})
+	}
+}
+
+func TestCacheStaleWhileRevalidate(t *testing.T) {
+	ctx := context.Background()
+	fake := NewServer(WithETags())
+	service, err := fake.NewService(
+		zscaler.WithCache(true),
+		zscaler.WithCacheTtl(time.Hour),
+		zscaler.WithCacheRule(zscaler.CacheRule{
+			Pattern:              "/zpa/mgmtconfig/*/admin/customers/*/segmentGroup",
+			TTL:                  20 * time.Millisecond,
+			StaleWhileRevalidate: time.Minute,
+		}),
+	)
+	require.NoError(t, err)
+	_, err = fake.Seed(ZPASegmentGroups, segmentgroup.SegmentGroup{Name: "web"})
+	require.NoError(t, err)
+
+	_, _, err = segmentgroup.GetAll(ctx, service)
+	require.NoError(t, err)
+	_, err = fake.Seed(ZPASegmentGroups, segmentgroup.SegmentGroup{Name: "changed out of band"})
+	require.NoError(t, err)
+	time.Sleep(30 * time.Millisecond)
+
+	groups, _, err := segmentgroup.GetAll(ctx, service)
+	require.NoError(t, err)
+	assert.Len(t, groups, 1, "the stale entry is served while it is revalidated")
+	assert.Eventually(t, func() bool {
+		groups, _, err := segmentgroup.GetAll(ctx, service)
+		return err == nil && len(groups) == 2
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestCacheInFlight_WaiterCancelled(t *testing.T) {
+	fake := NewServer()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var calls atomic.Int32
+	service, err := fake.NewService(
+		zscaler.WithCache(true),
+		zscaler.WithCacheTtl(time.Hour),
+		zscaler.WithInterceptor(zscaler.Interceptor{
+			BeforeRequest: func(ctx context.Context, info *zscaler.RequestInfo) error {
+				if calls.Add(1) == 1 {
+					close(started)
+					<-release
+				}
+				return nil
+			},
+		}),
+	)
+	require.NoError(t, err)
+	_, err = fake.Seed(ZPASegmentGroups, segmentgroup.SegmentGroup{Name: "web"})
+	require.NoError(t, err)
+
+	owner := make(chan error, 1)
+	go func() {
+		_, _, err := segmentgroup.GetAll(context.Background(), service)
+		owner <- err
+	}()
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	waiter := make(chan error, 1)
+	go func() {
+		_, _, err := segmentgroup.GetAll(ctx, service)
+		waiter <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	select {
+	case err := <-waiter:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("the waiter did not return when its context was cancelled")
+	}
+
+	close(release)
+	require.NoError(t, <-owner)
+	assert.Equal(t, int32(1), calls.Load(), "the waiter must not send its own request")
+}
+
+func TestCacheStaleWhileRevalidate_Close(t *testing.T) {
+	ctx := context.Background()
+	fake := NewServer(WithETags())
+	var block atomic.Bool
+	started := make(chan struct{})
+	var cancelled atomic.Bool
+	service, err := fake.NewService(
+		zscaler.WithCache(true),
+		zscaler.WithCacheTtl(time.Hour),
+		zscaler.WithCacheRule(zscaler.CacheRule{
+			Pattern:              "/zpa/mgmtconfig/*/admin/customers/*/segmentGroup",
+			TTL:                  20 * time.Millisecond,
+			StaleWhileRevalidate: time.Minute,
+		}),
+		zscaler.WithInterceptor(zscaler.Interceptor{
+			BeforeRequest: func(ctx context.Context, info *zscaler.RequestInfo) error {
+				if !block.Load() {
+					return nil
+				}
+				close(started)
+				<-ctx.Done()
+				cancelled.Store(true)
+				return ctx.Err()
+			},
+		}),
+	)
+	require.NoError(t, err)
+	_, err = fake.Seed(ZPASegmentGroups, segmentgroup.SegmentGroup{Name: "web"})
+	require.NoError(t, err)
+
+	_, _, err = segmentgroup.GetAll(ctx, service)
+	require.NoError(t, err)
+	time.Sleep(30 * time.Millisecond)
+	block.Store(true)
+	_, _, err = segmentgroup.GetAll(ctx, service)
+	require.NoError(t, err, "the stale entry is served while it is revalidated")
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("the background revalidation did not start")
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		service.Client.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Close did not cancel the background revalidation")
+	}
+	assert.True(t, cancelled.Load(), "Close returned before the revalidation did")
+}
@@ -0,0 +1,167 @@
+package zscalertest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// object is a stored API object. Objects are kept as decoded JSON so the fake
+// accepts every field the SDK sends and returns it unchanged.
+type object = map[string]interface{}
+
+// collection is the ordered list of objects of one resource.
+type collection struct {
+	items []object
+	// nameKey is the field holding the unique object name.
+	nameKey string
+	// orderKey, when set, is renumbered from 1 after every change so that it
+	// always matches an object's position. orderString stores it as a string,
+	// as ZPA does.
+	orderKey    string
+	orderString bool
+	newID       func() interface{}
+}
+
+func (c *collection) index(id string) int {
+	for i, o := range c.items {
+		if idString(o["id"]) == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// conflict returns the object other than the one with id skip that has the
+// same name as o, compared case-insensitively.
+func (c *collection) conflict(o object, skip string) object {
+	name := stringField(o, c.nameKey)
+	if name == "" {
+		return nil
+	}
+	for _, other := range c.items {
+		if idString(other["id"]) != skip && strings.EqualFold(stringField(other, c.nameKey), name) {
+			return other
+		}
+	}
+	return nil
+}
+
+// insert assigns o an ID unless it has a non-zero one and adds it at
+// position pos (0-based), or at the end when pos is out of range.
+func (c *collection) insert(o object, pos int) object {
+	if id := idString(o["id"]); id == "" || id == "0" {
+		id := c.newID()
+		for c.index(idString(id)) >= 0 {
+			id = c.newID()
+		}
+		o["id"] = id
+	}
+	if pos < 0 || pos > len(c.items) {
+		pos = len(c.items)
+	}
+	c.items = append(c.items, nil)
+	copy(c.items[pos+1:], c.items[pos:])
+	c.items[pos] = o
+	c.renumber()
+	return o
+}
+
+func (c *collection) remove(i int) object {
+	o := c.items[i]
+	c.items = append(c.items[:i], c.items[i+1:]...)
+	c.renumber()
+	return o
+}
+
+// move places the object at index i at position pos.
+func (c *collection) move(i, pos int) {
+	c.insert(c.remove(i), pos)
+}
+
+// position returns the 0-based position requested by the order field of o,
+// or -1 if o has none.
+func (c *collection) position(o object) int {
+	if c.orderKey == "" {
+		return -1
+	}
+	n, err := strconv.Atoi(idString(o[c.orderKey]))
+	if err != nil || n < 1 {
+		return -1
+	}
+	return n - 1
+}
+
+func (c *collection) renumber() {
+	if c.orderKey == "" {
+		return
+	}
+	for i, o := range c.items {
+		if c.orderString {
+			o[c.orderKey] = strconv.Itoa(i + 1)
+		} else {
+			o[c.orderKey] = json.Number(strconv.Itoa(i + 1))
+		}
+	}
+}
+
+// idString returns the string form of a JSON ID, which ZIA sends as a number
+// and ZPA as a string.
+func idString(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+func stringField(o object, key string) string {
+	s, _ := o[key].(string)
+	return s
+}
+
+// decodeObject reads a JSON object, keeping numbers as json.Number so IDs and
+// other integers survive unchanged.
+func decodeObject(r io.Reader) (object, error) {
+	var o object
+	if err := decodeJSON(r, &o); err != nil {
+		return nil, err
+	}
+	if o == nil {
+		return nil, fmt.Errorf("request body must be a JSON object")
+	}
+	return o, nil
+}
+
+func decodeJSON(r io.Reader, v interface{}) error {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+// toObject converts v, e.g. an SDK model struct, to an object.
+func toObject(v interface{}) (object, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return decodeObject(bytes.NewReader(data))
+}
+
+func cloneObject(o object) object {
+	c, err := toObject(o)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+func cloneObjects(items []object) []object {
+	out := make([]object, 0, len(items))
+	for _, o := range items {
+		out = append(out, cloneObject(o))
+	}
+	return out
+}
@@ -0,0 +1,216 @@
+package zscalertest
+
+import (
+	"net/http"
+	"strings"
+)
+
+const ziaPrefix = "/zia/api/v1"
+
+var ziaResources = map[string]Resource{
+	"urlCategories":          ZIAURLCategories,
+	"firewallFilteringRules": ZIAFirewallRules,
+	"locations":              ZIALocations,
+	"ruleLabels":             ZIARuleLabels,
+}
+
+func (s *Server) serveZIA(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 0 {
+		notImplemented(w, r)
+		return
+	}
+	res, ok := ziaResources[parts[0]]
+	if !(ok && len(parts) <= 2) && parts[0] != "status" {
+		notImplemented(w, r)
+		return
+	}
+	if r.Method != http.MethodGet && s.editLocks > 0 {
+		s.editLocks--
+		writeZIAError(w, http.StatusConflict, "EDIT_LOCK_NOT_AVAILABLE", "Edit lock is not available. Please try again later.")
+		return
+	}
+	if parts[0] == "status" {
+		s.serveActivation(w, r, parts[1:])
+		return
+	}
+
+	c, _ := s.collection(res)
+	if len(parts) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			s.listZIA(w, r, res, c)
+		case http.MethodPost:
+			s.createZIA(w, r, res, c)
+		default:
+			notImplemented(w, r)
+		}
+		return
+	}
+
+	id := parts[1]
+	switch {
+	case (res == ZIAURLCategories || res == ZIALocations) && id == "lite" && r.Method == http.MethodGet:
+		s.listZIA(w, r, res, c)
+		return
+	case res == ZIALocations && id == "bulkDelete" && r.Method == http.MethodPost:
+		s.bulkDeleteZIA(w, r, c)
+		return
+	}
+	i := c.index(id)
+	if i < 0 {
+		writeZIAError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", "Resource with id "+id+" not found")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, c.items[i])
+	case http.MethodPut:
+		s.updateZIA(w, r, res, c, i)
+	case http.MethodDelete:
+		c.remove(i)
+		s.activation = StatusPending
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		notImplemented(w, r)
+	}
+}
+
+// listZIA returns the objects of c as a bare JSON array. The search and
+// ruleName parameters match names case-insensitively by substring and
+// customOnly keeps custom URL categories only. Results are paginated when
+// page or pageSize is given.
+func (s *Server) listZIA(w http.ResponseWriter, r *http.Request, res Resource, c *collection) {
+	q := r.URL.Query()
+	items := []object{}
+	for _, o := range c.items {
+		name := strings.ToLower(stringField(o, c.nameKey))
+		if search := strings.ToLower(q.Get("search")); search != "" && !strings.Contains(name, search) {
+			continue
+		}
+		if ruleName := strings.ToLower(q.Get("ruleName")); res == ZIAFirewallRules && ruleName != "" && !strings.Contains(name, ruleName) {
+			continue
+		}
+		if res == ZIAURLCategories && q.Get("customOnly") == "true" && o["customCategory"] != true {
+			continue
+		}
+		items = append(items, o)
+	}
+	if q.Has("page") || q.Has("pageSize") {
+		items = page(items, queryInt(r, "page", 1), queryInt(r, "pageSize", 100))
+	}
+	writeJSON(w, http.StatusOK, items)
+}
+
+func (s *Server) createZIA(w http.ResponseWriter, r *http.Request, res Resource, c *collection) {
+	o, err := decodeObject(r.Body)
+	if err != nil {
+		writeZIAError(w, http.StatusBadRequest, "INVALID_INPUT_ARGUMENT", err.Error())
+		return
+	}
+	delete(o, "id")
+	// Only custom URL categories can be created.
+	if res == ZIAURLCategories {
+		o["customCategory"] = true
+	}
+	if !s.validZIA(w, c, o, "") {
+		return
+	}
+	o = c.insert(o, c.position(o))
+	s.activation = StatusPending
+	writeJSON(w, http.StatusOK, o)
+}
+
+// updateZIA replaces the object at index i. For URL categories the action
+// parameter ADD_TO_LIST or REMOVE_FROM_LIST edits the urls list instead.
+func (s *Server) updateZIA(w http.ResponseWriter, r *http.Request, res Resource, c *collection, i int) {
+	o, err := decodeObject(r.Body)
+	if err != nil {
+		writeZIAError(w, http.StatusBadRequest, "INVALID_INPUT_ARGUMENT", err.Error())
+		return
+	}
+	current := c.items[i]
+	if action := r.URL.Query().Get("action"); res == ZIAURLCategories && action != "" {
+		updated := cloneObject(current)
+		updated["urls"] = editList(current["urls"], o["urls"], action == "ADD_TO_LIST")
+		o = updated
+	}
+	o["id"] = current["id"]
+	if !s.validZIA(w, c, o, idString(current["id"])) {
+		return
+	}
+	pos := c.position(o)
+	if pos < 0 {
+		pos = i
+	}
+	c.remove(i)
+	o = c.insert(o, pos)
+	s.activation = StatusPending
+	writeJSON(w, http.StatusOK, o)
+}
+
+func (s *Server) validZIA(w http.ResponseWriter, c *collection, o object, id string) bool {
+	if stringField(o, c.nameKey) == "" {
+		writeZIAError(w, http.StatusBadRequest, "INVALID_INPUT_ARGUMENT", c.nameKey+" is required")
+		return false
+	}
+	if c.conflict(o, id) != nil {
+		writeZIAError(w, http.StatusConflict, "DUPLICATE_ITEM", "An item named "+stringField(o, c.nameKey)+" already exists")
+		return false
+	}
+	return true
+}
+
+func (s *Server) bulkDeleteZIA(w http.ResponseWriter, r *http.Request, c *collection) {
+	var body struct {
+		IDs []interface{} `json:"ids"`
+	}
+	if err := decodeJSON(r.Body, &body); err != nil {
+		writeZIAError(w, http.StatusBadRequest, "INVALID_INPUT_ARGUMENT", err.Error())
+		return
+	}
+	for _, id := range body.IDs {
+		if i := c.index(idString(id)); i >= 0 {
+			c.remove(i)
+		}
+	}
+	s.activation = StatusPending
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// serveActivation serves /status and /status/activate.
+func (s *Server) serveActivation(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+	case len(parts) == 1 && parts[0] == "activate" && r.Method == http.MethodPost:
+		s.activation = StatusActive
+	default:
+		notImplemented(w, r)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": s.activation})
+}
+
+// editList adds the values of change to, or removes them from, the JSON list
+// current.
+func editList(current, change interface{}, add bool) []interface{} {
+	list, _ := current.([]interface{})
+	values, _ := change.([]interface{})
+	remove := map[interface{}]bool{}
+	for _, v := range values {
+		remove[v] = true
+	}
+	out := []interface{}{}
+	for _, v := range list {
+		if !remove[v] {
+			out = append(out, v)
+		}
+	}
+	if add {
+		out = append(out, values...)
+	}
+	return out
+}
+
+func writeZIAError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]string{"code": code, "message": message})
+}
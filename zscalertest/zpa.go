@@ -0,0 +1,302 @@
+package zscalertest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var zpaResources = map[string]Resource{
+	"segmentGroup": ZPASegmentGroups,
+	"serverGroup":  ZPAServerGroups,
+	"application":  ZPAApplicationSegments,
+}
+
+// zpaMaxPageSize is the largest page the ZPA API returns.
+const zpaMaxPageSize = 500
+
+func (s *Server) serveZPA(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 0 {
+		notImplemented(w, r)
+		return
+	}
+	if parts[0] == "policySet" {
+		s.servePolicySet(w, r, parts[1:])
+		return
+	}
+	res, ok := zpaResources[parts[0]]
+	if !ok || len(parts) > 2 {
+		notImplemented(w, r)
+		return
+	}
+	c, _ := s.collection(res)
+	if len(parts) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			s.listZPA(w, r, c)
+		case http.MethodPost:
+			s.createZPA(w, r, c, nil)
+		default:
+			notImplemented(w, r)
+		}
+		return
+	}
+	s.serveZPAObject(w, r, c, parts[1])
+}
+
+// servePolicySet serves the policy set and policy rule endpoints:
+//
+//	GET    policySet/policyType/{type}
+//	GET    policySet/rules/policyType/{type}
+//	POST   policySet/{setID}/rule
+//	GET    policySet/{setID}/rule/{ruleID}        (also PUT and DELETE)
+//	PUT    policySet/{setID}/rule/{ruleID}/reorder/{order}
+//	PUT    policySet/{setID}/reorder
+func (s *Server) servePolicySet(w http.ResponseWriter, r *http.Request, parts []string) {
+	get := r.Method == http.MethodGet
+	switch {
+	case len(parts) == 2 && parts[0] == "policyType" && get:
+		policyType := parts[1]
+		c := s.policyRules(policyType)
+		writeJSON(w, http.StatusOK, object{
+			"id":         s.policySetID(policyType),
+			"name":       policyType,
+			"policyType": policyType,
+			"rules":      c.items,
+		})
+		return
+	case len(parts) == 3 && parts[0] == "rules" && parts[1] == "policyType" && get:
+		s.listZPA(w, r, s.policyRules(parts[2]))
+		return
+	case len(parts) < 2:
+		notImplemented(w, r)
+		return
+	}
+
+	setID := parts[0]
+	policyType := s.policyType(setID)
+	if policyType == "" {
+		writeZPAError(w, http.StatusNotFound, "resource.not.found", "policy set "+setID+" not found")
+		return
+	}
+	c := s.policyRules(policyType)
+	switch {
+	case len(parts) == 2 && parts[1] == "rule" && r.Method == http.MethodPost:
+		s.createZPA(w, r, c, object{"policySetId": setID})
+	case len(parts) == 2 && parts[1] == "reorder" && r.Method == http.MethodPut:
+		s.bulkReorder(w, r, c)
+	case len(parts) == 3 && parts[1] == "rule":
+		s.serveZPAObject(w, r, c, parts[2])
+	case len(parts) == 5 && parts[1] == "rule" && parts[3] == "reorder" && r.Method == http.MethodPut:
+		i := c.index(parts[2])
+		if i < 0 {
+			writeZPAError(w, http.StatusNotFound, "resource.not.found", "rule "+parts[2]+" not found")
+			return
+		}
+		order, err := strconv.Atoi(parts[4])
+		if err != nil || order < 1 || order > len(c.items) {
+			writeZPAError(w, http.StatusBadRequest, "invalid.rule.order", "invalid rule order "+parts[4])
+			return
+		}
+		c.move(i, order-1)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		notImplemented(w, r)
+	}
+}
+
+func (s *Server) serveZPAObject(w http.ResponseWriter, r *http.Request, c *collection, id string) {
+	i := c.index(id)
+	if i < 0 {
+		writeZPAError(w, http.StatusNotFound, "resource.not.found", "Resource with id "+id+" not found")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, c.items[i])
+	case http.MethodPut:
+		o, err := decodeObject(r.Body)
+		if err != nil {
+			writeZPAError(w, http.StatusBadRequest, "invalid.request", err.Error())
+			return
+		}
+		current := c.items[i]
+		o["id"] = current["id"]
+		if !validZPA(w, c, o, id) {
+			return
+		}
+		// Rules only move through the reorder endpoints.
+		if c.orderKey != "" {
+			o[c.orderKey] = current[c.orderKey]
+			if setID, ok := current["policySetId"]; ok {
+				o["policySetId"] = setID
+			}
+		}
+		c.items[i] = o
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		c.remove(i)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		notImplemented(w, r)
+	}
+}
+
+// listZPA returns one page of the objects of c in the ZPA list envelope. It
+// honours the page, pagesize, search and microtenantId parameters.
+func (s *Server) listZPA(w http.ResponseWriter, r *http.Request, c *collection) {
+	q := r.URL.Query()
+	match, err := parseZPASearch(q.Get("search"))
+	if err != nil {
+		writeZPAError(w, http.StatusBadRequest, "invalid.search", err.Error())
+		return
+	}
+	items := []object{}
+	for _, o := range c.items {
+		if mt := q.Get("microtenantId"); mt != "" && stringField(o, "microtenantId") != mt {
+			continue
+		}
+		if match(o) {
+			items = append(items, o)
+		}
+	}
+
+	size := queryInt(r, "pagesize", 20)
+	if size < 1 {
+		size = 20
+	}
+	if size > zpaMaxPageSize {
+		size = zpaMaxPageSize
+	}
+	totalPages := (len(items) + size - 1) / size
+	writeJSON(w, http.StatusOK, object{
+		"totalPages": strconv.Itoa(totalPages),
+		"totalCount": strconv.Itoa(len(items)),
+		"list":       page(items, queryInt(r, "page", 1), size),
+	})
+}
+
+// createZPA adds the request object to c with the fields of defaults set.
+func (s *Server) createZPA(w http.ResponseWriter, r *http.Request, c *collection, defaults object) {
+	o, err := decodeObject(r.Body)
+	if err != nil {
+		writeZPAError(w, http.StatusBadRequest, "invalid.request", err.Error())
+		return
+	}
+	delete(o, "id")
+	for k, v := range defaults {
+		o[k] = v
+	}
+	if mt := r.URL.Query().Get("microtenantId"); mt != "" && stringField(o, "microtenantId") == "" {
+		o["microtenantId"] = mt
+	}
+	if !validZPA(w, c, o, "") {
+		return
+	}
+	// New policy rules are appended; the ruleOrder sent is ignored.
+	o = c.insert(o, -1)
+	writeJSON(w, http.StatusCreated, o)
+}
+
+func validZPA(w http.ResponseWriter, c *collection, o object, id string) bool {
+	if stringField(o, c.nameKey) == "" {
+		writeZPAError(w, http.StatusBadRequest, "invalid.name", "name is required")
+		return false
+	}
+	if c.conflict(o, id) != nil {
+		writeZPAError(w, http.StatusConflict, "resource.name.duplicate", "Name "+stringField(o, c.nameKey)+" already exists")
+		return false
+	}
+	return true
+}
+
+// bulkReorder orders the rules of c as listed in the request, a JSON array of
+// every rule ID.
+func (s *Server) bulkReorder(w http.ResponseWriter, r *http.Request, c *collection) {
+	var ids []interface{}
+	if err := decodeJSON(r.Body, &ids); err != nil {
+		writeZPAError(w, http.StatusBadRequest, "invalid.request", err.Error())
+		return
+	}
+	if len(ids) != len(c.items) {
+		writeZPAError(w, http.StatusBadRequest, "invalid.rule.order", "the reorder request must list every rule")
+		return
+	}
+	ordered := make([]object, 0, len(ids))
+	seen := map[string]bool{}
+	for _, id := range ids {
+		i := c.index(idString(id))
+		if i < 0 || seen[idString(id)] {
+			writeZPAError(w, http.StatusBadRequest, "invalid.rule.order", fmt.Sprintf("invalid rule id %v", id))
+			return
+		}
+		seen[idString(id)] = true
+		ordered = append(ordered, c.items[i])
+	}
+	c.items = ordered
+	c.renumber()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// policyRules returns the rules of the policy set of policyType, creating the
+// set on first use.
+func (s *Server) policyRules(policyType string) *collection {
+	s.policySetID(policyType)
+	c, _ := s.collection(PolicyRules(policyType))
+	return c
+}
+
+func (s *Server) policySetID(policyType string) string {
+	id, ok := s.policySets[policyType]
+	if !ok {
+		id = s.zpaID().(string)
+		s.policySets[policyType] = id
+	}
+	return id
+}
+
+func (s *Server) policyType(setID string) string {
+	for policyType, id := range s.policySets {
+		if id == setID {
+			return policyType
+		}
+	}
+	return ""
+}
+
+// parseZPASearch parses a ZPA search filter, "field+OP+value" with OP one of
+// EQ, NE, CONTAINS, STARTSWITH or ENDSWITH. Values compare case-insensitively.
+// A search without an operator matches names containing it.
+func parseZPASearch(search string) (func(object) bool, error) {
+	if search == "" {
+		return func(object) bool { return true }, nil
+	}
+	parts := strings.SplitN(search, "+", 3)
+	if len(parts) != 3 {
+		parts = []string{"name", "CONTAINS", search}
+	}
+	field, value := parts[0], strings.ToLower(parts[2])
+	var cmp func(string) bool
+	switch strings.ToUpper(parts[1]) {
+	case "EQ":
+		cmp = func(s string) bool { return s == value }
+	case "NE":
+		cmp = func(s string) bool { return s != value }
+	case "CONTAINS":
+		cmp = func(s string) bool { return strings.Contains(s, value) }
+	case "STARTSWITH":
+		cmp = func(s string) bool { return strings.HasPrefix(s, value) }
+	case "ENDSWITH":
+		cmp = func(s string) bool { return strings.HasSuffix(s, value) }
+	default:
+		return nil, fmt.Errorf("unsupported search operator %q", parts[1])
+	}
+	return func(o object) bool {
+		return cmp(strings.ToLower(idString(o[field])))
+	}, nil
+}
+
+func writeZPAError(w http.ResponseWriter, status int, id, reason string) {
+	writeJSON(w, status, map[string]string{"id": id, "reason": reason})
+}